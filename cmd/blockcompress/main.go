@@ -0,0 +1,47 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/wavesplatform/gowaves/pkg/state"
+	"github.com/wavesplatform/gowaves/pkg/util/common"
+	"github.com/wavesplatform/gowaves/pkg/util/fdlimit"
+	"github.com/wavesplatform/gowaves/pkg/versioning"
+	"go.uber.org/zap"
+)
+
+var (
+	logLevel    = flag.String("log-level", "INFO", "Logging level. Supported levels: DEBUG, INFO, WARN, ERROR, FATAL. Default logging level INFO.")
+	statePath   = flag.String("state-path", "", "Path to node's state directory")
+	compression = flag.String("compression", "snappy", "Target compression of the block storage: 'none' or 'snappy'")
+)
+
+func main() {
+	flag.Parse()
+
+	common.SetupLogger(*logLevel)
+	zap.S().Infof("Gowaves BlockCompress version: %s", versioning.Version)
+
+	if *statePath == "" {
+		zap.S().Fatal("You must specify state-path option.")
+	}
+	c, err := state.ParseBlockCompression(*compression)
+	if err != nil {
+		zap.S().Fatal(err)
+	}
+	maxFDs, err := fdlimit.MaxFDs()
+	if err != nil {
+		zap.S().Fatalf("Initialization error: %v", err)
+	}
+	_, err = fdlimit.RaiseMaxFDs(maxFDs)
+	if err != nil {
+		zap.S().Fatalf("Initialization error: %v", err)
+	}
+
+	params := state.DefaultStorageParams()
+	params.DbParams.OpenFilesCacheCapacity = int(maxFDs - 10)
+	zap.S().Warn("Conversion rewrites the block storage files, make sure the state is backed up and no node uses it")
+	if err := state.ConvertBlockStorage(*statePath, params, c); err != nil {
+		zap.S().Fatalf("Failed to convert block storage: %v", err)
+	}
+}
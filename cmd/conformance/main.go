@@ -0,0 +1,134 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/conformance"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+	"github.com/wavesplatform/gowaves/pkg/state"
+	"github.com/wavesplatform/gowaves/pkg/util/common"
+	"github.com/wavesplatform/gowaves/pkg/util/fdlimit"
+	"github.com/wavesplatform/gowaves/pkg/versioning"
+	"go.uber.org/zap"
+)
+
+var (
+	logLevel       = flag.String("log-level", "INFO", "Logging level. Supported levels: DEBUG, INFO, WARN, ERROR, FATAL. Default logging level INFO.")
+	statePath      = flag.String("state-path", "", "Path to node's state directory to export the vector from. The state must be built with state hashes")
+	blockchainType = flag.String("blockchain-type", "mainnet", "Blockchain type of the state: mainnet/testnet/stagenet")
+	from           = flag.Uint64("from", 1, "First height of the exported expectations")
+	to             = flag.Uint64("to", 0, "Last height of the exported expectations, defaults to the height of the state")
+	description    = flag.String("description", "", "Description of the exported vector")
+	output         = flag.String("output", "", "Path to the file of the exported vector")
+	run            = flag.String("run", "", "Path to the vector file to apply to a new temporary state instead of export")
+)
+
+// Exports consensus conformance test vectors from the state or applies them.
+func main() {
+	flag.Parse()
+
+	common.SetupLogger(*logLevel)
+	zap.S().Infof("Gowaves Conformance version: %s", versioning.Version)
+
+	if *run != "" {
+		if err := runVector(*run); err != nil {
+			zap.S().Fatal(err)
+		}
+		return
+	}
+	if *statePath == "" {
+		zap.S().Fatal("You must specify state-path option.")
+	}
+	if *output == "" {
+		zap.S().Fatal("You must specify output option.")
+	}
+	if err := exportVector(); err != nil {
+		zap.S().Fatal(err)
+	}
+}
+
+func runVector(path string) error {
+	v, err := conformance.LoadVector(path)
+	if err != nil {
+		return err
+	}
+	dir, err := os.MkdirTemp("", "conformance")
+	if err != nil {
+		return errors.Wrap(err, "failed to create temporary state directory")
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			zap.S().Errorf("Failed to remove temporary state directory: %v", err)
+		}
+	}()
+	mismatches, err := conformance.Run(v, dir)
+	if err != nil {
+		return errors.Wrap(err, "failed to apply vector")
+	}
+	for _, m := range mismatches {
+		zap.S().Error(m.String())
+	}
+	if len(mismatches) != 0 {
+		return errors.Errorf("[NOT OK] %d mismatches found", len(mismatches))
+	}
+	zap.S().Infof("[OK] %d blocks applied, %d state hashes and %d transactions are as expected",
+		len(v.Blocks), len(v.StateHashes), len(v.Transactions))
+	return nil
+}
+
+func exportVector() error {
+	ss, err := settings.BlockchainSettingsByTypeName(*blockchainType)
+	if err != nil {
+		return errors.Wrap(err, "failed to load blockchain settings")
+	}
+	maxFDs, err := fdlimit.MaxFDs()
+	if err != nil {
+		return errors.Wrap(err, "initialization error")
+	}
+	_, err = fdlimit.RaiseMaxFDs(maxFDs)
+	if err != nil {
+		return errors.Wrap(err, "initialization error")
+	}
+	params := state.DefaultStateParams()
+	params.DbParams.OpenFilesCacheCapacity = int(maxFDs - 10)
+	params.BuildStateHashes = true
+	st, err := state.NewState(*statePath, false, params, ss)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open state at '%s'", *statePath)
+	}
+	defer func() {
+		if err := st.Close(); err != nil {
+			zap.S().Errorf("Failed to close state: %v", err)
+		}
+	}()
+	last := *to
+	if last == 0 {
+		last, err = st.Height()
+		if err != nil {
+			return errors.Wrap(err, "failed to get state height")
+		}
+	}
+	v, err := conformance.Export(st, ss, *from, last)
+	if err != nil {
+		return err
+	}
+	v.Description = *description
+	f, err := os.Create(filepath.Clean(*output))
+	if err != nil {
+		return errors.Wrap(err, "failed to create output file")
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			zap.S().Errorf("Failed to close output file: %v", err)
+		}
+	}()
+	if err := v.Write(f); err != nil {
+		return err
+	}
+	zap.S().Infof("Vector with %d blocks and expectations at heights [%d, %d] written to '%s'",
+		len(v.Blocks), *from, last, *output)
+	return nil
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/wavesplatform/gowaves/pkg/devnet"
+	"github.com/wavesplatform/gowaves/pkg/util/common"
+	"github.com/wavesplatform/gowaves/pkg/versioning"
+	"go.uber.org/zap"
+)
+
+var (
+	logLevel   = flag.String("log-level", "INFO", "Logging level. Supported levels: DEBUG, INFO, WARN, ERROR, FATAL. Default logging level INFO.")
+	configPath = flag.String("config", "", "Path to the node configuration written by devnet harness")
+)
+
+// Node of the local test network started by devnet harness as a subprocess.
+func main() {
+	flag.Parse()
+
+	common.SetupLogger(*logLevel)
+	zap.S().Infof("Gowaves devnet node version: %s", versioning.Version)
+
+	if *configPath == "" {
+		zap.S().Fatal("You must specify config option.")
+	}
+	cfg, err := devnet.ReadNodeConfig(*configPath)
+	if err != nil {
+		zap.S().Fatal(err)
+	}
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	if err := devnet.RunNode(ctx, cfg); err != nil {
+		zap.S().Fatalf("Node failed: %v", err)
+	}
+}
@@ -31,9 +31,12 @@ var (
 	dataDirPath               = flag.String("data-path", "", "Path to directory with previously created state.")
 	nBlocks                   = flag.Int("blocks-number", 1000, "Number of blocks to import.")
 	verificationGoroutinesNum = flag.Int("verification-goroutines-num", runtime.NumCPU()*2, " Number of goroutines that will be run for verification of transactions/blocks signatures.")
+	batchVerification         = flag.Bool("batch-verification", false, "Verify signatures of blocks and transactions in batches. It speeds up the import, but use it only for trusted blockchain file: a batch may accept a signature with a small order component which is rejected by one by one verification of the node.")
 	writeBufferSize           = flag.Int("write-buffer", 16, "Write buffer size in MiB.")
 	buildDataForExtendedApi   = flag.Bool("build-extended-api", false, "Build and store additional data required for extended API in state. WARNING: this slows down the import, use only if you do really need extended API.")
 	buildStateHashes          = flag.Bool("build-state-hashes", false, "Calculate and store state hashes for each block height.")
+	rideEngine                = flag.String("ride-engine", "tree", "Engine to evaluate RIDE verifiers and callables: 'tree', 'vm' or 'shadow'. Use it to compare import speed of engines.")
+	blockCompression          = flag.String("block-compression", "none", "Compression of blocks in the block storage: 'none' or 'snappy'. Use it to compare import speed and disk usage.")
 	// Debug.
 	cpuProfilePath = flag.String("cpuprofile", "", "Write cpu profile to this file.")
 	memProfilePath = flag.String("memprofile", "", "Write memory profile to this file.")
@@ -94,12 +97,23 @@ func main() {
 			zap.S().Fatalf("Failed to load blockchain settings: %v", err)
 		}
 	}
+	engine, err := state.ParseRideEngine(*rideEngine)
+	if err != nil {
+		zap.S().Fatalf("Failed to parse RIDE engine: %v", err)
+	}
+	compression, err := state.ParseBlockCompression(*blockCompression)
+	if err != nil {
+		zap.S().Fatalf("Failed to parse block compression: %v", err)
+	}
 	params := state.DefaultStateParams()
 	params.StorageParams.DbParams.OpenFilesCacheCapacity = int(maxFDs - 10)
+	params.Compression = compression
 	params.VerificationGoroutinesNum = *verificationGoroutinesNum
+	params.BatchSignatureVerification = *batchVerification
 	params.DbParams.WriteBuffer = *writeBufferSize * MiB
 	params.StoreExtendedApiData = *buildDataForExtendedApi
 	params.BuildStateHashes = *buildStateHashes
+	params.RideEngine = engine
 	// We do not need to provide any APIs during import.
 	params.ProvideExtendedApi = false
 
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/howeyc/gopass"
+	"github.com/mr-tron/base58"
+	"github.com/pkg/errors"
+	flag "github.com/spf13/pflag"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/wallet"
+)
+
+var usage = `
+
+Usage:
+  message command [flags]
+
+Available Commands:
+  sign         Sign text message with the key of account
+  verify       Verify signature of text message
+  encrypt      Encrypt message to the owner of public key
+  decrypt      Decrypt message received from the owner of public key
+
+The message is read from standard input if the flag --message is not set.
+The seed of account is requested interactively if the flag --seed is not set.
+
+`
+
+type Opts struct {
+	Seed      string
+	Base58    bool
+	Nonce     int64
+	Message   string
+	PublicKey string
+	Signature string
+	Prefix    string
+	Scheme    string
+}
+
+type signedText struct {
+	Message   string             `json:"message"`
+	Address   proto.WavesAddress `json:"address"`
+	PublicKey crypto.PublicKey   `json:"publicKey"`
+	Signature crypto.Signature   `json:"signature"`
+}
+
+func main() {
+	opts := Opts{}
+
+	flag.StringVar(&opts.Seed, "seed", "", "Seed of account")
+	flag.BoolVarP(&opts.Base58, "base58", "b", false, "Input seed as Base58 encoded string")
+	flag.Int64VarP(&opts.Nonce, "nonce", "n", -1, "Nonce of account derived from seed, the seed is used as account seed if negative")
+	flag.StringVarP(&opts.Message, "message", "m", "", "Message to sign, verify, encrypt or decrypt, encrypted messages are Base64 encoded")
+	flag.StringVarP(&opts.PublicKey, "public-key", "k", "", "Public key of signer for verification, recipient for encryption or sender for decryption")
+	flag.StringVar(&opts.Signature, "signature", "", "Signature of message to verify")
+	flag.StringVarP(&opts.Prefix, "prefix", "p", "", "Prefix of shared key used for encryption")
+	flag.StringVarP(&opts.Scheme, "scheme", "s", "W", "Network scheme symbol of addresses")
+
+	flag.Parse()
+
+	var err error
+	switch flag.Arg(0) {
+	case "sign":
+		err = sign(opts)
+	case "verify":
+		err = verify(opts)
+	case "encrypt":
+		err = encrypt(opts)
+	case "decrypt":
+		err = decrypt(opts)
+	default:
+		showUsageAndExit()
+	}
+	if err != nil {
+		fmt.Printf("Err: %s\n", err.Error())
+		os.Exit(1)
+	}
+}
+
+func showUsageAndExit() {
+	fmt.Print(usage)
+	flag.PrintDefaults()
+	os.Exit(0)
+}
+
+func sign(opts Opts) error {
+	scheme, err := parseScheme(opts.Scheme)
+	if err != nil {
+		return err
+	}
+	sk, pk, err := keyPair(opts)
+	if err != nil {
+		return err
+	}
+	message, err := readMessage(opts)
+	if err != nil {
+		return err
+	}
+	sig, err := crypto.Sign(sk, []byte(message))
+	if err != nil {
+		return errors.Wrap(err, "failed to sign message")
+	}
+	addr, err := proto.NewAddressFromPublicKey(scheme, pk)
+	if err != nil {
+		return err
+	}
+	out, err := json.MarshalIndent(signedText{Message: message, Address: addr, PublicKey: pk, Signature: sig}, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+func verify(opts Opts) error {
+	pk, err := crypto.NewPublicKeyFromBase58(opts.PublicKey)
+	if err != nil {
+		return errors.Wrap(err, "invalid public key")
+	}
+	sig, err := crypto.NewSignatureFromBase58(opts.Signature)
+	if err != nil {
+		return errors.Wrap(err, "invalid signature")
+	}
+	message, err := readMessage(opts)
+	if err != nil {
+		return err
+	}
+	if !crypto.Verify(pk, sig, []byte(message)) {
+		return errors.New("signature is invalid")
+	}
+	fmt.Println("Signature is valid")
+	return nil
+}
+
+func encrypt(opts Opts) error {
+	key, err := sharedKey(opts)
+	if err != nil {
+		return err
+	}
+	message, err := readMessage(opts)
+	if err != nil {
+		return err
+	}
+	encrypted, err := crypto.Encrypt(key, []byte(message))
+	if err != nil {
+		return err
+	}
+	fmt.Println(base64.StdEncoding.EncodeToString(encrypted))
+	return nil
+}
+
+func decrypt(opts Opts) error {
+	key, err := sharedKey(opts)
+	if err != nil {
+		return err
+	}
+	message, err := readMessage(opts)
+	if err != nil {
+		return err
+	}
+	encrypted, err := base64.StdEncoding.DecodeString(strings.TrimSpace(message))
+	if err != nil {
+		return errors.Wrap(err, "invalid encrypted message")
+	}
+	decrypted, err := crypto.Decrypt(key, encrypted)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(decrypted))
+	return nil
+}
+
+func sharedKey(opts Opts) ([]byte, error) {
+	pk, err := crypto.NewPublicKeyFromBase58(opts.PublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid public key")
+	}
+	sk, _, err := keyPair(opts)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.SharedKey(sk, pk, []byte(opts.Prefix))
+}
+
+func keyPair(opts Opts) (crypto.SecretKey, crypto.PublicKey, error) {
+	seed := []byte(opts.Seed)
+	if len(seed) == 0 {
+		fmt.Fprint(os.Stderr, "Enter seed: ")
+		s, err := gopass.GetPasswd()
+		if err != nil {
+			return crypto.SecretKey{}, crypto.PublicKey{}, errors.New("interrupted")
+		}
+		seed = s
+	}
+	if len(seed) == 0 {
+		return crypto.SecretKey{}, crypto.PublicKey{}, errors.New("seed required")
+	}
+	if opts.Base58 {
+		decoded, err := base58.Decode(string(seed))
+		if err != nil {
+			return crypto.SecretKey{}, crypto.PublicKey{}, errors.Wrap(err, "invalid Base58 seed")
+		}
+		seed = decoded
+	}
+	if opts.Nonce < 0 {
+		return crypto.GenerateKeyPair(seed)
+	}
+	return wallet.DeriveKeyPair(seed, uint32(opts.Nonce))
+}
+
+func readMessage(opts Opts) (string, error) {
+	if opts.Message != "" {
+		return opts.Message, nil
+	}
+	b, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read message")
+	}
+	return string(b), nil
+}
+
+func parseScheme(s string) (proto.Scheme, error) {
+	if len(s) != 1 {
+		return 0, errors.Errorf("invalid scheme '%s'", s)
+	}
+	return s[0], nil
+}
@@ -27,10 +27,13 @@ import (
 	"github.com/wavesplatform/gowaves/pkg/metamask"
 	"github.com/wavesplatform/gowaves/pkg/metrics"
 	"github.com/wavesplatform/gowaves/pkg/miner"
+	"github.com/wavesplatform/gowaves/pkg/miner/monitor"
 	"github.com/wavesplatform/gowaves/pkg/miner/scheduler"
+	"github.com/wavesplatform/gowaves/pkg/miner/selector"
 	"github.com/wavesplatform/gowaves/pkg/miner/utxpool"
 	"github.com/wavesplatform/gowaves/pkg/node"
 	"github.com/wavesplatform/gowaves/pkg/node/blocks_applier"
+	"github.com/wavesplatform/gowaves/pkg/node/events"
 	"github.com/wavesplatform/gowaves/pkg/node/messages"
 	"github.com/wavesplatform/gowaves/pkg/node/peer_manager"
 	peersPersistentStorage "github.com/wavesplatform/gowaves/pkg/node/peer_manager/storage"
@@ -38,6 +41,7 @@ import (
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/services"
 	"github.com/wavesplatform/gowaves/pkg/settings"
+	"github.com/wavesplatform/gowaves/pkg/signer"
 	"github.com/wavesplatform/gowaves/pkg/state"
 	"github.com/wavesplatform/gowaves/pkg/types"
 	"github.com/wavesplatform/gowaves/pkg/util/common"
@@ -70,6 +74,8 @@ var (
 	buildExtendedApi                      = flag.Bool("build-extended-api", false, "Builds extended API. Note that state must be re-imported in case it wasn't imported with similar flag set")
 	serveExtendedApi                      = flag.Bool("serve-extended-api", false, "Serves extended API requests since the very beginning. The default behavior is to import until first block close to current time, and start serving at this point")
 	buildStateHashes                      = flag.Bool("build-state-hashes", false, "Calculate and store state hashes for each block height.")
+	rideEngine                            = flag.String("ride-engine", "tree", "Engine to evaluate RIDE verifiers and callables: 'tree', 'vm' (bytecode VM where possible) or 'shadow' (tree evaluator with differential check on VM).")
+	blockCompression                      = flag.String("block-compression", "none", "Compression of blocks in the block storage: 'none' or 'snappy'. Existing state must be converted with blockcompress utility.")
 	bindAddress                           = flag.String("bind-address", "", "Bind address for incoming connections. If empty, will be same as declared address")
 	disableOutgoingConnections            = flag.Bool("no-connections", false, "Disable outgoing network connections to peers. Default value is false.")
 	minerVoteFeatures                     = flag.String("vote", "", "Miner vote features")
@@ -78,8 +84,11 @@ var (
 	outdatePeriod                         = flag.String("outdate", "4h", "Interval after last block then generation is allowed. Example 1d4h30m")
 	walletPath                            = flag.String("wallet-path", "", "Path to wallet, or ~/.waves by default.")
 	walletPassword                        = flag.String("wallet-password", "", "Pass password for wallet.")
+	remoteSigner                          = flag.String("remote-signer", "", "Address of remote signer in form 'unix:/path/to/socket'. Keys of embedded wallet are used for signing if empty. Remote signer signs only blocks, so gRPC method Sign of transactions is disabled if set.")
+	signedBlocksPath                      = flag.String("signed-blocks-path", "", "Path to file of signed key blocks used to prevent double signing after restart. Signed blocks are kept in memory if empty.")
 	limitAllConnections                   = flag.Uint("limit-connections", 60, "Total limit of network connections, both inbound and outbound. Divided in half to limit each direction. Default value is 60.")
 	minPeersMining                        = flag.Int("min-peers-mining", 1, "Minimum connected peers for allow mining.")
+	minerPolicy                           = flag.String("miner-policy", "", "Path to JSON file with miner transaction selection policy. Default policy orders transactions by fee per byte.")
 	disableMiner                          = flag.Bool("disable-miner", false, "Disable miner. Enabled by default.")
 	profiler                              = flag.Bool("profiler", false, "Start built-in profiler on 'http://localhost:6060/debug/pprof/'")
 	prometheus                            = flag.String("prometheus", "", "Provide collected metrics by prometheus client.")
@@ -121,13 +130,18 @@ func debugCommandLineParameters() {
 	zap.S().Debugf("build-extended-api: %v", *buildExtendedApi)
 	zap.S().Debugf("serve-extended-api: %v", *serveExtendedApi)
 	zap.S().Debugf("build-state-hashes: %v", *buildStateHashes)
+	zap.S().Debugf("ride-engine: %s", *rideEngine)
+	zap.S().Debugf("block-compression: %s", *blockCompression)
 	zap.S().Debugf("bind-address: %s", *bindAddress)
 	zap.S().Debugf("vote: %s", *minerVoteFeatures)
 	zap.S().Debugf("reward: %s", *reward)
 	zap.S().Debugf("miner-delay: %s", *outdatePeriod)
 	zap.S().Debugf("disable-miner %v", *disableMiner)
+	zap.S().Debugf("miner-policy: %s", *minerPolicy)
 	zap.S().Debugf("wallet-path: %s", *walletPath)
 	zap.S().Debugf("hashed wallet-password: %s", crypto.MustFastHash([]byte(*walletPassword)))
+	zap.S().Debugf("remote-signer: %s", *remoteSigner)
+	zap.S().Debugf("signed-blocks-path: %s", *signedBlocksPath)
 	zap.S().Debugf("limit-connections: %d", *limitAllConnections)
 	zap.S().Debugf("profiler: %v", *profiler)
 	zap.S().Debugf("bloom: %v", *bloomFilter)
@@ -241,6 +255,28 @@ func main() {
 		}
 	}
 
+	var sgn types.Signer
+	if *remoteSigner != "" {
+		remote, err := signer.NewRemote(*remoteSigner, cfg.AddressSchemeCharacter)
+		if err != nil {
+			zap.S().Error(err)
+			return
+		}
+		defer func() { _ = remote.Close() }()
+		sgn = remote
+		wal = signer.NewWallet(remote)
+	} else {
+		guard := signer.NewGuard()
+		if *signedBlocksPath != "" {
+			guard, err = signer.NewFileGuard(*signedBlocksPath)
+			if err != nil {
+				zap.S().Error(err)
+				return
+			}
+		}
+		sgn = signer.NewLocal(wal, cfg.AddressSchemeCharacter, guard)
+	}
+
 	path := *statePath
 	if path == "" {
 		path, err = common.GetStatePath()
@@ -270,11 +306,27 @@ func main() {
 		return
 	}
 
+	engine, err := state.ParseRideEngine(*rideEngine)
+	if err != nil {
+		zap.S().Error(err)
+		cancel()
+		return
+	}
+
+	compression, err := state.ParseBlockCompression(*blockCompression)
+	if err != nil {
+		zap.S().Error(err)
+		cancel()
+		return
+	}
+
 	params := state.DefaultStateParams()
 	params.StorageParams.DbParams.OpenFilesCacheCapacity = *dbFileDescriptors
+	params.Compression = compression
 	params.StoreExtendedApiData = *buildExtendedApi
 	params.ProvideExtendedApi = *serveExtendedApi
 	params.BuildStateHashes = *buildStateHashes
+	params.RideEngine = engine
 	params.Time = ntpTime
 	if !*bloomFilter {
 		params.DbParams.BloomFilterParams.Disable = true
@@ -314,7 +366,14 @@ func main() {
 	declAddr := proto.NewTCPAddrFromString(conf.DeclaredAddr)
 	bindAddr := proto.NewTCPAddrFromString(*bindAddress)
 
-	utx := utxpool.New(uint64(1024*mb), utxpool.NewValidator(st, ntpTime, outdatePeriodSeconds*1000), cfg)
+	bus := events.NewBus()
+	utx := utxpool.New(uint64(1024*mb), utxpool.NewValidator(st, ntpTime, outdatePeriodSeconds*1000), cfg, bus)
+	policy, err := selector.ReadPolicyFile(*minerPolicy)
+	if err != nil {
+		zap.S().Errorf("Failed to read miner policy: %v", err)
+		cancel()
+		return
+	}
 	parent := peer.NewParent()
 
 	nodeNonce, err := rand.Int(rand.Reader, new(big.Int).SetUint64(math.MaxUint64))
@@ -350,21 +409,24 @@ func main() {
 		conf.WavesNetwork,
 		!*disableOutgoingConnections,
 		*newConnectionsLimit,
+		bus,
 	)
 	go peerManager.Run(ctx)
 
+	minerMonitor := monitor.NewMonitor()
 	var minerScheduler Scheduler = scheduler.NewScheduler(
 		st,
-		wal,
+		sgn,
 		cfg,
 		ntpTime,
 		scheduler.NewMinerConsensus(peerManager, *minPeersMining),
 		proto.NewTimestampFromUSeconds(outdatePeriodSeconds),
+		minerMonitor,
 	)
 	if *disableMiner {
 		minerScheduler = scheduler.DisabledScheduler{}
 	}
-	blockApplier := blocks_applier.NewBlocksApplier()
+	blockApplier := blocks_applier.NewBlocksApplier(bus)
 
 	svs := services.Services{
 		State:           st,
@@ -372,14 +434,19 @@ func main() {
 		Scheduler:       minerScheduler,
 		BlocksApplier:   blockApplier,
 		UtxPool:         utx,
+		TxSelector:      selector.NewSelector(st, utx, cfg.AddressSchemeCharacter, policy),
+		MinerMonitor:    minerMonitor,
+		Events:          bus,
 		Scheme:          cfg.AddressSchemeCharacter,
 		LoggableRunner:  logRunner,
 		Time:            ntpTime,
 		Wallet:          wal,
+		Signer:          sgn,
 		MicroBlockCache: microblock_cache.NewMicroblockCache(),
 		InternalChannel: messages.NewInternalChannel(),
 		MinPeersMining:  *minPeersMining,
 		SkipMessageList: parent.SkipMessageList,
+		VoteFeatures:    features,
 	}
 
 	mine := miner.NewMicroblockMiner(svs, features, reward, maxTransactionTimeForwardOffset)
@@ -440,6 +507,10 @@ func main() {
 		if err != nil {
 			zap.S().Errorf("Failed to create gRPC server: %v", err)
 		}
+		if *remoteSigner != "" {
+			grpcServer.DisableSign()
+			zap.S().Info("gRPC method Sign is disabled because transactions are not signed by remote signer")
+		}
 		go func() {
 			err := grpcServer.Run(ctx, conf.GrpcAddr)
 			if err != nil {
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/wavesplatform/gowaves/pkg/settings"
+	"github.com/wavesplatform/gowaves/pkg/signer"
+	"github.com/wavesplatform/gowaves/pkg/util/common"
+	"github.com/wavesplatform/gowaves/pkg/versioning"
+	"github.com/wavesplatform/gowaves/pkg/wallet"
+	"go.uber.org/zap"
+)
+
+var (
+	logLevel         = flag.String("log-level", "INFO", "Logging level. Supported levels: DEBUG, INFO, WARN, ERROR, FATAL. Default logging level INFO.")
+	blockchainType   = flag.String("blockchain-type", "mainnet", "Blockchain type: mainnet/testnet/stagenet")
+	address          = flag.String("address", "", "Path of Unix socket to listen on in form 'unix:/path/to/socket'. Only the owner of signer process can connect to it.")
+	walletPath       = flag.String("wallet-path", "", "Path to wallet, or ~/.waves by default.")
+	walletPassword   = flag.String("wallet-password", "", "Password of wallet, WAVES_WALLET_PASSWORD environment variable is used if empty.")
+	signedBlocksPath = flag.String("signed-blocks-path", "", "Path to file of signed key blocks used to prevent double signing after restart.")
+)
+
+func main() {
+	flag.Parse()
+
+	common.SetupLogger(*logLevel)
+	zap.S().Infof("Gowaves Signer version: %s", versioning.Version)
+
+	if *address == "" {
+		zap.S().Error("Signer address is required")
+		return
+	}
+	if *signedBlocksPath == "" {
+		zap.S().Warn("Signed blocks are kept in memory, double signing is possible after restart")
+	}
+	cfg, err := settings.BlockchainSettingsByTypeName(*blockchainType)
+	if err != nil {
+		zap.S().Error(err)
+		return
+	}
+	password := *walletPassword
+	if password == "" {
+		password = os.Getenv("WAVES_WALLET_PASSWORD")
+	}
+	wlt := wallet.NewEmbeddedWallet(wallet.NewLoader(*walletPath), wallet.NewWallet(), cfg.AddressSchemeCharacter)
+	if err := wlt.Load([]byte(password)); err != nil {
+		zap.S().Errorf("Failed to load wallet: %v", err)
+		return
+	}
+	guard := signer.NewGuard()
+	if *signedBlocksPath != "" {
+		guard, err = signer.NewFileGuard(*signedBlocksPath)
+		if err != nil {
+			zap.S().Error(err)
+			return
+		}
+	}
+	local := signer.NewLocal(wlt, cfg.AddressSchemeCharacter, guard)
+	pks, err := local.PublicKeys()
+	if err != nil {
+		zap.S().Error(err)
+		return
+	}
+	for _, pk := range pks {
+		zap.S().Infof("Signing with public key %s", pk.String())
+	}
+
+	l, err := signer.Listen(*address)
+	if err != nil {
+		zap.S().Error(err)
+		return
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+	zap.S().Infof("Serving signing requests on %s", *address)
+	if err := signer.Serve(ctx, l, local, cfg.AddressSchemeCharacter); err != nil {
+		zap.S().Error(err)
+		return
+	}
+	zap.S().Info("Signer stopped")
+}
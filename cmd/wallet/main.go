@@ -11,6 +11,8 @@ import (
 	"github.com/howeyc/gopass"
 	"github.com/mr-tron/base58"
 	flag "github.com/spf13/pflag"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/wallet"
 )
 
@@ -21,7 +23,9 @@ Usage:
 
 Available Commands:
   add          Add seed to wallet
+  generate     Generate new mnemonic and add it to wallet
   show         Print wallet data
+  addresses    Print addresses of wallet accounts
 
 `
 
@@ -29,6 +33,10 @@ type Opts struct {
 	Force        bool
 	PathToWallet string
 	Base58       bool
+	Mnemonic     bool
+	Accounts     uint32
+	Words        int
+	Scheme       string
 }
 
 func main() {
@@ -37,6 +45,10 @@ func main() {
 	flag.BoolVarP(&opts.Force, "force", "f", false, "Overwrite existing wallet")
 	flag.StringVarP(&opts.PathToWallet, "wallet", "w", "", "Path to wallet")
 	flag.BoolVarP(&opts.Base58, "base58", "b", false, "Input seed as Base58 encoded string")
+	flag.BoolVarP(&opts.Mnemonic, "mnemonic", "m", false, "Input seed as BIP39 mnemonic, the mnemonic checksum is validated")
+	flag.Uint32VarP(&opts.Accounts, "accounts", "n", 0, "Number of accounts derived from seed with nonces, the seed is used as account seed if not set")
+	flag.IntVar(&opts.Words, "words", wallet.DefaultMnemonicWords, "Number of words in generated mnemonic: 12, 15, 18, 21 or 24")
+	flag.StringVarP(&opts.Scheme, "scheme", "s", "W", "Network scheme symbol of addresses")
 
 	flag.Parse()
 
@@ -45,8 +57,12 @@ func main() {
 	switch command {
 	case "add":
 		addToWallet(opts)
+	case "generate":
+		generate(opts)
 	case "show":
 		show(opts)
+	case "addresses":
+		addresses(opts)
 	default:
 		showUsageAndExit()
 	}
@@ -86,6 +102,9 @@ func show(opts Opts) {
 	for _, s := range wlt.Seeds() {
 		fmt.Printf("seed: %s\n", string(s))
 	}
+	for _, d := range wlt.DerivedSeeds() {
+		fmt.Printf("seed: %s (accounts: %d)\n", string(d.Seed), d.Accounts)
+	}
 }
 
 func showUsageAndExit() {
@@ -109,20 +128,10 @@ func addToWallet(opts Opts) {
 		return
 	}
 
-	var wlt wallet.Wallet
-	if exists(walletPath) {
-		b, err := os.ReadFile(walletPath) // #nosec: in this case check for prevent G304 (CWE-22) is not necessary
-		if err != nil {
-			fmt.Printf("Err: %s\n", err.Error())
-			return
-		}
-		wlt, err = wallet.Decode(b, pass)
-		if err != nil {
-			fmt.Printf("Err: %s\n", err.Error())
-			return
-		}
-	} else {
-		wlt = wallet.NewWallet()
+	wlt, err := loadOrCreateWallet(walletPath, pass)
+	if err != nil {
+		fmt.Printf("Err: %s\n", err.Error())
+		return
 	}
 
 	fmt.Print("Enter seed: ")
@@ -140,24 +149,143 @@ func addToWallet(opts Opts) {
 		}
 	}
 
-	err = wlt.AddSeed(seed)
+	if opts.Mnemonic {
+		mnemonic := wallet.NormalizeMnemonic(string(seed))
+		if err := wallet.ValidateMnemonic(mnemonic); err != nil {
+			fmt.Printf("Err: %s\n", err.Error())
+			return
+		}
+		seed = []byte(mnemonic)
+	}
+
+	if opts.Accounts > 0 {
+		err = wlt.AddDerivedSeed(seed, opts.Accounts)
+	} else {
+		err = wlt.AddSeed(seed)
+	}
 	if err != nil {
 		fmt.Printf("Err: %s\n", err.Error())
 		return
 	}
 
-	bts, err := wlt.Encode(pass)
+	if err := saveWallet(walletPath, wlt, pass); err != nil {
+		fmt.Printf("Err: %s\n", err.Error())
+		return
+	}
+	fmt.Println("Created!")
+}
+
+func generate(opts Opts) {
+	walletPath := getWalletPath(opts.PathToWallet)
+
+	fmt.Print("Enter password: ")
+	pass, err := gopass.GetPasswd()
+	if err != nil {
+		fmt.Println("Interrupt")
+		return
+	}
+
+	if len(pass) == 0 {
+		fmt.Println("Err: Password required")
+		return
+	}
+
+	wlt, err := loadOrCreateWallet(walletPath, pass)
 	if err != nil {
 		fmt.Printf("Err: %s\n", err.Error())
 		return
 	}
 
-	err = os.WriteFile(walletPath, bts, 0600)
+	mnemonic, err := wallet.GenerateMnemonic(opts.Words)
 	if err != nil {
 		fmt.Printf("Err: %s\n", err.Error())
 		return
 	}
-	fmt.Println("Created!")
+	accounts := opts.Accounts
+	if accounts == 0 {
+		accounts = 1
+	}
+	if err := wlt.AddDerivedSeed([]byte(mnemonic), accounts); err != nil {
+		fmt.Printf("Err: %s\n", err.Error())
+		return
+	}
+
+	if err := saveWallet(walletPath, wlt, pass); err != nil {
+		fmt.Printf("Err: %s\n", err.Error())
+		return
+	}
+	fmt.Printf("mnemonic: %s\n", mnemonic)
+	fmt.Println("Write down the mnemonic and keep it in a safe place, it is the only way to restore the accounts.")
+}
+
+func addresses(opts Opts) {
+	walletPath := getWalletPath(opts.PathToWallet)
+	if !exists(walletPath) {
+		fmt.Println("Err: wallet not found")
+		return
+	}
+
+	if len(opts.Scheme) != 1 {
+		fmt.Printf("Err: invalid scheme '%s'\n", opts.Scheme)
+		return
+	}
+	scheme := opts.Scheme[0]
+
+	fmt.Print("Enter password: ")
+	pass, err := gopass.GetPasswd()
+	if err != nil {
+		fmt.Println("Interrupt")
+		return
+	}
+
+	wlt, err := loadOrCreateWallet(walletPath, pass)
+	if err != nil {
+		fmt.Printf("Err: %s\n", err.Error())
+		return
+	}
+
+	for _, s := range wlt.Seeds() {
+		_, pk, err := crypto.GenerateKeyPair(s)
+		if err != nil {
+			fmt.Printf("Err: %s\n", err.Error())
+			return
+		}
+		addr, err := proto.NewAddressFromPublicKey(scheme, pk)
+		if err != nil {
+			fmt.Printf("Err: %s\n", err.Error())
+			return
+		}
+		fmt.Printf("address: %s public key: %s\n", addr.String(), pk.String())
+	}
+	for i, d := range wlt.DerivedSeeds() {
+		accounts, err := wallet.DeriveAccounts(scheme, d.Seed, d.Accounts)
+		if err != nil {
+			fmt.Printf("Err: %s\n", err.Error())
+			return
+		}
+		for _, a := range accounts {
+			fmt.Printf("seed #%d nonce %d address: %s public key: %s\n", i, a.Nonce, a.Address.String(), a.PublicKey.String())
+		}
+	}
+}
+
+func loadOrCreateWallet(walletPath string, pass []byte) (wallet.Wallet, error) {
+	if !exists(walletPath) {
+		return wallet.NewWallet(), nil
+	}
+	b, err := os.ReadFile(walletPath) // #nosec: in this case check for prevent G304 (CWE-22) is not necessary
+	if err != nil {
+		return nil, err
+	}
+	return wallet.Decode(b, pass)
+}
+
+func saveWallet(walletPath string, wlt wallet.Wallet, pass []byte) error {
+	bts, err := wlt.Encode(pass)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(walletPath, bts, 0600)
 }
 
 func userHomeDir() (string, error) {
@@ -53,21 +53,29 @@ func NewApp(apiKey string, scheduler SchedulerEmits, services services.Services)
 	}, nil
 }
 
-func (a *App) TransactionsBroadcast(ctx context.Context, b []byte) error {
+func unmarshalTransactionJSON(b []byte) (proto.Transaction, error) {
 	tt := proto.TransactionTypeVersion{}
 	err := json.Unmarshal(b, &tt)
 	if err != nil {
-		return &BadRequestError{err}
+		return nil, &BadRequestError{err}
 	}
 
 	realType, err := proto.GuessTransactionType(&tt)
 	if err != nil {
-		return &BadRequestError{err}
+		return nil, &BadRequestError{err}
 	}
 
 	err = json.Unmarshal(b, realType)
 	if err != nil {
-		return &BadRequestError{err}
+		return nil, &BadRequestError{err}
+	}
+	return realType, nil
+}
+
+func (a *App) TransactionsBroadcast(ctx context.Context, b []byte) error {
+	realType, err := unmarshalTransactionJSON(b)
+	if err != nil {
+		return err
 	}
 
 	respCh := make(chan error, 1)
@@ -97,14 +105,12 @@ func (a *App) LoadKeys(apiKey string, password []byte) error {
 }
 
 func (a *App) Accounts() ([]account, error) {
-	seeds := a.services.Wallet.Seeds()
-
-	accounts := make([]account, 0, len(seeds))
-	for _, seed := range seeds {
-		_, pk, err := crypto.GenerateKeyPair(seed)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to generate key pair for seed")
-		}
+	pks, err := a.publicKeys()
+	if err != nil {
+		return nil, err
+	}
+	accounts := make([]account, 0, len(pks))
+	for _, pk := range pks {
 		addr, err := proto.NewAddressFromPublicKey(a.services.Scheme, pk)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to generate new address from public key")
@@ -114,6 +120,27 @@ func (a *App) Accounts() ([]account, error) {
 	return accounts, nil
 }
 
+// publicKeys returns keys of node's accounts from the signer, the wallet seeds are used if signer is not set.
+func (a *App) publicKeys() ([]crypto.PublicKey, error) {
+	if a.services.Signer != nil {
+		pks, err := a.services.Signer.PublicKeys()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get public keys from signer")
+		}
+		return pks, nil
+	}
+	seeds := a.services.Wallet.Seeds()
+	pks := make([]crypto.PublicKey, 0, len(seeds))
+	for _, seed := range seeds {
+		_, pk, err := crypto.GenerateKeyPair(seed)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate key pair for seed")
+		}
+		pks = append(pks, pk)
+	}
+	return pks, nil
+}
+
 func (a *App) checkAuth(key string) error {
 	if !a.apiKeyEnabled {
 		// TODO(nickeskov): use new types of errors
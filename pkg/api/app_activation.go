@@ -0,0 +1,156 @@
+package api
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+	"github.com/wavesplatform/gowaves/pkg/state"
+)
+
+const (
+	blockchainFeatureVoting    = "VOTING"
+	blockchainFeatureApproved  = "APPROVED"
+	blockchainFeatureActivated = "ACTIVATED"
+
+	nodeFeatureNotImplemented = "NOT_IMPLEMENTED"
+	nodeFeatureImplemented    = "IMPLEMENTED"
+	nodeFeatureVoted          = "VOTED"
+)
+
+// FeatureActivationStatus is the status of the feature in the form of Scala node.
+// ApprovalHeight is the extension of Scala node response, ActivationHeight of the approved feature is the expected one.
+type FeatureActivationStatus struct {
+	ID               int16         `json:"id"`
+	Description      string        `json:"description"`
+	BlockchainStatus string        `json:"blockchainStatus"`
+	NodeStatus       string        `json:"nodeStatus"`
+	ApprovalHeight   *proto.Height `json:"approvalHeight,omitempty"`
+	ActivationHeight *proto.Height `json:"activationHeight,omitempty"`
+	SupportingBlocks *uint64       `json:"supportingBlocks,omitempty"`
+}
+
+type ActivationStatus struct {
+	Height          proto.Height              `json:"height"`
+	VotingInterval  uint64                    `json:"votingInterval"`
+	VotingThreshold uint64                    `json:"votingThreshold"`
+	NextCheck       proto.Height              `json:"nextCheck"`
+	Features        []FeatureActivationStatus `json:"features"`
+}
+
+// ActivationStatus returns statuses of features known to the node or to the blockchain at the current height.
+func (a *App) ActivationStatus() (ActivationStatus, error) {
+	rs, err := a.state.MapR(func(info state.StateInfo) (interface{}, error) {
+		height, err := info.Height()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get state height")
+		}
+		bs, err := info.BlockchainSettings()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get blockchain settings")
+		}
+		interval := bs.ActivationWindowSize(height)
+		res := ActivationStatus{
+			Height:          height,
+			VotingInterval:  interval,
+			VotingThreshold: bs.VotesForFeatureElection(height),
+			NextCheck:       height - height%interval + interval,
+		}
+		ids, err := a.knownFeatures(info)
+		if err != nil {
+			return nil, err
+		}
+		res.Features = make([]FeatureActivationStatus, len(ids))
+		for i, id := range ids {
+			res.Features[i], err = a.featureActivationStatus(info, id, interval)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get status of feature %d", id)
+			}
+		}
+		return res, nil
+	})
+	if err != nil {
+		return ActivationStatus{}, err
+	}
+	return rs.(ActivationStatus), nil
+}
+
+// knownFeatures combines features from state with features which are defined in settings.
+func (a *App) knownFeatures(info state.StateInfo) ([]int16, error) {
+	fromState, err := info.AllFeatures()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get blockchain features")
+	}
+	known := make(map[int16]struct{}, len(fromState)+len(settings.FeaturesInfo))
+	for _, id := range fromState {
+		known[id] = struct{}{}
+	}
+	for id := range settings.FeaturesInfo {
+		known[int16(id)] = struct{}{}
+	}
+	res := make([]int16, 0, len(known))
+	for id := range known {
+		res = append(res, id)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+	return res, nil
+}
+
+func (a *App) featureActivationStatus(info state.StateInfo, id int16, interval uint64) (FeatureActivationStatus, error) {
+	res := FeatureActivationStatus{ID: id, NodeStatus: nodeFeatureNotImplemented}
+	if fi, ok := settings.FeaturesInfo[settings.Feature(id)]; ok {
+		res.Description = fi.Description
+		if fi.Implemented {
+			res.NodeStatus = nodeFeatureImplemented
+		}
+	}
+	if res.NodeStatus == nodeFeatureImplemented && a.votesFor(id) {
+		res.NodeStatus = nodeFeatureVoted
+	}
+	activated, err := info.IsActivated(id)
+	if err != nil {
+		return FeatureActivationStatus{}, err
+	}
+	approved, err := info.IsApproved(id)
+	if err != nil {
+		return FeatureActivationStatus{}, err
+	}
+	if approved {
+		h, err := info.ApprovalHeight(id)
+		if err != nil {
+			return FeatureActivationStatus{}, err
+		}
+		res.ApprovalHeight = &h
+	}
+	switch {
+	case activated:
+		h, err := info.ActivationHeight(id)
+		if err != nil {
+			return FeatureActivationStatus{}, err
+		}
+		res.BlockchainStatus = blockchainFeatureActivated
+		res.ActivationHeight = &h
+	case approved:
+		h := *res.ApprovalHeight + interval
+		res.BlockchainStatus = blockchainFeatureApproved
+		res.ActivationHeight = &h
+	default:
+		votes, err := info.VotesNum(id)
+		if err != nil {
+			return FeatureActivationStatus{}, err
+		}
+		res.BlockchainStatus = blockchainFeatureVoting
+		res.SupportingBlocks = &votes
+	}
+	return res, nil
+}
+
+func (a *App) votesFor(id int16) bool {
+	for _, f := range a.services.VoteFeatures {
+		if int16(f) == id {
+			return true
+		}
+	}
+	return false
+}
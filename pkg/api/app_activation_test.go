@@ -0,0 +1,81 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/mock"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/services"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+	"github.com/wavesplatform/gowaves/pkg/state"
+)
+
+func TestApp_ActivationStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mock.NewMockState(ctrl)
+	st.EXPECT().MapR(gomock.Any()).DoAndReturn(func(f func(state.StateInfo) (interface{}, error)) (interface{}, error) {
+		return f(st)
+	})
+	app, err := NewApp("api-key", nil, services.Services{
+		State:        st,
+		Scheme:       proto.TestNetScheme,
+		VoteFeatures: []settings.Feature{settings.RideV6},
+	})
+	require.NoError(t, err)
+
+	sets := *settings.MainNetSettings
+	st.EXPECT().Height().Return(proto.Height(25000), nil)
+	st.EXPECT().BlockchainSettings().Return(&sets, nil)
+	st.EXPECT().AllFeatures().Return([]int16{100}, nil)
+	st.EXPECT().IsActivated(gomock.Any()).DoAndReturn(func(id int16) (bool, error) {
+		return id <= int16(settings.BlockV5), nil
+	}).AnyTimes()
+	st.EXPECT().IsApproved(gomock.Any()).DoAndReturn(func(id int16) (bool, error) {
+		return id <= int16(settings.RideV5), nil
+	}).AnyTimes()
+	st.EXPECT().ApprovalHeight(gomock.Any()).Return(proto.Height(10000), nil).AnyTimes()
+	st.EXPECT().ActivationHeight(gomock.Any()).Return(proto.Height(20000), nil).AnyTimes()
+	st.EXPECT().VotesNum(gomock.Any()).Return(uint64(42), nil).AnyTimes()
+
+	rs, err := app.ActivationStatus()
+	require.NoError(t, err)
+	assert.Equal(t, proto.Height(25000), rs.Height)
+	assert.Equal(t, sets.ActivationWindowSize(25000), rs.VotingInterval)
+	assert.Equal(t, sets.VotesForFeatureElection(25000), rs.VotingThreshold)
+	assert.Equal(t, proto.Height(25000-25000%rs.VotingInterval+rs.VotingInterval), rs.NextCheck)
+	require.Len(t, rs.Features, len(settings.FeaturesInfo)+1)
+
+	statuses := make(map[int16]FeatureActivationStatus, len(rs.Features))
+	for i, f := range rs.Features {
+		if i > 0 {
+			assert.Less(t, rs.Features[i-1].ID, f.ID)
+		}
+		statuses[f.ID] = f
+	}
+	activated := statuses[int16(settings.BlockV5)]
+	assert.Equal(t, blockchainFeatureActivated, activated.BlockchainStatus)
+	assert.Equal(t, nodeFeatureImplemented, activated.NodeStatus)
+	assert.Equal(t, proto.Height(10000), *activated.ApprovalHeight)
+	assert.Equal(t, proto.Height(20000), *activated.ActivationHeight)
+	assert.Nil(t, activated.SupportingBlocks)
+
+	approved := statuses[int16(settings.RideV5)]
+	assert.Equal(t, blockchainFeatureApproved, approved.BlockchainStatus)
+	assert.Equal(t, proto.Height(10000+rs.VotingInterval), *approved.ActivationHeight)
+
+	voting := statuses[int16(settings.RideV6)]
+	assert.Equal(t, blockchainFeatureVoting, voting.BlockchainStatus)
+	assert.Equal(t, nodeFeatureVoted, voting.NodeStatus)
+	assert.Nil(t, voting.ApprovalHeight)
+	assert.Nil(t, voting.ActivationHeight)
+	assert.Equal(t, uint64(42), *voting.SupportingBlocks)
+
+	unknown := statuses[100]
+	assert.Equal(t, nodeFeatureNotImplemented, unknown.NodeStatus)
+	assert.Empty(t, unknown.Description)
+}
@@ -1,6 +1,33 @@
 package api
 
-import "github.com/pkg/errors"
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride/serialization"
+	"github.com/wavesplatform/gowaves/pkg/signer"
+	"github.com/wavesplatform/gowaves/pkg/state"
+	"github.com/wavesplatform/gowaves/pkg/types"
+)
+
+type SignedText struct {
+	Message   string           `json:"message"`
+	PublicKey crypto.PublicKey `json:"publicKey"`
+	Signature crypto.Signature `json:"signature"`
+}
+
+type VerifyTextRequest struct {
+	Message   string           `json:"message"`
+	PublicKey crypto.PublicKey `json:"publickey"`
+	Signature crypto.Signature `json:"signature"`
+}
+
+type VerifyTextResult struct {
+	Valid bool `json:"valid"`
+}
 
 func (a *App) Addresses() ([]string, error) {
 	accounts, err := a.Accounts()
@@ -15,3 +42,120 @@ func (a *App) Addresses() ([]string, error) {
 
 	return addresses, nil
 }
+
+// SignText signs UTF-8 bytes of the message with the key of node's account.
+func (a *App) SignText(addr proto.WavesAddress, message string) (SignedText, error) {
+	sgn, pk, err := a.accountSigner(addr)
+	if err != nil {
+		return SignedText{}, err
+	}
+	sig, err := sgn.SignText(pk, []byte(message))
+	if err != nil {
+		if err := signerError(err); err != nil {
+			return SignedText{}, err
+		}
+		return SignedText{}, errors.Wrap(err, "failed to sign message")
+	}
+	return SignedText{Message: message, PublicKey: pk, Signature: sig}, nil
+}
+
+// VerifyText checks that the message is signed by the owner of address.
+func (a *App) VerifyText(addr proto.WavesAddress, req VerifyTextRequest) (VerifyTextResult, error) {
+	pkAddr, err := proto.NewAddressFromPublicKey(a.services.Scheme, req.PublicKey)
+	if err != nil {
+		return VerifyTextResult{}, errors.Wrap(err, "failed to create address from public key")
+	}
+	if pkAddr != addr {
+		return VerifyTextResult{}, apiErrs.NewCustomValidationError("public key does not match address")
+	}
+	return VerifyTextResult{Valid: crypto.Verify(req.PublicKey, req.Signature, []byte(req.Message))}, nil
+}
+
+// accountSigner returns the signer and the public key of node's account with given address.
+// Keys of the wallet are used if the signer is not set.
+func (a *App) accountSigner(addr proto.WavesAddress) (types.Signer, crypto.PublicKey, error) {
+	sgn := a.services.Signer
+	if sgn == nil {
+		sgn = signer.NewLocal(a.services.Wallet, a.services.Scheme, nil)
+	}
+	pks, err := a.publicKeys()
+	if err != nil {
+		return nil, crypto.PublicKey{}, err
+	}
+	for _, pk := range pks {
+		accAddr, err := proto.NewAddressFromPublicKey(a.services.Scheme, pk)
+		if err != nil {
+			return nil, crypto.PublicKey{}, errors.Wrap(err, "failed to create address from public key")
+		}
+		if accAddr == addr {
+			return sgn, pk, nil
+		}
+	}
+	return nil, crypto.PublicKey{}, apiErrs.MissingSenderPrivateKey
+}
+
+// signerError converts known errors of signer to API errors, nil is returned for other errors.
+func signerError(err error) error {
+	switch errors.Cause(err) {
+	case signer.ErrUnknownPublicKey:
+		return apiErrs.MissingSenderPrivateKey
+	case signer.ErrNotSupported:
+		return apiErrs.NewCustomValidationError(err.Error())
+	default:
+		return nil
+	}
+}
+
+// ScriptInfoMeta holds complexities of account script: stored in state and calculated by all versions of estimator.
+// For an account without script both lists of complexities are empty.
+type ScriptInfoMeta struct {
+	Address                proto.WavesAddress `json:"address"`
+	ActiveEstimatorVersion int                `json:"activeEstimatorVersion"`
+	StoredComplexities     []ScriptComplexity `json:"storedComplexities"`
+	Estimations            []ScriptComplexity `json:"estimations"`
+}
+
+func (a *App) ScriptInfoMeta(addr proto.WavesAddress) (ScriptInfoMeta, error) {
+	active, err := a.state.EstimatorVersion()
+	if err != nil {
+		return ScriptInfoMeta{}, errors.Wrap(err, "failed to get active estimator version")
+	}
+	rs := ScriptInfoMeta{
+		Address:                addr,
+		ActiveEstimatorVersion: active,
+		StoredComplexities:     []ScriptComplexity{},
+		Estimations:            []ScriptComplexity{},
+	}
+	rcp := proto.NewRecipientFromAddress(addr)
+	info, err := a.state.ScriptInfoByAccount(rcp)
+	if err != nil {
+		if state.IsNotFound(err) {
+			return rs, nil
+		}
+		return ScriptInfoMeta{}, errors.Wrapf(err, "failed to get script of account %s", addr.String())
+	}
+	if len(info.Bytes) == 0 {
+		return rs, nil
+	}
+	tree, err := serialization.Parse(info.Bytes)
+	if err != nil {
+		return ScriptInfoMeta{}, errors.Wrapf(err, "failed to parse script of account %s", addr.String())
+	}
+	rs.Estimations = estimateScript(tree)
+	stored, err := a.state.ScriptComplexitiesByAccount(rcp)
+	if err != nil {
+		if state.IsNotFound(err) {
+			return rs, nil
+		}
+		return ScriptInfoMeta{}, errors.Wrapf(err, "failed to get script complexities of account %s", addr.String())
+	}
+	versions := make([]int, 0, len(stored))
+	for ev := range stored {
+		versions = append(versions, ev)
+	}
+	sort.Ints(versions)
+	for _, ev := range versions {
+		rs.StoredComplexities = append(rs.StoredComplexities, newScriptComplexity(ev, stored[ev]))
+	}
+	return rs, nil
+}
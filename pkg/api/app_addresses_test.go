@@ -0,0 +1,169 @@
+package api
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/keyvalue"
+	"github.com/wavesplatform/gowaves/pkg/mock"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride"
+	"github.com/wavesplatform/gowaves/pkg/ride/serialization"
+	"github.com/wavesplatform/gowaves/pkg/services"
+	"github.com/wavesplatform/gowaves/pkg/signer"
+	"github.com/wavesplatform/gowaves/pkg/state"
+	"github.com/wavesplatform/gowaves/pkg/wallet"
+)
+
+func TestApp_SignVerifyText(t *testing.T) {
+	seed := []byte("sign text seed")
+	app, err := NewApp("api-key", nil, services.Services{Wallet: wallet.Stub{S: [][]byte{seed}}, Scheme: proto.TestNetScheme})
+	require.NoError(t, err)
+	_, pk, err := crypto.GenerateKeyPair(seed)
+	require.NoError(t, err)
+	addr, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+
+	signed, err := app.SignText(addr, "hello")
+	require.NoError(t, err)
+	assert.Equal(t, pk, signed.PublicKey)
+	assert.Equal(t, "hello", signed.Message)
+
+	rs, err := app.VerifyText(addr, VerifyTextRequest{Message: "hello", PublicKey: pk, Signature: signed.Signature})
+	require.NoError(t, err)
+	assert.True(t, rs.Valid)
+	rs, err = app.VerifyText(addr, VerifyTextRequest{Message: "hello!", PublicKey: pk, Signature: signed.Signature})
+	require.NoError(t, err)
+	assert.False(t, rs.Valid)
+
+	_, otherPK, err := crypto.GenerateKeyPair([]byte("other seed"))
+	require.NoError(t, err)
+	other := proto.MustAddressFromPublicKey(proto.TestNetScheme, otherPK)
+	_, err = app.SignText(other, "hello")
+	assert.Equal(t, apiErrs.MissingSenderPrivateKey, err)
+	_, err = app.VerifyText(other, VerifyTextRequest{Message: "hello", PublicKey: pk, Signature: signed.Signature})
+	assert.Error(t, err)
+}
+
+func TestApp_EncryptDecrypt(t *testing.T) {
+	seedA, seedB := []byte("encrypt seed A"), []byte("encrypt seed B")
+	app, err := NewApp("api-key", nil, services.Services{Wallet: wallet.Stub{S: [][]byte{seedA, seedB}}, Scheme: proto.TestNetScheme})
+	require.NoError(t, err)
+	_, pkA, err := crypto.GenerateKeyPair(seedA)
+	require.NoError(t, err)
+	_, pkB, err := crypto.GenerateKeyPair(seedB)
+	require.NoError(t, err)
+	addrA := proto.MustAddressFromPublicKey(proto.TestNetScheme, pkA)
+	addrB := proto.MustAddressFromPublicKey(proto.TestNetScheme, pkB)
+
+	enc, err := app.Encrypt(EncryptRequest{Address: addrA, PublicKey: pkB, Prefix: "test", Message: "secret"})
+	require.NoError(t, err)
+	dec, err := app.Decrypt(DecryptRequest{Address: addrB, PublicKey: pkA, Prefix: "test", Encrypted: enc.Encrypted})
+	require.NoError(t, err)
+	assert.Equal(t, "secret", dec.Message)
+
+	_, err = app.Decrypt(DecryptRequest{Address: addrB, PublicKey: pkA, Prefix: "other", Encrypted: enc.Encrypted})
+	assert.Error(t, err)
+	_, err = app.Decrypt(DecryptRequest{Address: addrB, PublicKey: pkA, Encrypted: "not base64!"})
+	assert.Equal(t, apiErrs.InvalidMessage, err)
+}
+
+// keysOnlySigner provides public keys but refuses to sign texts and derive keys like the remote signer.
+type keysOnlySigner struct {
+	*signer.Local
+}
+
+func (keysOnlySigner) SignText(crypto.PublicKey, []byte) (crypto.Signature, error) {
+	return crypto.Signature{}, errors.Wrap(signer.ErrNotSupported, "text signing")
+}
+
+func (keysOnlySigner) SharedKey(crypto.PublicKey, crypto.PublicKey, []byte) ([]byte, error) {
+	return nil, errors.Wrap(signer.ErrNotSupported, "shared key derivation")
+}
+
+func TestApp_SignerNotSupported(t *testing.T) {
+	seed := []byte("remote signer seed")
+	sgn := keysOnlySigner{signer.NewLocal(wallet.Stub{S: [][]byte{seed}}, proto.TestNetScheme, nil)}
+	app, err := NewApp("api-key", nil, services.Services{Wallet: signer.NewWallet(sgn), Signer: sgn, Scheme: proto.TestNetScheme})
+	require.NoError(t, err)
+	_, pk, err := crypto.GenerateKeyPair(seed)
+	require.NoError(t, err)
+	addr := proto.MustAddressFromPublicKey(proto.TestNetScheme, pk)
+
+	_, err = app.SignText(addr, "hello")
+	assert.IsType(t, &apiErrs.CustomValidationError{}, err)
+	assert.Contains(t, err.Error(), signer.ErrNotSupported.Error())
+	_, err = app.Encrypt(EncryptRequest{Address: addr, PublicKey: pk, Message: "secret"})
+	assert.IsType(t, &apiErrs.CustomValidationError{}, err)
+	assert.Contains(t, err.Error(), signer.ErrNotSupported.Error())
+}
+
+func TestApp_ScriptEstimateAndInfoMeta(t *testing.T) {
+	const code = "AAIFAAAAAAAAAAQIAhIAAAAAAAAAAAEAAAABaQEAAAAEY2FsbAAAAAAJAARMAAAAAgkBAAAADEJvb2xlYW5FbnRyeQAAAAICAAAAA2FiYwYFAAAAA25pbAAAAAEAAAACdHgBAAAABnZlcmlmeQAAAAAGzqWv4w=="
+	scriptBytes, err := base64.StdEncoding.DecodeString(code)
+	require.NoError(t, err)
+	tree, err := serialization.Parse(scriptBytes)
+	require.NoError(t, err)
+	est, err := ride.EstimateTree(tree, 3)
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	s := mock.NewMockState(ctrl)
+	s.EXPECT().EstimatorVersion().Return(3, nil).AnyTimes()
+	app, err := NewApp("api-key", nil, services.Services{State: s, Scheme: proto.TestNetScheme})
+	require.NoError(t, err)
+
+	rs, err := app.ScriptEstimate("base64:" + code)
+	require.NoError(t, err)
+	assert.Equal(t, "base64:"+code, rs.Script)
+	assert.Equal(t, 3, rs.ActiveEstimatorVersion)
+	assert.Equal(t, est.Estimation, rs.Complexity)
+	assert.Equal(t, est.Verifier, rs.VerifierComplexity)
+	assert.Equal(t, est.Functions, rs.CallableComplexities)
+	require.Len(t, rs.Estimations, ride.MaxEstimatorVersion)
+	for i, e := range rs.Estimations {
+		assert.Equal(t, i+1, e.EstimatorVersion)
+		assert.Empty(t, e.Error)
+		assert.Contains(t, e.CallableComplexities, "call")
+	}
+
+	_, err = app.ScriptEstimate("base64:!!!")
+	assert.IsType(t, &apiErrs.CustomValidationError{}, err)
+	_, err = app.ScriptEstimate(base64.StdEncoding.EncodeToString([]byte{0xff, 0x01}))
+	assert.IsType(t, &apiErrs.CustomValidationError{}, err)
+
+	_, scriptedPK, err := crypto.GenerateKeyPair([]byte("scripted seed"))
+	require.NoError(t, err)
+	scripted := proto.MustAddressFromPublicKey(proto.TestNetScheme, scriptedPK)
+	_, emptyPK, err := crypto.GenerateKeyPair([]byte("empty seed"))
+	require.NoError(t, err)
+	empty := proto.MustAddressFromPublicKey(proto.TestNetScheme, emptyPK)
+	s.EXPECT().ScriptInfoByAccount(proto.NewRecipientFromAddress(scripted)).
+		Return(&proto.ScriptInfo{Bytes: scriptBytes}, nil)
+	s.EXPECT().ScriptComplexitiesByAccount(proto.NewRecipientFromAddress(scripted)).
+		Return(map[int]ride.TreeEstimation{4: est, 3: est}, nil)
+	s.EXPECT().ScriptInfoByAccount(proto.NewRecipientFromAddress(empty)).
+		Return(nil, state.NewStateError(state.NotFoundError, keyvalue.ErrNotFound))
+
+	meta, err := app.ScriptInfoMeta(scripted)
+	require.NoError(t, err)
+	assert.Equal(t, scripted, meta.Address)
+	assert.Equal(t, 3, meta.ActiveEstimatorVersion)
+	require.Len(t, meta.StoredComplexities, 2)
+	assert.Equal(t, 3, meta.StoredComplexities[0].EstimatorVersion)
+	assert.Equal(t, 4, meta.StoredComplexities[1].EstimatorVersion)
+	assert.Equal(t, est.Verifier, meta.StoredComplexities[0].VerifierComplexity)
+	assert.Len(t, meta.Estimations, ride.MaxEstimatorVersion)
+
+	meta, err = app.ScriptInfoMeta(empty)
+	require.NoError(t, err)
+	assert.Empty(t, meta.StoredComplexities)
+	assert.Empty(t, meta.Estimations)
+}
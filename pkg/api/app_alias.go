@@ -0,0 +1,44 @@
+package api
+
+import (
+	"github.com/pkg/errors"
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/state"
+)
+
+type AliasAddress struct {
+	Address proto.WavesAddress `json:"address"`
+}
+
+// AddrByAlias returns the address the alias belongs to. Disabled aliases are reported as nonexistent.
+func (a *App) AddrByAlias(aliasStr string) (AliasAddress, error) {
+	alias := proto.NewAlias(a.services.Scheme, aliasStr)
+	if _, err := alias.Valid(); err != nil {
+		return AliasAddress{}, apiErrs.NewCustomValidationError(err.Error())
+	}
+	addr, err := a.state.AddrByAlias(*alias)
+	if err != nil {
+		if state.IsNotFound(err) {
+			return AliasAddress{}, apiErrs.NewAliasDoesNotExistError(alias.String())
+		}
+		return AliasAddress{}, errors.Wrapf(err, "failed to get address by alias %q", aliasStr)
+	}
+	return AliasAddress{Address: addr}, nil
+}
+
+// AliasesByAddr returns active aliases of the address.
+func (a *App) AliasesByAddr(addr proto.WavesAddress) ([]proto.Alias, error) {
+	infos, err := a.state.AliasesByAddr(addr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get aliases by address %q", addr.String())
+	}
+	aliases := make([]proto.Alias, 0, len(infos))
+	for _, info := range infos {
+		if info.Disabled {
+			continue
+		}
+		aliases = append(aliases, info.Alias)
+	}
+	return aliases, nil
+}
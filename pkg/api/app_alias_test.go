@@ -0,0 +1,49 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
+	"github.com/wavesplatform/gowaves/pkg/keyvalue"
+	"github.com/wavesplatform/gowaves/pkg/mock"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/services"
+	"github.com/wavesplatform/gowaves/pkg/state"
+)
+
+func TestApp_Aliases(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_, addr := leasingTestAccount(t, "alias owner")
+	st := mock.NewMockState(ctrl)
+	app, err := NewApp("api-key", nil, services.Services{State: st, Scheme: proto.TestNetScheme})
+	require.NoError(t, err)
+
+	st.EXPECT().AddrByAlias(*proto.NewAlias(proto.TestNetScheme, "owner")).Return(addr, nil)
+	rs, err := app.AddrByAlias("owner")
+	require.NoError(t, err)
+	assert.Equal(t, addr, rs.Address)
+
+	st.EXPECT().AddrByAlias(*proto.NewAlias(proto.TestNetScheme, "missing")).
+		Return(proto.WavesAddress{}, state.NewStateError(state.NotFoundError, keyvalue.ErrNotFound))
+	_, err = app.AddrByAlias("missing")
+	assert.IsType(t, &apiErrs.AliasDoesNotExistError{}, err)
+
+	_, err = app.AddrByAlias("Invalid alias")
+	assert.IsType(t, &apiErrs.CustomValidationError{}, err)
+
+	st.EXPECT().AliasesByAddr(addr).Return([]proto.AliasInfo{
+		{Alias: *proto.NewAlias(proto.TestNetScheme, "owner"), Height: 1},
+		{Alias: *proto.NewAlias(proto.TestNetScheme, "stolen"), Height: 2, Disabled: true},
+	}, nil)
+	aliases, err := app.AliasesByAddr(addr)
+	require.NoError(t, err)
+	js, err := json.Marshal(aliases)
+	require.NoError(t, err)
+	assert.JSONEq(t, `["alias:T:owner"]`, string(js))
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"github.com/pkg/errors"
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/state"
+)
+
+type RewardVotes struct {
+	Increase uint32 `json:"increase"`
+	Decrease uint32 `json:"decrease"`
+}
+
+// RewardsStatus describes the block reward and monetary policy voting at some height in the form of Scala node.
+type RewardsStatus struct {
+	Height              proto.Height `json:"height"`
+	TotalWavesAmount    uint64       `json:"totalWavesAmount"`
+	CurrentReward       uint64       `json:"currentReward"`
+	MinIncrement        uint64       `json:"minIncrement"`
+	Term                uint64       `json:"term"`
+	NextCheck           proto.Height `json:"nextCheck"`
+	VotingIntervalStart proto.Height `json:"votingIntervalStart"`
+	VotingInterval      uint64       `json:"votingInterval"`
+	VotingThreshold     uint64       `json:"votingThreshold"`
+	Votes               RewardVotes  `json:"votes"`
+}
+
+func newRewardsStatus(info *proto.RewardsInfo) RewardsStatus {
+	return RewardsStatus{
+		Height:              info.Height,
+		TotalWavesAmount:    info.TotalWavesAmount,
+		CurrentReward:       info.CurrentReward,
+		MinIncrement:        info.MinIncrement,
+		Term:                info.Term,
+		NextCheck:           info.NextCheck,
+		VotingIntervalStart: info.VotingIntervalStart,
+		VotingInterval:      info.VotingInterval,
+		VotingThreshold:     info.VotingThreshold,
+		Votes:               RewardVotes{Increase: info.VotesIncrease, Decrease: info.VotesDecrease},
+	}
+}
+
+// BlockchainRewards returns the rewards status at the given height or at the current height if height is zero.
+func (a *App) BlockchainRewards(height proto.Height) (RewardsStatus, error) {
+	rs, err := a.state.MapR(func(info state.StateInfo) (interface{}, error) {
+		h := height
+		if h == 0 {
+			var err error
+			h, err = info.Height()
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to get state height")
+			}
+		}
+		return info.RewardsInfo(h)
+	})
+	if err != nil {
+		switch {
+		case state.IsNotFound(err):
+			return RewardsStatus{}, apiErrs.NewCustomValidationError("Block reward feature is not activated yet")
+		case state.IsInvalidInput(err):
+			return RewardsStatus{}, apiErrs.NewCustomValidationError(err.Error())
+		default:
+			return RewardsStatus{}, errors.Wrapf(err, "failed to get rewards at height %d", height)
+		}
+	}
+	return newRewardsStatus(rs.(*proto.RewardsInfo)), nil
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
+	"github.com/wavesplatform/gowaves/pkg/mock"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/services"
+	"github.com/wavesplatform/gowaves/pkg/state"
+)
+
+func TestApp_BlockchainRewards(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mock.NewMockState(ctrl)
+	st.EXPECT().MapR(gomock.Any()).DoAndReturn(func(f func(state.StateInfo) (interface{}, error)) (interface{}, error) {
+		return f(st)
+	}).AnyTimes()
+	app, err := NewApp("api-key", nil, services.Services{State: st, Scheme: proto.TestNetScheme})
+	require.NoError(t, err)
+
+	info := &proto.RewardsInfo{
+		Height:              1609,
+		TotalWavesAmount:    10000000000000000 + 1000*600000000,
+		CurrentReward:       600000000,
+		MinIncrement:        50000000,
+		Term:                100000,
+		NextCheck:           100609,
+		VotingIntervalStart: 90610,
+		VotingInterval:      10000,
+		VotingThreshold:     5001,
+		VotesIncrease:       3,
+		VotesDecrease:       1,
+	}
+	st.EXPECT().Height().Return(proto.Height(1609), nil)
+	st.EXPECT().RewardsInfo(proto.Height(1609)).Return(info, nil)
+	rs, err := app.BlockchainRewards(0)
+	require.NoError(t, err)
+	js, err := json.Marshal(rs)
+	require.NoError(t, err)
+	expected := `{"height":1609,"totalWavesAmount":10000600000000000,"currentReward":600000000,"minIncrement":50000000,` +
+		`"term":100000,"nextCheck":100609,"votingIntervalStart":90610,"votingInterval":10000,"votingThreshold":5001,` +
+		`"votes":{"increase":3,"decrease":1}}`
+	assert.JSONEq(t, expected, string(js))
+
+	st.EXPECT().RewardsInfo(proto.Height(10)).
+		Return(nil, state.NewStateError(state.NotFoundError, errors.New("not activated")))
+	_, err = app.BlockchainRewards(10)
+	assert.IsType(t, &apiErrs.CustomValidationError{}, err)
+
+	st.EXPECT().RewardsInfo(proto.Height(2000)).
+		Return(nil, state.NewStateError(state.InvalidInputError, errors.New("invalid height")))
+	_, err = app.BlockchainRewards(2000)
+	assert.IsType(t, &apiErrs.CustomValidationError{}, err)
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/consensus"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/state"
+)
+
+const (
+	defaultForecastBlocks = 1000
+	maxForecastBlocks     = 100000
+)
+
+type ConsensusGeneratingBalance struct {
+	Address proto.WavesAddress `json:"address"`
+	Height  proto.Height       `json:"height"`
+	consensus.GeneratingBalance
+}
+
+type ConsensusForecast struct {
+	Address proto.WavesAddress `json:"address"`
+	Height  proto.Height       `json:"height"`
+	consensus.Forecast
+}
+
+func (a *App) ConsensusGeneratingBalance(addr proto.WavesAddress) (ConsensusGeneratingBalance, error) {
+	rs, err := a.state.MapR(func(info state.StateInfo) (interface{}, error) {
+		f, height, err := forecaster(info)
+		if err != nil {
+			return nil, err
+		}
+		gb, err := f.GeneratingBalance(addr, height)
+		if err != nil {
+			return nil, err
+		}
+		return ConsensusGeneratingBalance{Address: addr, Height: height, GeneratingBalance: gb}, nil
+	})
+	if err != nil {
+		return ConsensusGeneratingBalance{}, err
+	}
+	return rs.(ConsensusGeneratingBalance), nil
+}
+
+func (a *App) ConsensusForecast(addr proto.WavesAddress, blocks uint64) (ConsensusForecast, error) {
+	rs, err := a.state.MapR(func(info state.StateInfo) (interface{}, error) {
+		f, height, err := forecaster(info)
+		if err != nil {
+			return nil, err
+		}
+		fc, err := f.Forecast(addr, height, blocks)
+		if err != nil {
+			return nil, err
+		}
+		return ConsensusForecast{Address: addr, Height: height, Forecast: fc}, nil
+	})
+	if err != nil {
+		return ConsensusForecast{}, err
+	}
+	return rs.(ConsensusForecast), nil
+}
+
+func forecaster(info state.StateInfo) (*consensus.Forecaster, proto.Height, error) {
+	height, err := info.Height()
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to get state height")
+	}
+	bs, err := info.BlockchainSettings()
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to get blockchain settings")
+	}
+	return consensus.NewForecaster(info, bs), height, nil
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/state"
+)
+
+// maxLeasingInfoIDs limits the number of leases requested at once.
+const maxLeasingInfoIDs = 100
+
+type LeaseStatus string
+
+const (
+	LeaseStatusActive   LeaseStatus = "active"
+	LeaseStatusCanceled LeaseStatus = "canceled"
+)
+
+// LeaseOrigin is the kind of transaction that created the lease.
+type LeaseOrigin string
+
+const (
+	LeaseOriginLease  LeaseOrigin = "lease"
+	LeaseOriginInvoke LeaseOrigin = "invoke"
+)
+
+type LeaseInfo struct {
+	ID                  crypto.Digest      `json:"id"`
+	OriginTransactionID crypto.Digest      `json:"originTransactionId"`
+	Origin              LeaseOrigin        `json:"origin"`
+	Sender              proto.WavesAddress `json:"sender"`
+	Recipient           proto.WavesAddress `json:"recipient"`
+	Amount              uint64             `json:"amount"`
+	Height              uint64             `json:"height"`
+	Status              LeaseStatus        `json:"status"`
+	CancelHeight        *uint64            `json:"cancelHeight"`
+	CancelTransactionID *crypto.Digest     `json:"cancelTransactionId"`
+}
+
+func newLeaseInfo(id crypto.Digest, l *proto.LeaseInfo) LeaseInfo {
+	li := LeaseInfo{
+		ID:                  id,
+		OriginTransactionID: id,
+		Origin:              LeaseOriginLease,
+		Sender:              l.Sender,
+		Recipient:           l.Recipient,
+		Amount:              l.LeaseAmount,
+		Height:              l.Height,
+		Status:              LeaseStatusActive,
+	}
+	if l.OriginTransactionID != nil && *l.OriginTransactionID != id {
+		li.OriginTransactionID = *l.OriginTransactionID
+		li.Origin = LeaseOriginInvoke
+	}
+	if !l.IsActive {
+		li.Status = LeaseStatusCanceled
+		if l.CancelTransactionID != nil {
+			h := l.CancelHeight
+			li.CancelHeight = &h
+			li.CancelTransactionID = l.CancelTransactionID
+		}
+	}
+	return li
+}
+
+// LeasingInfo returns the information about leases by their IDs, error InvalidIdsError lists unknown IDs.
+func (a *App) LeasingInfo(ids []crypto.Digest) ([]LeaseInfo, error) {
+	if len(ids) > maxLeasingInfoIDs {
+		return nil, apiErrs.NewTooBigArrayAllocationError(maxLeasingInfoIDs)
+	}
+	r := make([]LeaseInfo, 0, len(ids))
+	var invalid []string
+	for _, id := range ids {
+		l, err := a.state.LeasingInfo(id)
+		if err != nil {
+			if state.IsNotFound(err) {
+				invalid = append(invalid, id.String())
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to get leasing info by id %q", id.String())
+		}
+		r = append(r, newLeaseInfo(id, l))
+	}
+	if len(invalid) > 0 {
+		return nil, apiErrs.NewInvalidIdsError(invalid)
+	}
+	return r, nil
+}
+
+// LeasingActive returns active leases where the address is the lessor or the recipient.
+func (a *App) LeasingActive(addr proto.WavesAddress) ([]LeaseInfo, error) {
+	return a.addressLeases(addr, true, nil)
+}
+
+// LeasingHistory returns all leases, active and canceled, created by the address with Lease transactions
+// or by the address' dApp with Invoke transactions.
+func (a *App) LeasingHistory(addr proto.WavesAddress) ([]LeaseInfo, error) {
+	return a.addressLeases(addr, false, func(l LeaseInfo) bool {
+		return l.Sender == addr
+	})
+}
+
+// addressLeases returns leases sent or received by the address taken from the state index of leases,
+// the most recent leases go first. Nil filter accepts all leases.
+func (a *App) addressLeases(addr proto.WavesAddress, activeOnly bool, filter func(l LeaseInfo) bool) ([]LeaseInfo, error) {
+	ids, err := a.state.LeasesByAddr(addr, activeOnly)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get leases of address %q", addr.String())
+	}
+	r := make([]LeaseInfo, 0, len(ids))
+	for _, id := range ids {
+		l, err := a.state.LeasingInfo(id)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get leasing info by id %q", id.String())
+		}
+		if li := newLeaseInfo(id, l); filter == nil || filter(li) {
+			r = append(r, li)
+		}
+	}
+	sort.SliceStable(r, func(i, j int) bool {
+		return r[i].Height > r[j].Height
+	})
+	return r, nil
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/keyvalue"
+	"github.com/wavesplatform/gowaves/pkg/mock"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/services"
+)
+
+func leasingTestAccount(t *testing.T, seed string) (crypto.PublicKey, proto.WavesAddress) {
+	_, pk, err := crypto.GenerateKeyPair([]byte(seed))
+	require.NoError(t, err)
+	addr, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+	return pk, addr
+}
+
+func TestApp_LeasingActiveAndHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_, dApp := leasingTestAccount(t, "dapp")
+	_, sender := leasingTestAccount(t, "sender")
+	_, recipient := leasingTestAccount(t, "recipient")
+
+	leaseID := crypto.MustFastHash([]byte("lease"))
+	invokeID := crypto.MustFastHash([]byte("invoke"))
+	activeID := crypto.MustFastHash([]byte("active"))
+	canceledID := crypto.MustFastHash([]byte("canceled"))
+	cancelTxID := crypto.MustFastHash([]byte("cancel"))
+
+	infos := map[crypto.Digest]*proto.LeaseInfo{
+		leaseID: {IsActive: true, LeaseAmount: 100, Sender: sender, Recipient: dApp, Height: 1,
+			OriginTransactionID: &leaseID},
+		activeID: {IsActive: true, LeaseAmount: 200, Sender: dApp, Recipient: recipient, Height: 3,
+			OriginTransactionID: &invokeID},
+		canceledID: {IsActive: false, LeaseAmount: 300, Sender: dApp, Recipient: recipient, Height: 2,
+			OriginTransactionID: &invokeID, CancelHeight: 3, CancelTransactionID: &cancelTxID},
+	}
+
+	s := mock.NewMockState(ctrl)
+	s.EXPECT().LeasesByAddr(dApp, true).Return([]crypto.Digest{leaseID, activeID}, nil)
+	s.EXPECT().LeasesByAddr(dApp, false).Return([]crypto.Digest{leaseID, canceledID, activeID}, nil)
+	s.EXPECT().LeasingInfo(gomock.Any()).DoAndReturn(func(id crypto.Digest) (*proto.LeaseInfo, error) {
+		return infos[id], nil
+	}).AnyTimes()
+
+	app, err := NewApp("api-key", nil, services.Services{State: s, Scheme: proto.TestNetScheme})
+	require.NoError(t, err)
+
+	active, err := app.LeasingActive(dApp)
+	require.NoError(t, err)
+	require.Len(t, active, 2)
+	assert.Equal(t, activeID, active[0].ID)
+	assert.Equal(t, invokeID, active[0].OriginTransactionID)
+	assert.Equal(t, LeaseOriginInvoke, active[0].Origin)
+	assert.Equal(t, leaseID, active[1].ID)
+	assert.Equal(t, LeaseOriginLease, active[1].Origin)
+	assert.Equal(t, sender, active[1].Sender)
+
+	history, err := app.LeasingHistory(dApp)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, activeID, history[0].ID)
+	assert.Equal(t, canceledID, history[1].ID)
+	assert.Equal(t, LeaseStatusCanceled, history[1].Status)
+	require.NotNil(t, history[1].CancelHeight)
+	assert.Equal(t, uint64(3), *history[1].CancelHeight)
+	assert.Equal(t, &cancelTxID, history[1].CancelTransactionID)
+}
+
+func TestApp_LeasingInfo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_, sender := leasingTestAccount(t, "sender")
+	_, recipient := leasingTestAccount(t, "recipient")
+	known := crypto.MustFastHash([]byte("known"))
+	unknown := crypto.MustFastHash([]byte("unknown"))
+
+	s := mock.NewMockState(ctrl)
+	s.EXPECT().LeasingInfo(known).Return(&proto.LeaseInfo{
+		IsActive: true, LeaseAmount: 100, Sender: sender, Recipient: recipient, Height: 10, OriginTransactionID: &known,
+	}, nil).Times(2)
+	s.EXPECT().LeasingInfo(unknown).Return(nil, keyvalue.ErrNotFound)
+
+	app, err := NewApp("api-key", nil, services.Services{State: s})
+	require.NoError(t, err)
+
+	infos, err := app.LeasingInfo([]crypto.Digest{known})
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	js, err := json.Marshal(infos[0])
+	require.NoError(t, err)
+	expected := `{"id":"` + known.String() + `","originTransactionId":"` + known.String() + `","origin":"lease",` +
+		`"sender":"` + sender.String() + `","recipient":"` + recipient.String() + `","amount":100,"height":10,` +
+		`"status":"active","cancelHeight":null,"cancelTransactionId":null}`
+	assert.JSONEq(t, expected, string(js))
+
+	_, err = app.LeasingInfo([]crypto.Digest{known, unknown})
+	var invalidIds *apiErrs.InvalidIdsError
+	require.ErrorAs(t, err, &invalidIds)
+	assert.Equal(t, []string{unknown.String()}, invalidIds.Ids)
+}
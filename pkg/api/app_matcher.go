@@ -0,0 +1,101 @@
+package api
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/state"
+)
+
+const (
+	orderStatusNotFound        = "NotFound"
+	orderStatusPartiallyFilled = "PartiallyFilled"
+	orderStatusFilled          = "Filled"
+)
+
+// OrderFill describes the filling of the order by exchange transactions.
+type OrderFill struct {
+	Amount          uint64          `json:"amount"`
+	FilledAmount    uint64          `json:"filledAmount"`
+	RemainingAmount uint64          `json:"remainingAmount"`
+	MatcherFee      uint64          `json:"matcherFee"`
+	FilledFee       uint64          `json:"filledFee"`
+	RemainingFee    uint64          `json:"remainingFee"`
+	Transactions    []crypto.Digest `json:"transactions"`
+}
+
+// OrderStatus is the status of the order on the blockchain. Fill details are omitted for not found orders.
+type OrderStatus struct {
+	ID     crypto.Digest `json:"id"`
+	Status string        `json:"status"`
+	*OrderFill
+}
+
+// OrderStatus returns the status of the order that was used in exchange transactions.
+func (a *App) OrderStatus(orderID crypto.Digest) (OrderStatus, error) {
+	rs, err := a.state.MapR(func(info state.StateInfo) (interface{}, error) {
+		fill, err := info.OrderFillInfo(orderID)
+		if err != nil {
+			return nil, err
+		}
+		if len(fill.Transactions) == 0 {
+			return nil, errors.Errorf("no exchange transactions for order %s", orderID.String())
+		}
+		tx, err := info.TransactionByID(fill.Transactions[0].Bytes())
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get exchange transaction %s", fill.Transactions[0].String())
+		}
+		order, err := exchangeOrderByID(tx, orderID)
+		if err != nil {
+			return nil, err
+		}
+		return newOrderStatus(orderID, order, fill), nil
+	})
+	if err != nil {
+		if state.IsNotFound(err) {
+			return OrderStatus{ID: orderID, Status: orderStatusNotFound}, nil
+		}
+		return OrderStatus{}, errors.Wrapf(err, "failed to get status of order %s", orderID.String())
+	}
+	return rs.(OrderStatus), nil
+}
+
+func newOrderStatus(id crypto.Digest, order proto.Order, fill *proto.OrderFillInfo) OrderStatus {
+	f := &OrderFill{
+		Amount:       order.GetAmount(),
+		FilledAmount: fill.FilledAmount,
+		MatcherFee:   order.GetMatcherFee(),
+		FilledFee:    fill.FilledFee,
+		Transactions: fill.Transactions,
+	}
+	if f.Amount > f.FilledAmount {
+		f.RemainingAmount = f.Amount - f.FilledAmount
+	}
+	if f.MatcherFee > f.FilledFee {
+		f.RemainingFee = f.MatcherFee - f.FilledFee
+	}
+	status := orderStatusPartiallyFilled
+	if f.RemainingAmount == 0 {
+		status = orderStatusFilled
+	}
+	return OrderStatus{ID: id, Status: status, OrderFill: f}
+}
+
+func exchangeOrderByID(tx proto.Transaction, id crypto.Digest) (proto.Order, error) {
+	exchange, ok := tx.(proto.Exchange)
+	if !ok {
+		return nil, errors.Errorf("unexpected transaction type %T", tx)
+	}
+	for _, order := range []proto.Order{exchange.GetOrder1(), exchange.GetOrder2()} {
+		orderID, err := order.GetID()
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(orderID, id.Bytes()) {
+			return order, nil
+		}
+	}
+	return nil, errors.Errorf("order %s not found in exchange transaction", id.String())
+}
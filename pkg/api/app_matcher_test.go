@@ -0,0 +1,73 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/mock"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/services"
+	"github.com/wavesplatform/gowaves/pkg/state"
+)
+
+func TestApp_OrderStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mock.NewMockState(ctrl)
+	st.EXPECT().MapR(gomock.Any()).DoAndReturn(func(f func(state.StateInfo) (interface{}, error)) (interface{}, error) {
+		return f(st)
+	}).AnyTimes()
+	app, err := NewApp("api-key", nil, services.Services{State: st, Scheme: proto.TestNetScheme})
+	require.NoError(t, err)
+
+	sk, pk, err := crypto.GenerateKeyPair([]byte("trader"))
+	require.NoError(t, err)
+	msk, mpk, err := crypto.GenerateKeyPair([]byte("matcher"))
+	require.NoError(t, err)
+	waves := proto.NewOptionalAssetWaves()
+	asset := *proto.NewOptionalAssetFromDigest(crypto.MustFastHash([]byte("asset")))
+	buy := proto.NewUnsignedOrderV3(pk, mpk, asset, waves, proto.Buy, 100, 1000, 1, 2, 300000, waves)
+	require.NoError(t, buy.Sign(proto.TestNetScheme, sk))
+	sell := proto.NewUnsignedOrderV3(pk, mpk, asset, waves, proto.Sell, 100, 400, 1, 2, 300000, waves)
+	require.NoError(t, sell.Sign(proto.TestNetScheme, sk))
+	tx := proto.NewUnsignedExchangeWithProofs(2, buy, sell, 100, 400, 120000, 300000, 300000, 1)
+	require.NoError(t, tx.Sign(proto.TestNetScheme, msk))
+	txID, err := tx.GetID(proto.TestNetScheme)
+	require.NoError(t, err)
+	id, err := crypto.NewDigestFromBytes(txID)
+	require.NoError(t, err)
+
+	buyID, err := buy.GetID()
+	require.NoError(t, err)
+	orderID, err := crypto.NewDigestFromBytes(buyID)
+	require.NoError(t, err)
+	st.EXPECT().OrderFillInfo(orderID).Return(&proto.OrderFillInfo{
+		FilledAmount: 400,
+		FilledFee:    120000,
+		Transactions: []crypto.Digest{id},
+	}, nil)
+	st.EXPECT().TransactionByID(id.Bytes()).Return(tx, nil)
+	rs, err := app.OrderStatus(orderID)
+	require.NoError(t, err)
+	js, err := json.Marshal(rs)
+	require.NoError(t, err)
+	expected := `{"id":"` + orderID.String() + `","status":"PartiallyFilled","amount":1000,"filledAmount":400,` +
+		`"remainingAmount":600,"matcherFee":300000,"filledFee":120000,"remainingFee":180000,"transactions":["` +
+		id.String() + `"]}`
+	assert.JSONEq(t, expected, string(js))
+
+	missing := crypto.MustFastHash([]byte("missing"))
+	st.EXPECT().OrderFillInfo(missing).
+		Return(nil, state.NewStateError(state.NotFoundError, errors.New("order is not filled")))
+	rs, err = app.OrderStatus(missing)
+	require.NoError(t, err)
+	js, err = json.Marshal(rs)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"`+missing.String()+`","status":"NotFound"}`, string(js))
+}
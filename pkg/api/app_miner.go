@@ -1,9 +1,15 @@
 package api
 
 import (
+	"encoding/json"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/miner/monitor"
+	"github.com/wavesplatform/gowaves/pkg/miner/selector"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/types"
 )
 
 type Scheduler struct {
@@ -17,6 +23,7 @@ type Next struct {
 
 type MinerInfo struct {
 	Scheduler Scheduler
+	Selection *selector.Report
 }
 
 func (a *App) Miner() MinerInfo {
@@ -25,15 +32,75 @@ func (a *App) Miner() MinerInfo {
 	next := make([]Next, 0, len(e))
 	for _, row := range e {
 		next = append(next, Next{
-			PublicKey: row.KeyPair.Public,
+			PublicKey: row.PublicKey,
 			Time:      time.Unix(int64(row.Timestamp/1000), 0).Add(time.Duration(row.Timestamp%1000) * time.Millisecond),
 		})
 	}
 
+	var selection *selector.Report
+	if a.services.TxSelector != nil {
+		selection = a.services.TxSelector.LastReport()
+	}
+
 	return MinerInfo{
 		Scheduler: Scheduler{
 			TimeNow: time.Now(),
 			Next:    next,
 		},
+		Selection: selection,
+	}
+}
+
+type MinerBundle struct {
+	IDs []crypto.Digest `json:"ids"`
+}
+
+// MinerAddBundle adds transactions to UTX pool as a bundle that is mined all together in the given order or not at all.
+// Bundle transactions are not broadcast to other nodes.
+func (a *App) MinerAddBundle(b []byte) (MinerBundle, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return MinerBundle{}, &BadRequestError{err}
 	}
+	if len(raw) == 0 {
+		return MinerBundle{}, &BadRequestError{errors.New("empty bundle")}
+	}
+	bundle := make(types.TransactionsBundle, len(raw))
+	ids := make([]crypto.Digest, len(raw))
+	for i, txJSON := range raw {
+		tx, err := unmarshalTransactionJSON(txJSON)
+		if err != nil {
+			return MinerBundle{}, err
+		}
+		if err := tx.GenerateID(a.services.Scheme); err != nil {
+			return MinerBundle{}, &BadRequestError{err}
+		}
+		id, err := tx.GetID(a.services.Scheme)
+		if err != nil {
+			return MinerBundle{}, &BadRequestError{err}
+		}
+		ids[i], err = crypto.NewDigestFromBytes(id)
+		if err != nil {
+			return MinerBundle{}, &BadRequestError{err}
+		}
+		bts, err := proto.MarshalTx(a.services.Scheme, tx)
+		if err != nil {
+			return MinerBundle{}, &BadRequestError{err}
+		}
+		bundle[i] = &types.TransactionWithBytes{T: tx, B: bts}
+	}
+	if err := a.utx.AddBundle(bundle); err != nil {
+		return MinerBundle{}, &BadRequestError{errors.Wrap(err, "failed to add bundle")}
+	}
+	return MinerBundle{IDs: ids}, nil
+}
+
+// MinerProduction returns the state of key blocks and microblocks production.
+func (a *App) MinerProduction() monitor.Status {
+	return a.services.MinerMonitor.Status()
+}
+
+// MinerProductionEvents subscribes to the events of key blocks and microblocks production.
+func (a *App) MinerProductionEvents() (<-chan monitor.Event, func()) {
+	return a.services.MinerMonitor.Subscribe()
 }
@@ -0,0 +1,157 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/state"
+	"github.com/wavesplatform/gowaves/pkg/txbuilder"
+)
+
+// maxTransactionProofsIDs limits the number of transactions proofs requested at once.
+const maxTransactionProofsIDs = 100
+
+// TransactionProof is the proof of transaction inclusion into the transactions root of the block.
+// MerkleProof is ordered from the root to the leaf, as expected by Ride function createMerkleRoot.
+type TransactionProof struct {
+	ID               crypto.Digest   `json:"id"`
+	Height           proto.Height    `json:"height"`
+	BlockID          proto.BlockID   `json:"blockId"`
+	TransactionIndex int             `json:"transactionIndex"`
+	MerkleProof      []crypto.Digest `json:"merkleProof"`
+}
+
+// TransactionsMerkleProofs returns proofs of inclusion into blocks for the transactions.
+// Error InvalidIdsError lists IDs of unknown transactions.
+func (a *App) TransactionsMerkleProofs(ids []crypto.Digest) ([]TransactionProof, error) {
+	if len(ids) > maxTransactionProofsIDs {
+		return nil, apiErrs.NewTooBigArrayAllocationError(maxTransactionProofsIDs)
+	}
+	r := make([]TransactionProof, 0, len(ids))
+	blocks := make(map[proto.Height]*proto.Block)
+	var invalid []string
+	for _, id := range ids {
+		height, err := a.state.TransactionHeightByID(id.Bytes())
+		if err != nil {
+			if state.IsNotFound(err) {
+				invalid = append(invalid, id.String())
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to get height of transaction %q", id.String())
+		}
+		block, ok := blocks[height]
+		if !ok {
+			block, err = a.state.BlockByHeight(height)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get block at height %d", height)
+			}
+			blocks[height] = block
+		}
+		if block.Version < proto.ProtobufBlockVersion {
+			return nil, apiErrs.NewCustomValidationError(
+				fmt.Sprintf("transaction %s is in block of version %d without transactions root", id.String(), block.Version),
+			)
+		}
+		index, err := a.transactionIndex(block, id)
+		if err != nil {
+			return nil, err
+		}
+		proofs, err := block.TransactionProof(a.services.Scheme, index)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to build proof for transaction %q", id.String())
+		}
+		r = append(r, TransactionProof{
+			ID:               id,
+			Height:           height,
+			BlockID:          block.BlockID(),
+			TransactionIndex: index,
+			MerkleProof:      proofs,
+		})
+	}
+	if len(invalid) > 0 {
+		return nil, apiErrs.NewInvalidIdsError(invalid)
+	}
+	return r, nil
+}
+
+func (a *App) transactionIndex(block *proto.Block, id crypto.Digest) (int, error) {
+	for i, tx := range block.Transactions {
+		txID, err := tx.GetID(a.services.Scheme)
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to get transaction ID")
+		}
+		if bytes.Equal(txID, id.Bytes()) {
+			return i, nil
+		}
+	}
+	return 0, errors.Errorf("transaction %q not found in block %q", id.String(), block.BlockID().String())
+}
+
+// FeeCalculation is the minimal fee of transaction in the fee asset and in Waves.
+type FeeCalculation struct {
+	FeeAssetID     proto.OptionalAsset `json:"feeAssetId"`
+	FeeAmount      uint64              `json:"feeAmount"`
+	WavesFeeAmount uint64              `json:"wavesFeeAmount"`
+}
+
+// TransactionsCalculateFee calculates the minimal fee of unsigned transaction given in JSON.
+// The fee is paid in the asset from the field feeAssetId, if it's set. Only transfer and invoke script
+// transactions can be paid in sponsored asset. The fee of invoke transactions isn't calculated, because it depends
+// on the result of invocation.
+func (a *App) TransactionsCalculateFee(b []byte) (FeeCalculation, error) {
+	tx, err := unmarshalTransactionJSON(b)
+	if err != nil {
+		return FeeCalculation{}, err
+	}
+	fa := struct {
+		FeeAssetID proto.OptionalAsset `json:"feeAssetId"`
+	}{}
+	if err := json.Unmarshal(b, &fa); err != nil {
+		return FeeCalculation{}, &BadRequestError{err}
+	}
+	if fa.FeeAssetID.Present {
+		if err := a.checkFeeAsset(tx, fa.FeeAssetID); err != nil {
+			return FeeCalculation{}, err
+		}
+	}
+	waves, inAsset, err := txbuilder.MinFee(a.services.Scheme, txbuilder.NewStateBlockchain(a.state), tx, fa.FeeAssetID)
+	if errors.Is(err, txbuilder.ErrInvokeFee) {
+		return FeeCalculation{}, apiErrs.NewCustomValidationError(err.Error())
+	}
+	if err != nil {
+		return FeeCalculation{}, errors.Wrap(err, "failed to calculate minimal fee")
+	}
+	r := FeeCalculation{FeeAssetID: fa.FeeAssetID, FeeAmount: waves, WavesFeeAmount: waves}
+	if fa.FeeAssetID.Present {
+		r.FeeAmount = inAsset
+	}
+	return r, nil
+}
+
+func (a *App) checkFeeAsset(tx proto.Transaction, asset proto.OptionalAsset) error {
+	switch tx.GetTypeInfo().Type {
+	case proto.TransferTransaction, proto.InvokeScriptTransaction:
+	default:
+		return apiErrs.NewCustomValidationError(
+			fmt.Sprintf("fee of transaction type %d can't be paid in asset", tx.GetTypeInfo().Type),
+		)
+	}
+	info, err := a.state.FullAssetInfo(proto.AssetIDFromDigest(asset.ID))
+	if err != nil {
+		if state.IsNotFound(err) {
+			return apiErrs.NewCustomValidationError(fmt.Sprintf("unknown asset %s", asset.String()))
+		}
+		return errors.Wrapf(err, "failed to get info of asset %s", asset.String())
+	}
+	if info.SponsorshipCost == 0 {
+		return apiErrs.NewCustomValidationError(
+			fmt.Sprintf("Asset %s is not sponsored, cannot be used to pay fees", asset.String()),
+		)
+	}
+	return nil
+}
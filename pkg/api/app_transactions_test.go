@@ -0,0 +1,72 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/keyvalue"
+	"github.com/wavesplatform/gowaves/pkg/mock"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/services"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+	"github.com/wavesplatform/gowaves/pkg/state"
+)
+
+func TestApp_TransactionsCalculateFee(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	senderPK, _ := leasingTestAccount(t, "sender")
+	_, recipient := leasingTestAccount(t, "recipient")
+	sponsored := crypto.MustFastHash([]byte("sponsored"))
+	notSponsored := crypto.MustFastHash([]byte("not sponsored"))
+
+	s := mock.NewMockState(ctrl)
+	s.EXPECT().IsActivated(gomock.Any()).DoAndReturn(func(featureID int16) (bool, error) {
+		return featureID == int16(settings.SmartAccounts), nil
+	}).AnyTimes()
+	s.EXPECT().ScriptInfoByAccount(gomock.Any()).
+		Return(nil, state.NewStateError(state.NotFoundError, keyvalue.ErrNotFound)).AnyTimes()
+	s.EXPECT().AssetInfo(proto.AssetIDFromDigest(sponsored)).Return(&proto.AssetInfo{ID: sponsored}, nil).AnyTimes()
+	s.EXPECT().FullAssetInfo(proto.AssetIDFromDigest(sponsored)).
+		Return(&proto.FullAssetInfo{AssetInfo: proto.AssetInfo{ID: sponsored}, SponsorshipCost: 10}, nil).AnyTimes()
+	s.EXPECT().FullAssetInfo(proto.AssetIDFromDigest(notSponsored)).
+		Return(&proto.FullAssetInfo{AssetInfo: proto.AssetInfo{ID: notSponsored}}, nil).AnyTimes()
+
+	app, err := NewApp("api-key", nil, services.Services{State: s, Scheme: proto.TestNetScheme})
+	require.NoError(t, err)
+
+	calculate := func(feeAsset proto.OptionalAsset) (FeeCalculation, error) {
+		tx := proto.NewUnsignedTransferWithProofs(2, senderPK, proto.NewOptionalAssetWaves(), feeAsset, 1, 1, 0,
+			proto.NewRecipientFromAddress(recipient), nil)
+		js, err := json.Marshal(tx)
+		require.NoError(t, err)
+		return app.TransactionsCalculateFee(js)
+	}
+
+	rs, err := calculate(proto.NewOptionalAssetWaves())
+	require.NoError(t, err)
+	assert.Equal(t, FeeCalculation{FeeAssetID: proto.NewOptionalAssetWaves(), FeeAmount: 100000, WavesFeeAmount: 100000}, rs)
+
+	rs, err = calculate(*proto.NewOptionalAssetFromDigest(sponsored))
+	require.NoError(t, err)
+	assert.Equal(t, FeeCalculation{FeeAssetID: *proto.NewOptionalAssetFromDigest(sponsored), FeeAmount: 10, WavesFeeAmount: 100000}, rs)
+
+	_, err = calculate(*proto.NewOptionalAssetFromDigest(notSponsored))
+	assert.IsType(t, &apiErrs.CustomValidationError{}, err)
+
+	invoke := proto.NewUnsignedInvokeScriptWithProofs(2, proto.TestNetScheme, senderPK,
+		proto.NewRecipientFromAddress(recipient), proto.FunctionCall{Default: true}, nil, proto.NewOptionalAssetWaves(), 0, 1)
+	js, err := json.Marshal(invoke)
+	require.NoError(t, err)
+	_, err = app.TransactionsCalculateFee(js)
+	assert.IsType(t, &apiErrs.CustomValidationError{}, err)
+
+	_, err = app.TransactionsCalculateFee([]byte("{"))
+	assert.IsType(t, &BadRequestError{}, err)
+}
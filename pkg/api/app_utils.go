@@ -0,0 +1,167 @@
+package api
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride"
+	"github.com/wavesplatform/gowaves/pkg/ride/ast"
+	"github.com/wavesplatform/gowaves/pkg/ride/serialization"
+)
+
+const base64Prefix = "base64:"
+
+// EncryptRequest is the request to encrypt message from wallet account to the owner of public key.
+type EncryptRequest struct {
+	Address   proto.WavesAddress `json:"address"`
+	PublicKey crypto.PublicKey   `json:"publicKey"`
+	Prefix    string             `json:"prefix"`
+	Message   string             `json:"message"`
+}
+
+type EncryptedMessage struct {
+	Encrypted string `json:"encrypted"`
+}
+
+// DecryptRequest is the request to decrypt message sent by the owner of public key to wallet account.
+type DecryptRequest struct {
+	Address   proto.WavesAddress `json:"address"`
+	PublicKey crypto.PublicKey   `json:"publicKey"`
+	Prefix    string             `json:"prefix"`
+	Encrypted string             `json:"encrypted"`
+}
+
+type DecryptedMessage struct {
+	Message string `json:"message"`
+}
+
+// Encrypt encrypts message with the shared key of node's account and the recipient's public key.
+// The encrypted message is encoded with Base64.
+func (a *App) Encrypt(req EncryptRequest) (EncryptedMessage, error) {
+	key, err := a.sharedKey(req.Address, req.PublicKey, req.Prefix)
+	if err != nil {
+		return EncryptedMessage{}, err
+	}
+	encrypted, err := crypto.Encrypt(key, []byte(req.Message))
+	if err != nil {
+		return EncryptedMessage{}, errors.Wrap(err, "failed to encrypt message")
+	}
+	return EncryptedMessage{Encrypted: base64.StdEncoding.EncodeToString(encrypted)}, nil
+}
+
+// Decrypt decrypts Base64 encoded message with the shared key of node's account and the sender's public key.
+func (a *App) Decrypt(req DecryptRequest) (DecryptedMessage, error) {
+	encrypted, err := base64.StdEncoding.DecodeString(req.Encrypted)
+	if err != nil {
+		return DecryptedMessage{}, apiErrs.InvalidMessage
+	}
+	key, err := a.sharedKey(req.Address, req.PublicKey, req.Prefix)
+	if err != nil {
+		return DecryptedMessage{}, err
+	}
+	message, err := crypto.Decrypt(key, encrypted)
+	if err != nil {
+		return DecryptedMessage{}, apiErrs.NewCustomValidationError(err.Error())
+	}
+	return DecryptedMessage{Message: string(message)}, nil
+}
+
+func (a *App) sharedKey(addr proto.WavesAddress, pk crypto.PublicKey, prefix string) ([]byte, error) {
+	sgn, accPK, err := a.accountSigner(addr)
+	if err != nil {
+		return nil, err
+	}
+	key, err := sgn.SharedKey(accPK, pk, []byte(prefix))
+	if err != nil {
+		if err := signerError(err); err != nil {
+			return nil, err
+		}
+		return nil, apiErrs.InvalidPublicKey
+	}
+	return key, nil
+}
+
+// ScriptComplexity is the complexity of script calculated by the estimator of specific version.
+// Error is set if the estimator fails to estimate the script.
+type ScriptComplexity struct {
+	EstimatorVersion     int            `json:"estimatorVersion"`
+	Complexity           int            `json:"complexity"`
+	VerifierComplexity   int            `json:"verifierComplexity"`
+	CallableComplexities map[string]int `json:"callableComplexities"`
+	Error                string         `json:"error,omitempty"`
+}
+
+func newScriptComplexity(ev int, est ride.TreeEstimation) ScriptComplexity {
+	callables := est.Functions
+	if callables == nil {
+		callables = map[string]int{}
+	}
+	return ScriptComplexity{
+		EstimatorVersion:     ev,
+		Complexity:           est.Estimation,
+		VerifierComplexity:   est.Verifier,
+		CallableComplexities: callables,
+	}
+}
+
+// ScriptEstimation holds complexities of script by all versions of estimator.
+// The fields complexity, verifierComplexity and callableComplexities are calculated by the active estimator.
+type ScriptEstimation struct {
+	Script                 string             `json:"script"`
+	Complexity             int                `json:"complexity"`
+	VerifierComplexity     int                `json:"verifierComplexity"`
+	CallableComplexities   map[string]int     `json:"callableComplexities"`
+	ActiveEstimatorVersion int                `json:"activeEstimatorVersion"`
+	Estimations            []ScriptComplexity `json:"estimations"`
+}
+
+// ScriptEstimate estimates the compiled script given as Base64 string with optional prefix 'base64:'.
+func (a *App) ScriptEstimate(script string) (ScriptEstimation, error) {
+	b, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(strings.TrimSpace(script), base64Prefix))
+	if err != nil {
+		return ScriptEstimation{}, apiErrs.NewCustomValidationError("invalid Base64 encoded script")
+	}
+	tree, err := serialization.Parse(b)
+	if err != nil {
+		return ScriptEstimation{}, apiErrs.NewCustomValidationError(fmt.Sprintf("failed to parse script: %v", err))
+	}
+	active, err := a.state.EstimatorVersion()
+	if err != nil {
+		return ScriptEstimation{}, errors.Wrap(err, "failed to get active estimator version")
+	}
+	if active < 1 || active > ride.MaxEstimatorVersion {
+		return ScriptEstimation{}, errors.Errorf("unsupported active estimator version %d", active)
+	}
+	estimations := estimateScript(tree)
+	activeEstimation := estimations[active-1]
+	if activeEstimation.Error != "" {
+		return ScriptEstimation{}, apiErrs.NewCustomValidationError(activeEstimation.Error)
+	}
+	return ScriptEstimation{
+		Script:                 base64Prefix + base64.StdEncoding.EncodeToString(b),
+		Complexity:             activeEstimation.Complexity,
+		VerifierComplexity:     activeEstimation.VerifierComplexity,
+		CallableComplexities:   activeEstimation.CallableComplexities,
+		ActiveEstimatorVersion: active,
+		Estimations:            estimations,
+	}, nil
+}
+
+// estimateScript estimates the script with all versions of estimator.
+func estimateScript(tree *ast.Tree) []ScriptComplexity {
+	r := make([]ScriptComplexity, 0, ride.MaxEstimatorVersion)
+	for ev := 1; ev <= ride.MaxEstimatorVersion; ev++ {
+		est, err := ride.EstimateTree(tree, ev)
+		if err != nil {
+			r = append(r, ScriptComplexity{EstimatorVersion: ev, Error: err.Error()})
+			continue
+		}
+		r = append(r, newScriptComplexity(ev, est))
+	}
+	return r
+}
@@ -2,7 +2,9 @@ package errors
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/pkg/errors"
 )
@@ -168,3 +170,26 @@ func NewCustomValidationError(message string) *CustomValidationError {
 		},
 	}
 }
+
+func NewAliasDoesNotExistError(alias string) *AliasDoesNotExistError {
+	return &AliasDoesNotExistError{
+		genericError: genericError{
+			ID:       AliasDoesNotExistErrorID,
+			HttpCode: http.StatusNotFound,
+			Message:  fmt.Sprintf("alias '%s' doesn't exist", alias),
+		},
+	}
+}
+
+func NewInvalidIdsError(ids []string) *InvalidIdsError {
+	return &InvalidIdsError{
+		validationError: validationError{
+			genericError: genericError{
+				ID:       InvalidIdsErrorID,
+				HttpCode: http.StatusBadRequest,
+				Message:  fmt.Sprintf("Request contains invalid IDs. %s", strings.Join(ids, ", ")),
+			},
+		},
+		Ids: ids,
+	}
+}
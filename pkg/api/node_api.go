@@ -25,6 +25,7 @@ const (
 	defaultTimeout = 30 * time.Second
 
 	maxDebugMessageLength = 100
+	maxTextMessageLength  = 64 * 1024
 )
 
 type NodeApi struct {
@@ -54,6 +55,21 @@ func (a *NodeApi) TransactionsBroadcast(_ http.ResponseWriter, r *http.Request)
 	return nil
 }
 
+func (a *NodeApi) TransactionsCalculateFee(w http.ResponseWriter, r *http.Request) error {
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		return errors.Wrap(err, "TransactionsCalculateFee: failed to read request body")
+	}
+	rs, err := a.app.TransactionsCalculateFee(b)
+	if err != nil {
+		return errors.Wrap(err, "TransactionsCalculateFee")
+	}
+	if err := trySendJson(w, rs); err != nil {
+		return errors.Wrap(err, "TransactionsCalculateFee")
+	}
+	return nil
+}
+
 func transactionIDAtInvalidLenErr(key string) *apiErrs.InvalidTransactionIdError {
 	return apiErrs.NewInvalidTransactionIDError(
 		fmt.Sprintf("%s has invalid length %d. Length can either be %d or %d",
@@ -465,6 +481,82 @@ func (a *NodeApi) GoMinerInfo(w http.ResponseWriter, _ *http.Request) error {
 	return nil
 }
 
+func (a *NodeApi) GoMinerAddBundle(w http.ResponseWriter, r *http.Request) error {
+	// TODO: use io.LimitReader
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		return errors.Wrap(err, "GoMinerAddBundle: failed to read request body")
+	}
+	rs, err := a.app.MinerAddBundle(b)
+	if err != nil {
+		return errors.Wrap(err, "GoMinerAddBundle")
+	}
+	if err := trySendJson(w, rs); err != nil {
+		return errors.Wrap(err, "GoMinerAddBundle")
+	}
+	return nil
+}
+
+func (a *NodeApi) GoMinerProduction(w http.ResponseWriter, _ *http.Request) error {
+	rs := a.app.MinerProduction()
+	if err := trySendJson(w, rs); err != nil {
+		return errors.Wrap(err, "GoMinerProduction")
+	}
+	return nil
+}
+
+const serverSentEventsKeepAlive = 15 * time.Second
+
+// GoMinerProductionEvents streams the events of key blocks and microblocks production as server-sent events.
+func (a *NodeApi) GoMinerProductionEvents(w http.ResponseWriter, r *http.Request) error {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return errors.New("GoMinerProductionEvents: streaming is not supported")
+	}
+	events, cancel := a.app.MinerProductionEvents()
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(serverSentEventsKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return nil
+		case <-keepAlive.C:
+			if _, err := io.WriteString(w, ": keep-alive\n\n"); err != nil {
+				zap.S().Debugf("GoMinerProductionEvents: failed to write keep-alive: %v", err)
+				return nil
+			}
+		case e, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := writeServerSentEvent(w, string(e.Type), e); err != nil {
+				zap.S().Debugf("GoMinerProductionEvents: %v", err)
+				return nil
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+func writeServerSentEvent(w io.Writer, event string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal %T to JSON", v)
+	}
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data); err != nil {
+		return errors.Wrap(err, "failed to write server-sent event")
+	}
+	return nil
+}
+
 func (a *NodeApi) Addresses(w http.ResponseWriter, _ *http.Request) error {
 	addresses, err := a.app.Addresses()
 	if err != nil {
@@ -513,13 +605,9 @@ func wavesAddressInvalidCharErr(invalidChar rune, id string) *apiErrs.CustomVali
 }
 
 func (a *NodeApi) EthereumDAppABI(w http.ResponseWriter, r *http.Request) error {
-	s := chi.URLParam(r, "address")
-	addr, err := proto.NewAddressFromString(s)
+	addr, err := parseAddressParam(r)
 	if err != nil {
-		if invalidRune, isInvalid := findFirstInvalidRuneInBase58String(s); isInvalid {
-			return wavesAddressInvalidCharErr(invalidRune, s)
-		}
-		return apiErrs.InvalidAddress
+		return err
 	}
 	methods, err := a.app.EthereumDAppMethods(addr)
 	if err != nil {
@@ -534,6 +622,332 @@ func (a *NodeApi) EthereumDAppABI(w http.ResponseWriter, r *http.Request) error
 	return nil
 }
 
+func parseAddressParam(r *http.Request) (proto.WavesAddress, error) {
+	s := chi.URLParam(r, "address")
+	addr, err := proto.NewAddressFromString(s)
+	if err != nil {
+		if invalidRune, isInvalid := findFirstInvalidRuneInBase58String(s); isInvalid {
+			return proto.WavesAddress{}, wavesAddressInvalidCharErr(invalidRune, s)
+		}
+		return proto.WavesAddress{}, apiErrs.InvalidAddress
+	}
+	return addr, nil
+}
+
+func (a *NodeApi) MatcherOrderStatus(w http.ResponseWriter, r *http.Request) error {
+	s := chi.URLParam(r, "orderId")
+	id, err := crypto.NewDigestFromBase58(s)
+	if err != nil {
+		return apiErrs.NewCustomValidationError(fmt.Sprintf("invalid order ID '%s'", s))
+	}
+	rs, err := a.app.OrderStatus(id)
+	if err != nil {
+		return err
+	}
+	if err := trySendJson(w, rs); err != nil {
+		return errors.Wrap(err, "MatcherOrderStatus")
+	}
+	return nil
+}
+
+func (a *NodeApi) ActivationStatus(w http.ResponseWriter, _ *http.Request) error {
+	rs, err := a.app.ActivationStatus()
+	if err != nil {
+		return errors.Wrap(err, "failed to get features activation status")
+	}
+	if err := trySendJson(w, rs); err != nil {
+		return errors.Wrap(err, "ActivationStatus")
+	}
+	return nil
+}
+
+func (a *NodeApi) BlockchainRewards(w http.ResponseWriter, _ *http.Request) error {
+	return a.sendRewards(w, 0)
+}
+
+func (a *NodeApi) BlockchainRewardsAt(w http.ResponseWriter, r *http.Request) error {
+	s := chi.URLParam(r, "height")
+	height, err := strconv.ParseUint(s, 10, 64)
+	if err != nil || height == 0 {
+		return apiErrs.NewCustomValidationError(fmt.Sprintf("invalid height '%s'", s))
+	}
+	return a.sendRewards(w, height)
+}
+
+func (a *NodeApi) sendRewards(w http.ResponseWriter, height proto.Height) error {
+	rs, err := a.app.BlockchainRewards(height)
+	if err != nil {
+		return err
+	}
+	if err := trySendJson(w, rs); err != nil {
+		return errors.Wrap(err, "BlockchainRewards")
+	}
+	return nil
+}
+
+func (a *NodeApi) ConsensusGeneratingBalance(w http.ResponseWriter, r *http.Request) error {
+	addr, err := parseAddressParam(r)
+	if err != nil {
+		return err
+	}
+	rs, err := a.app.ConsensusGeneratingBalance(addr)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get generating balance by address=%q", addr.String())
+	}
+	if err := trySendJson(w, rs); err != nil {
+		return errors.Wrap(err, "ConsensusGeneratingBalance")
+	}
+	return nil
+}
+
+func (a *NodeApi) ConsensusForecast(w http.ResponseWriter, r *http.Request) error {
+	addr, err := parseAddressParam(r)
+	if err != nil {
+		return err
+	}
+	blocks := uint64(defaultForecastBlocks)
+	if s := r.URL.Query().Get("blocks"); s != "" {
+		blocks, err = strconv.ParseUint(s, 10, 64)
+		if err != nil || blocks == 0 || blocks > maxForecastBlocks {
+			return apiErrs.NewCustomValidationError(
+				fmt.Sprintf("blocks should be a number from 1 to %d", maxForecastBlocks),
+			)
+		}
+	}
+	rs, err := a.app.ConsensusForecast(addr, blocks)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get forecast by address=%q", addr.String())
+	}
+	if err := trySendJson(w, rs); err != nil {
+		return errors.Wrap(err, "ConsensusForecast")
+	}
+	return nil
+}
+
+func (a *NodeApi) AddressesSignText(w http.ResponseWriter, r *http.Request) error {
+	addr, err := parseAddressParam(r)
+	if err != nil {
+		return err
+	}
+	message, err := io.ReadAll(io.LimitReader(r.Body, maxTextMessageLength+1))
+	if err != nil {
+		return errors.Wrap(err, "AddressesSignText: failed to read request body")
+	}
+	if len(message) > maxTextMessageLength {
+		return apiErrs.NewCustomValidationError(fmt.Sprintf("message is longer than %d bytes", maxTextMessageLength))
+	}
+	rs, err := a.app.SignText(addr, string(message))
+	if err != nil {
+		return errors.Wrapf(err, "failed to sign text by address=%q", addr.String())
+	}
+	if err := trySendJson(w, rs); err != nil {
+		return errors.Wrap(err, "AddressesSignText")
+	}
+	return nil
+}
+
+func (a *NodeApi) AddressesVerifyText(w http.ResponseWriter, r *http.Request) error {
+	addr, err := parseAddressParam(r)
+	if err != nil {
+		return err
+	}
+	req := VerifyTextRequest{}
+	if err := tryParseJson(r.Body, &req); err != nil {
+		return &BadRequestError{errors.Wrap(err, "failed to parse VerifyText request body as JSON")}
+	}
+	rs, err := a.app.VerifyText(addr, req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to verify text by address=%q", addr.String())
+	}
+	if err := trySendJson(w, rs); err != nil {
+		return errors.Wrap(err, "AddressesVerifyText")
+	}
+	return nil
+}
+
+func (a *NodeApi) UtilsEncrypt(w http.ResponseWriter, r *http.Request) error {
+	req := EncryptRequest{}
+	if err := tryParseJson(r.Body, &req); err != nil {
+		return &BadRequestError{errors.Wrap(err, "failed to parse Encrypt request body as JSON")}
+	}
+	rs, err := a.app.Encrypt(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to encrypt message")
+	}
+	if err := trySendJson(w, rs); err != nil {
+		return errors.Wrap(err, "UtilsEncrypt")
+	}
+	return nil
+}
+
+func (a *NodeApi) UtilsDecrypt(w http.ResponseWriter, r *http.Request) error {
+	req := DecryptRequest{}
+	if err := tryParseJson(r.Body, &req); err != nil {
+		return &BadRequestError{errors.Wrap(err, "failed to parse Decrypt request body as JSON")}
+	}
+	rs, err := a.app.Decrypt(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to decrypt message")
+	}
+	if err := trySendJson(w, rs); err != nil {
+		return errors.Wrap(err, "UtilsDecrypt")
+	}
+	return nil
+}
+
+func (a *NodeApi) UtilsScriptEstimate(w http.ResponseWriter, r *http.Request) error {
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		return errors.Wrap(err, "UtilsScriptEstimate: failed to read request body")
+	}
+	rs, err := a.app.ScriptEstimate(string(b))
+	if err != nil {
+		return errors.Wrap(err, "failed to estimate script")
+	}
+	if err := trySendJson(w, rs); err != nil {
+		return errors.Wrap(err, "UtilsScriptEstimate")
+	}
+	return nil
+}
+
+func (a *NodeApi) AddressesScriptInfoMeta(w http.ResponseWriter, r *http.Request) error {
+	addr, err := parseAddressParam(r)
+	if err != nil {
+		return err
+	}
+	rs, err := a.app.ScriptInfoMeta(addr)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get script info meta by address=%q", addr.String())
+	}
+	if err := trySendJson(w, rs); err != nil {
+		return errors.Wrap(err, "AddressesScriptInfoMeta")
+	}
+	return nil
+}
+
+func (a *NodeApi) LeasingActive(w http.ResponseWriter, r *http.Request) error {
+	addr, err := parseAddressParam(r)
+	if err != nil {
+		return err
+	}
+	rs, err := a.app.LeasingActive(addr)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get active leases by address=%q", addr.String())
+	}
+	if err := trySendJson(w, rs); err != nil {
+		return errors.Wrap(err, "LeasingActive")
+	}
+	return nil
+}
+
+func (a *NodeApi) GoLeasingHistory(w http.ResponseWriter, r *http.Request) error {
+	addr, err := parseAddressParam(r)
+	if err != nil {
+		return err
+	}
+	rs, err := a.app.LeasingHistory(addr)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get leasing history by address=%q", addr.String())
+	}
+	if err := trySendJson(w, rs); err != nil {
+		return errors.Wrap(err, "GoLeasingHistory")
+	}
+	return nil
+}
+
+func (a *NodeApi) AliasByAlias(w http.ResponseWriter, r *http.Request) error {
+	alias := chi.URLParam(r, "alias")
+	rs, err := a.app.AddrByAlias(alias)
+	if err != nil {
+		return err
+	}
+	if err := trySendJson(w, rs); err != nil {
+		return errors.Wrap(err, "AliasByAlias")
+	}
+	return nil
+}
+
+func (a *NodeApi) AliasByAddress(w http.ResponseWriter, r *http.Request) error {
+	addr, err := parseAddressParam(r)
+	if err != nil {
+		return err
+	}
+	rs, err := a.app.AliasesByAddr(addr)
+	if err != nil {
+		return err
+	}
+	if err := trySendJson(w, rs); err != nil {
+		return errors.Wrap(err, "AliasByAddress")
+	}
+	return nil
+}
+
+func (a *NodeApi) LeasingInfo(w http.ResponseWriter, r *http.Request) error {
+	ids, err := parseIDs(r)
+	if err != nil {
+		return err
+	}
+	rs, err := a.app.LeasingInfo(ids)
+	if err != nil {
+		return errors.Wrap(err, "failed to get leasing info")
+	}
+	if err := trySendJson(w, rs); err != nil {
+		return errors.Wrap(err, "LeasingInfo")
+	}
+	return nil
+}
+
+func (a *NodeApi) TransactionsMerkleProof(w http.ResponseWriter, r *http.Request) error {
+	ids, err := parseIDs(r)
+	if err != nil {
+		return err
+	}
+	rs, err := a.app.TransactionsMerkleProofs(ids)
+	if err != nil {
+		return errors.Wrap(err, "failed to get transactions merkle proofs")
+	}
+	if err := trySendJson(w, rs); err != nil {
+		return errors.Wrap(err, "TransactionsMerkleProof")
+	}
+	return nil
+}
+
+// parseIDs parses IDs from repeated or comma separated 'id' query parameters for GET requests
+// and from the 'ids' field of JSON body for POST requests.
+func parseIDs(r *http.Request) ([]crypto.Digest, error) {
+	var ids []string
+	if r.Method == http.MethodPost {
+		req := struct {
+			IDs []string `json:"ids"`
+		}{}
+		if err := tryParseJson(r.Body, &req); err != nil {
+			return nil, &BadRequestError{errors.Wrap(err, "failed to parse IDs from request body")}
+		}
+		ids = req.IDs
+	} else {
+		for _, v := range r.URL.Query()["id"] {
+			ids = append(ids, strings.Split(v, ",")...)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, apiErrs.NewCustomValidationError("IDs are not provided")
+	}
+	digests := make([]crypto.Digest, 0, len(ids))
+	var invalid []string
+	for _, s := range ids {
+		d, err := crypto.NewDigestFromBase58(s)
+		if err != nil {
+			invalid = append(invalid, s)
+			continue
+		}
+		digests = append(digests, d)
+	}
+	if len(invalid) > 0 {
+		return nil, apiErrs.NewInvalidIdsError(invalid)
+	}
+	return digests, nil
+}
+
 func (a *NodeApi) version(w http.ResponseWriter, _ *http.Request) error {
 	rs := a.app.version()
 	if err := trySendJson(w, rs); err != nil {
@@ -87,7 +87,20 @@ func (a *NodeApi) routes(opts *RunOptions) (chi.Router, error) {
 			rAuth.Post("/load", wrapper(WalletLoadKeys(a.app)))
 		})
 
-		r.Get("/miner/info", wrapper(a.GoMinerInfo))
+		r.Route("/miner", func(r chi.Router) {
+			r.Get("/info", wrapper(a.GoMinerInfo))
+			r.Get("/production", wrapper(a.GoMinerProduction))
+			r.Get("/production/events", wrapper(a.GoMinerProductionEvents))
+
+			rAuth := r.With(checkAuthMiddleware)
+
+			rAuth.Post("/bundle", wrapper(a.GoMinerAddBundle))
+		})
+
+		r.Route("/leasing", func(r chi.Router) {
+			r.Get("/history/{address}", wrapper(a.GoLeasingHistory))
+		})
+
 		r.Get("/node/processes", wrapper(a.nodeProcesses))
 		r.Get("/pool/transactions", wrapper(a.poolTransactions))
 	})
@@ -104,11 +117,35 @@ func (a *NodeApi) routes(opts *RunOptions) (chi.Router, error) {
 
 		r.Route("/addresses", func(r chi.Router) {
 			r.Get("/", wrapper(a.Addresses))
+			r.Post("/verifyText/{address}", wrapper(a.AddressesVerifyText))
+			r.Get("/scriptInfo/{address}/meta", wrapper(a.AddressesScriptInfoMeta))
+
+			rAuth := r.With(checkAuthMiddleware)
+
+			rAuth.Post("/signText/{address}", wrapper(a.AddressesSignText))
+		})
+
+		r.Route("/alias", func(r chi.Router) {
+			r.Get("/by-alias/{alias}", wrapper(a.AliasByAlias))
+			r.Get("/by-address/{address}", wrapper(a.AliasByAddress))
+		})
+
+		r.Route("/utils", func(r chi.Router) {
+			r.Post("/script/estimate", wrapper(a.UtilsScriptEstimate))
+
+			rAuth := r.With(checkAuthMiddleware)
+
+			rAuth.Post("/encrypt", wrapper(a.UtilsEncrypt))
+			rAuth.Post("/decrypt", wrapper(a.UtilsDecrypt))
 		})
 
 		r.Route("/transactions", func(r chi.Router) {
 			r.Get("/unconfirmed/size", wrapper(a.unconfirmedSize))
 			r.Get("/info/{id}", wrapper(a.TransactionInfo))
+			r.Get("/merkleProof", wrapper(a.TransactionsMerkleProof))
+			r.Post("/merkleProof", wrapper(a.TransactionsMerkleProof))
+			r.Post("/calculateFee", wrapper(a.TransactionsCalculateFee))
+
 			rAuth := r.With(checkAuthMiddleware)
 
 			rAuth.Post("/broadcast", wrapper(a.TransactionsBroadcast))
@@ -134,6 +171,26 @@ func (a *NodeApi) routes(opts *RunOptions) (chi.Router, error) {
 		r.Route("/node", func(r chi.Router) {
 			r.Get("/version", wrapper(a.version))
 		})
+		r.Route("/matcher", func(r chi.Router) {
+			r.Get("/orders/{orderId}/status", wrapper(a.MatcherOrderStatus))
+		})
+		r.Route("/activation", func(r chi.Router) {
+			r.Get("/status", wrapper(a.ActivationStatus))
+		})
+		r.Route("/blockchain", func(r chi.Router) {
+			r.Get("/rewards", wrapper(a.BlockchainRewards))
+			r.Get("/rewards/{height}", wrapper(a.BlockchainRewardsAt))
+		})
+		r.Route("/consensus", func(r chi.Router) {
+			r.Get("/generatingbalance/{address}", wrapper(a.ConsensusGeneratingBalance))
+			r.Get("/forecast/{address}", wrapper(a.ConsensusForecast))
+		})
+		r.Route("/leasing", func(r chi.Router) {
+			r.Get("/active/{address}", wrapper(a.LeasingActive))
+			r.Get("/info", wrapper(a.LeasingInfo))
+			r.Post("/info", wrapper(a.LeasingInfo))
+		})
+
 		r.Route("/eth", func(r chi.Router) {
 			r.Get("/abi/{address}", wrapper(a.EthereumDAppABI))
 		})
@@ -0,0 +1,86 @@
+package conformance
+
+import (
+	"bytes"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/importer"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+	"github.com/wavesplatform/gowaves/pkg/state"
+)
+
+var blocksPath = filepath.Join("..", "state", "testdata", "blocks-10000")
+
+func exportTestVector(t *testing.T, from, to uint64) *Vector {
+	params := state.DefaultTestingStateParams()
+	params.BuildStateHashes = true
+	st, err := state.NewState(t.TempDir(), true, params, settings.MainNetSettings)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, st.Close())
+	}()
+	require.NoError(t, importer.ApplyFromFile(st, blocksPath, to-1, 1))
+	v, err := Export(st, settings.MainNetSettings, from, to)
+	require.NoError(t, err)
+	return v
+}
+
+func TestExportAndRun(t *testing.T) {
+	v := exportTestVector(t, 50, 150)
+	assert.Len(t, v.Blocks, 149)
+	assert.Len(t, v.StateHashes, 101)
+	assert.NotEmpty(t, v.Transactions)
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, v.Write(buf))
+	v, err := ReadVector(buf)
+	require.NoError(t, err)
+	mismatches, err := Run(v, t.TempDir())
+	require.NoError(t, err)
+	assert.Empty(t, mismatches)
+
+	v.StateHashes[10].StateHash.WavesBalanceHash[0] ^= 0xff
+	v.Transactions[0].Status = StatusScriptExecutionFailed
+	v.Transactions[1].Height++
+	mismatches, err = Run(v, t.TempDir())
+	require.NoError(t, err)
+	require.Len(t, mismatches, 3)
+	assert.Equal(t, v.StateHashes[10].Height, mismatches[0].Height)
+	assert.Equal(t, "waves balances hash", mismatches[0].Subject)
+	assert.Equal(t, string(StatusSucceeded), mismatches[1].Actual)
+	assert.Equal(t, strconv.FormatUint(v.Transactions[1].Height, 10), mismatches[2].Expected)
+	assert.Equal(t, strconv.FormatUint(v.Transactions[1].Height-1, 10), mismatches[2].Actual)
+}
+
+func TestExportInvalidRange(t *testing.T) {
+	params := state.DefaultTestingStateParams()
+	st, err := state.NewState(t.TempDir(), true, params, settings.MainNetSettings)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, st.Close())
+	}()
+	_, err = Export(st, settings.MainNetSettings, 1, 1)
+	assert.Error(t, err, "state without state hashes")
+}
+
+// TestVectors applies vectors from testdata.
+func TestVectors(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "*.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+	for _, fn := range files {
+		t.Run(filepath.Base(fn), func(t *testing.T) {
+			v, err := LoadVector(fn)
+			require.NoError(t, err)
+			mismatches, err := Run(v, t.TempDir())
+			require.NoError(t, err)
+			for _, m := range mismatches {
+				t.Error(m.String())
+			}
+		})
+	}
+}
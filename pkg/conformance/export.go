@@ -0,0 +1,76 @@
+package conformance
+
+import (
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+	"github.com/wavesplatform/gowaves/pkg/state"
+)
+
+// Export makes the vector from the state built with the settings. Expectations are taken at heights
+// in the range [from, to]. Blocks below the range are included too, because vectors are applied from genesis.
+// The state must be built with state hashes.
+func Export(st state.StateInfo, s *settings.BlockchainSettings, from, to proto.Height) (*Vector, error) {
+	ok, err := st.ProvidesStateHashes()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to export vector")
+	}
+	if !ok {
+		return nil, errors.New("failed to export vector: state has no state hashes")
+	}
+	height, err := st.Height()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to export vector")
+	}
+	if from < 1 || from > to || to > height {
+		return nil, errors.Errorf("invalid range [%d, %d] for the state of height %d", from, to, height)
+	}
+	scheme := s.AddressSchemeCharacter
+	v := &Vector{
+		Settings:     s,
+		Blocks:       make([]Block, 0, to-1),
+		StateHashes:  make([]HeightStateHash, 0, to-from+1),
+		Transactions: make([]TransactionStatus, 0),
+	}
+	for h := proto.Height(1); h <= to; h++ {
+		block, err := st.BlockByHeight(h)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get block at height %d", h)
+		}
+		if h > 1 {
+			b, err := newBlock(block, scheme)
+			if err != nil {
+				return nil, err
+			}
+			v.Blocks = append(v.Blocks, b)
+		}
+		if h < from {
+			continue
+		}
+		sh, err := st.StateHashAtHeight(h)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get state hash at height %d", h)
+		}
+		v.StateHashes = append(v.StateHashes, HeightStateHash{Height: h, StateHash: *sh})
+		for _, tx := range block.Transactions {
+			ts, err := transactionStatus(st, scheme, tx, h)
+			if err != nil {
+				return nil, err
+			}
+			v.Transactions = append(v.Transactions, ts)
+		}
+	}
+	return v, nil
+}
+
+func transactionStatus(st state.StateInfo, scheme proto.Scheme, tx proto.Transaction, height proto.Height) (TransactionStatus, error) {
+	id, err := tx.GetID(scheme)
+	if err != nil {
+		return TransactionStatus{}, errors.Wrapf(err, "failed to get ID of transaction at height %d", height)
+	}
+	_, failed, err := st.TransactionByIDWithStatus(id)
+	if err != nil {
+		return TransactionStatus{}, errors.Wrapf(err, "failed to get status of transaction '%s'", proto.B58Bytes(id).String())
+	}
+	return TransactionStatus{ID: id, Height: height, Status: newStatus(failed)}, nil
+}
@@ -0,0 +1,111 @@
+package conformance
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/state"
+)
+
+// Mismatch describes the difference between the expected and the actual state.
+type Mismatch struct {
+	Height   uint64
+	Subject  string
+	Expected string
+	Actual   string
+}
+
+func (m Mismatch) String() string {
+	return fmt.Sprintf("%s at height %d: expected %s, actual %s", m.Subject, m.Height, m.Expected, m.Actual)
+}
+
+// Run applies blocks of the vector to a new state in the empty directory dataDir and compares the state
+// with the expectations of the vector. The error is returned if the vector can't be applied,
+// the differences from the expectations are returned as mismatches.
+func Run(v *Vector, dataDir string) ([]Mismatch, error) {
+	blocks := make([]*proto.Block, len(v.Blocks))
+	for i, b := range v.Blocks {
+		block, err := b.block()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal block at height %d", i+2)
+		}
+		blocks[i] = block
+	}
+	params := state.DefaultTestingStateParams()
+	params.BuildStateHashes = true
+	st, err := state.NewState(dataDir, true, params, v.Settings)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create state")
+	}
+	defer func() {
+		_ = st.Close()
+	}()
+	if len(blocks) > 0 {
+		if _, err := st.AddDeserializedBlocks(blocks); err != nil {
+			return nil, errors.Wrap(err, "failed to apply blocks")
+		}
+	}
+	var res []Mismatch
+	for _, e := range v.StateHashes {
+		sh, err := st.StateHashAtHeight(e.Height)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get state hash at height %d", e.Height)
+		}
+		res = append(res, compareStateHashes(e.Height, &e.StateHash, sh)...)
+	}
+	for _, e := range v.Transactions {
+		res = append(res, checkTransaction(st, e)...)
+	}
+	return res, nil
+}
+
+func checkTransaction(st state.State, e TransactionStatus) []Mismatch {
+	subject := fmt.Sprintf("transaction '%s'", e.ID.String())
+	_, failed, err := st.TransactionByIDWithStatus(e.ID.Bytes())
+	if err != nil {
+		return []Mismatch{{Height: e.Height, Subject: subject, Expected: "applied transaction", Actual: err.Error()}}
+	}
+	var res []Mismatch
+	if s := newStatus(failed); s != e.Status {
+		res = append(res, Mismatch{Height: e.Height, Subject: subject + " status", Expected: string(e.Status), Actual: string(s)})
+	}
+	h, err := st.TransactionHeightByID(e.ID.Bytes())
+	if err != nil {
+		return append(res, Mismatch{Height: e.Height, Subject: subject + " height", Expected: "stored height", Actual: err.Error()})
+	}
+	if h != e.Height {
+		res = append(res, Mismatch{Height: e.Height, Subject: subject + " height", Expected: fmt.Sprint(e.Height), Actual: fmt.Sprint(h)})
+	}
+	return res
+}
+
+func compareStateHashes(height uint64, expected, actual *proto.StateHash) []Mismatch {
+	var res []Mismatch
+	if expected.BlockID != actual.BlockID {
+		res = append(res, Mismatch{Height: height, Subject: "block ID", Expected: expected.BlockID.String(), Actual: actual.BlockID.String()})
+	}
+	fields := []struct {
+		name             string
+		expected, actual crypto.Digest
+	}{
+		{"state hash", expected.SumHash, actual.SumHash},
+		{"data entries hash", expected.DataEntryHash, actual.DataEntryHash},
+		{"account scripts hash", expected.AccountScriptHash, actual.AccountScriptHash},
+		{"asset scripts hash", expected.AssetScriptHash, actual.AssetScriptHash},
+		{"lease statuses hash", expected.LeaseStatusHash, actual.LeaseStatusHash},
+		{"sponsorships hash", expected.SponsorshipHash, actual.SponsorshipHash},
+		{"aliases hash", expected.AliasesHash, actual.AliasesHash},
+		{"waves balances hash", expected.WavesBalanceHash, actual.WavesBalanceHash},
+		{"asset balances hash", expected.AssetBalanceHash, actual.AssetBalanceHash},
+		{"lease balances hash", expected.LeaseBalanceHash, actual.LeaseBalanceHash},
+	}
+	for _, f := range fields {
+		if f.expected != f.actual {
+			res = append(res, Mismatch{Height: height, Subject: f.name, Expected: hex.EncodeToString(f.expected[:]), Actual: hex.EncodeToString(f.actual[:])})
+		}
+	}
+	return res
+}
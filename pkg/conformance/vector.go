@@ -0,0 +1,117 @@
+// Package conformance provides consensus conformance test vectors. A vector holds blockchain settings with
+// the genesis block, a sequence of blocks and the expected state hashes and transaction statuses.
+// Vectors are exported from an existing state and applied offline to a new state to catch consensus regressions.
+package conformance
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+)
+
+// Status is the application status of a transaction.
+type Status string
+
+const (
+	StatusSucceeded             Status = "succeeded"
+	StatusScriptExecutionFailed Status = "script_execution_failed"
+)
+
+func newStatus(failed bool) Status {
+	if failed {
+		return StatusScriptExecutionFailed
+	}
+	return StatusSucceeded
+}
+
+// HeightStateHash is the expected state hash at the height.
+type HeightStateHash struct {
+	Height    uint64          `json:"height"`
+	StateHash proto.StateHash `json:"stateHash"`
+}
+
+// TransactionStatus is the expected height and application status of the transaction.
+type TransactionStatus struct {
+	ID     proto.B58Bytes `json:"id"`
+	Height uint64         `json:"height"`
+	Status Status         `json:"status"`
+}
+
+// Vector is a consensus conformance test vector.
+// Blocks start from height 2, the genesis block is a part of the settings.
+type Vector struct {
+	Description  string                       `json:"description,omitempty"`
+	Settings     *settings.BlockchainSettings `json:"settings"`
+	Blocks       []Block                      `json:"blocks"`
+	StateHashes  []HeightStateHash            `json:"stateHashes"`
+	Transactions []TransactionStatus          `json:"transactions"`
+}
+
+// Block is a block in protobuf encoding, which is supported for blocks of all versions.
+type Block []byte
+
+func newBlock(b *proto.Block, scheme proto.Scheme) (Block, error) {
+	data, err := b.MarshalToProtobuf(scheme)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal block '%s'", b.BlockID().String())
+	}
+	return data, nil
+}
+
+func (b Block) block() (*proto.Block, error) {
+	res := new(proto.Block)
+	if err := res.UnmarshalFromProtobuf(b); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ReadVector reads the vector in JSON format. Omitted settings take the same default values
+// as in the settings files of custom blockchains.
+func ReadVector(r io.Reader) (*Vector, error) {
+	var js struct {
+		Vector
+		Settings json.RawMessage `json:"settings"`
+	}
+	if err := json.NewDecoder(r).Decode(&js); err != nil {
+		return nil, errors.Wrap(err, "failed to read vector")
+	}
+	if len(js.Settings) == 0 || bytes.Equal(js.Settings, []byte("null")) {
+		return nil, errors.New("failed to read vector: no blockchain settings")
+	}
+	s, err := settings.ReadBlockchainSettings(bytes.NewReader(js.Settings))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read vector")
+	}
+	v := js.Vector
+	v.Settings = s
+	return &v, nil
+}
+
+// LoadVector reads the vector from the file.
+func LoadVector(path string) (*Vector, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open vector file")
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	return ReadVector(f)
+}
+
+// Write writes the vector in JSON format.
+func (v *Vector) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return errors.Wrap(err, "failed to write vector")
+	}
+	return nil
+}
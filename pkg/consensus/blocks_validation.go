@@ -174,6 +174,14 @@ func (cv *Validator) ValidateHeadersBatch(headers []proto.BlockHeader, startHeig
 	return nil
 }
 
+// MinimalGeneratingBalance returns the minimal effective balance required for block generation.
+func MinimalGeneratingBalance(smallerMinimalGeneratingBalanceActivated bool) uint64 {
+	if smallerMinimalGeneratingBalanceActivated {
+		return minimalEffectiveBalanceForGenerator2
+	}
+	return minimalEffectiveBalanceForGenerator1
+}
+
 func (cv *Validator) validateEffectiveBalance(header *proto.BlockHeader, balance, height uint64) error {
 	if header.Timestamp < cv.settings.MinimalGeneratingBalanceCheckAfterTime {
 		return nil
@@ -0,0 +1,233 @@
+package consensus
+
+import (
+	"math"
+	"math/big"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+)
+
+const (
+	// forecastSamples is the number of hit values used to calculate the mean delay.
+	forecastSamples = 1000
+	// balanceSearchSteps is the number of bisection steps to find the total generating balance of the network.
+	balanceSearchSteps = 64
+)
+
+var delayPercentiles = []float64{0.1, 0.25, 0.5, 0.75, 0.9}
+
+// GeneratingBalance is the generating balance of an account calculated as the minimal effective balance
+// on the range of heights [StartHeight, EndHeight].
+type GeneratingBalance struct {
+	Balance        uint64 `json:"balance"`
+	StartHeight    uint64 `json:"start_height"`
+	EndHeight      uint64 `json:"end_height"`
+	MinimalBalance uint64 `json:"minimal_balance"`
+	Eligible       bool   `json:"eligible"`
+}
+
+// DelayDistribution describes the distribution of block generation delays in milliseconds.
+type DelayDistribution struct {
+	Mean uint64 `json:"mean"`
+	P10  uint64 `json:"p10"`
+	P25  uint64 `json:"p25"`
+	P50  uint64 `json:"p50"`
+	P75  uint64 `json:"p75"`
+	P90  uint64 `json:"p90"`
+}
+
+// Forecast is the expected mining performance of an account with the current base target.
+type Forecast struct {
+	GeneratingBalance GeneratingBalance `json:"generating_balance"`
+	BaseTarget        uint64            `json:"base_target"`
+	Blocks            uint64            `json:"blocks"`
+	// BlockShare is the approximate share of blocks generated by the account, calculated as the ratio
+	// of the generating balance of the account to the total generating balance of the network.
+	// The total generating balance is estimated by the base target, which keeps the average block delay.
+	BlockShare     float64            `json:"block_share"`
+	ExpectedBlocks float64            `json:"expected_blocks"`
+	Delay          *DelayDistribution `json:"delay"`
+}
+
+// ForecastState is the part of state required for generating balance and forecast calculations.
+type ForecastState interface {
+	HeaderByHeight(height uint64) (*proto.BlockHeader, error)
+	EffectiveBalance(addr proto.Recipient, startHeight, endHeight uint64) (uint64, error)
+	IsActiveAtHeight(featureID int16, height proto.Height) (bool, error)
+}
+
+// forecastStateAdapter provides ForecastState to Validator, methods not required for calculations are not supported.
+type forecastStateAdapter struct {
+	ForecastState
+}
+
+func (a forecastStateAdapter) NewestHitSourceAtHeight(uint64) ([]byte, error) {
+	return nil, errors.New("not supported")
+}
+
+func (a forecastStateAdapter) NewestEffectiveBalance(addr proto.Recipient, startHeight, endHeight uint64) (uint64, error) {
+	return a.EffectiveBalance(addr, startHeight, endHeight)
+}
+
+func (a forecastStateAdapter) NewestIsActiveAtHeight(featureID int16, height proto.Height) (bool, error) {
+	return a.IsActiveAtHeight(featureID, height)
+}
+
+func (a forecastStateAdapter) NewestAccountHasScript(proto.WavesAddress) (bool, error) {
+	return false, errors.New("not supported")
+}
+
+// Forecaster calculates generating balances and mining forecasts for accounts.
+type Forecaster struct {
+	cv *Validator
+}
+
+func NewForecaster(state ForecastState, settings *settings.BlockchainSettings) *Forecaster {
+	return &Forecaster{cv: NewValidator(forecastStateAdapter{state}, settings, nil)}
+}
+
+// GeneratingBalance returns the generating balance of the address for the block on top of the given height.
+func (f *Forecaster) GeneratingBalance(addr proto.WavesAddress, height uint64) (GeneratingBalance, error) {
+	cv := f.cv
+	balance, err := cv.generatingBalance(height, addr)
+	if err != nil {
+		return GeneratingBalance{}, errors.Wrapf(err, "failed to get generating balance of %q", addr.String())
+	}
+	smaller, err := cv.smallerMinimalGeneratingBalanceActivated(height)
+	if err != nil {
+		return GeneratingBalance{}, err
+	}
+	start, end := cv.RangeForGeneratingBalanceByHeight(height)
+	minimal := MinimalGeneratingBalance(smaller)
+	return GeneratingBalance{
+		Balance:        balance,
+		StartHeight:    start,
+		EndHeight:      end,
+		MinimalBalance: minimal,
+		Eligible:       balance >= minimal,
+	}, nil
+}
+
+// Forecast returns the expected share of the next blocks generated by the address and
+// the distribution of generation delays for the block on top of the given height.
+func (f *Forecaster) Forecast(addr proto.WavesAddress, height, blocks uint64) (Forecast, error) {
+	cv := f.cv
+	gb, err := f.GeneratingBalance(addr, height)
+	if err != nil {
+		return Forecast{}, err
+	}
+	header, err := cv.state.HeaderByHeight(height)
+	if err != nil {
+		return Forecast{}, errors.Wrapf(err, "failed to get block header at height %d", height)
+	}
+	fc := Forecast{GeneratingBalance: gb, BaseTarget: header.BaseTarget, Blocks: blocks}
+	if !gb.Eligible || gb.Balance == 0 {
+		return fc, nil
+	}
+	pos, err := cv.posAlgo(height)
+	if err != nil {
+		return Forecast{}, err
+	}
+	delay, err := delayDistribution(pos, header.BaseTarget, gb.Balance)
+	if err != nil {
+		return Forecast{}, err
+	}
+	fc.Delay = &delay
+	total, err := networkBalance(pos, header.BaseTarget, cv.settings.AverageBlockDelaySeconds*1000)
+	if err != nil {
+		return Forecast{}, err
+	}
+	fc.BlockShare = math.Min(1, float64(gb.Balance)/float64(total))
+	fc.ExpectedBlocks = fc.BlockShare * float64(blocks)
+	return fc, nil
+}
+
+// hitQuantile returns the hit value at the quantile q of the distribution of hits.
+type hitQuantile func(q float64) *big.Int
+
+// minerHit is the distribution of hits of a single miner, hits are uniformly distributed over the range of values.
+func minerHit(q float64) *big.Int {
+	var maxHit big.Int
+	maxHit.SetBytes(maxSignature)
+	var hitFloat big.Float
+	hitFloat.SetInt(&maxHit)
+	hitFloat.Mul(&hitFloat, big.NewFloat(q))
+	hit, _ := hitFloat.Int(nil)
+	return hit
+}
+
+// networkHit is the distribution of the best hit of many miners scaled to the hit of a single miner with
+// the total generating balance. The best of many uniformly distributed hits scaled by balances is distributed
+// exponentially. Fair PoS calculates delay of the logarithm of hit, so the uniformly distributed hit of a single
+// miner with the total generating balance gives the same delays as the best hit of many miners.
+func networkHit(pos PosCalculator) hitQuantile {
+	if _, ok := pos.(*nxtPosCalculator); !ok {
+		return minerHit
+	}
+	return func(q float64) *big.Int {
+		return minerHit(-math.Log(1 - q))
+	}
+}
+
+// meanDelay calculates the mean delay for hits of the given distribution.
+func meanDelay(pos PosCalculator, hits hitQuantile, baseTarget, balance uint64) (float64, error) {
+	var sum float64
+	for i := 0; i < forecastSamples; i++ {
+		d, err := pos.CalculateDelay(hits((float64(i)+0.5)/forecastSamples), baseTarget, balance)
+		if err != nil {
+			return 0, err
+		}
+		sum += float64(d)
+	}
+	return sum / forecastSamples, nil
+}
+
+// networkBalance estimates the total generating balance of the network with the given base target.
+// Base target is adjusted to keep the average block delay, so the total generating balance is the balance
+// which gives the average block delay for the best hits of the network.
+func networkBalance(pos PosCalculator, baseTarget, averageDelay uint64) (uint64, error) {
+	hits := networkHit(pos)
+	lo, hi := uint64(1), uint64(math.MaxInt64)
+	for i := 0; i < balanceSearchSteps && lo < hi; i++ {
+		mid := lo + (hi-lo)/2
+		d, err := meanDelay(pos, hits, baseTarget, mid)
+		if err != nil {
+			return 0, err
+		}
+		if d > float64(averageDelay) {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo, nil
+}
+
+// delayDistribution calculates delays for hits uniformly distributed over the range of possible hit values.
+// Delay is a monotonic function of hit for all PoS calculators, so delays at hit quantiles are delay quantiles.
+func delayDistribution(pos PosCalculator, baseTarget, balance uint64) (DelayDistribution, error) {
+	mean, err := meanDelay(pos, minerHit, baseTarget, balance)
+	if err != nil {
+		return DelayDistribution{}, err
+	}
+	quantiles := make([]uint64, len(delayPercentiles))
+	for i, p := range delayPercentiles {
+		d, err := pos.CalculateDelay(minerHit(p), baseTarget, balance)
+		if err != nil {
+			return DelayDistribution{}, err
+		}
+		quantiles[i] = d
+	}
+	sort.Slice(quantiles, func(i, j int) bool { return quantiles[i] < quantiles[j] })
+	return DelayDistribution{
+		Mean: uint64(mean),
+		P10:  quantiles[0],
+		P25:  quantiles[1],
+		P50:  quantiles[2],
+		P75:  quantiles[3],
+		P90:  quantiles[4],
+	}, nil
+}
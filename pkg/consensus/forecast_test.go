@@ -0,0 +1,144 @@
+package consensus
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+)
+
+type forecastStateMock struct {
+	baseTarget uint64
+	balance    uint64
+	features   map[settings.Feature]bool
+	start, end uint64
+}
+
+func (m *forecastStateMock) HeaderByHeight(uint64) (*proto.BlockHeader, error) {
+	return &proto.BlockHeader{NxtConsensus: proto.NxtConsensus{BaseTarget: m.baseTarget}}, nil
+}
+
+func (m *forecastStateMock) EffectiveBalance(_ proto.Recipient, startHeight, endHeight uint64) (uint64, error) {
+	m.start, m.end = startHeight, endHeight
+	return m.balance, nil
+}
+
+func (m *forecastStateMock) IsActiveAtHeight(featureID int16, _ proto.Height) (bool, error) {
+	return m.features[settings.Feature(featureID)], nil
+}
+
+func TestForecaster_GeneratingBalance(t *testing.T) {
+	st := &forecastStateMock{balance: 1000 * proto.PriceConstant, features: map[settings.Feature]bool{}}
+	f := NewForecaster(st, settings.MainNetSettings)
+
+	gb, err := f.GeneratingBalance(proto.WavesAddress{}, 3000000)
+	require.NoError(t, err)
+	assert.Equal(t, GeneratingBalance{
+		Balance:        1000 * proto.PriceConstant,
+		StartHeight:    3000000 - 1000 + 1,
+		EndHeight:      3000000,
+		MinimalBalance: minimalEffectiveBalanceForGenerator1,
+		Eligible:       false,
+	}, gb)
+	assert.Equal(t, gb.StartHeight, st.start)
+	assert.Equal(t, gb.EndHeight, st.end)
+
+	st.features[settings.SmallerMinimalGeneratingBalance] = true
+	gb, err = f.GeneratingBalance(proto.WavesAddress{}, 100)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(51), gb.StartHeight)
+	assert.Equal(t, minimalEffectiveBalanceForGenerator2, gb.MinimalBalance)
+	assert.True(t, gb.Eligible)
+}
+
+func TestForecaster_Forecast(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		features map[settings.Feature]bool
+	}{
+		{"NXT", map[settings.Feature]bool{settings.SmallerMinimalGeneratingBalance: true}},
+		{"FairPoS", map[settings.Feature]bool{settings.SmallerMinimalGeneratingBalance: true, settings.FairPoS: true, settings.BlockV5: true}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			st := &forecastStateMock{baseTarget: 100, features: test.features}
+			f := NewForecaster(st, settings.MainNetSettings)
+
+			st.balance = 999 * proto.PriceConstant
+			fc, err := f.Forecast(proto.WavesAddress{}, 3000000, 1000)
+			require.NoError(t, err)
+			assert.False(t, fc.GeneratingBalance.Eligible)
+			assert.Nil(t, fc.Delay)
+			assert.Zero(t, fc.ExpectedBlocks)
+
+			st.balance = 10000 * proto.PriceConstant
+			small, err := f.Forecast(proto.WavesAddress{}, 3000000, 1000)
+			require.NoError(t, err)
+			require.NotNil(t, small.Delay)
+			d := small.Delay
+			assert.True(t, d.P10 <= d.P25 && d.P25 <= d.P50 && d.P50 <= d.P75 && d.P75 <= d.P90, "%+v", d)
+			assert.Equal(t, uint64(100), small.BaseTarget)
+			assert.Equal(t, uint64(1000), small.Blocks)
+
+			st.balance = 1000000 * proto.PriceConstant
+			big, err := f.Forecast(proto.WavesAddress{}, 3000000, 1000)
+			require.NoError(t, err)
+			require.NotNil(t, big.Delay)
+			assert.Less(t, big.Delay.Mean, small.Delay.Mean)
+			assert.Greater(t, big.BlockShare, small.BlockShare)
+			assert.LessOrEqual(t, big.BlockShare, 1.0)
+			assert.InDelta(t, big.BlockShare*1000, big.ExpectedBlocks, 1e-9)
+		})
+	}
+}
+
+// TestForecaster_ForecastSimulation compares the forecasted share of blocks of the account with the share of blocks
+// won by the account in the simulated network of many miners.
+func TestForecaster_ForecastSimulation(t *testing.T) {
+	const rounds = 40000
+	account := uint64(200000 * proto.PriceConstant)
+	balances := []uint64{account}
+	for i := 1; i < 20; i++ {
+		balances = append(balances, uint64(i)*50000*proto.PriceConstant)
+	}
+	for _, test := range []struct {
+		name       string
+		baseTarget uint64
+		features   map[settings.Feature]bool
+	}{
+		{"NXT", 300, map[settings.Feature]bool{settings.SmallerMinimalGeneratingBalance: true}},
+		{"FairPoS", 500, map[settings.Feature]bool{settings.SmallerMinimalGeneratingBalance: true, settings.FairPoS: true, settings.BlockV5: true}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			st := &forecastStateMock{baseTarget: test.baseTarget, balance: account, features: test.features}
+			pos, err := NewForecaster(st, settings.MainNetSettings).cv.posAlgo(3000000)
+			require.NoError(t, err)
+			r := rand.New(rand.NewSource(1))
+			wins, sum := 0, 0.0
+			for i := 0; i < rounds; i++ {
+				winner, best := 0, uint64(math.MaxUint64)
+				for j, b := range balances {
+					d, err := pos.CalculateDelay(new(big.Int).SetUint64(r.Uint64()), test.baseTarget, b)
+					require.NoError(t, err)
+					if d < best {
+						winner, best = j, d
+					}
+				}
+				if winner == 0 {
+					wins++
+				}
+				sum += float64(best)
+			}
+			// Base target of the simulated network keeps the average delay of the simulated blocks.
+			bs := *settings.MainNetSettings
+			bs.AverageBlockDelaySeconds = uint64(math.Round(sum / rounds / 1000))
+			fc, err := NewForecaster(st, &bs).Forecast(proto.WavesAddress{}, 3000000, rounds)
+			require.NoError(t, err)
+			assert.InEpsilon(t, float64(wins), fc.ExpectedBlocks, 0.15, "simulated %d, forecasted %f", wins, fc.ExpectedBlocks)
+		})
+	}
+}
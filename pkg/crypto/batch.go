@@ -0,0 +1,137 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha512"
+
+	edwards "filippo.io/edwards25519"
+)
+
+// batchCoefficientSize is the size in bytes of random coefficients of the linear combination of verification equations.
+const batchCoefficientSize = 16
+
+type batchEntry struct {
+	r *edwards.Point
+	a *edwards.Point
+	s *edwards.Scalar
+	k *edwards.Scalar
+}
+
+// BatchVerifier verifies many signatures at once. All signatures of the batch are valid if its Verify returns true,
+// otherwise at least one signature is invalid and signatures should be checked one by one with Verify function
+// to find the invalid ones.
+//
+// The batch is checked with a random linear combination of verification equations with 128-bit coefficients.
+// Signatures that Verify rejects for encoding reasons and signatures with public keys or R points of small order
+// make the batch fail, so they are always reported by the per-item check.
+// Note that Verify uses the cofactorless equation, the batch may accept a signature which R point has a non-zero
+// torsion component with the probability up to 1/2, the exact check of the prime order subgroup costs as much as
+// the signature verification itself. The cofactored batch equation doesn't help, it accepts any signature which
+// differs from a valid one by a torsion component, while Verify rejects them all. That's why the batch verification
+// must be used only where the signatures are known to be valid, that is on import of trusted blockchain files.
+type BatchVerifier struct {
+	entries []batchEntry
+	// fallback is set if a signature that can't be checked in batch was added.
+	fallback bool
+}
+
+// NewBatchVerifier creates the batch with capacity for size signatures.
+func NewBatchVerifier(size int) *BatchVerifier {
+	return &BatchVerifier{entries: make([]batchEntry, 0, size)}
+}
+
+// Add adds the signature of data to the batch.
+func (bv *BatchVerifier) Add(publicKey PublicKey, sig Signature, data []byte) {
+	if bv.fallback {
+		return
+	}
+	e, ok := newBatchEntry(publicKey, sig, data)
+	if !ok {
+		bv.fallback = true
+		return
+	}
+	bv.entries = append(bv.entries, e)
+}
+
+// Len returns the number of signatures added to the batch.
+func (bv *BatchVerifier) Len() int {
+	return len(bv.entries)
+}
+
+// Reset removes all signatures from the batch.
+func (bv *BatchVerifier) Reset() {
+	bv.entries = bv.entries[:0]
+	bv.fallback = false
+}
+
+// Verify returns true if all signatures of the batch are valid.
+func (bv *BatchVerifier) Verify() bool {
+	if bv.fallback {
+		return false
+	}
+	n := len(bv.entries)
+	if n == 0 {
+		return true
+	}
+	scalars := make([]*edwards.Scalar, 0, 2*n+1)
+	points := make([]*edwards.Point, 0, 2*n+1)
+	bs := edwards.NewScalar()
+	zb := make([]byte, 32)
+	for i := range bv.entries {
+		e := &bv.entries[i]
+		if _, err := rand.Read(zb[:batchCoefficientSize]); err != nil {
+			return false
+		}
+		z, err := edwards.NewScalar().SetCanonicalBytes(zb)
+		if err != nil {
+			return false
+		}
+		bs.MultiplyAdd(z, e.s, bs)
+		scalars = append(scalars, z, edwards.NewScalar().Multiply(z, e.k))
+		points = append(points, e.r, e.a)
+	}
+	scalars = append(scalars, bs.Negate(bs))
+	points = append(points, edwards.NewGeneratorPoint())
+	res := new(edwards.Point).VarTimeMultiScalarMult(scalars, points)
+	return res.Equal(edwards.NewIdentityPoint()) == 1
+}
+
+// newBatchEntry decodes the signature and public key, false is returned if the signature must be checked by Verify.
+// The checks repeat the checks of Verify function.
+func newBatchEntry(publicKey PublicKey, sig Signature, data []byte) (batchEntry, bool) {
+	pk := publicKeyFromMontgomery(publicKey, sig[63])
+	sig[63] &= 0x7f
+	if sig[63]&224 != 0 {
+		return batchEntry{}, false
+	}
+	a, err := new(edwards.Point).SetBytes(pk)
+	if err != nil {
+		return batchEntry{}, false
+	}
+	r, err := new(edwards.Point).SetBytes(sig[:32])
+	if err != nil || !bytes.Equal(r.Bytes(), sig[:32]) {
+		return batchEntry{}, false
+	}
+	if isSmallOrder(a) || isSmallOrder(r) {
+		return batchEntry{}, false
+	}
+	s, err := edwards.NewScalar().SetCanonicalBytes(sig[32:])
+	if err != nil {
+		return batchEntry{}, false
+	}
+	h := sha512.New()
+	h.Write(sig[:32])
+	h.Write(pk)
+	h.Write(data)
+	k, err := edwards.NewScalar().SetUniformBytes(h.Sum(nil))
+	if err != nil {
+		return batchEntry{}, false
+	}
+	return batchEntry{r: r, a: a, s: s, k: k}, true
+}
+
+// isSmallOrder checks that [8]P is the identity.
+func isSmallOrder(p *edwards.Point) bool {
+	return new(edwards.Point).MultByCofactor(p).Equal(edwards.NewIdentityPoint()) == 1
+}
@@ -0,0 +1,103 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSignature struct {
+	pk   PublicKey
+	sig  Signature
+	data []byte
+}
+
+func generateTestSignatures(t testing.TB, n int) []testSignature {
+	r := make([]testSignature, n)
+	for i := range r {
+		seed := make([]byte, 32)
+		_, err := rand.Read(seed)
+		require.NoError(t, err)
+		sk, pk, err := GenerateKeyPair(seed)
+		require.NoError(t, err)
+		data := make([]byte, 128+i)
+		_, err = rand.Read(data)
+		require.NoError(t, err)
+		sig, err := Sign(sk, data)
+		require.NoError(t, err)
+		r[i] = testSignature{pk: pk, sig: sig, data: data}
+	}
+	return r
+}
+
+func TestBatchVerifier(t *testing.T) {
+	signatures := generateTestSignatures(t, 64)
+	bv := NewBatchVerifier(len(signatures))
+	assert.True(t, bv.Verify())
+	for _, s := range signatures {
+		bv.Add(s.pk, s.sig, s.data)
+	}
+	assert.Equal(t, len(signatures), bv.Len())
+	assert.True(t, bv.Verify())
+
+	bv.Reset()
+	assert.Equal(t, 0, bv.Len())
+	for i, s := range signatures {
+		data := s.data
+		if i == 42 {
+			data = append([]byte{0}, data...)
+		}
+		bv.Add(s.pk, s.sig, data)
+	}
+	assert.False(t, bv.Verify())
+}
+
+func TestBatchVerifierFallback(t *testing.T) {
+	signatures := generateTestSignatures(t, 4)
+	smallOrderR := signatures[1].sig
+	copy(smallOrderR[:32], make([]byte, 32))
+	smallOrderR[0] = 1 // Encoding of the identity point
+	nonCanonicalS := signatures[2].sig
+	nonCanonicalS[62] = 0xff
+	for i, sig := range []Signature{smallOrderR, nonCanonicalS} {
+		bv := NewBatchVerifier(len(signatures))
+		bv.Add(signatures[0].pk, signatures[0].sig, signatures[0].data)
+		bv.Add(signatures[i+1].pk, sig, signatures[i+1].data)
+		bv.Add(signatures[3].pk, signatures[3].sig, signatures[3].data)
+		assert.False(t, bv.Verify(), i)
+		assert.False(t, Verify(signatures[i+1].pk, sig, signatures[i+1].data), i)
+		bv.Reset()
+		bv.Add(signatures[0].pk, signatures[0].sig, signatures[0].data)
+		assert.True(t, bv.Verify(), i)
+	}
+}
+
+func BenchmarkBatchVerifier(b *testing.B) {
+	for size := 16; size <= 1024; size *= 4 {
+		signatures := generateTestSignatures(b, size)
+		b.Run(fmt.Sprintf("Sequential-%d", size), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				for _, s := range signatures {
+					if !Verify(s.pk, s.sig, s.data) {
+						b.Fatal("Verify() failed")
+					}
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("Batch-%d", size), func(b *testing.B) {
+			bv := NewBatchVerifier(size)
+			for n := 0; n < b.N; n++ {
+				bv.Reset()
+				for _, s := range signatures {
+					bv.Add(s.pk, s.sig, s.data)
+				}
+				if !bv.Verify() {
+					b.Fatal("BatchVerifier.Verify() failed")
+				}
+			}
+		})
+	}
+}
@@ -20,8 +20,9 @@ type subTree struct {
 }
 
 type MerkleTree struct {
-	h     hash.Hash
-	stack []subTree
+	h      hash.Hash
+	stack  []subTree
+	leaves []Digest
 }
 
 func NewMerkleTree() (*MerkleTree, error) {
@@ -37,6 +38,7 @@ func NewMerkleTree() (*MerkleTree, error) {
 
 func (t *MerkleTree) Push(data []byte) {
 	leaf := t.leafDigest(data)
+	t.leaves = append(t.leaves, leaf)
 	t.stack = append(t.stack, subTree{height: 0, digest: leaf})
 	t.joinAllSubTrees()
 }
@@ -77,6 +79,35 @@ func (t *MerkleTree) RebuildRoot(leaf Digest, proofs []Digest, index uint64) Dig
 	return digest
 }
 
+// Proof returns the digests required to rebuild the root of the tree from the leaf at the given index.
+// Digests are ordered from the root to the leaf, as expected by RebuildRoot.
+func (t *MerkleTree) Proof(index uint64) ([]Digest, error) {
+	if index >= uint64(len(t.leaves)) {
+		return nil, errors.Errorf("leaf index %d is out of range [0, %d)", index, len(t.leaves))
+	}
+	level := append(make([]Digest, 0, len(t.leaves)+1), t.leaves...)
+	var proofs []Digest
+	for {
+		if len(level)%2 == 1 {
+			level = append(level, ZeroDigest)
+		}
+		proofs = append(proofs, level[index^1])
+		next := make([]Digest, len(level)/2)
+		for i := range next {
+			next[i] = t.nodeDigest(level[2*i], level[2*i+1])
+		}
+		level = next
+		index /= 2
+		if len(level) == 1 {
+			break
+		}
+	}
+	for i, j := 0, len(proofs)-1; i < j; i, j = i+1, j-1 {
+		proofs[i], proofs[j] = proofs[j], proofs[i]
+	}
+	return proofs, nil
+}
+
 func (t *MerkleTree) leafDigest(data []byte) Digest {
 	t.h.Reset()
 	_, err := t.h.Write(data)
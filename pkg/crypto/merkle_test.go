@@ -93,6 +93,55 @@ func TestMerkleTreeRebuildRoot(t *testing.T) {
 	}
 }
 
+func TestMerkleTreeProof(t *testing.T) {
+	for i, test := range []struct {
+		leafs  int
+		index  uint64
+		proofs []string
+	}{
+		{1, 0, []string{"D4bn122GiEqs99z526GdhYETJqctLHGSmWokypEo9qu"}},
+		{2, 1, []string{"H2NvG7X3qQK7Uptsgoe514hUciZK81qsYiswxSXeiLKn"}},
+		{3, 2, []string{"75Aaexax3uEQNg5HAb137jC3TK64RG1S6xrBGvuupWXp", "D4bn122GiEqs99z526GdhYETJqctLHGSmWokypEo9qu"}},
+		{4, 2, []string{"75Aaexax3uEQNg5HAb137jC3TK64RG1S6xrBGvuupWXp", "7jsrwD9Xi7TjVoksaV1CDDUWYhFaz7HQmAoWwLEiZa6D"}},
+		{5, 1, []string{"q1u2PJhro1cwZw5mUuujXm94f245tGS5vbP5yNwLbEv", "5pZuB8CRjdAuedfhTpkcvwRxCioTL9Fu2dDdJAPMRHPg", "H2NvG7X3qQK7Uptsgoe514hUciZK81qsYiswxSXeiLKn"}},
+		{5, 4, []string{"2AYMXo9fKWK6swVeAx4DnLuW2wKP8u3S8Ypax6MVWkNh", "D4bn122GiEqs99z526GdhYETJqctLHGSmWokypEo9qu", "D4bn122GiEqs99z526GdhYETJqctLHGSmWokypEo9qu"}},
+	} {
+		tree, err := NewMerkleTree()
+		require.NoError(t, err)
+		for j := 1; j <= test.leafs; j++ {
+			tree.Push([]byte{byte(j)})
+		}
+		proofs, err := tree.Proof(test.index)
+		require.NoError(t, err)
+		expected := make([]Digest, len(test.proofs))
+		for j, p := range test.proofs {
+			expected[j] = MustDigestFromBase58(p)
+		}
+		assert.Equal(t, expected, proofs, fmt.Sprintf("#%d", i+1))
+	}
+}
+
+func TestMerkleTreeProofRebuildsRoot(t *testing.T) {
+	for n := 1; n <= 33; n++ {
+		tree, err := NewMerkleTree()
+		require.NoError(t, err)
+		leafs := make([]Digest, n)
+		for i := 0; i < n; i++ {
+			data := []byte{byte(i), byte(n)}
+			tree.Push(data)
+			leafs[i] = MustFastHash(data)
+		}
+		root := tree.Root()
+		for i := 0; i < n; i++ {
+			proofs, err := tree.Proof(uint64(i))
+			require.NoError(t, err)
+			assert.Equal(t, root, tree.RebuildRoot(leafs[i], proofs, uint64(i)), "leafs %d, index %d", n, i)
+		}
+		_, err = tree.Proof(uint64(n))
+		assert.Error(t, err)
+	}
+}
+
 func TestStagenetFailure(t *testing.T) {
 	tree, err := NewMerkleTree()
 	require.NoError(t, err)
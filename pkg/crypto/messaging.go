@@ -76,12 +76,12 @@ func Encrypt(key, message []byte) ([]byte, error) {
 }
 
 func Decrypt(key, encrypted []byte) ([]byte, error) {
-	if encrypted[0] != messageProtocolVersion {
-		return nil, errors.Errorf("invalid message protocol version, must be %d", messageProtocolVersion)
-	}
 	if len(encrypted) < messageHeaderSize {
 		return nil, errors.Errorf("invalid message length")
 	}
+	if encrypted[0] != messageProtocolVersion {
+		return nil, errors.Errorf("invalid message protocol version, must be %d", messageProtocolVersion)
+	}
 	sessionKey, err := decryptAESECB(encrypted[1:1+KeySize+aes.BlockSize], key)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to decrypt session key")
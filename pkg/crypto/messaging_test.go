@@ -225,6 +225,15 @@ func TestEncryptDecryptRoundTrip(t *testing.T) {
 	}
 }
 
+func TestDecryptInvalidMessage(t *testing.T) {
+	key, err := hex.DecodeString("a2c4f8ecdadf346377826602856e7c4aba50b8d129e7be09ea4384b6047c86a3")
+	require.NoError(t, err)
+	for _, enc := range [][]byte{nil, {messageProtocolVersion}, make([]byte, messageHeaderSize)} {
+		_, err := Decrypt(key, enc)
+		assert.Error(t, err)
+	}
+}
+
 func generateAccountSeed(t *testing.T, seed []byte) []byte {
 	n := make([]byte, 4)
 	s := append(n, []byte(seed)...)
@@ -0,0 +1,336 @@
+package devnet
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"go.uber.org/zap"
+)
+
+const (
+	configFileName       = "node.json"
+	logFileName          = "node.log"
+	controlTimeout       = 30 * time.Second
+	processStopTimeout   = 10 * time.Second
+	processStartInterval = 100 * time.Millisecond
+)
+
+type heightResponse struct {
+	Height proto.Height `json:"height"`
+}
+
+type blockIDResponse struct {
+	ID proto.BlockID `json:"id"`
+}
+
+type mineRequest struct {
+	Parent proto.BlockID `json:"parent"`
+}
+
+type timeRequest struct {
+	Time proto.Timestamp `json:"time"`
+}
+
+type addressesRequest struct {
+	Addresses []string `json:"addresses"`
+}
+
+type connectedResponse struct {
+	Count int `json:"count"`
+}
+
+// RunNode runs the node of the network until the context is canceled, the node is controlled by the harness
+// over HTTP API on the control address. It's the entry point of devnet node subprocesses.
+func RunNode(ctx context.Context, cfg NodeConfig) error {
+	n, err := startLocalNode(cfg)
+	if err != nil {
+		return err
+	}
+	s := &http.Server{Addr: cfg.ControlAddress, Handler: newControlHandler(n), ReadHeaderTimeout: controlTimeout}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- s.ListenAndServe()
+	}()
+	select {
+	case <-ctx.Done():
+	case err = <-errCh:
+		err = errors.Wrap(err, "control API failed")
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), controlTimeout)
+	defer cancel()
+	_ = s.Shutdown(shutdownCtx)
+	if closeErr := n.close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// ReadNodeConfig reads the node configuration written by the harness.
+func ReadNodeConfig(path string) (NodeConfig, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return NodeConfig{}, err
+	}
+	var cfg NodeConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return NodeConfig{}, errors.Wrap(err, "failed to read node config")
+	}
+	return cfg, nil
+}
+
+func newControlHandler(n *localNode) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/height", func(w http.ResponseWriter, r *http.Request) {
+		h, err := n.height(r.Context())
+		reply(w, heightResponse{Height: h}, err)
+	})
+	mux.HandleFunc("/block-id", func(w http.ResponseWriter, r *http.Request) {
+		h, err := strconv.ParseUint(r.URL.Query().Get("height"), 10, 64)
+		if err != nil {
+			reply(w, nil, err)
+			return
+		}
+		id, err := n.blockID(r.Context(), h)
+		reply(w, blockIDResponse{ID: id}, err)
+	})
+	mux.HandleFunc("/slot", func(w http.ResponseWriter, r *http.Request) {
+		s, err := n.scheduled(r.Context())
+		reply(w, s, err)
+	})
+	mux.HandleFunc("/mine", func(w http.ResponseWriter, r *http.Request) {
+		var req mineRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			reply(w, nil, err)
+			return
+		}
+		reply(w, nil, n.mine(r.Context(), req.Parent))
+	})
+	mux.HandleFunc("/time", func(w http.ResponseWriter, r *http.Request) {
+		var req timeRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			reply(w, nil, err)
+			return
+		}
+		reply(w, nil, n.setTime(r.Context(), time.UnixMilli(int64(req.Time))))
+	})
+	mux.HandleFunc("/isolate", func(w http.ResponseWriter, r *http.Request) {
+		addrs, err := decodeAddresses(r.Body)
+		if err != nil {
+			reply(w, nil, err)
+			return
+		}
+		reply(w, nil, n.isolate(r.Context(), addrs))
+	})
+	mux.HandleFunc("/connect", func(w http.ResponseWriter, r *http.Request) {
+		addrs, err := decodeAddresses(r.Body)
+		if err != nil {
+			reply(w, nil, err)
+			return
+		}
+		reply(w, nil, n.connect(r.Context(), addrs))
+	})
+	mux.HandleFunc("/connected", func(w http.ResponseWriter, r *http.Request) {
+		c, err := n.connected(r.Context())
+		reply(w, connectedResponse{Count: c}, err)
+	})
+	return mux
+}
+
+func decodeAddresses(r io.Reader) ([]proto.TCPAddr, error) {
+	var req addressesRequest
+	if err := json.NewDecoder(r).Decode(&req); err != nil {
+		return nil, err
+	}
+	addrs := make([]proto.TCPAddr, len(req.Addresses))
+	for i, a := range req.Addresses {
+		addrs[i] = proto.NewTCPAddrFromString(a)
+		if addrs[i].Empty() {
+			return nil, errors.Errorf("invalid address %q", a)
+		}
+	}
+	return addrs, nil
+}
+
+func encodeAddresses(addrs []proto.TCPAddr) addressesRequest {
+	req := addressesRequest{Addresses: make([]string, len(addrs))}
+	for i, a := range addrs {
+		req.Addresses[i] = a.String()
+	}
+	return req
+}
+
+func reply(w http.ResponseWriter, v interface{}, err error) {
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		zap.S().Errorf("Failed to write control API response: %v", err)
+	}
+}
+
+// processNode is the node running in a subprocess, it's controlled over HTTP.
+type processNode struct {
+	cfg    NodeConfig
+	cmd    *exec.Cmd
+	log    *os.File
+	client *http.Client
+	exited chan struct{}
+}
+
+func startProcessNode(ctx context.Context, binary string, cfg NodeConfig) (*processNode, error) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	cfgPath := filepath.Join(cfg.Dir, configFileName)
+	if err := os.WriteFile(cfgPath, data, 0600); err != nil {
+		return nil, err
+	}
+	log, err := os.Create(filepath.Join(cfg.Dir, logFileName))
+	if err != nil {
+		return nil, err
+	}
+	// #nosec: the binary is provided by the test configuration
+	cmd := exec.Command(binary, "-config", cfgPath)
+	cmd.Stdout = log
+	cmd.Stderr = log
+	if err := cmd.Start(); err != nil {
+		_ = log.Close()
+		return nil, errors.Wrap(err, "failed to start node process")
+	}
+	n := &processNode{
+		cfg:    cfg,
+		cmd:    cmd,
+		log:    log,
+		client: &http.Client{Timeout: controlTimeout},
+		exited: make(chan struct{}),
+	}
+	go func() {
+		_ = cmd.Wait()
+		close(n.exited)
+	}()
+	if err := n.waitStarted(ctx); err != nil {
+		_ = n.close()
+		return nil, err
+	}
+	return n, nil
+}
+
+func (n *processNode) waitStarted(ctx context.Context) error {
+	ticker := time.NewTicker(processStartInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := n.height(ctx); err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-n.exited:
+			return errors.Errorf("node process exited, see %s", n.log.Name())
+		case <-ticker.C:
+		}
+	}
+}
+
+func (n *processNode) call(ctx context.Context, method, path string, req, resp interface{}) error {
+	var body io.Reader
+	if req != nil {
+		data, err := json.Marshal(req)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	r, err := http.NewRequestWithContext(ctx, method, "http://"+n.cfg.ControlAddress+path, body)
+	if err != nil {
+		return err
+	}
+	res, err := n.client.Do(r)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+	if res.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(res.Body)
+		return errors.Errorf("node %q: %s", n.cfg.Name, bytes.TrimSpace(msg))
+	}
+	if resp == nil {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(resp)
+}
+
+func (n *processNode) address() proto.TCPAddr {
+	return proto.NewTCPAddrFromString(n.cfg.Address)
+}
+
+func (n *processNode) height(ctx context.Context) (proto.Height, error) {
+	var resp heightResponse
+	err := n.call(ctx, http.MethodGet, "/height", nil, &resp)
+	return resp.Height, err
+}
+
+func (n *processNode) blockID(ctx context.Context, height proto.Height) (proto.BlockID, error) {
+	var resp blockIDResponse
+	err := n.call(ctx, http.MethodGet, "/block-id?height="+strconv.FormatUint(height, 10), nil, &resp)
+	return resp.ID, err
+}
+
+func (n *processNode) scheduled(ctx context.Context) (slot, error) {
+	var resp slot
+	err := n.call(ctx, http.MethodGet, "/slot", nil, &resp)
+	return resp, err
+}
+
+func (n *processNode) mine(ctx context.Context, parent proto.BlockID) error {
+	return n.call(ctx, http.MethodPost, "/mine", mineRequest{Parent: parent}, nil)
+}
+
+func (n *processNode) setTime(ctx context.Context, t time.Time) error {
+	return n.call(ctx, http.MethodPost, "/time", timeRequest{Time: proto.NewTimestampFromTime(t)}, nil)
+}
+
+func (n *processNode) isolate(ctx context.Context, addrs []proto.TCPAddr) error {
+	return n.call(ctx, http.MethodPost, "/isolate", encodeAddresses(addrs), nil)
+}
+
+func (n *processNode) connect(ctx context.Context, addrs []proto.TCPAddr) error {
+	return n.call(ctx, http.MethodPost, "/connect", encodeAddresses(addrs), nil)
+}
+
+func (n *processNode) connected(ctx context.Context) (int, error) {
+	var resp connectedResponse
+	err := n.call(ctx, http.MethodGet, "/connected", nil, &resp)
+	return resp.Count, err
+}
+
+func (n *processNode) close() error {
+	defer func() { _ = n.log.Close() }()
+	if err := n.cmd.Process.Signal(os.Interrupt); err != nil {
+		// Process has already exited.
+		return nil
+	}
+	select {
+	case <-n.exited:
+		return nil
+	case <-time.After(processStopTimeout):
+		if err := n.cmd.Process.Kill(); err != nil {
+			return err
+		}
+		<-n.exited
+		return errors.Errorf("node %q was killed after stop timeout", n.cfg.Name)
+	}
+}
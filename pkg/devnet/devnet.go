@@ -0,0 +1,411 @@
+// Package devnet starts local networks of Go nodes for integration tests without Docker.
+//
+// Nodes run in the current process or as subprocesses of the devnet node binary (cmd/devnet) and connect to each
+// other over loopback. The network has its own genesis block, the time of nodes follows the local clock and is moved
+// forward by the harness, and key blocks are mined only on request, so the tests control the blockchain completely.
+package devnet
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/libs/ntptime"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+	"go.uber.org/zap"
+)
+
+const (
+	pollInterval   = 50 * time.Millisecond
+	defaultBalance = 10_000_000 * proto.PriceConstant
+)
+
+// netNode is the harness side of the network node, running in the current process or in a subprocess.
+type netNode interface {
+	address() proto.TCPAddr
+	height(ctx context.Context) (proto.Height, error)
+	blockID(ctx context.Context, height proto.Height) (proto.BlockID, error)
+	scheduled(ctx context.Context) (slot, error)
+	mine(ctx context.Context, parent proto.BlockID) error
+	setTime(ctx context.Context, t time.Time) error
+	isolate(ctx context.Context, addrs []proto.TCPAddr) error
+	connect(ctx context.Context, addrs []proto.TCPAddr) error
+	connected(ctx context.Context) (int, error)
+	close() error
+}
+
+// Config describes the network to start.
+type Config struct {
+	// Nodes is the number of nodes in the network.
+	Nodes int
+	// Dir is the directory for states and logs of nodes.
+	Dir string
+	// Balance is the genesis balance of each node account, 10M WAVES by default.
+	Balance uint64
+	// StartTime is the timestamp of the genesis block and the initial time of the network, current time by default.
+	StartTime time.Time
+	// Settings customizes blockchain settings before the genesis block is generated,
+	// for example sets the address scheme or preactivated features.
+	Settings func(s *settings.BlockchainSettings)
+	// NodeBinary is the path to the devnet node binary built from cmd/devnet.
+	// Nodes run in the current process if it's empty.
+	NodeBinary string
+}
+
+// Network is the running local network.
+type Network struct {
+	settings *settings.BlockchainSettings
+	accounts []Account
+	nodes    []netNode
+	clock    *ntptime.AdjustableStub
+}
+
+// New starts the network and waits until all nodes are connected to each other.
+func New(ctx context.Context, cfg Config) (*Network, error) {
+	if cfg.Nodes <= 0 {
+		return nil, errors.New("number of nodes should be positive")
+	}
+	if cfg.Dir == "" {
+		return nil, errors.New("directory of the network is not set")
+	}
+	if cfg.Balance == 0 {
+		cfg.Balance = defaultBalance
+	}
+	if cfg.StartTime.IsZero() {
+		cfg.StartTime = time.Now()
+	}
+	cfg.StartTime = cfg.StartTime.Truncate(time.Millisecond)
+	bs, accounts, err := newBlockchainSettings(cfg)
+	if err != nil {
+		return nil, err
+	}
+	n := &Network{settings: bs, accounts: accounts, clock: ntptime.NewAdjustableStub(cfg.StartTime)}
+	for i := 0; i < cfg.Nodes; i++ {
+		nd, err := n.startNode(ctx, cfg, i)
+		if err != nil {
+			_ = n.Close()
+			return nil, errors.Wrapf(err, "failed to start node %d", i)
+		}
+		n.nodes = append(n.nodes, nd)
+	}
+	if err := n.connectAll(ctx); err != nil {
+		_ = n.Close()
+		return nil, err
+	}
+	return n, nil
+}
+
+func (n *Network) startNode(ctx context.Context, cfg Config, i int) (netNode, error) {
+	name := fmt.Sprintf("node%d", i)
+	dir := filepath.Join(cfg.Dir, name)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, err
+	}
+	addr, err := freeAddress()
+	if err != nil {
+		return nil, err
+	}
+	nc := NodeConfig{
+		Name:     name,
+		Dir:      dir,
+		Address:  addr,
+		Seed:     n.accounts[i].Seed,
+		Time:     proto.NewTimestampFromTime(n.clock.Now()),
+		Settings: n.settings,
+	}
+	if cfg.NodeBinary == "" {
+		return startLocalNode(nc)
+	}
+	if nc.ControlAddress, err = freeAddress(); err != nil {
+		return nil, err
+	}
+	return startProcessNode(ctx, cfg.NodeBinary, nc)
+}
+
+// freeAddress returns loopback address with a port that is free at the moment.
+func freeAddress() (string, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to find free port")
+	}
+	addr := l.Addr().String()
+	if err := l.Close(); err != nil {
+		return "", err
+	}
+	return addr, nil
+}
+
+// Close stops all nodes of the network.
+func (n *Network) Close() error {
+	var res error
+	for i, nd := range n.nodes {
+		if err := nd.close(); err != nil && res == nil {
+			res = errors.Wrapf(err, "failed to close node %d", i)
+		}
+	}
+	n.nodes = nil
+	return res
+}
+
+// Settings returns blockchain settings of the network with the generated genesis block.
+func (n *Network) Settings() *settings.BlockchainSettings {
+	return n.settings
+}
+
+// Account returns the mining account of the node.
+func (n *Network) Account(i int) Account {
+	return n.accounts[i]
+}
+
+// Size returns the number of nodes in the network.
+func (n *Network) Size() int {
+	return len(n.nodes)
+}
+
+// Now returns the current time of the network.
+func (n *Network) Now() time.Time {
+	return n.clock.Now()
+}
+
+// SetTime moves the time of all nodes forward to t.
+func (n *Network) SetTime(ctx context.Context, t time.Time) error {
+	if !t.After(n.clock.Now()) {
+		return nil
+	}
+	for i, nd := range n.nodes {
+		if err := nd.setTime(ctx, t); err != nil {
+			return errors.Wrapf(err, "failed to set time of node %d", i)
+		}
+	}
+	n.clock.Set(t)
+	return nil
+}
+
+// AdvanceTime moves the time of all nodes forward by the duration.
+func (n *Network) AdvanceTime(ctx context.Context, d time.Duration) error {
+	return n.SetTime(ctx, n.clock.Now().Add(d))
+}
+
+// Height returns the blockchain height of the node.
+func (n *Network) Height(ctx context.Context, i int) (proto.Height, error) {
+	return n.nodes[i].height(ctx)
+}
+
+// BlockID returns ID of the block at the height in the blockchain of the node.
+func (n *Network) BlockID(ctx context.Context, i int, height proto.Height) (proto.BlockID, error) {
+	return n.nodes[i].blockID(ctx, height)
+}
+
+// Mine makes the node mine a key block on top of its last block. The time of the network is moved forward
+// to the timestamp of the block if required. It returns ID of the block after the node applies it.
+func (n *Network) Mine(ctx context.Context, i int) (proto.BlockID, error) {
+	nd := n.nodes[i]
+	h, err := nd.height(ctx)
+	if err != nil {
+		return proto.BlockID{}, err
+	}
+	s, err := nd.scheduled(ctx)
+	if err != nil {
+		return proto.BlockID{}, err
+	}
+	if err := n.SetTime(ctx, time.UnixMilli(int64(s.Timestamp))); err != nil {
+		return proto.BlockID{}, err
+	}
+	if err := nd.mine(ctx, s.Parent); err != nil {
+		return proto.BlockID{}, err
+	}
+	if err := n.WaitForHeight(ctx, h+1, i); err != nil {
+		return proto.BlockID{}, err
+	}
+	id, err := nd.blockID(ctx, h+1)
+	if err != nil {
+		return proto.BlockID{}, err
+	}
+	zap.S().Debugf("Node %d mined block %s at height %d", i, id.String(), h+1)
+	return id, nil
+}
+
+// MineBlocks makes the node mine the number of key blocks one after another.
+func (n *Network) MineBlocks(ctx context.Context, i, count int) error {
+	for k := 0; k < count; k++ {
+		if _, err := n.Mine(ctx, i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WaitForHeight waits until the nodes reach the height. All nodes are checked if none is given.
+func (n *Network) WaitForHeight(ctx context.Context, height proto.Height, nodes ...int) error {
+	return n.waitFor(ctx, n.selectNodes(nodes), func(ctx context.Context, nodes []int) (bool, error) {
+		for _, i := range nodes {
+			h, err := n.nodes[i].height(ctx)
+			if err != nil {
+				return false, err
+			}
+			if h < height {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+}
+
+// WaitForSync waits until the nodes have the same last block. All nodes are checked if none is given.
+func (n *Network) WaitForSync(ctx context.Context, nodes ...int) error {
+	return n.waitFor(ctx, n.selectNodes(nodes), func(ctx context.Context, nodes []int) (bool, error) {
+		var (
+			height proto.Height
+			top    proto.BlockID
+		)
+		for k, i := range nodes {
+			h, err := n.nodes[i].height(ctx)
+			if err != nil {
+				return false, err
+			}
+			id, err := n.nodes[i].blockID(ctx, h)
+			if err != nil {
+				return false, err
+			}
+			if k == 0 {
+				height, top = h, id
+				continue
+			}
+			if h != height || id != top {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+}
+
+// Partition splits the network into the groups of nodes, connections between the groups are dropped and refused.
+// Nodes not included in any group are isolated from all other nodes.
+func (n *Network) Partition(ctx context.Context, groups ...[]int) error {
+	group := make(map[int]int)
+	for g, nodes := range groups {
+		for _, i := range nodes {
+			if i < 0 || i >= len(n.nodes) {
+				return errors.Errorf("invalid node %d", i)
+			}
+			if _, ok := group[i]; ok {
+				return errors.Errorf("node %d is in several groups", i)
+			}
+			group[i] = g
+		}
+	}
+	for i, nd := range n.nodes {
+		gi, iok := group[i]
+		var isolated []proto.TCPAddr
+		for j, other := range n.nodes {
+			if gj, jok := group[j]; i != j && (!iok || !jok || gi != gj) {
+				isolated = append(isolated, other.address())
+			}
+		}
+		if err := nd.isolate(ctx, isolated); err != nil {
+			return errors.Wrapf(err, "failed to isolate node %d", i)
+		}
+	}
+	return nil
+}
+
+// Heal removes the partitions and waits until all nodes are connected to each other again.
+// Nodes resolve the forks by themselves, use WaitForSync to wait for it.
+func (n *Network) Heal(ctx context.Context) error {
+	for i, nd := range n.nodes {
+		if err := nd.isolate(ctx, nil); err != nil {
+			return errors.Wrapf(err, "failed to heal node %d", i)
+		}
+	}
+	return n.connectAll(ctx)
+}
+
+// InjectFork partitions the network into the groups and mines the number of blocks in each group
+// by its first node, so each group ends up with its own fork. The network stays partitioned, Heal it to let
+// nodes resolve the forks.
+func (n *Network) InjectFork(ctx context.Context, groups [][]int, blocks []int) error {
+	if len(groups) != len(blocks) {
+		return errors.New("number of blocks should be set for each group")
+	}
+	if err := n.Partition(ctx, groups...); err != nil {
+		return err
+	}
+	for g, nodes := range groups {
+		if len(nodes) == 0 {
+			continue
+		}
+		if err := n.MineBlocks(ctx, nodes[0], blocks[g]); err != nil {
+			return errors.Wrapf(err, "failed to mine blocks in group %d", g)
+		}
+		if err := n.WaitForSync(ctx, nodes...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// connectAll connects each node with all others and waits until the connections are established.
+// Failed connections are retried, because nodes start listening asynchronously.
+func (n *Network) connectAll(ctx context.Context) error {
+	return n.waitFor(ctx, n.selectNodes(nil), func(ctx context.Context, nodes []int) (bool, error) {
+		connected := true
+		for _, i := range nodes {
+			c, err := n.nodes[i].connected(ctx)
+			if err != nil {
+				return false, err
+			}
+			if c < len(n.nodes)-1 {
+				connected = false
+			}
+		}
+		if connected {
+			return true, nil
+		}
+		// Nodes skip addresses that are already connected.
+		for i, nd := range n.nodes {
+			addrs := make([]proto.TCPAddr, 0, i)
+			for _, other := range n.nodes[:i] {
+				addrs = append(addrs, other.address())
+			}
+			if err := nd.connect(ctx, addrs); err != nil {
+				return false, errors.Wrapf(err, "failed to connect node %d", i)
+			}
+		}
+		return false, nil
+	})
+}
+
+func (n *Network) selectNodes(nodes []int) []int {
+	if len(nodes) != 0 {
+		return nodes
+	}
+	all := make([]int, len(n.nodes))
+	for i := range all {
+		all[i] = i
+	}
+	return all
+}
+
+func (n *Network) waitFor(ctx context.Context, nodes []int, cond func(ctx context.Context, nodes []int) (bool, error)) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		ok, err := cond(ctx, nodes)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "nodes %v", nodes)
+		case <-ticker.C:
+		}
+	}
+}
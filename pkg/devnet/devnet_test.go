@@ -0,0 +1,76 @@
+package devnet
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+const testTimeout = 2 * time.Minute
+
+func testNetwork(t *testing.T, cfg Config) {
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	cfg.Nodes = 3
+	cfg.Dir = t.TempDir()
+	n, err := New(ctx, cfg)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, n.Close())
+	}()
+	start := n.Now()
+
+	// Blocks mined by any node are received by all others.
+	id1, err := n.Mine(ctx, 0)
+	require.NoError(t, err)
+	require.NoError(t, n.WaitForSync(ctx))
+	id2, err := n.Mine(ctx, 1)
+	require.NoError(t, err)
+	require.NoError(t, n.WaitForSync(ctx))
+	for i := 0; i < n.Size(); i++ {
+		id, err := n.BlockID(ctx, i, 2)
+		require.NoError(t, err)
+		assert.Equal(t, id1, id)
+		id, err = n.BlockID(ctx, i, 3)
+		require.NoError(t, err)
+		assert.Equal(t, id2, id)
+	}
+	assert.True(t, n.Now().After(start))
+
+	// The longer fork wins after the partition is healed.
+	require.NoError(t, n.InjectFork(ctx, [][]int{{0}, {1, 2}}, []int{1, 3}))
+	minority, err := n.BlockID(ctx, 0, 4)
+	require.NoError(t, err)
+	majority, err := n.BlockID(ctx, 1, 4)
+	require.NoError(t, err)
+	assert.NotEqual(t, minority, majority)
+	h, err := n.Height(ctx, 0)
+	require.NoError(t, err)
+	assert.Equal(t, proto.Height(4), h)
+
+	require.NoError(t, n.Heal(ctx))
+	require.NoError(t, n.WaitForHeight(ctx, 6))
+	require.NoError(t, n.WaitForSync(ctx))
+	id, err := n.BlockID(ctx, 0, 4)
+	require.NoError(t, err)
+	assert.Equal(t, majority, id)
+}
+
+func TestNetwork(t *testing.T) {
+	testNetwork(t, Config{})
+}
+
+// TestProcessNetwork runs nodes in subprocesses, the path to devnet node binary built from cmd/devnet
+// should be set in DEVNET_NODE_BINARY environment variable.
+func TestProcessNetwork(t *testing.T) {
+	binary := os.Getenv("DEVNET_NODE_BINARY")
+	if binary == "" {
+		t.Skip("DEVNET_NODE_BINARY is not set")
+	}
+	testNetwork(t, Config{NodeBinary: binary})
+}
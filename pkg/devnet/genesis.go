@@ -0,0 +1,158 @@
+package devnet
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/consensus"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+	"github.com/wavesplatform/gowaves/pkg/types"
+	"github.com/wavesplatform/gowaves/pkg/util/genesis_generator"
+)
+
+const (
+	defaultAverageBlockDelay = 10 // seconds
+	maxBaseTarget            = 1000000
+	// baseTargetPrecision is the acceptable difference in milliseconds between the delay and the average block delay.
+	baseTargetPrecision = 100
+)
+
+// Account is the mining account of a node, it receives the genesis balance.
+type Account struct {
+	Seed      []byte
+	SecretKey crypto.SecretKey
+	PublicKey crypto.PublicKey
+	Address   proto.WavesAddress
+}
+
+func newAccount(scheme proto.Scheme, i int) (Account, error) {
+	seed := []byte(fmt.Sprintf("devnet-node-%d", i))
+	sk, pk, err := crypto.GenerateKeyPair(seed)
+	if err != nil {
+		return Account{}, errors.Wrap(err, "failed to generate key pair")
+	}
+	addr, err := proto.NewAddressFromPublicKey(scheme, pk)
+	if err != nil {
+		return Account{}, errors.Wrap(err, "failed to create address")
+	}
+	return Account{Seed: seed, SecretKey: sk, PublicKey: pk, Address: addr}, nil
+}
+
+// newBlockchainSettings creates settings of the network with generated genesis block that distributes
+// balances between the accounts of nodes.
+func newBlockchainSettings(cfg Config) (*settings.BlockchainSettings, []Account, error) {
+	s := *settings.DefaultCustomSettings
+	s.AverageBlockDelaySeconds = defaultAverageBlockDelay
+	if cfg.Settings != nil {
+		cfg.Settings(&s)
+	}
+	accounts := make([]Account, cfg.Nodes)
+	txs := make([]genesis_generator.GenesisTransactionInfo, cfg.Nodes)
+	ts := proto.NewTimestampFromTime(cfg.StartTime)
+	for i := range accounts {
+		acc, err := newAccount(s.AddressSchemeCharacter, i)
+		if err != nil {
+			return nil, nil, err
+		}
+		accounts[i] = acc
+		txs[i] = genesis_generator.GenesisTransactionInfo{Address: acc.Address, Amount: cfg.Balance, Timestamp: ts}
+	}
+	bt, err := initialBaseTarget(&s, accounts, cfg.Balance)
+	if err != nil {
+		return nil, nil, err
+	}
+	block, err := genesis_generator.GenerateGenesisBlock(s.AddressSchemeCharacter, txs, bt, ts)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to generate genesis block")
+	}
+	s.Genesis = *block
+	return &s, accounts, nil
+}
+
+func isPreactivated(s *settings.BlockchainSettings, feature settings.Feature) bool {
+	for _, f := range s.PreactivatedFeatures {
+		if f == int16(feature) {
+			return true
+		}
+	}
+	return false
+}
+
+func posCalculator(s *settings.BlockchainSettings) consensus.PosCalculator {
+	if !isPreactivated(s, settings.FairPoS) {
+		return consensus.NXTPosCalculator
+	}
+	if isPreactivated(s, settings.BlockV5) {
+		return consensus.NewFairPosCalculator(s.DelayDelta, s.MinBlockTime)
+	}
+	return consensus.FairPosCalculatorV1
+}
+
+// initialBaseTarget selects the base target of the genesis block so that the delay of the first block
+// for each account is close to the average block delay.
+func initialBaseTarget(s *settings.BlockchainSettings, accounts []Account, balance uint64) (types.BaseTarget, error) {
+	pos := posCalculator(s)
+	var res types.BaseTarget
+	for _, acc := range accounts {
+		hit, err := firstHit(s, acc)
+		if err != nil {
+			return 0, err
+		}
+		bt, err := searchBaseTarget(pos, hit, balance, s.AverageBlockDelaySeconds*1000)
+		if err != nil {
+			return 0, err
+		}
+		if bt > res {
+			res = bt
+		}
+	}
+	return res, nil
+}
+
+// firstHit calculates the hit of the account for the block on top of the genesis block.
+func firstHit(s *settings.BlockchainSettings, acc Account) (*consensus.Hit, error) {
+	hitSource := make([]byte, crypto.DigestSize)
+	if isPreactivated(s, settings.BlockV5) {
+		proof, err := crypto.SignVRF(acc.SecretKey, hitSource)
+		if err != nil {
+			return nil, err
+		}
+		ok, source, err := crypto.VerifyVRF(acc.PublicKey, hitSource, proof)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, errors.New("invalid VRF proof")
+		}
+		return consensus.GenHit(source)
+	}
+	source, err := consensus.NXTGenerationSignatureProvider.GenerationSignature(acc.PublicKey, hitSource)
+	if err != nil {
+		return nil, err
+	}
+	return consensus.GenHit(source)
+}
+
+// searchBaseTarget finds the base target that gives the delay close to the expected one by binary search.
+func searchBaseTarget(pos consensus.PosCalculator, hit *consensus.Hit, balance, delay uint64) (types.BaseTarget, error) {
+	var minBT, maxBT types.BaseTarget = consensus.MinBaseTarget, maxBaseTarget
+	for maxBT-minBT > 1 {
+		bt := (minBT + maxBT) / 2
+		d, err := pos.CalculateDelay(hit, bt, balance)
+		if err != nil {
+			return 0, err
+		}
+		diff := int64(d) - int64(delay)
+		if diff < baseTargetPrecision && diff > -baseTargetPrecision {
+			return bt, nil
+		}
+		if d > delay {
+			minBT = bt
+		} else {
+			maxBT = bt
+		}
+	}
+	return maxBT, nil
+}
@@ -0,0 +1,236 @@
+package devnet
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/libs/microblock_cache"
+	"github.com/wavesplatform/gowaves/pkg/libs/ntptime"
+	"github.com/wavesplatform/gowaves/pkg/libs/runner"
+	"github.com/wavesplatform/gowaves/pkg/miner"
+	"github.com/wavesplatform/gowaves/pkg/miner/monitor"
+	"github.com/wavesplatform/gowaves/pkg/miner/scheduler"
+	"github.com/wavesplatform/gowaves/pkg/miner/selector"
+	"github.com/wavesplatform/gowaves/pkg/miner/utxpool"
+	"github.com/wavesplatform/gowaves/pkg/node"
+	"github.com/wavesplatform/gowaves/pkg/node/blocks_applier"
+	"github.com/wavesplatform/gowaves/pkg/node/events"
+	"github.com/wavesplatform/gowaves/pkg/node/messages"
+	"github.com/wavesplatform/gowaves/pkg/node/peer_manager"
+	peersPersistentStorage "github.com/wavesplatform/gowaves/pkg/node/peer_manager/storage"
+	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/services"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+	"github.com/wavesplatform/gowaves/pkg/signer"
+	"github.com/wavesplatform/gowaves/pkg/state"
+	"github.com/wavesplatform/gowaves/pkg/wallet"
+	"go.uber.org/zap"
+)
+
+const (
+	utxPoolSize                     = 64 << 20
+	connectionsLimit                = 30
+	maxTransactionTimeForwardOffset = 300 // seconds
+	// Blockchain never becomes outdated, the time of the network is controlled by the harness.
+	outdatePeriod = proto.Timestamp(math.MaxInt64)
+)
+
+// NodeConfig is the configuration of a single node of the local network.
+type NodeConfig struct {
+	Name string `json:"name"`
+	// Dir is the directory of the node state.
+	Dir string `json:"dir"`
+	// Address is the loopback address the node listens to for peer connections.
+	Address string `json:"address"`
+	// ControlAddress is the address of the control API, it's used only by nodes running in subprocesses.
+	ControlAddress string `json:"control_address"`
+	// Seed is the seed of the mining account of the node.
+	Seed []byte `json:"seed"`
+	// Time is the initial time of the node in milliseconds.
+	Time     proto.Timestamp              `json:"time"`
+	Settings *settings.BlockchainSettings `json:"settings"`
+}
+
+// slot is the time of the key block that the node can mine on top of the parent block.
+type slot struct {
+	Parent    proto.BlockID   `json:"parent"`
+	Timestamp proto.Timestamp `json:"timestamp"`
+}
+
+// localNode is a node that runs in the current process. Its time follows the local clock with jumps made by the harness and
+// key blocks are mined only on request.
+type localNode struct {
+	cfg       NodeConfig
+	ctx       context.Context
+	cancel    context.CancelFunc
+	clock     *ntptime.AdjustableStub
+	pk        crypto.PublicKey
+	state     state.State
+	peers     *isolatingPeers
+	scheduler *scheduler.SchedulerImpl
+	node      *node.Node
+}
+
+func startLocalNode(cfg NodeConfig) (*localNode, error) {
+	_, pk, err := crypto.GenerateKeyPair(cfg.Seed)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate key pair")
+	}
+	clock := ntptime.NewAdjustableStub(time.UnixMilli(int64(cfg.Time)))
+	params := state.DefaultTestingStateParams()
+	params.Time = clock
+	st, err := state.NewState(cfg.Dir, true, params, cfg.Settings)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create state")
+	}
+	peerStorage, err := peersPersistentStorage.NewCBORStorage(cfg.Dir, time.Now())
+	if err != nil {
+		_ = st.Close()
+		return nil, errors.Wrap(err, "failed to create peers storage")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	scheme := cfg.Settings.AddressSchemeCharacter
+	addr := proto.NewTCPAddrFromString(cfg.Address)
+	bus := events.NewBus()
+	parent := peer.NewParent()
+	spawner := peer_manager.NewPeerSpawner(parent, proto.NetworkStrFromScheme(scheme), addr, cfg.Name, uint64(time.Now().UnixNano()), proto.ProtocolVersion)
+	// Nodes of the network send identical requests simultaneously, for example, when they start synchronization
+	// with the same peer. The checker shared by all connections drops such requests from all peers but the first.
+	spawner.DuplicateChecker = nil
+	peerManager := peer_manager.NewPeerManager(
+		spawner,
+		peerStorage,
+		connectionsLimit,
+		proto.ProtocolVersion,
+		proto.NetworkStrFromScheme(scheme),
+		false,
+		0,
+		bus,
+	)
+	go peerManager.Run(ctx)
+	peers := newIsolatingPeers(peerManager)
+
+	wal := wallet.Stub{S: [][]byte{cfg.Seed}}
+	sgn := signer.NewLocal(wal, scheme, signer.NewGuard())
+	minerMonitor := monitor.NewMonitor()
+	// Mining doesn't depend on connected peers, so partitioned nodes continue to mine their own forks.
+	sch := scheduler.NewManualScheduler(st, sgn, cfg.Settings, clock, scheduler.StubConsensus{}, outdatePeriod, minerMonitor)
+	utx := utxpool.New(utxPoolSize, utxpool.NewValidator(st, clock, outdatePeriod), cfg.Settings, bus)
+	svs := services.Services{
+		State:           st,
+		Peers:           peers,
+		Scheduler:       sch,
+		BlocksApplier:   blocks_applier.NewBlocksApplier(bus),
+		UtxPool:         utx,
+		TxSelector:      selector.NewSelector(st, utx, scheme, selector.DefaultPolicy()),
+		MinerMonitor:    minerMonitor,
+		Events:          bus,
+		Scheme:          scheme,
+		LoggableRunner:  runner.NewLogRunner(runner.NewAsync()),
+		Time:            clock,
+		Wallet:          wal,
+		Signer:          sgn,
+		MicroBlockCache: microblock_cache.NewMicroblockCache(),
+		InternalChannel: messages.NewInternalChannel(),
+		SkipMessageList: parent.SkipMessageList,
+	}
+	mine := miner.NewMicroblockMiner(svs, nil, 0, maxTransactionTimeForwardOffset)
+	go miner.Run(ctx, mine, sch, svs.InternalChannel)
+
+	n := node.NewNode(svs, addr, addr, outdatePeriod)
+	go n.Run(ctx, parent, svs.InternalChannel)
+	zap.S().Infof("Node %q with account %s started on %s", cfg.Name, pk.String(), cfg.Address)
+	return &localNode{
+		cfg:       cfg,
+		ctx:       ctx,
+		cancel:    cancel,
+		clock:     clock,
+		pk:        pk,
+		state:     st,
+		peers:     peers,
+		scheduler: sch,
+		node:      n,
+	}, nil
+}
+
+func (n *localNode) address() proto.TCPAddr {
+	return proto.NewTCPAddrFromString(n.cfg.Address)
+}
+
+func (n *localNode) height(context.Context) (proto.Height, error) {
+	return n.state.Height()
+}
+
+func (n *localNode) blockID(_ context.Context, height proto.Height) (proto.BlockID, error) {
+	return n.state.HeightToBlockID(height)
+}
+
+// scheduled returns the slot of the node account on top of the last block.
+func (n *localNode) scheduled(context.Context) (slot, error) {
+	n.scheduler.Reschedule()
+	top := n.state.TopBlock()
+	emit, ok := n.emit(top.BlockID())
+	if !ok {
+		return slot{}, errors.Errorf("node %q can't mine on top of block %s", n.cfg.Name, top.BlockID().String())
+	}
+	return slot{Parent: emit.Parent, Timestamp: emit.Timestamp}, nil
+}
+
+// mine sends the emit of the node account on top of the parent block to the miner.
+func (n *localNode) mine(ctx context.Context, parent proto.BlockID) error {
+	emit, ok := n.emit(parent)
+	if !ok {
+		return errors.Errorf("node %q has no emit on top of block %s", n.cfg.Name, parent.String())
+	}
+	select {
+	case n.scheduler.Mine() <- emit:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (n *localNode) emit(parent proto.BlockID) (scheduler.Emit, bool) {
+	for _, e := range n.scheduler.Emits() {
+		if e.PublicKey == n.pk && e.Parent == parent {
+			return e, true
+		}
+	}
+	return scheduler.Emit{}, false
+}
+
+func (n *localNode) setTime(_ context.Context, t time.Time) error {
+	n.clock.Set(t)
+	return nil
+}
+
+func (n *localNode) isolate(_ context.Context, addrs []proto.TCPAddr) error {
+	n.peers.isolate(addrs)
+	return nil
+}
+
+func (n *localNode) connect(_ context.Context, addrs []proto.TCPAddr) error {
+	for _, addr := range addrs {
+		// Connections live as long as the node, so the node context is used.
+		if err := n.peers.Connect(n.ctx, addr); err != nil {
+			return errors.Wrapf(err, "failed to connect to %s", addr.String())
+		}
+	}
+	return nil
+}
+
+func (n *localNode) connected(context.Context) (int, error) {
+	return n.peers.ConnectedCount(), nil
+}
+
+func (n *localNode) close() error {
+	// Halt closes peers and the state.
+	n.node.Close()
+	n.cancel()
+	return nil
+}
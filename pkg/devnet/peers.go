@@ -0,0 +1,56 @@
+package devnet
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/node/peer_manager"
+	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+// isolatingPeers is the peer manager that refuses connections with isolated peers.
+// Nodes of the network share the loopback IP, so peers are identified by the declared addresses from handshakes.
+type isolatingPeers struct {
+	peer_manager.PeerManager
+	mu       sync.Mutex
+	isolated map[proto.IpPort]struct{}
+}
+
+func newIsolatingPeers(pm peer_manager.PeerManager) *isolatingPeers {
+	return &isolatingPeers{PeerManager: pm, isolated: make(map[proto.IpPort]struct{})}
+}
+
+func (p *isolatingPeers) NewConnection(pr peer.Peer) error {
+	if p.isIsolated(pr) {
+		_ = pr.Close()
+		return proto.NewInfoMsg(errors.Errorf("peer '%s' is isolated", pr.ID()))
+	}
+	return p.PeerManager.NewConnection(pr)
+}
+
+// isolate replaces the set of isolated peers, disconnects peers with the addresses and refuses new connections with them.
+func (p *isolatingPeers) isolate(addrs []proto.TCPAddr) {
+	p.mu.Lock()
+	p.isolated = make(map[proto.IpPort]struct{}, len(addrs))
+	for _, addr := range addrs {
+		p.isolated[addr.ToIpPort()] = struct{}{}
+	}
+	p.mu.Unlock()
+	var disconnect []peer.Peer
+	p.EachConnected(func(pr peer.Peer, _ *proto.Score) {
+		if p.isIsolated(pr) {
+			disconnect = append(disconnect, pr)
+		}
+	})
+	for _, pr := range disconnect {
+		p.Disconnect(pr)
+	}
+}
+
+func (p *isolatingPeers) isIsolated(pr peer.Peer) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, ok := p.isolated[pr.Handshake().DeclaredAddr.ToIpPort()]
+	return ok
+}
@@ -0,0 +1,388 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.4
+// source: gowaves/node/grpc/blockchain_api.proto
+
+package grpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// RewardsRequest selects the height to get the rewards at. Zero height means the current height.
+type RewardsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Height int32 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (x *RewardsRequest) Reset() {
+	*x = RewardsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gowaves_node_grpc_blockchain_api_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RewardsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RewardsRequest) ProtoMessage() {}
+
+func (x *RewardsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gowaves_node_grpc_blockchain_api_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RewardsRequest.ProtoReflect.Descriptor instead.
+func (*RewardsRequest) Descriptor() ([]byte, []int) {
+	return file_gowaves_node_grpc_blockchain_api_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RewardsRequest) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+type RewardsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Height              int32        `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	TotalWavesAmount    int64        `protobuf:"varint,2,opt,name=total_waves_amount,json=totalWavesAmount,proto3" json:"total_waves_amount,omitempty"`
+	CurrentReward       int64        `protobuf:"varint,3,opt,name=current_reward,json=currentReward,proto3" json:"current_reward,omitempty"`
+	MinIncrement        int64        `protobuf:"varint,4,opt,name=min_increment,json=minIncrement,proto3" json:"min_increment,omitempty"`
+	Term                int32        `protobuf:"varint,5,opt,name=term,proto3" json:"term,omitempty"`
+	NextCheck           int32        `protobuf:"varint,6,opt,name=next_check,json=nextCheck,proto3" json:"next_check,omitempty"`
+	VotingIntervalStart int32        `protobuf:"varint,7,opt,name=voting_interval_start,json=votingIntervalStart,proto3" json:"voting_interval_start,omitempty"`
+	VotingInterval      int32        `protobuf:"varint,8,opt,name=voting_interval,json=votingInterval,proto3" json:"voting_interval,omitempty"`
+	VotingThreshold     int32        `protobuf:"varint,9,opt,name=voting_threshold,json=votingThreshold,proto3" json:"voting_threshold,omitempty"`
+	Votes               *RewardVotes `protobuf:"bytes,10,opt,name=votes,proto3" json:"votes,omitempty"`
+}
+
+func (x *RewardsResponse) Reset() {
+	*x = RewardsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gowaves_node_grpc_blockchain_api_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RewardsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RewardsResponse) ProtoMessage() {}
+
+func (x *RewardsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gowaves_node_grpc_blockchain_api_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RewardsResponse.ProtoReflect.Descriptor instead.
+func (*RewardsResponse) Descriptor() ([]byte, []int) {
+	return file_gowaves_node_grpc_blockchain_api_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RewardsResponse) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *RewardsResponse) GetTotalWavesAmount() int64 {
+	if x != nil {
+		return x.TotalWavesAmount
+	}
+	return 0
+}
+
+func (x *RewardsResponse) GetCurrentReward() int64 {
+	if x != nil {
+		return x.CurrentReward
+	}
+	return 0
+}
+
+func (x *RewardsResponse) GetMinIncrement() int64 {
+	if x != nil {
+		return x.MinIncrement
+	}
+	return 0
+}
+
+func (x *RewardsResponse) GetTerm() int32 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *RewardsResponse) GetNextCheck() int32 {
+	if x != nil {
+		return x.NextCheck
+	}
+	return 0
+}
+
+func (x *RewardsResponse) GetVotingIntervalStart() int32 {
+	if x != nil {
+		return x.VotingIntervalStart
+	}
+	return 0
+}
+
+func (x *RewardsResponse) GetVotingInterval() int32 {
+	if x != nil {
+		return x.VotingInterval
+	}
+	return 0
+}
+
+func (x *RewardsResponse) GetVotingThreshold() int32 {
+	if x != nil {
+		return x.VotingThreshold
+	}
+	return 0
+}
+
+func (x *RewardsResponse) GetVotes() *RewardVotes {
+	if x != nil {
+		return x.Votes
+	}
+	return nil
+}
+
+type RewardVotes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Increase int32 `protobuf:"varint,1,opt,name=increase,proto3" json:"increase,omitempty"`
+	Decrease int32 `protobuf:"varint,2,opt,name=decrease,proto3" json:"decrease,omitempty"`
+}
+
+func (x *RewardVotes) Reset() {
+	*x = RewardVotes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gowaves_node_grpc_blockchain_api_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RewardVotes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RewardVotes) ProtoMessage() {}
+
+func (x *RewardVotes) ProtoReflect() protoreflect.Message {
+	mi := &file_gowaves_node_grpc_blockchain_api_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RewardVotes.ProtoReflect.Descriptor instead.
+func (*RewardVotes) Descriptor() ([]byte, []int) {
+	return file_gowaves_node_grpc_blockchain_api_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RewardVotes) GetIncrease() int32 {
+	if x != nil {
+		return x.Increase
+	}
+	return 0
+}
+
+func (x *RewardVotes) GetDecrease() int32 {
+	if x != nil {
+		return x.Decrease
+	}
+	return 0
+}
+
+var File_gowaves_node_grpc_blockchain_api_proto protoreflect.FileDescriptor
+
+var file_gowaves_node_grpc_blockchain_api_proto_rawDesc = []byte{
+	0x0a, 0x26, 0x67, 0x6f, 0x77, 0x61, 0x76, 0x65, 0x73, 0x2f, 0x6e, 0x6f, 0x64, 0x65, 0x2f, 0x67,
+	0x72, 0x70, 0x63, 0x2f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x61,
+	0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x11, 0x67, 0x6f, 0x77, 0x61, 0x76, 0x65,
+	0x73, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x22, 0x28, 0x0a, 0x0e, 0x52,
+	0x65, 0x77, 0x61, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x94, 0x03, 0x0a, 0x0f, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x12, 0x2c, 0x0a, 0x12, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x77, 0x61, 0x76, 0x65, 0x73,
+	0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x57, 0x61, 0x76, 0x65, 0x73, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x25, 0x0a, 0x0e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x77, 0x61, 0x72,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x69, 0x6e, 0x5f, 0x69, 0x6e,
+	0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x6d,
+	0x69, 0x6e, 0x49, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x65, 0x72, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x12,
+	0x1d, 0x0a, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x09, 0x6e, 0x65, 0x78, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x32,
+	0x0a, 0x15, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x13, 0x76,
+	0x6f, 0x74, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x76, 0x6f, 0x74,
+	0x69, 0x6e, 0x67, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x29, 0x0a, 0x10, 0x76,
+	0x6f, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x54, 0x68, 0x72,
+	0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x34, 0x0a, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x67, 0x6f, 0x77, 0x61, 0x76, 0x65, 0x73, 0x2e,
+	0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64,
+	0x56, 0x6f, 0x74, 0x65, 0x73, 0x52, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x22, 0x45, 0x0a, 0x0b,
+	0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x56, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x69,
+	0x6e, 0x63, 0x72, 0x65, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x69,
+	0x6e, 0x63, 0x72, 0x65, 0x61, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x63, 0x72, 0x65,
+	0x61, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x64, 0x65, 0x63, 0x72, 0x65,
+	0x61, 0x73, 0x65, 0x32, 0x64, 0x0a, 0x0d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x63, 0x68, 0x61, 0x69,
+	0x6e, 0x41, 0x70, 0x69, 0x12, 0x53, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x52, 0x65, 0x77, 0x61, 0x72,
+	0x64, 0x73, 0x12, 0x21, 0x2e, 0x67, 0x6f, 0x77, 0x61, 0x76, 0x65, 0x73, 0x2e, 0x6e, 0x6f, 0x64,
+	0x65, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x67, 0x6f, 0x77, 0x61, 0x76, 0x65, 0x73, 0x2e,
+	0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x47, 0x5a, 0x45, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x77, 0x61, 0x76, 0x65, 0x73, 0x70, 0x6c, 0x61,
+	0x74, 0x66, 0x6f, 0x72, 0x6d, 0x2f, 0x67, 0x6f, 0x77, 0x61, 0x76, 0x65, 0x73, 0x2f, 0x70, 0x6b,
+	0x67, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64,
+	0x2f, 0x67, 0x6f, 0x77, 0x61, 0x76, 0x65, 0x73, 0x2f, 0x6e, 0x6f, 0x64, 0x65, 0x2f, 0x67, 0x72,
+	0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_gowaves_node_grpc_blockchain_api_proto_rawDescOnce sync.Once
+	file_gowaves_node_grpc_blockchain_api_proto_rawDescData = file_gowaves_node_grpc_blockchain_api_proto_rawDesc
+)
+
+func file_gowaves_node_grpc_blockchain_api_proto_rawDescGZIP() []byte {
+	file_gowaves_node_grpc_blockchain_api_proto_rawDescOnce.Do(func() {
+		file_gowaves_node_grpc_blockchain_api_proto_rawDescData = protoimpl.X.CompressGZIP(file_gowaves_node_grpc_blockchain_api_proto_rawDescData)
+	})
+	return file_gowaves_node_grpc_blockchain_api_proto_rawDescData
+}
+
+var file_gowaves_node_grpc_blockchain_api_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_gowaves_node_grpc_blockchain_api_proto_goTypes = []interface{}{
+	(*RewardsRequest)(nil),  // 0: gowaves.node.grpc.RewardsRequest
+	(*RewardsResponse)(nil), // 1: gowaves.node.grpc.RewardsResponse
+	(*RewardVotes)(nil),     // 2: gowaves.node.grpc.RewardVotes
+}
+var file_gowaves_node_grpc_blockchain_api_proto_depIdxs = []int32{
+	2, // 0: gowaves.node.grpc.RewardsResponse.votes:type_name -> gowaves.node.grpc.RewardVotes
+	0, // 1: gowaves.node.grpc.BlockchainApi.GetRewards:input_type -> gowaves.node.grpc.RewardsRequest
+	1, // 2: gowaves.node.grpc.BlockchainApi.GetRewards:output_type -> gowaves.node.grpc.RewardsResponse
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_gowaves_node_grpc_blockchain_api_proto_init() }
+func file_gowaves_node_grpc_blockchain_api_proto_init() {
+	if File_gowaves_node_grpc_blockchain_api_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_gowaves_node_grpc_blockchain_api_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RewardsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gowaves_node_grpc_blockchain_api_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RewardsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gowaves_node_grpc_blockchain_api_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RewardVotes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_gowaves_node_grpc_blockchain_api_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_gowaves_node_grpc_blockchain_api_proto_goTypes,
+		DependencyIndexes: file_gowaves_node_grpc_blockchain_api_proto_depIdxs,
+		MessageInfos:      file_gowaves_node_grpc_blockchain_api_proto_msgTypes,
+	}.Build()
+	File_gowaves_node_grpc_blockchain_api_proto = out.File
+	file_gowaves_node_grpc_blockchain_api_proto_rawDesc = nil
+	file_gowaves_node_grpc_blockchain_api_proto_goTypes = nil
+	file_gowaves_node_grpc_blockchain_api_proto_depIdxs = nil
+}
@@ -1,6 +1,9 @@
 package server
 
-import "github.com/wavesplatform/gowaves/pkg/grpc/generated/waves/node/grpc"
+import (
+	gg "github.com/wavesplatform/gowaves/pkg/grpc/generated/gowaves/node/grpc"
+	"github.com/wavesplatform/gowaves/pkg/grpc/generated/waves/node/grpc"
+)
 
 type GrpcHandlers interface {
 	grpc.AccountsApiServer
@@ -8,4 +11,7 @@ type GrpcHandlers interface {
 	grpc.BlockchainApiServer
 	grpc.BlocksApiServer
 	grpc.TransactionsApiServer
+	gg.MerkleProofsApiServer
+	gg.EventsApiServer
+	gg.BlockchainApiServer
 }
@@ -3,8 +3,10 @@ package server
 import (
 	"context"
 
+	gg "github.com/wavesplatform/gowaves/pkg/grpc/generated/gowaves/node/grpc"
 	g "github.com/wavesplatform/gowaves/pkg/grpc/generated/waves/node/grpc"
 	"github.com/wavesplatform/gowaves/pkg/settings"
+	"github.com/wavesplatform/gowaves/pkg/state"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -132,3 +134,40 @@ func (s *Server) GetCumulativeScore(ctx context.Context, req *emptypb.Empty) (*g
 	}
 	return &g.ScoreResponse{Score: scoreBytes}, nil
 }
+
+func (s *Server) GetRewards(ctx context.Context, req *gg.RewardsRequest) (*gg.RewardsResponse, error) {
+	if req.Height < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid height %d", req.Height)
+	}
+	height := uint64(req.Height)
+	if height == 0 {
+		h, err := s.state.Height()
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, err.Error())
+		}
+		height = h
+	}
+	info, err := s.state.RewardsInfo(height)
+	if err != nil {
+		switch {
+		case state.IsNotFound(err):
+			return nil, status.Errorf(codes.FailedPrecondition, err.Error())
+		case state.IsInvalidInput(err):
+			return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		default:
+			return nil, status.Errorf(codes.Internal, err.Error())
+		}
+	}
+	return &gg.RewardsResponse{
+		Height:              int32(info.Height),
+		TotalWavesAmount:    int64(info.TotalWavesAmount),
+		CurrentReward:       int64(info.CurrentReward),
+		MinIncrement:        int64(info.MinIncrement),
+		Term:                int32(info.Term),
+		NextCheck:           int32(info.NextCheck),
+		VotingIntervalStart: int32(info.VotingIntervalStart),
+		VotingInterval:      int32(info.VotingInterval),
+		VotingThreshold:     int32(info.VotingThreshold),
+		Votes:               &gg.RewardVotes{Increase: int32(info.VotesIncrease), Decrease: int32(info.VotesDecrease)},
+	}, nil
+}
@@ -5,10 +5,14 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gg "github.com/wavesplatform/gowaves/pkg/grpc/generated/gowaves/node/grpc"
 	g "github.com/wavesplatform/gowaves/pkg/grpc/generated/waves/node/grpc"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/settings"
 	"github.com/wavesplatform/gowaves/pkg/state"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -63,3 +67,47 @@ func TestGetCumulativeScore(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, resultBytes, res.Score)
 }
+
+func TestGetRewards(t *testing.T) {
+	genesisPath, err := globalPathFromLocal("testdata/genesis/lease_genesis.json")
+	require.NoError(t, err)
+	sets := *customSettingsWithGenesis(t, genesisPath)
+	sets.PreactivatedFeatures = []int16{int16(settings.BlockReward)}
+	sets.InitialBlockReward = 600000000
+	sets.BlockRewardIncrement = 50000000
+	sets.BlockRewardVotingPeriod = 10000
+	st, err := state.NewState(t.TempDir(), true, defaultStateParams(), &sets)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, st.Close())
+	})
+	ctx := withAutoCancel(t, context.Background())
+	err = server.initServer(st, nil, createTestNetWallet(t))
+	require.NoError(t, err)
+
+	conn := connectAutoClose(t, grpcTestAddr)
+	cl := gg.NewBlockchainApiClient(conn)
+
+	var total int64
+	for _, tx := range sets.Genesis.Transactions {
+		if g, ok := tx.(*proto.Genesis); ok {
+			total += int64(g.Amount)
+		}
+	}
+	res, err := cl.GetRewards(ctx, &gg.RewardsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), res.Height)
+	assert.Equal(t, total, res.TotalWavesAmount)
+	assert.Equal(t, int64(600000000), res.CurrentReward)
+	assert.Equal(t, int64(50000000), res.MinIncrement)
+	assert.Equal(t, int32(100000), res.Term)
+	assert.Equal(t, int32(100000), res.NextCheck)
+	assert.Equal(t, int32(90001), res.VotingIntervalStart)
+	assert.Equal(t, int32(10000), res.VotingInterval)
+	assert.Equal(t, int32(5001), res.VotingThreshold)
+	assert.Equal(t, int32(0), res.Votes.Increase)
+	assert.Equal(t, int32(0), res.Votes.Decrease)
+
+	_, err = cl.GetRewards(ctx, &gg.RewardsRequest{Height: 2})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	gg "github.com/wavesplatform/gowaves/pkg/grpc/generated/gowaves/node/grpc"
+	"github.com/wavesplatform/gowaves/pkg/node/events"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const eventsSubscriberName = "grpc"
+
+var eventKinds = map[gg.EventKind]events.Kind{
+	gg.EventKind_BLOCK_APPLIED:        events.BlockAppliedKind,
+	gg.EventKind_MICRO_BLOCK_APPENDED: events.MicroBlockAppendedKind,
+	gg.EventKind_ROLLBACK:             events.RollbackKind,
+	gg.EventKind_TRANSACTION_ADDED:    events.TransactionAddedKind,
+	gg.EventKind_TRANSACTION_REMOVED:  events.TransactionRemovedKind,
+	gg.EventKind_PEER_CONNECTED:       events.PeerConnectedKind,
+	gg.EventKind_PEER_DISCONNECTED:    events.PeerDisconnectedKind,
+}
+
+func (s *Server) Subscribe(req *gg.EventsRequest, srv gg.EventsApi_SubscribeServer) error {
+	if s.services.Events == nil {
+		return status.Errorf(codes.Unavailable, "events are not available")
+	}
+	kinds := make([]events.Kind, len(req.Kinds))
+	for i, k := range req.Kinds {
+		ek, ok := eventKinds[k]
+		if !ok {
+			return status.Errorf(codes.InvalidArgument, "unsupported event kind %s", k.String())
+		}
+		kinds[i] = ek
+	}
+	sub, err := s.services.Events.Subscribe(eventsSubscriberName, events.DefaultBufferSize, kinds...)
+	if err != nil {
+		return status.Errorf(codes.Internal, err.Error())
+	}
+	defer s.services.Events.Unsubscribe(sub)
+	for {
+		select {
+		case <-srv.Context().Done():
+			return nil
+		case e, ok := <-sub.Events():
+			if !ok {
+				return nil
+			}
+			if err := srv.Send(nodeEvent(e)); err != nil {
+				return status.Errorf(codes.Internal, err.Error())
+			}
+		}
+	}
+}
+
+func nodeEvent(e events.Event) *gg.NodeEvent {
+	switch e := e.(type) {
+	case events.BlockApplied:
+		return &gg.NodeEvent{Kind: gg.EventKind_BLOCK_APPLIED, Height: int32(e.Height), BlockId: e.BlockID.Bytes()}
+	case events.MicroBlockAppended:
+		return &gg.NodeEvent{
+			Kind:      gg.EventKind_MICRO_BLOCK_APPENDED,
+			Height:    int32(e.Height),
+			BlockId:   e.BlockID.Bytes(),
+			Reference: e.Reference.Bytes(),
+		}
+	case events.Rollback:
+		return &gg.NodeEvent{Kind: gg.EventKind_ROLLBACK, Height: int32(e.Height)}
+	case events.TransactionAdded:
+		return &gg.NodeEvent{Kind: gg.EventKind_TRANSACTION_ADDED, TransactionId: e.ID.Bytes()}
+	case events.TransactionRemoved:
+		return &gg.NodeEvent{Kind: gg.EventKind_TRANSACTION_REMOVED, TransactionId: e.ID.Bytes()}
+	case events.PeerConnected:
+		return &gg.NodeEvent{
+			Kind:          gg.EventKind_PEER_CONNECTED,
+			PeerId:        e.ID,
+			PeerAddress:   e.Address.String(),
+			PeerDirection: e.Direction,
+		}
+	case events.PeerDisconnected:
+		return &gg.NodeEvent{Kind: gg.EventKind_PEER_DISCONNECTED, PeerId: e.ID, PeerAddress: e.Address.String()}
+	default:
+		return &gg.NodeEvent{Kind: gg.EventKind_UNSPECIFIED}
+	}
+}
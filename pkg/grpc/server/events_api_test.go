@@ -0,0 +1,73 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	gg "github.com/wavesplatform/gowaves/pkg/grpc/generated/gowaves/node/grpc"
+	"github.com/wavesplatform/gowaves/pkg/node/events"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/services"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type eventsStream struct {
+	gg.EventsApi_SubscribeServer
+	ctx    context.Context
+	events chan *gg.NodeEvent
+}
+
+func (s *eventsStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *eventsStream) Send(e *gg.NodeEvent) error {
+	s.events <- e
+	return nil
+}
+
+func TestSubscribeEvents(t *testing.T) {
+	bus := events.NewBus()
+	s := &Server{services: services.Services{Events: bus}}
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &eventsStream{ctx: ctx, events: make(chan *gg.NodeEvent, 10)}
+	req := &gg.EventsRequest{Kinds: []gg.EventKind{gg.EventKind_BLOCK_APPLIED, gg.EventKind_TRANSACTION_REMOVED}}
+	done := make(chan error)
+	go func() {
+		done <- s.Subscribe(req, stream)
+	}()
+	require.Eventually(t, func() bool { return bus.Len() == 1 }, time.Second, 10*time.Millisecond)
+
+	id := proto.NewBlockIDFromDigest(crypto.MustFastHash([]byte("block")))
+	txID := crypto.MustFastHash([]byte("tx"))
+	bus.Publish(events.Rollback{Height: 5})
+	bus.Publish(events.BlockApplied{Height: 6, BlockID: id})
+	bus.Publish(events.TransactionRemoved{ID: txID})
+
+	e := <-stream.events
+	assert.Equal(t, gg.EventKind_BLOCK_APPLIED, e.Kind)
+	assert.Equal(t, int32(6), e.Height)
+	assert.Equal(t, id.Bytes(), e.BlockId)
+	e = <-stream.events
+	assert.Equal(t, gg.EventKind_TRANSACTION_REMOVED, e.Kind)
+	assert.Equal(t, txID.Bytes(), e.TransactionId)
+
+	cancel()
+	require.NoError(t, <-done)
+	assert.Equal(t, 0, bus.Len())
+}
+
+func TestSubscribeEventsErrors(t *testing.T) {
+	s := &Server{}
+	err := s.Subscribe(&gg.EventsRequest{}, &eventsStream{ctx: context.Background()})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	s = &Server{services: services.Services{Events: events.NewBus()}}
+	err = s.Subscribe(&gg.EventsRequest{Kinds: []gg.EventKind{gg.EventKind_UNSPECIFIED}}, &eventsStream{ctx: context.Background()})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
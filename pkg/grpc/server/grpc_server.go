@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	gg "github.com/wavesplatform/gowaves/pkg/grpc/generated/gowaves/node/grpc"
 	g "github.com/wavesplatform/gowaves/pkg/grpc/generated/waves/node/grpc"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/services"
@@ -24,6 +25,8 @@ type Server struct {
 	services   services.Services
 	handlers   GrpcHandlers
 	grpcServer *grpc.Server
+	// signDisabled is set if the wallet of the node can't sign transactions.
+	signDisabled bool
 }
 
 func NewServer(services services.Services) (*Server, error) {
@@ -45,6 +48,12 @@ func NewServerWithHandlers(services services.Services, h GrpcHandlers) (*Server,
 	return s, nil
 }
 
+// DisableSign makes the server reject Sign requests. It is used if the keys of the node are held by the remote signer,
+// which signs only blocks.
+func (s *Server) DisableSign() {
+	s.signDisabled = true
+}
+
 func (s *Server) initServer(state state.StateInfo, utx types.UtxPool, sch types.EmbeddedWallet) error {
 	s.state = state
 	s.scheme = s.services.Scheme
@@ -66,6 +75,9 @@ func (s *Server) Run(ctx context.Context, address string) error {
 	g.RegisterBlockchainApiServer(grpcServer, s)
 	g.RegisterBlocksApiServer(grpcServer, s)
 	g.RegisterTransactionsApiServer(grpcServer, s)
+	gg.RegisterMerkleProofsApiServer(grpcServer, s)
+	gg.RegisterEventsApiServer(grpcServer, s)
+	gg.RegisterBlockchainApiServer(grpcServer, s)
 
 	go func() {
 		<-ctx.Done()
@@ -106,6 +118,9 @@ func (s *Server) Serve(l net.Listener) error {
 	g.RegisterBlockchainApiServer(grpcServer, s.handlers)
 	g.RegisterBlocksApiServer(grpcServer, s.handlers)
 	g.RegisterTransactionsApiServer(grpcServer, s.handlers)
+	gg.RegisterMerkleProofsApiServer(grpcServer, s.handlers)
+	gg.RegisterEventsApiServer(grpcServer, s.handlers)
+	gg.RegisterBlockchainApiServer(grpcServer, s.handlers)
 	s.grpcServer = grpcServer
 
 	if err := grpcServer.Serve(l); err != nil {
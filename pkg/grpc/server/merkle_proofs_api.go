@@ -0,0 +1,76 @@
+package server
+
+import (
+	"bytes"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	gg "github.com/wavesplatform/gowaves/pkg/grpc/generated/gowaves/node/grpc"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/state"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) GetTransactionProofs(req *gg.TransactionProofsRequest, srv gg.MerkleProofsApi_GetTransactionProofsServer) error {
+	var (
+		block  *proto.Block
+		height proto.Height
+	)
+	for _, bts := range req.TransactionIds {
+		id, err := crypto.NewDigestFromBytes(bts)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, err.Error())
+		}
+		h, err := s.state.TransactionHeightByID(bts)
+		if err != nil {
+			if state.IsNotFound(err) {
+				return status.Errorf(codes.NotFound, "transaction %s not found", id.String())
+			}
+			return status.Errorf(codes.Internal, err.Error())
+		}
+		// Transactions are usually requested in batches from the same block.
+		if block == nil || h != height {
+			block, err = s.state.BlockByHeight(h)
+			if err != nil {
+				return status.Errorf(codes.Internal, err.Error())
+			}
+			height = h
+		}
+		if block.Version < proto.ProtobufBlockVersion {
+			return status.Errorf(codes.FailedPrecondition,
+				"transaction %s is in block of version %d without transactions root", id.String(), block.Version)
+		}
+		index := -1
+		for i, tx := range block.Transactions {
+			txID, err := tx.GetID(s.scheme)
+			if err != nil {
+				return status.Errorf(codes.Internal, err.Error())
+			}
+			if bytes.Equal(txID, bts) {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return status.Errorf(codes.Internal, "transaction %s not found in block at height %d", id.String(), h)
+		}
+		proofs, err := block.TransactionProof(s.scheme, index)
+		if err != nil {
+			return status.Errorf(codes.Internal, err.Error())
+		}
+		res := &gg.TransactionProofResponse{
+			Id:               bts,
+			Height:           int32(h),
+			BlockId:          block.BlockID().Bytes(),
+			TransactionIndex: int32(index),
+			MerkleProof:      make([][]byte, len(proofs)),
+		}
+		for i, p := range proofs {
+			res.MerkleProof[i] = p.Bytes()
+		}
+		if err := srv.Send(res); err != nil {
+			return status.Errorf(codes.Internal, err.Error())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	gg "github.com/wavesplatform/gowaves/pkg/grpc/generated/gowaves/node/grpc"
+	"github.com/wavesplatform/gowaves/pkg/keyvalue"
+	"github.com/wavesplatform/gowaves/pkg/mock"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type transactionProofsStream struct {
+	gg.MerkleProofsApi_GetTransactionProofsServer
+	responses []*gg.TransactionProofResponse
+}
+
+func (s *transactionProofsStream) Send(r *gg.TransactionProofResponse) error {
+	s.responses = append(s.responses, r)
+	return nil
+}
+
+func TestGetTransactionProofs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sk, pk, err := crypto.GenerateKeyPair([]byte("proofs"))
+	require.NoError(t, err)
+	addr, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+	waves := proto.NewOptionalAssetWaves()
+	txs := make(proto.Transactions, 3)
+	ids := make([][]byte, len(txs))
+	for i := range txs {
+		tx := proto.NewUnsignedTransferWithProofs(3, pk, waves, waves, uint64(i), 1, 100000, proto.NewRecipientFromAddress(addr), nil)
+		require.NoError(t, tx.Sign(proto.TestNetScheme, sk))
+		txs[i] = tx
+		ids[i], err = tx.GetID(proto.TestNetScheme)
+		require.NoError(t, err)
+	}
+	block := &proto.Block{
+		BlockHeader:  proto.BlockHeader{Version: proto.ProtobufBlockVersion, TransactionCount: len(txs)},
+		Transactions: txs,
+	}
+	require.NoError(t, block.SetTransactionsRoot(proto.TestNetScheme))
+	block.ID = proto.NewBlockIDFromDigest(crypto.MustFastHash([]byte("block")))
+
+	st := mock.NewMockState(ctrl)
+	st.EXPECT().TransactionHeightByID(gomock.Any()).Return(uint64(10), nil).Times(2)
+	st.EXPECT().BlockByHeight(proto.Height(10)).Return(block, nil)
+	s := &Server{state: st, scheme: proto.TestNetScheme}
+
+	stream := &transactionProofsStream{}
+	err = s.GetTransactionProofs(&gg.TransactionProofsRequest{TransactionIds: [][]byte{ids[2], ids[0]}}, stream)
+	require.NoError(t, err)
+	require.Len(t, stream.responses, 2)
+	for i, idx := range []int{2, 0} {
+		r := stream.responses[i]
+		assert.Equal(t, ids[idx], r.Id)
+		assert.Equal(t, int32(10), r.Height)
+		assert.Equal(t, block.ID.Bytes(), r.BlockId)
+		assert.Equal(t, int32(idx), r.TransactionIndex)
+		proofs := make([]crypto.Digest, len(r.MerkleProof))
+		for j, p := range r.MerkleProof {
+			proofs[j], err = crypto.NewDigestFromBytes(p)
+			require.NoError(t, err)
+		}
+		mb, err := txs[idx].MerkleBytes(proto.TestNetScheme)
+		require.NoError(t, err)
+		tree, err := crypto.NewMerkleTree()
+		require.NoError(t, err)
+		root := tree.RebuildRoot(crypto.MustFastHash(mb), proofs, uint64(idx))
+		assert.Equal(t, []byte(block.TransactionsRoot), root.Bytes())
+	}
+
+	unknown := crypto.MustFastHash([]byte("unknown"))
+	st.EXPECT().TransactionHeightByID(unknown.Bytes()).Return(uint64(0), keyvalue.ErrNotFound)
+	err = s.GetTransactionProofs(&gg.TransactionProofsRequest{TransactionIds: [][]byte{unknown.Bytes()}}, &transactionProofsStream{})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
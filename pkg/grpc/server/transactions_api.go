@@ -206,6 +206,9 @@ func (s *Server) GetUnconfirmed(req *g.TransactionsRequest, srv g.TransactionsAp
 }
 
 func (s *Server) Sign(ctx context.Context, req *g.SignRequest) (*pb.SignedTransaction, error) {
+	if s.signDisabled {
+		return nil, status.Errorf(codes.Unimplemented, "transaction signing is disabled on the node with remote signer")
+	}
 	c := proto.ProtobufConverter{FallbackChainID: s.scheme}
 	tx, err := c.Transaction(req.Transaction)
 	if err != nil {
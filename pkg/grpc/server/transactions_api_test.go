@@ -20,7 +20,9 @@ import (
 	"github.com/wavesplatform/gowaves/pkg/services"
 	"github.com/wavesplatform/gowaves/pkg/settings"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
 func TestGetTransactions(t *testing.T) {
@@ -31,7 +33,7 @@ func TestGetTransactions(t *testing.T) {
 	require.NoError(t, err)
 	ctx := withAutoCancel(t, context.Background())
 	sch := createTestNetWallet(t)
-	err = server.initServer(st, utxpool.New(utxSize, utxpool.NewValidator(st, ntptime.Stub{}, 86400*1000), sets), sch)
+	err = server.initServer(st, utxpool.New(utxSize, utxpool.NewValidator(st, ntptime.Stub{}, 86400*1000), sets, nil), sch)
 	require.NoError(t, err)
 
 	conn := connectAutoClose(t, grpcTestAddr)
@@ -109,7 +111,7 @@ func TestGetStatuses(t *testing.T) {
 	st := newTestState(t, true, params, settings.MainNetSettings)
 	ctx := withAutoCancel(t, context.Background())
 	sch := createTestNetWallet(t)
-	utx := utxpool.New(utxSize, utxpool.NoOpValidator{}, settings.MainNetSettings)
+	utx := utxpool.New(utxSize, utxpool.NoOpValidator{}, settings.MainNetSettings, nil)
 	err := server.initServer(st, utx, sch)
 	require.NoError(t, err)
 
@@ -161,7 +163,7 @@ func TestGetUnconfirmed(t *testing.T) {
 	st := newTestState(t, true, params, settings.MainNetSettings)
 	ctx := withAutoCancel(t, context.Background())
 	sch := createTestNetWallet(t)
-	utx := utxpool.New(utxSize, utxpool.NoOpValidator{}, settings.MainNetSettings)
+	utx := utxpool.New(utxSize, utxpool.NoOpValidator{}, settings.MainNetSettings, nil)
 	err := server.initServer(st, utx, sch)
 	require.NoError(t, err)
 
@@ -278,6 +280,11 @@ func TestSign(t *testing.T) {
 	ok, err = transfer.Verify(server.scheme, pk)
 	require.NoError(t, err)
 	assert.Equal(t, true, ok)
+
+	server.DisableSign()
+	defer func() { server.signDisabled = false }()
+	_, err = cl.Sign(ctx, req)
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
 }
 
 func TestBroadcast(t *testing.T) {
@@ -0,0 +1,256 @@
+package grpcclient
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	g "github.com/wavesplatform/gowaves/pkg/grpc/generated/waves/node/grpc"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type Accounts struct {
+	options Options
+	api     g.AccountsApiClient
+}
+
+func NewAccounts(conn grpc.ClientConnInterface, options Options) *Accounts {
+	return &Accounts{
+		options: options,
+		api:     g.NewAccountsApiClient(conn),
+	}
+}
+
+type WavesBalance struct {
+	Regular    uint64
+	Generating uint64
+	Available  uint64
+	Effective  uint64
+	LeaseIn    uint64
+	LeaseOut   uint64
+}
+
+type AssetBalance struct {
+	AssetID crypto.Digest
+	Balance uint64
+}
+
+// ScriptInfo is the script attached to an account or an asset.
+type ScriptInfo struct {
+	Bytes      []byte
+	Text       string
+	Complexity int64
+}
+
+type Lease struct {
+	ID                  crypto.Digest
+	OriginTransactionID crypto.Digest
+	Sender              proto.WavesAddress
+	Recipient           proto.Recipient
+	Amount              uint64
+	Height              proto.Height
+}
+
+// WavesBalance returns all kinds of Waves balance of the address.
+func (a *Accounts) WavesBalance(ctx context.Context, address proto.WavesAddress) (*WavesBalance, error) {
+	var out *WavesBalance
+	err := a.balances(ctx, &g.BalancesRequest{Address: address.Body()}, func(r *g.BalanceResponse) error {
+		if w := r.GetWaves(); w != nil {
+			out = &WavesBalance{
+				Regular:    uint64(w.Regular),
+				Generating: uint64(w.Generating),
+				Available:  uint64(w.Available),
+				Effective:  uint64(w.Effective),
+				LeaseIn:    uint64(w.LeaseIn),
+				LeaseOut:   uint64(w.LeaseOut),
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if out == nil {
+		return nil, errors.New("no Waves balance in response")
+	}
+	return out, nil
+}
+
+// AssetBalances returns balances of the address in the given assets, all non-zero balances are returned
+// if no assets provided.
+func (a *Accounts) AssetBalances(ctx context.Context, address proto.WavesAddress, assets ...crypto.Digest) ([]AssetBalance, error) {
+	req := &g.BalancesRequest{Address: address.Body(), Assets: make([][]byte, len(assets))}
+	for i, id := range assets {
+		req.Assets[i] = id.Bytes()
+	}
+	out := make([]AssetBalance, 0, len(assets))
+	err := a.balances(ctx, req, func(r *g.BalanceResponse) error {
+		amount := r.GetAsset()
+		if amount == nil {
+			return nil
+		}
+		id, err := crypto.NewDigestFromBytes(amount.AssetId)
+		if err != nil {
+			return errors.Wrap(err, "invalid asset ID")
+		}
+		out = append(out, AssetBalance{AssetID: id, Balance: uint64(amount.Amount)})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (a *Accounts) balances(ctx context.Context, req *g.BalancesRequest, handle func(r *g.BalanceResponse) error) error {
+	s := newStreamReader(ctx, a.options.Retry, func(ctx context.Context) (receiver, error) {
+		stream, err := a.api.GetBalances(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return func() (interface{}, error) { return stream.Recv() }, nil
+	})
+	defer s.stop()
+	for msg, ok := s.next(); ok; msg, ok = s.next() {
+		if err := handle(msg.(*g.BalanceResponse)); err != nil {
+			return err
+		}
+	}
+	if err := s.error(); err != nil {
+		return errors.Wrap(err, "failed to get balances")
+	}
+	return nil
+}
+
+// Script returns the script of the account, nil is returned if the account has no script.
+func (a *Accounts) Script(ctx context.Context, address proto.WavesAddress) (*ScriptInfo, error) {
+	var res *g.ScriptData
+	err := a.options.Retry.do(ctx, func() error {
+		var err error
+		res, err = a.api.GetScript(ctx, &g.AccountRequest{Address: address.Body()})
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get script")
+	}
+	return scriptInfo(res), nil
+}
+
+// ActiveLeases returns active leases where the address is the lessor or the recipient.
+func (a *Accounts) ActiveLeases(ctx context.Context, address proto.WavesAddress) ([]Lease, error) {
+	s := newStreamReader(ctx, a.options.Retry, func(ctx context.Context) (receiver, error) {
+		stream, err := a.api.GetActiveLeases(ctx, &g.AccountRequest{Address: address.Body()})
+		if err != nil {
+			return nil, err
+		}
+		return func() (interface{}, error) { return stream.Recv() }, nil
+	})
+	defer s.stop()
+	c := a.options.converter()
+	var out []Lease
+	for msg, ok := s.next(); ok; msg, ok = s.next() {
+		l, err := lease(c, a.options.Scheme, msg.(*g.LeaseResponse))
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, l)
+	}
+	if err := s.error(); err != nil {
+		return nil, errors.Wrap(err, "failed to get active leases")
+	}
+	return out, nil
+}
+
+func lease(c *proto.ProtobufConverter, scheme proto.Scheme, r *g.LeaseResponse) (Lease, error) {
+	id, err := crypto.NewDigestFromBytes(r.LeaseId)
+	if err != nil {
+		return Lease{}, errors.Wrap(err, "invalid lease ID")
+	}
+	origin, err := crypto.NewDigestFromBytes(r.OriginTransactionId)
+	if err != nil {
+		return Lease{}, errors.Wrap(err, "invalid lease origin transaction ID")
+	}
+	sender, err := c.Address(scheme, r.Sender)
+	if err != nil {
+		return Lease{}, errors.Wrap(err, "invalid lease sender")
+	}
+	recipient, err := c.Recipient(scheme, r.Recipient)
+	if err != nil {
+		return Lease{}, errors.Wrap(err, "invalid lease recipient")
+	}
+	return Lease{
+		ID:                  id,
+		OriginTransactionID: origin,
+		Sender:              sender,
+		Recipient:           recipient,
+		Amount:              uint64(r.Amount),
+		Height:              proto.Height(r.Height),
+	}, nil
+}
+
+// DataEntries returns all data entries of the account.
+func (a *Accounts) DataEntries(ctx context.Context, address proto.WavesAddress) (proto.DataEntries, error) {
+	return a.dataEntries(ctx, &g.DataRequest{Address: address.Body()})
+}
+
+// DataEntry returns the data entry of the account by key, nil is returned if there is no such entry.
+func (a *Accounts) DataEntry(ctx context.Context, address proto.WavesAddress, key string) (proto.DataEntry, error) {
+	entries, err := a.dataEntries(ctx, &g.DataRequest{Address: address.Body(), Key: key})
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	return entries[0], nil
+}
+
+func (a *Accounts) dataEntries(ctx context.Context, req *g.DataRequest) (proto.DataEntries, error) {
+	s := newStreamReader(ctx, a.options.Retry, func(ctx context.Context) (receiver, error) {
+		stream, err := a.api.GetDataEntries(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return func() (interface{}, error) { return stream.Recv() }, nil
+	})
+	defer s.stop()
+	c := a.options.converter()
+	var out proto.DataEntries
+	for msg, ok := s.next(); ok; msg, ok = s.next() {
+		e, err := c.Entry(msg.(*g.DataEntryResponse).Entry)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid data entry")
+		}
+		out = append(out, e)
+	}
+	if err := s.error(); err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to get data entries")
+	}
+	return out, nil
+}
+
+// ResolveAlias returns the address of the alias.
+func (a *Accounts) ResolveAlias(ctx context.Context, alias string) (proto.WavesAddress, error) {
+	var res *wrapperspb.BytesValue
+	err := a.options.Retry.do(ctx, func() error {
+		var err error
+		res, err = a.api.ResolveAlias(ctx, wrapperspb.String(alias))
+		return err
+	})
+	if err != nil {
+		return proto.WavesAddress{}, errors.Wrapf(err, "failed to resolve alias %q", alias)
+	}
+	return a.options.converter().Address(a.options.Scheme, res.GetValue())
+}
+
+func scriptInfo(d *g.ScriptData) *ScriptInfo {
+	if d == nil || len(d.ScriptBytes) == 0 {
+		return nil
+	}
+	return &ScriptInfo{Bytes: d.ScriptBytes, Text: d.ScriptText, Complexity: d.Complexity}
+}
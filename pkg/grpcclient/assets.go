@@ -0,0 +1,153 @@
+package grpcclient
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	g "github.com/wavesplatform/gowaves/pkg/grpc/generated/waves/node/grpc"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"google.golang.org/grpc"
+)
+
+type Assets struct {
+	options Options
+	api     g.AssetsApiClient
+}
+
+func NewAssets(conn grpc.ClientConnInterface, options Options) *Assets {
+	return &Assets{
+		options: options,
+		api:     g.NewAssetsApiClient(conn),
+	}
+}
+
+type AssetInfo struct {
+	ID               crypto.Digest
+	Issuer           crypto.PublicKey
+	Name             string
+	Description      string
+	Decimals         uint8
+	Reissuable       bool
+	TotalVolume      uint64
+	Script           *ScriptInfo
+	Sponsorship      uint64
+	SponsorBalance   uint64
+	IssueTransaction proto.Transaction
+}
+
+// Info returns the asset description.
+func (a *Assets) Info(ctx context.Context, assetID crypto.Digest) (*AssetInfo, error) {
+	var res *g.AssetInfoResponse
+	err := a.options.Retry.do(ctx, func() error {
+		var err error
+		res, err = a.api.GetInfo(ctx, &g.AssetRequest{AssetId: assetID.Bytes()})
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get info of asset %s", assetID.String())
+	}
+	return assetInfo(a.options.converter(), assetID, res)
+}
+
+// NFTList returns the iterator over NFTs of the address. At most limit NFTs are returned,
+// the listing starts after the given asset if it is not nil.
+func (a *Assets) NFTList(ctx context.Context, address proto.WavesAddress, limit int, after *crypto.Digest) *NFTIterator {
+	req := &g.NFTRequest{Address: address.Body(), Limit: int32(limit)}
+	if after != nil {
+		req.AfterAssetId = after.Bytes()
+	}
+	s := newStreamReader(ctx, a.options.Retry, func(ctx context.Context) (receiver, error) {
+		stream, err := a.api.GetNFTList(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return func() (interface{}, error) { return stream.Recv() }, nil
+	})
+	return &NFTIterator{s: s, c: a.options.converter()}
+}
+
+// NFTIterator iterates over NFTs received from the stream.
+type NFTIterator struct {
+	s       *streamReader
+	c       *proto.ProtobufConverter
+	current *AssetInfo
+	err     error
+}
+
+// Next advances the iterator to the next NFT, false is returned at the end of the stream or on error.
+func (it *NFTIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	msg, ok := it.s.next()
+	if !ok {
+		return false
+	}
+	r := msg.(*g.NFTResponse)
+	id, err := crypto.NewDigestFromBytes(r.AssetId)
+	if err != nil {
+		it.fail(errors.Wrap(err, "invalid NFT ID"))
+		return false
+	}
+	info, err := assetInfo(it.c, id, r.AssetInfo)
+	if err != nil {
+		it.fail(err)
+		return false
+	}
+	it.current = info
+	return true
+}
+
+func (it *NFTIterator) fail(err error) {
+	it.err = err
+	it.s.stop()
+}
+
+// NFT returns the current NFT.
+func (it *NFTIterator) NFT() *AssetInfo {
+	return it.current
+}
+
+// Error returns the error that stopped the iteration.
+func (it *NFTIterator) Error() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.s.error()
+}
+
+// Close cancels the underlying stream.
+func (it *NFTIterator) Close() {
+	it.s.stop()
+}
+
+func assetInfo(c *proto.ProtobufConverter, id crypto.Digest, r *g.AssetInfoResponse) (*AssetInfo, error) {
+	if r == nil {
+		return nil, errors.Errorf("empty info of asset %s", id.String())
+	}
+	issuer, err := crypto.NewPublicKeyFromBytes(r.Issuer)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid asset issuer")
+	}
+	info := &AssetInfo{
+		ID:             id,
+		Issuer:         issuer,
+		Name:           r.Name,
+		Description:    r.Description,
+		Decimals:       uint8(r.Decimals),
+		Reissuable:     r.Reissuable,
+		TotalVolume:    uint64(r.TotalVolume),
+		Script:         scriptInfo(r.Script),
+		Sponsorship:    uint64(r.Sponsorship),
+		SponsorBalance: uint64(r.SponsorBalance),
+	}
+	if r.IssueTransaction != nil {
+		tx, err := c.SignedTransaction(r.IssueTransaction)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid asset issue transaction")
+		}
+		info.IssueTransaction = tx
+	}
+	return info, nil
+}
@@ -0,0 +1,124 @@
+package grpcclient
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/pkg/errors"
+	g "github.com/wavesplatform/gowaves/pkg/grpc/generated/waves/node/grpc"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type Blockchain struct {
+	options Options
+	api     g.BlockchainApiClient
+}
+
+func NewBlockchain(conn grpc.ClientConnInterface, options Options) *Blockchain {
+	return &Blockchain{
+		options: options,
+		api:     g.NewBlockchainApiClient(conn),
+	}
+}
+
+type FeatureBlockchainStatus byte
+
+const (
+	FeatureUndefined FeatureBlockchainStatus = iota
+	FeatureApproved
+	FeatureActivated
+)
+
+type FeatureStatus struct {
+	ID               int16
+	Description      string
+	BlockchainStatus FeatureBlockchainStatus
+	// Voted is set if the node votes for the feature.
+	Voted bool
+	// Implemented is set if the feature is supported by the node.
+	Implemented      bool
+	ActivationHeight proto.Height
+	SupportingBlocks uint64
+}
+
+type ActivationStatus struct {
+	Height          proto.Height
+	VotingInterval  uint64
+	VotingThreshold uint64
+	NextCheck       proto.Height
+	Features        []FeatureStatus
+}
+
+// ActivationStatus returns the status of features at height.
+func (b *Blockchain) ActivationStatus(ctx context.Context, height proto.Height) (*ActivationStatus, error) {
+	var res *g.ActivationStatusResponse
+	err := b.options.Retry.do(ctx, func() error {
+		var err error
+		res, err = b.api.GetActivationStatus(ctx, &g.ActivationStatusRequest{Height: int32(height)})
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get activation status")
+	}
+	out := &ActivationStatus{
+		Height:          proto.Height(res.Height),
+		VotingInterval:  uint64(res.VotingInterval),
+		VotingThreshold: uint64(res.VotingThreshold),
+		NextCheck:       proto.Height(res.NextCheck),
+		Features:        make([]FeatureStatus, len(res.Features)),
+	}
+	for i, f := range res.Features {
+		fs := FeatureStatus{
+			ID:               int16(f.Id),
+			Description:      f.Description,
+			Voted:            f.NodeStatus == g.FeatureActivationStatus_VOTED,
+			Implemented:      f.NodeStatus != g.FeatureActivationStatus_NOT_IMPLEMENTED,
+			ActivationHeight: proto.Height(f.ActivationHeight),
+			SupportingBlocks: uint64(f.SupportingBlocks),
+		}
+		switch f.BlockchainStatus {
+		case g.FeatureActivationStatus_APPROVED:
+			fs.BlockchainStatus = FeatureApproved
+		case g.FeatureActivationStatus_ACTIVATED:
+			fs.BlockchainStatus = FeatureActivated
+		default:
+			fs.BlockchainStatus = FeatureUndefined
+		}
+		out.Features[i] = fs
+	}
+	return out, nil
+}
+
+// BaseTarget returns the base target of the last block.
+func (b *Blockchain) BaseTarget(ctx context.Context) (uint64, error) {
+	var res *g.BaseTargetResponse
+	err := b.options.Retry.do(ctx, func() error {
+		var err error
+		res, err = b.api.GetBaseTarget(ctx, &emptypb.Empty{})
+		return err
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get base target")
+	}
+	return uint64(res.BaseTarget), nil
+}
+
+// CumulativeScore returns the score of the blockchain.
+func (b *Blockchain) CumulativeScore(ctx context.Context) (*big.Int, error) {
+	var res *g.ScoreResponse
+	err := b.options.Retry.do(ctx, func() error {
+		var err error
+		res, err = b.api.GetCumulativeScore(ctx, &emptypb.Empty{})
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get cumulative score")
+	}
+	score := new(big.Int)
+	if err := score.GobDecode(res.Score); err != nil {
+		return nil, errors.Wrap(err, "invalid cumulative score")
+	}
+	return score, nil
+}
@@ -0,0 +1,141 @@
+package grpcclient
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	g "github.com/wavesplatform/gowaves/pkg/grpc/generated/waves/node/grpc"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type Blocks struct {
+	options Options
+	api     g.BlocksApiClient
+}
+
+func NewBlocks(conn grpc.ClientConnInterface, options Options) *Blocks {
+	return &Blocks{
+		options: options,
+		api:     g.NewBlocksApiClient(conn),
+	}
+}
+
+// Height returns the current height of the blockchain.
+func (b *Blocks) Height(ctx context.Context) (proto.Height, error) {
+	var res *wrapperspb.UInt32Value
+	err := b.options.Retry.do(ctx, func() error {
+		var err error
+		res, err = b.api.GetCurrentHeight(ctx, &emptypb.Empty{})
+		return err
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get height")
+	}
+	return proto.Height(res.GetValue()), nil
+}
+
+// Block returns the block by ID with its height, transactions are included on request.
+func (b *Blocks) Block(ctx context.Context, id proto.BlockID, includeTransactions bool) (*proto.Block, proto.Height, error) {
+	return b.block(ctx, &g.BlockRequest{
+		Request:             &g.BlockRequest_BlockId{BlockId: id.Bytes()},
+		IncludeTransactions: includeTransactions,
+	})
+}
+
+// BlockAt returns the block at height, transactions are included on request.
+func (b *Blocks) BlockAt(ctx context.Context, height proto.Height, includeTransactions bool) (*proto.Block, proto.Height, error) {
+	return b.block(ctx, &g.BlockRequest{
+		Request:             &g.BlockRequest_Height{Height: int32(height)},
+		IncludeTransactions: includeTransactions,
+	})
+}
+
+func (b *Blocks) block(ctx context.Context, req *g.BlockRequest) (*proto.Block, proto.Height, error) {
+	var res *g.BlockWithHeight
+	err := b.options.Retry.do(ctx, func() error {
+		var err error
+		res, err = b.api.GetBlock(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to get block")
+	}
+	return blockWithHeight(b.options.converter(), res)
+}
+
+// Range returns the iterator over blocks from one height to another inclusive.
+func (b *Blocks) Range(ctx context.Context, from, to proto.Height, includeTransactions bool) *BlockIterator {
+	req := &g.BlockRangeRequest{
+		FromHeight:          uint32(from),
+		ToHeight:            uint32(to),
+		IncludeTransactions: includeTransactions,
+	}
+	s := newStreamReader(ctx, b.options.Retry, func(ctx context.Context) (receiver, error) {
+		stream, err := b.api.GetBlockRange(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return func() (interface{}, error) { return stream.Recv() }, nil
+	})
+	return &BlockIterator{s: s, c: b.options.converter()}
+}
+
+// BlockIterator iterates over blocks received from the stream.
+type BlockIterator struct {
+	s      *streamReader
+	c      *proto.ProtobufConverter
+	block  *proto.Block
+	height proto.Height
+	err    error
+}
+
+// Next advances the iterator to the next block, false is returned at the end of the stream or on error.
+func (it *BlockIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	msg, ok := it.s.next()
+	if !ok {
+		return false
+	}
+	block, height, err := blockWithHeight(it.c, msg.(*g.BlockWithHeight))
+	if err != nil {
+		it.err = err
+		it.s.stop()
+		return false
+	}
+	it.block, it.height = block, height
+	return true
+}
+
+// Block returns the current block and its height.
+func (it *BlockIterator) Block() (*proto.Block, proto.Height) {
+	return it.block, it.height
+}
+
+// Error returns the error that stopped the iteration.
+func (it *BlockIterator) Error() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.s.error()
+}
+
+// Close cancels the underlying stream.
+func (it *BlockIterator) Close() {
+	it.s.stop()
+}
+
+func blockWithHeight(c *proto.ProtobufConverter, r *g.BlockWithHeight) (*proto.Block, proto.Height, error) {
+	if r.GetBlock() == nil {
+		return nil, 0, errors.New("empty block in response")
+	}
+	block, err := c.Block(r.Block)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "invalid block")
+	}
+	return &block, proto.Height(r.Height), nil
+}
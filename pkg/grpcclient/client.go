@@ -0,0 +1,114 @@
+package grpcclient
+
+import (
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type Options struct {
+	// Address is the host:port of the node's gRPC server.
+	Address string
+	// Scheme is used to restore addresses and transactions from protobuf messages.
+	Scheme proto.Scheme
+	// DialOptions are passed to grpc.Dial, insecure transport credentials are used if empty.
+	DialOptions []grpc.DialOption
+	Retry       RetryPolicy
+}
+
+var defaultOptions = Options{
+	Address: "127.0.0.1:6870",
+	Scheme:  proto.MainNetScheme,
+	Retry:   DefaultRetryPolicy(),
+}
+
+type Client struct {
+	options      Options
+	conn         *grpc.ClientConn
+	Accounts     *Accounts
+	Assets       *Assets
+	Blocks       *Blocks
+	Transactions *Transactions
+	Blockchain   *Blockchain
+}
+
+// NewClient creates new client instance and dials the node.
+// If no options provided will use default.
+func NewClient(options ...Options) (*Client, error) {
+	opts, err := mergeOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	dialOptions := opts.DialOptions
+	if len(dialOptions) == 0 {
+		dialOptions = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+	conn, err := grpc.Dial(opts.Address, dialOptions...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to dial %q", opts.Address)
+	}
+	c := newClient(conn, opts)
+	c.conn = conn
+	return c, nil
+}
+
+// NewClientWithConn creates new client instance on top of existing connection.
+// The connection is not closed by Close.
+func NewClientWithConn(conn grpc.ClientConnInterface, options ...Options) (*Client, error) {
+	opts, err := mergeOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	return newClient(conn, opts), nil
+}
+
+func newClient(conn grpc.ClientConnInterface, opts Options) *Client {
+	return &Client{
+		options:      opts,
+		Accounts:     NewAccounts(conn, opts),
+		Assets:       NewAssets(conn, opts),
+		Blocks:       NewBlocks(conn, opts),
+		Transactions: NewTransactions(conn, opts),
+		Blockchain:   NewBlockchain(conn, opts),
+	}
+}
+
+func mergeOptions(options []Options) (Options, error) {
+	if len(options) > 1 {
+		return Options{}, errors.New("too many options provided. Expects no or just one item")
+	}
+	opts := defaultOptions
+	if len(options) == 1 {
+		option := options[0]
+		if option.Address != "" {
+			opts.Address = option.Address
+		}
+		if option.Scheme != 0 {
+			opts.Scheme = option.Scheme
+		}
+		if len(option.DialOptions) > 0 {
+			opts.DialOptions = option.DialOptions
+		}
+		if option.Retry != (RetryPolicy{}) {
+			opts.Retry = option.Retry
+		}
+	}
+	return opts, nil
+}
+
+func (c *Client) GetOptions() Options {
+	return c.options
+}
+
+// Close closes the connection created by NewClient.
+func (c *Client) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
+
+func (o Options) converter() *proto.ProtobufConverter {
+	return &proto.ProtobufConverter{FallbackChainID: o.Scheme}
+}
@@ -0,0 +1,179 @@
+package grpcclient
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	g "github.com/wavesplatform/gowaves/pkg/grpc/generated/waves/node/grpc"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var testRetryPolicy = RetryPolicy{MaxRetries: 3, InitialInterval: time.Millisecond, MaxInterval: 5 * time.Millisecond}
+
+type blocksServer struct {
+	g.UnimplementedBlocksApiServer
+	heightFailures int
+	rangeFailures  int
+	rangeBlocks    []*g.BlockWithHeight
+	heightCalls    int
+	rangeCalls     int
+}
+
+func (s *blocksServer) GetCurrentHeight(context.Context, *emptypb.Empty) (*wrapperspb.UInt32Value, error) {
+	s.heightCalls++
+	if s.heightCalls <= s.heightFailures {
+		return nil, status.Error(codes.Unavailable, "not ready")
+	}
+	return wrapperspb.UInt32(42), nil
+}
+
+func (s *blocksServer) GetBlockRange(req *g.BlockRangeRequest, srv g.BlocksApi_GetBlockRangeServer) error {
+	s.rangeCalls++
+	if s.rangeCalls <= s.rangeFailures {
+		return status.Error(codes.Unavailable, "not ready")
+	}
+	for _, b := range s.rangeBlocks {
+		if b.Height < req.FromHeight || b.Height > req.ToHeight {
+			continue
+		}
+		if err := srv.Send(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type accountsServer struct {
+	g.UnimplementedAccountsApiServer
+}
+
+func (s *accountsServer) GetBalances(req *g.BalancesRequest, srv g.AccountsApi_GetBalancesServer) error {
+	if len(req.Assets) == 0 {
+		return srv.Send(&g.BalanceResponse{Balance: &g.BalanceResponse_Waves{Waves: &g.BalanceResponse_WavesBalances{
+			Regular:    100,
+			Generating: 90,
+			Available:  80,
+			Effective:  70,
+			LeaseIn:    20,
+			LeaseOut:   30,
+		}}})
+	}
+	return status.Error(codes.InvalidArgument, "assets are not supported")
+}
+
+func newTestClient(t *testing.T, register func(s *grpc.Server)) *Client {
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	register(s)
+	go func() {
+		_ = s.Serve(lis)
+	}()
+	t.Cleanup(s.Stop)
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	c, err := NewClientWithConn(conn, Options{Scheme: proto.TestNetScheme, Retry: testRetryPolicy})
+	require.NoError(t, err)
+	return c
+}
+
+func testBlock(t *testing.T, parent proto.BlockID) *proto.Block {
+	sk, pk, err := crypto.GenerateKeyPair([]byte("test block generator"))
+	require.NoError(t, err)
+	gs, err := crypto.NewDigestFromBytes(make([]byte, crypto.DigestSize))
+	require.NoError(t, err)
+	b, err := proto.CreateBlock(proto.Transactions{}, 1666000000000, parent, pk,
+		proto.NxtConsensus{BaseTarget: 100, GenSignature: gs.Bytes()}, proto.ProtobufBlockVersion, nil, -1, proto.TestNetScheme)
+	require.NoError(t, err)
+	require.NoError(t, b.Sign(proto.TestNetScheme, sk))
+	return b
+}
+
+func TestBlocksHeightRetried(t *testing.T) {
+	srv := &blocksServer{heightFailures: 2}
+	c := newTestClient(t, func(s *grpc.Server) { g.RegisterBlocksApiServer(s, srv) })
+
+	h, err := c.Blocks.Height(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, proto.Height(42), h)
+	assert.Equal(t, 3, srv.heightCalls)
+}
+
+func TestBlocksHeightRetriesExhausted(t *testing.T) {
+	srv := &blocksServer{heightFailures: 10}
+	c := newTestClient(t, func(s *grpc.Server) { g.RegisterBlocksApiServer(s, srv) })
+
+	_, err := c.Blocks.Height(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, 4, srv.heightCalls)
+}
+
+func TestBlocksRange(t *testing.T) {
+	b1 := testBlock(t, proto.NewBlockIDFromDigest(crypto.Digest{}))
+	b2 := testBlock(t, b1.BlockID())
+	srv := &blocksServer{rangeFailures: 1}
+	for i, b := range []*proto.Block{b1, b2} {
+		pb, err := b.ToProtobufWithHeight(proto.TestNetScheme, uint64(i+1))
+		require.NoError(t, err)
+		srv.rangeBlocks = append(srv.rangeBlocks, pb)
+	}
+	c := newTestClient(t, func(s *grpc.Server) { g.RegisterBlocksApiServer(s, srv) })
+
+	it := c.Blocks.Range(context.Background(), 1, 2, false)
+	defer it.Close()
+	var heights []proto.Height
+	var ids []proto.BlockID
+	for it.Next() {
+		block, height := it.Block()
+		heights = append(heights, height)
+		ids = append(ids, block.BlockID())
+	}
+	require.NoError(t, it.Error())
+	assert.Equal(t, []proto.Height{1, 2}, heights)
+	assert.Equal(t, []proto.BlockID{b1.BlockID(), b2.BlockID()}, ids)
+	assert.Equal(t, 2, srv.rangeCalls)
+}
+
+func TestBlocksRangeCanceled(t *testing.T) {
+	srv := &blocksServer{rangeFailures: 10}
+	c := newTestClient(t, func(s *grpc.Server) { g.RegisterBlocksApiServer(s, srv) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	it := c.Blocks.Range(ctx, 1, 2, false)
+	defer it.Close()
+	assert.False(t, it.Next())
+	assert.Error(t, it.Error())
+}
+
+func TestAccountsWavesBalance(t *testing.T) {
+	c := newTestClient(t, func(s *grpc.Server) { g.RegisterAccountsApiServer(s, &accountsServer{}) })
+	_, pk, err := crypto.GenerateKeyPair([]byte("test account"))
+	require.NoError(t, err)
+	addr, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+
+	b, err := c.Accounts.WavesBalance(context.Background(), addr)
+	require.NoError(t, err)
+	assert.Equal(t, WavesBalance{Regular: 100, Generating: 90, Available: 80, Effective: 70, LeaseIn: 20, LeaseOut: 30}, *b)
+
+	_, err = c.Accounts.AssetBalances(context.Background(), addr, crypto.Digest{})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(errors.Cause(err)))
+}
@@ -0,0 +1,34 @@
+/*
+Package grpcclient provides gRPC client for waves nodes.
+
+Unlike the generated stubs the client accepts and returns domain types of package proto,
+retries calls failed with transient errors and exposes server streams as iterators.
+
+Creating client with default params:
+
+	c, err := grpcclient.NewClient()
+	...
+	defer c.Close()
+
+Client can accept custom node address, blockchain scheme, dial options and retry policy:
+
+	c, err := grpcclient.NewClient(grpcclient.Options{
+		Address: "127.0.0.1:6870",
+		Scheme:  proto.TestNetScheme,
+		Retry:   grpcclient.RetryPolicy{MaxRetries: 5, InitialInterval: time.Second, MaxInterval: 10 * time.Second},
+	})
+	...
+
+Streaming calls return iterators, iteration stops on context cancellation:
+
+	it := c.Blocks.Range(ctx, 1, 100, false)
+	defer it.Close()
+	for it.Next() {
+		block, height := it.Block()
+		...
+	}
+	if err := it.Error(); err != nil {
+		// handle error
+	}
+*/
+package grpcclient
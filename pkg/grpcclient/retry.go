@@ -0,0 +1,72 @@
+package grpcclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPolicy defines how calls failed with transient errors are retried.
+// Zero value of RetryPolicy in Options is replaced with DefaultRetryPolicy.
+type RetryPolicy struct {
+	// Disabled turns retries off.
+	Disabled bool
+	// MaxRetries is the number of retries after the first failed attempt.
+	MaxRetries uint64
+	// InitialInterval is the delay before the first retry, the delay grows exponentially up to MaxInterval.
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:      3,
+		InitialInterval: 100 * time.Millisecond,
+		MaxInterval:     2 * time.Second,
+	}
+}
+
+// NoRetryPolicy returns the policy that makes every call only once.
+func NoRetryPolicy() RetryPolicy {
+	return RetryPolicy{Disabled: true}
+}
+
+// IsRetryable reports whether the error returned by the call is transient and the call can be repeated.
+func IsRetryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p RetryPolicy) backOff(ctx context.Context) backoff.BackOff {
+	if p.Disabled {
+		return backoff.WithContext(&backoff.StopBackOff{}, ctx)
+	}
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = p.InitialInterval
+	bo.MaxInterval = p.MaxInterval
+	bo.MaxElapsedTime = 0
+	bo.Reset()
+	return backoff.WithContext(backoff.WithMaxRetries(bo, p.MaxRetries), ctx)
+}
+
+// do calls op until it succeeds, fails with non-retryable error, retries are exhausted or context is done.
+func (p RetryPolicy) do(ctx context.Context, op func() error) error {
+	return backoff.Retry(func() error {
+		err := op()
+		if err != nil && !IsRetryable(err) {
+			return backoff.Permanent(err)
+		}
+		return err
+	}, p.backOff(ctx))
+}
+
+func isNotFound(err error) bool {
+	return status.Code(err) == codes.NotFound
+}
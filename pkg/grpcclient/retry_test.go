@@ -0,0 +1,55 @@
+package grpcclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetryPolicyDo(t *testing.T) {
+	for _, test := range []struct {
+		policy   RetryPolicy
+		code     codes.Code
+		failures int
+		calls    int
+		ok       bool
+	}{
+		{testRetryPolicy, codes.Unavailable, 0, 1, true},
+		{testRetryPolicy, codes.Unavailable, 3, 4, true},
+		{testRetryPolicy, codes.Unavailable, 4, 4, false},
+		{testRetryPolicy, codes.ResourceExhausted, 1, 2, true},
+		{testRetryPolicy, codes.InvalidArgument, 1, 1, false},
+		{testRetryPolicy, codes.NotFound, 1, 1, false},
+		{NoRetryPolicy(), codes.Unavailable, 1, 1, false},
+	} {
+		calls := 0
+		err := test.policy.do(context.Background(), func() error {
+			calls++
+			if calls <= test.failures {
+				return status.Error(test.code, "failure")
+			}
+			return nil
+		})
+		assert.Equal(t, test.calls, calls)
+		if test.ok {
+			assert.NoError(t, err)
+		} else {
+			assert.Equal(t, test.code, status.Code(err))
+		}
+	}
+}
+
+func TestRetryPolicyDoCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls := 0
+	err := DefaultRetryPolicy().do(ctx, func() error {
+		calls++
+		return status.Error(codes.Unavailable, "failure")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
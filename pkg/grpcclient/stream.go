@@ -0,0 +1,109 @@
+package grpcclient
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+// receiver receives the next message of a server stream.
+type receiver func() (interface{}, error)
+
+// opener starts a server stream call.
+type opener func(ctx context.Context) (receiver, error)
+
+// streamReader reads messages from a server stream. The call is started again on a retryable error
+// until the first message is received, after that errors are returned as is to avoid duplicate messages.
+type streamReader struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	open     opener
+	recv     receiver
+	policy   RetryPolicy
+	bo       backoff.BackOff
+	received bool
+	err      error
+}
+
+func newStreamReader(ctx context.Context, policy RetryPolicy, open opener) *streamReader {
+	ctx, cancel := context.WithCancel(ctx)
+	return &streamReader{ctx: ctx, cancel: cancel, open: open, policy: policy}
+}
+
+// next returns the next message, false is returned at the end of the stream or on error.
+func (s *streamReader) next() (interface{}, bool) {
+	if s.err != nil {
+		return nil, false
+	}
+	for {
+		if s.recv == nil {
+			recv, err := s.open(s.ctx)
+			if err != nil {
+				if s.retry(err) {
+					continue
+				}
+				return nil, false
+			}
+			s.recv = recv
+		}
+		msg, err := s.recv()
+		switch {
+		case err == nil:
+			s.received = true
+			return msg, true
+		case err == io.EOF:
+			s.close(io.EOF)
+			return nil, false
+		case !s.received && s.retry(err):
+			s.recv = nil
+		default:
+			s.close(err)
+			return nil, false
+		}
+	}
+}
+
+// retry waits before the next attempt and returns true if the call should be started again.
+func (s *streamReader) retry(err error) bool {
+	if !IsRetryable(err) {
+		s.close(err)
+		return false
+	}
+	if s.bo == nil {
+		s.bo = s.policy.backOff(s.ctx)
+	}
+	d := s.bo.NextBackOff()
+	if d == backoff.Stop {
+		s.close(err)
+		return false
+	}
+	select {
+	case <-s.ctx.Done():
+		s.close(s.ctx.Err())
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
+
+func (s *streamReader) close(err error) {
+	if s.err == nil {
+		s.err = err
+	}
+	s.cancel()
+}
+
+// stop cancels the call, messages that were not read yet are dropped.
+func (s *streamReader) stop() {
+	s.close(io.EOF)
+}
+
+// error returns the error that stopped the reading, nil is returned if the stream ended successfully.
+func (s *streamReader) error() error {
+	if s.err == io.EOF {
+		return nil
+	}
+	return s.err
+}
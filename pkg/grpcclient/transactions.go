@@ -0,0 +1,241 @@
+package grpcclient
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	g "github.com/wavesplatform/gowaves/pkg/grpc/generated/waves/node/grpc"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"google.golang.org/grpc"
+)
+
+type Transactions struct {
+	options Options
+	api     g.TransactionsApiClient
+}
+
+func NewTransactions(conn grpc.ClientConnInterface, options Options) *Transactions {
+	return &Transactions{
+		options: options,
+		api:     g.NewTransactionsApiClient(conn),
+	}
+}
+
+// TransactionsFilter selects transactions by sender, recipient or IDs. Empty fields are not used.
+type TransactionsFilter struct {
+	Sender    *proto.WavesAddress
+	Recipient *proto.Recipient
+	IDs       []crypto.Digest
+}
+
+func (f TransactionsFilter) request() (*g.TransactionsRequest, error) {
+	req := &g.TransactionsRequest{TransactionIds: make([][]byte, len(f.IDs))}
+	if f.Sender != nil {
+		req.Sender = f.Sender.Body()
+	}
+	if f.Recipient != nil {
+		r, err := f.Recipient.ToProtobuf()
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid recipient")
+		}
+		req.Recipient = r
+	}
+	for i, id := range f.IDs {
+		req.TransactionIds[i] = id.Bytes()
+	}
+	return req, nil
+}
+
+type TransactionInfo struct {
+	ID          crypto.Digest
+	Height      proto.Height
+	Transaction proto.Transaction
+	// Failed is set if the transaction was stored in the blockchain with failed script execution.
+	Failed bool
+	// InvokeResult is set for invocations of scripts.
+	InvokeResult *proto.ScriptResult
+}
+
+type TxStatus byte
+
+const (
+	TxNotExists TxStatus = iota
+	TxUnconfirmed
+	TxConfirmed
+)
+
+type TransactionStatus struct {
+	ID     crypto.Digest
+	Status TxStatus
+	Height proto.Height
+	Failed bool
+}
+
+// Transactions returns the iterator over confirmed transactions matching the filter.
+func (t *Transactions) Transactions(ctx context.Context, filter TransactionsFilter) *TransactionIterator {
+	return t.iterator(ctx, filter, func(ctx context.Context, req *g.TransactionsRequest) (transactionsStream, error) {
+		return t.api.GetTransactions(ctx, req)
+	})
+}
+
+// Unconfirmed returns the iterator over transactions from UTX pool matching the filter.
+func (t *Transactions) Unconfirmed(ctx context.Context, filter TransactionsFilter) *TransactionIterator {
+	return t.iterator(ctx, filter, func(ctx context.Context, req *g.TransactionsRequest) (transactionsStream, error) {
+		return t.api.GetUnconfirmed(ctx, req)
+	})
+}
+
+type transactionsStream interface {
+	Recv() (*g.TransactionResponse, error)
+}
+
+func (t *Transactions) iterator(
+	ctx context.Context,
+	filter TransactionsFilter,
+	call func(ctx context.Context, req *g.TransactionsRequest) (transactionsStream, error),
+) *TransactionIterator {
+	req, err := filter.request()
+	s := newStreamReader(ctx, t.options.Retry, func(ctx context.Context) (receiver, error) {
+		stream, err := call(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return func() (interface{}, error) { return stream.Recv() }, nil
+	})
+	it := &TransactionIterator{s: s, c: t.options.converter(), scheme: t.options.Scheme}
+	if err != nil {
+		it.fail(err)
+	}
+	return it
+}
+
+// TransactionIterator iterates over transactions received from the stream.
+type TransactionIterator struct {
+	s       *streamReader
+	c       *proto.ProtobufConverter
+	scheme  proto.Scheme
+	current *TransactionInfo
+	err     error
+}
+
+// Next advances the iterator to the next transaction, false is returned at the end of the stream or on error.
+func (it *TransactionIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	msg, ok := it.s.next()
+	if !ok {
+		return false
+	}
+	info, err := transactionInfo(it.c, it.scheme, msg.(*g.TransactionResponse))
+	if err != nil {
+		it.fail(err)
+		return false
+	}
+	it.current = info
+	return true
+}
+
+func (it *TransactionIterator) fail(err error) {
+	it.err = err
+	it.s.stop()
+}
+
+// Transaction returns the current transaction.
+func (it *TransactionIterator) Transaction() *TransactionInfo {
+	return it.current
+}
+
+// Error returns the error that stopped the iteration.
+func (it *TransactionIterator) Error() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.s.error()
+}
+
+// Close cancels the underlying stream.
+func (it *TransactionIterator) Close() {
+	it.s.stop()
+}
+
+// Statuses returns statuses of transactions in the order of IDs.
+func (t *Transactions) Statuses(ctx context.Context, ids ...crypto.Digest) ([]TransactionStatus, error) {
+	req := &g.TransactionsByIdRequest{TransactionIds: make([][]byte, len(ids))}
+	for i, id := range ids {
+		req.TransactionIds[i] = id.Bytes()
+	}
+	s := newStreamReader(ctx, t.options.Retry, func(ctx context.Context) (receiver, error) {
+		stream, err := t.api.GetStatuses(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return func() (interface{}, error) { return stream.Recv() }, nil
+	})
+	defer s.stop()
+	out := make([]TransactionStatus, 0, len(ids))
+	for msg, ok := s.next(); ok; msg, ok = s.next() {
+		r := msg.(*g.TransactionStatus)
+		id, err := crypto.NewDigestFromBytes(r.Id)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid transaction ID")
+		}
+		st := TransactionStatus{
+			ID:     id,
+			Height: proto.Height(r.Height),
+			Failed: r.ApplicationStatus == g.ApplicationStatus_SCRIPT_EXECUTION_FAILED,
+		}
+		switch r.Status {
+		case g.TransactionStatus_CONFIRMED:
+			st.Status = TxConfirmed
+		case g.TransactionStatus_UNCONFIRMED:
+			st.Status = TxUnconfirmed
+		default:
+			st.Status = TxNotExists
+		}
+		out = append(out, st)
+	}
+	if err := s.error(); err != nil {
+		return nil, errors.Wrap(err, "failed to get transactions statuses")
+	}
+	return out, nil
+}
+
+// Broadcast sends the signed transaction to the node. The call is not retried because
+// the transaction could be accepted before the failure.
+func (t *Transactions) Broadcast(ctx context.Context, tx proto.Transaction) error {
+	stx, err := tx.ToProtobufSigned(t.options.Scheme)
+	if err != nil {
+		return errors.Wrap(err, "failed to convert transaction")
+	}
+	if _, err := t.api.Broadcast(ctx, stx); err != nil {
+		return errors.Wrap(err, "failed to broadcast transaction")
+	}
+	return nil
+}
+
+func transactionInfo(c *proto.ProtobufConverter, scheme proto.Scheme, r *g.TransactionResponse) (*TransactionInfo, error) {
+	id, err := crypto.NewDigestFromBytes(r.Id)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid transaction ID")
+	}
+	tx, err := c.SignedTransaction(r.Transaction)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid transaction %s", id.String())
+	}
+	info := &TransactionInfo{
+		ID:          id,
+		Height:      proto.Height(r.Height),
+		Transaction: tx,
+		Failed:      r.ApplicationStatus == g.ApplicationStatus_SCRIPT_EXECUTION_FAILED,
+	}
+	if r.InvokeScriptResult != nil {
+		res := new(proto.ScriptResult)
+		if err := res.FromProtobuf(scheme, r.InvokeScriptResult); err != nil {
+			return nil, errors.Wrapf(err, "invalid invoke result of transaction %s", id.String())
+		}
+		info.InvokeResult = res
+	}
+	return info, nil
+}
@@ -2,6 +2,7 @@ package keyvalue
 
 import (
 	"sync"
+	"time"
 
 	"github.com/coocood/freecache"
 	"github.com/pkg/errors"
@@ -167,6 +168,7 @@ func NewKeyVal(path string, params KeyValParams) (*KeyVal, error) {
 	if err := initBloomFilter(kv, params.BloomFilterParams); err != nil {
 		return nil, err
 	}
+	statsCollector.setKeyVal(kv)
 	return kv, nil
 }
 
@@ -194,6 +196,7 @@ func (k *KeyVal) Get(key []byte) ([]byte, error) {
 			return nil, err // Hashing error here
 		}
 		if notInTheSet {
+			metricBloomFilterNegatives.Inc()
 			return nil, ErrNotFound
 		}
 	}
@@ -214,6 +217,7 @@ func (k *KeyVal) Has(key []byte) (bool, error) {
 			return false, err
 		}
 		if notInTheSet {
+			metricBloomFilterNegatives.Inc()
 			return false, nil
 		}
 	}
@@ -250,9 +254,13 @@ func (k *KeyVal) Flush(b1 Batch) error {
 	if !ok {
 		return errors.New("can't convert Batch interface to leveldb batch")
 	}
-	if err := k.db.Write(b.leveldbBatch(), nil); err != nil {
+	start := time.Now()
+	leveldbBatch := b.leveldbBatch()
+	if err := k.db.Write(leveldbBatch, nil); err != nil {
 		return err
 	}
+	metricBatchFlushDuration.Observe(time.Since(start).Seconds())
+	metricBatchFlushSize.Observe(float64(leveldbBatch.Len()))
 	b.addToCache(k.cache)
 	if err := b.addToFilter(k.filter); err != nil {
 		return err
@@ -274,6 +282,7 @@ func (k *KeyVal) NewKeyIterator(prefix []byte) (Iterator, error) {
 func (k *KeyVal) Close() error {
 	k.mu.Lock()
 	defer k.mu.Unlock()
+	statsCollector.unsetKeyVal(k)
 	zap.S().Infof("Cache hit rate: %v", k.cache.HitRate())
 	err := storeBloomFilter(k.filter)
 	if err != nil {
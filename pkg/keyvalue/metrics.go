@@ -0,0 +1,174 @@
+package keyvalue
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/syndtr/goleveldb/leveldb"
+	"go.uber.org/zap"
+)
+
+const keyvalueMetricsNamespace = "keyvalue"
+
+var (
+	metricBloomFilterNegatives = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: keyvalueMetricsNamespace,
+			Name:      "bloom_filter_negatives_total",
+			Help:      "Number of lookups rejected by the bloom filter without accessing the database.",
+		},
+	)
+
+	metricBatchFlushDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: keyvalueMetricsNamespace,
+			Name:      "batch_flush_duration_seconds",
+			Help:      "Duration of writing batches to the database.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 14),
+		},
+	)
+
+	metricBatchFlushSize = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: keyvalueMetricsNamespace,
+			Name:      "batch_flush_records",
+			Help:      "Number of records in batches written to the database.",
+			Buckets:   prometheus.ExponentialBuckets(16, 4, 10),
+		},
+	)
+
+	statsCollector = newKeyValStatsCollector()
+)
+
+func init() {
+	prometheus.MustRegister(
+		metricBloomFilterNegatives,
+		metricBatchFlushDuration,
+		metricBatchFlushSize,
+		statsCollector,
+	)
+}
+
+var (
+	descCacheHits = prometheus.NewDesc(
+		prometheus.BuildFQName(keyvalueMetricsNamespace, "cache", "hits_total"),
+		"Number of lookups served from the in-memory cache.",
+		nil, nil,
+	)
+	descCacheMisses = prometheus.NewDesc(
+		prometheus.BuildFQName(keyvalueMetricsNamespace, "cache", "misses_total"),
+		"Number of lookups missed the in-memory cache.",
+		nil, nil,
+	)
+	descCacheEntries = prometheus.NewDesc(
+		prometheus.BuildFQName(keyvalueMetricsNamespace, "cache", "entries"),
+		"Number of entries in the in-memory cache.",
+		nil, nil,
+	)
+	descCacheEvictions = prometheus.NewDesc(
+		prometheus.BuildFQName(keyvalueMetricsNamespace, "cache", "evictions_total"),
+		"Number of entries evicted from the in-memory cache.",
+		nil, nil,
+	)
+	descCompactions = prometheus.NewDesc(
+		prometheus.BuildFQName(keyvalueMetricsNamespace, "leveldb", "compactions_total"),
+		"Number of LevelDB compactions by compaction type.",
+		[]string{"type"}, nil,
+	)
+	descWriteDelays = prometheus.NewDesc(
+		prometheus.BuildFQName(keyvalueMetricsNamespace, "leveldb", "write_delays_total"),
+		"Number of writes delayed by LevelDB because of compaction.",
+		nil, nil,
+	)
+	descWriteDelayDuration = prometheus.NewDesc(
+		prometheus.BuildFQName(keyvalueMetricsNamespace, "leveldb", "write_delay_seconds_total"),
+		"Total time writes were delayed by LevelDB because of compaction.",
+		nil, nil,
+	)
+	descIO = prometheus.NewDesc(
+		prometheus.BuildFQName(keyvalueMetricsNamespace, "leveldb", "io_bytes_total"),
+		"Number of bytes read from and written to LevelDB storage.",
+		[]string{"op"}, nil,
+	)
+	descLevelSize = prometheus.NewDesc(
+		prometheus.BuildFQName(keyvalueMetricsNamespace, "leveldb", "level_size_bytes"),
+		"Size of LevelDB levels.",
+		[]string{"level"}, nil,
+	)
+	descLevelTables = prometheus.NewDesc(
+		prometheus.BuildFQName(keyvalueMetricsNamespace, "leveldb", "level_tables"),
+		"Number of tables in LevelDB levels.",
+		[]string{"level"}, nil,
+	)
+)
+
+// keyValStatsCollector collects statistics of the most recently opened KeyVal on scrape.
+type keyValStatsCollector struct {
+	mu *sync.Mutex
+	kv *KeyVal
+}
+
+func newKeyValStatsCollector() *keyValStatsCollector {
+	return &keyValStatsCollector{mu: &sync.Mutex{}}
+}
+
+func (c *keyValStatsCollector) setKeyVal(kv *KeyVal) {
+	c.mu.Lock()
+	c.kv = kv
+	c.mu.Unlock()
+}
+
+func (c *keyValStatsCollector) unsetKeyVal(kv *KeyVal) {
+	c.mu.Lock()
+	if c.kv == kv {
+		c.kv = nil
+	}
+	c.mu.Unlock()
+}
+
+func (c *keyValStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- descCacheHits
+	ch <- descCacheMisses
+	ch <- descCacheEntries
+	ch <- descCacheEvictions
+	ch <- descCompactions
+	ch <- descWriteDelays
+	ch <- descWriteDelayDuration
+	ch <- descIO
+	ch <- descLevelSize
+	ch <- descLevelTables
+}
+
+func (c *keyValStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.kv == nil {
+		return
+	}
+	cache := c.kv.cache
+	ch <- prometheus.MustNewConstMetric(descCacheHits, prometheus.CounterValue, float64(cache.HitCount()))
+	ch <- prometheus.MustNewConstMetric(descCacheMisses, prometheus.CounterValue, float64(cache.MissCount()))
+	ch <- prometheus.MustNewConstMetric(descCacheEntries, prometheus.GaugeValue, float64(cache.EntryCount()))
+	ch <- prometheus.MustNewConstMetric(descCacheEvictions, prometheus.CounterValue, float64(cache.EvacuateCount()))
+
+	stats := new(leveldb.DBStats)
+	if err := c.kv.db.Stats(stats); err != nil {
+		zap.S().Debugf("Failed to collect LevelDB stats: %v", err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(descCompactions, prometheus.CounterValue, float64(stats.MemComp), "memory")
+	ch <- prometheus.MustNewConstMetric(descCompactions, prometheus.CounterValue, float64(stats.Level0Comp), "level0")
+	ch <- prometheus.MustNewConstMetric(descCompactions, prometheus.CounterValue, float64(stats.NonLevel0Comp), "non_level0")
+	ch <- prometheus.MustNewConstMetric(descCompactions, prometheus.CounterValue, float64(stats.SeekComp), "seek")
+	ch <- prometheus.MustNewConstMetric(descWriteDelays, prometheus.CounterValue, float64(stats.WriteDelayCount))
+	ch <- prometheus.MustNewConstMetric(descWriteDelayDuration, prometheus.CounterValue, stats.WriteDelayDuration.Seconds())
+	ch <- prometheus.MustNewConstMetric(descIO, prometheus.CounterValue, float64(stats.IORead), "read")
+	ch <- prometheus.MustNewConstMetric(descIO, prometheus.CounterValue, float64(stats.IOWrite), "write")
+	for i, size := range stats.LevelSizes {
+		ch <- prometheus.MustNewConstMetric(descLevelSize, prometheus.GaugeValue, float64(size), strconv.Itoa(i))
+	}
+	for i, tables := range stats.LevelTablesCounts {
+		ch <- prometheus.MustNewConstMetric(descLevelTables, prometheus.GaugeValue, float64(tables), strconv.Itoa(i))
+	}
+}
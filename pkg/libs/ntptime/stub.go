@@ -1,6 +1,7 @@
 package ntptime
 
 import (
+	"sync"
 	"time"
 
 	"github.com/beevik/ntp"
@@ -21,3 +22,40 @@ type Stub struct {
 func (s Stub) Now() time.Time {
 	return time.Now()
 }
+
+// AdjustableStub is a time source that follows the local clock with the offset changed by Set and Add calls.
+// It's used to control the time of nodes in local test networks, time jumps are deterministic while timeouts
+// of nodes still expire.
+type AdjustableStub struct {
+	mu     sync.RWMutex
+	offset time.Duration
+}
+
+// NewAdjustableStub creates the time source that starts from the given time.
+func NewAdjustableStub(now time.Time) *AdjustableStub {
+	return &AdjustableStub{offset: time.Until(now)}
+}
+
+func (s *AdjustableStub) Now() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return time.Now().Add(s.offset)
+}
+
+// Set moves the time forward to t, the time is never moved backwards.
+func (s *AdjustableStub) Set(t time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if d := time.Until(t); d > s.offset {
+		s.offset = d
+	}
+}
+
+// Add moves the time forward by the duration.
+func (s *AdjustableStub) Add(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if d > 0 {
+		s.offset += d
+	}
+}
@@ -27,3 +27,17 @@ func TestStub_Query(t *testing.T) {
 func TestStub_Now(t *testing.T) {
 	require.NotEmpty(t, Stub{}.Now())
 }
+
+func TestAdjustableStub(t *testing.T) {
+	start := time.Now().Add(time.Hour)
+	s := NewAdjustableStub(start)
+	require.False(t, s.Now().Before(start))
+	s.Add(time.Hour)
+	require.False(t, s.Now().Before(start.Add(time.Hour)))
+	s.Set(start)
+	require.False(t, s.Now().Before(start.Add(time.Hour)))
+	s.Set(start.Add(2 * time.Hour))
+	now := s.Now()
+	require.False(t, now.Before(start.Add(2*time.Hour)))
+	require.True(t, now.Before(start.Add(2*time.Hour+time.Minute)))
+}
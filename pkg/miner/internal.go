@@ -1,15 +1,17 @@
 package miner
 
 import (
+	"github.com/wavesplatform/gowaves/pkg/crypto"
 	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/types"
 )
 
-func MineBlock(version proto.BlockVersion, nxt proto.NxtConsensus, pair proto.KeyPair, validatedFeatured Features, t proto.Timestamp, parent proto.BlockID, reward int64, scheme proto.Scheme) (*proto.Block, error) {
-	b, err := proto.CreateBlock(proto.Transactions(nil), t, parent, pair.Public, nxt, version, FeaturesToInt16(validatedFeatured), reward, scheme)
+func MineBlock(version proto.BlockVersion, nxt proto.NxtConsensus, pk crypto.PublicKey, signer types.Signer, validatedFeatured Features, t proto.Timestamp, parent proto.BlockID, reward int64, scheme proto.Scheme) (*proto.Block, error) {
+	b, err := proto.CreateBlock(proto.Transactions(nil), t, parent, pk, nxt, version, FeaturesToInt16(validatedFeatured), reward, scheme)
 	if err != nil {
 		return nil, err
 	}
-	err = b.Sign(scheme, pair.Secret)
+	err = signer.SignBlock(pk, b)
 	if err != nil {
 		return nil, err
 	}
@@ -3,6 +3,9 @@ package miner
 import (
 	"errors"
 
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/miner/monitor"
+	"github.com/wavesplatform/gowaves/pkg/miner/selector"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/services"
 	"github.com/wavesplatform/gowaves/pkg/state"
@@ -18,20 +21,28 @@ var NoTransactionsErr = errors.New("no transactions")
 var StateChangedErr = errors.New("state changed")
 
 type MicroMiner struct {
-	state  state.State
-	utx    types.UtxPool
-	scheme proto.Scheme
+	state    state.State
+	selector selector.TransactionSelector
+	monitor  *monitor.Monitor
+	signer   types.Signer
+	scheme   proto.Scheme
 }
 
 func NewMicroMiner(services services.Services) *MicroMiner {
+	txSelector := services.TxSelector
+	if txSelector == nil {
+		txSelector = selector.NewSelector(services.State, services.UtxPool, services.Scheme, selector.DefaultPolicy())
+	}
 	return &MicroMiner{
-		state:  services.State,
-		utx:    services.UtxPool,
-		scheme: services.Scheme,
+		state:    services.State,
+		selector: txSelector,
+		monitor:  services.MinerMonitor,
+		signer:   services.Signer,
+		scheme:   services.Scheme,
 	}
 }
 
-func (a *MicroMiner) Micro(minedBlock *proto.Block, rest proto.MiningLimits, keyPair proto.KeyPair) (*proto.Block, *proto.MicroBlock, proto.MiningLimits, error) {
+func (a *MicroMiner) Micro(minedBlock *proto.Block, rest proto.MiningLimits, pk crypto.PublicKey) (*proto.Block, *proto.MicroBlock, proto.MiningLimits, error) {
 	// way to stop mine microblocks
 	if minedBlock == nil {
 		return nil, nil, rest, errors.New("no block provided")
@@ -57,60 +68,119 @@ func (a *MicroMiner) Micro(minedBlock *proto.Block, rest proto.MiningLimits, key
 		parentTimestamp = parent.Timestamp
 	}
 
-	//
+	const transactionLenBytes = 4
 	txCount := 0
 	binSize := 0
+	complexity := 0
+
+	candidates := a.selector.Candidates()
+	report := a.selector.NewReport()
+	defer a.selector.SetLastReport(report)
 
-	var appliedTransactions []*types.TransactionWithBytes
-	var inapplicable []*types.TransactionWithBytes
+	var applied []selector.Candidate
+	var inapplicable []selector.Candidate
 
 	_ = a.state.Map(func(s state.NonThreadSafeState) error {
 		defer s.ResetValidationList()
 
-		for {
-			if txCount >= maxMicroblockTransactions {
-				break
+		validate := func(c selector.Candidate) error {
+			for _, t := range c.Transactions {
+				// In miner we pack transactions from UTX into new block.
+				// We should accept failed transactions here.
+				if err := s.ValidateNextTx(t.T, minedBlock.Timestamp, parentTimestamp, minedBlock.Version, true); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		candidateSize := func(c selector.Candidate) int {
+			return c.Size() + transactionLenBytes*len(c.Transactions)
+		}
+		// revalidate rebuilds the validation list from the applied candidates.
+		// Candidates that are not valid anymore are dropped and returned to the pool.
+		revalidate := func() {
+			for {
+				s.ResetValidationList()
+				failed := -1
+				var err error
+				for i, ac := range applied {
+					if err = validate(ac); err != nil {
+						failed = i
+						break
+					}
+				}
+				if failed < 0 {
+					return
+				}
+				ac := applied[failed]
+				zap.S().Debugf("micro_miner dropped candidate of %d transactions on revalidation: %v", len(ac.Transactions), err)
+				report.Exclude(ac, selector.SkipValidationFailed, err)
+				inapplicable = append(inapplicable, ac)
+				txCount -= len(ac.Transactions)
+				binSize -= candidateSize(ac)
+				complexity -= ac.Complexity
+				applied = append(applied[:failed], applied[failed+1:]...)
+			}
+		}
+
+		for _, c := range candidates {
+			if reason := a.selector.Check(c); reason != "" {
+				report.Skip(c, reason, nil)
+				inapplicable = append(inapplicable, c)
+				continue
 			}
-			t := a.utx.Pop()
-			if t == nil {
-				break
+			if txCount+len(c.Transactions) > maxMicroblockTransactions {
+				report.Skip(c, selector.SkipTransactionsLimit, nil)
+				inapplicable = append(inapplicable, c)
+				continue
 			}
-			binTr := t.B
-			transactionLenBytes := 4
-			if binSize+len(binTr)+transactionLenBytes > rest.MaxTxsSizeInBytes {
-				inapplicable = append(inapplicable, t)
+			size := candidateSize(c)
+			if binSize+size > rest.MaxTxsSizeInBytes {
+				report.Skip(c, selector.SkipSizeLimit, nil)
+				inapplicable = append(inapplicable, c)
 				continue
 			}
 
-			// In miner we pack transactions from UTX into new block.
-			// We should accept failed transactions here.
-			err = s.ValidateNextTx(t.T, minedBlock.Timestamp, parentTimestamp, minedBlock.Version, true)
+			err := validate(c)
 			if state.IsTxCommitmentError(err) {
 				// This should not happen in practice.
 				// Reset state, tx count, return applied transactions to UTX.
 				s.ResetValidationList()
 				txCount = 0
-				for _, appliedTx := range appliedTransactions {
-					_ = a.utx.AddWithBytes(appliedTx.T, appliedTx.B)
+				binSize = 0
+				complexity = 0
+				for _, appliedCandidate := range applied {
+					a.selector.Return(appliedCandidate)
 				}
-				appliedTransactions = nil
+				applied = nil
+				report.Skip(c, selector.SkipValidationFailed, err)
 				continue
 			}
 			if err != nil {
-				inapplicable = append(inapplicable, t)
+				reason := selector.SkipValidationFailed
+				if c.Bundle {
+					// Some transactions of the bundle could be already applied to the validation list,
+					// so it has to be rebuilt from the transactions that are included.
+					revalidate()
+					reason = selector.SkipBundleValidationFailed
+				}
+				report.Skip(c, reason, err)
+				inapplicable = append(inapplicable, c)
 				continue
 			}
 
-			txCount += 1
-			binSize += len(binTr) + transactionLenBytes
-			appliedTransactions = append(appliedTransactions, t)
+			txCount += len(c.Transactions)
+			binSize += size
+			complexity += c.Complexity
+			applied = append(applied, c)
+			report.Include(c)
 		}
 		return nil
 	})
 
 	// return inapplicable transactions
-	for _, tx := range inapplicable {
-		_ = a.utx.AddWithBytes(tx.T, tx.B)
+	for _, c := range inapplicable {
+		a.selector.Return(c)
 	}
 
 	// no transactions applied, skip
@@ -120,9 +190,11 @@ func (a *MicroMiner) Micro(minedBlock *proto.Block, rest proto.MiningLimits, key
 
 	zap.S().Debugf("micro_miner top block sig %s", a.state.TopBlock().BlockSignature)
 
-	transactions := make([]proto.Transaction, len(appliedTransactions))
-	for i, appliedTx := range appliedTransactions {
-		transactions[i] = appliedTx.T
+	transactions := make([]proto.Transaction, 0, txCount)
+	for _, c := range applied {
+		for _, appliedTx := range c.Transactions {
+			transactions = append(transactions, appliedTx.T)
+		}
 	}
 	newTransactions := minedBlock.Transactions.Join(transactions)
 
@@ -140,13 +212,11 @@ func (a *MicroMiner) Micro(minedBlock *proto.Block, rest proto.MiningLimits, key
 	if err != nil {
 		return nil, nil, rest, err
 	}
-	sk := keyPair.Secret
-
 	err = newBlock.SetTransactionsRootIfPossible(a.scheme)
 	if err != nil {
 		return nil, nil, rest, err
 	}
-	err = newBlock.Sign(a.scheme, keyPair.Secret)
+	err = a.signer.SignBlock(pk, newBlock)
 	if err != nil {
 		return nil, nil, rest, err
 	}
@@ -156,7 +226,7 @@ func (a *MicroMiner) Micro(minedBlock *proto.Block, rest proto.MiningLimits, key
 	}
 	micro := proto.MicroBlock{
 		VersionField:          byte(newBlock.Version),
-		SenderPK:              keyPair.Public,
+		SenderPK:              pk,
 		Transactions:          transactions,
 		TransactionCount:      uint32(txCount),
 		Reference:             a.state.TopBlock().BlockID(),
@@ -164,7 +234,7 @@ func (a *MicroMiner) Micro(minedBlock *proto.Block, rest proto.MiningLimits, key
 		TotalBlockID:          newBlock.BlockID(),
 	}
 
-	err = micro.Sign(a.scheme, sk)
+	err = a.signer.SignMicroBlock(pk, &micro)
 	if err != nil {
 		return nil, nil, rest, err
 	}
@@ -177,5 +247,14 @@ func (a *MicroMiner) Micro(minedBlock *proto.Block, rest proto.MiningLimits, key
 		ClassicAmountOfTxsInBlock:   rest.ClassicAmountOfTxsInBlock,
 		MaxTxsSizeInBytes:           rest.MaxTxsSizeInBytes - binSize,
 	}
+	a.monitor.MicroBlockMined(monitor.MicroBlock{
+		TotalBlockID:     micro.TotalBlockID,
+		Reference:        micro.Reference,
+		PublicKey:        micro.SenderPK,
+		TransactionCount: txCount,
+		Bytes:            binSize,
+		Complexity:       complexity,
+		RestLimits:       newRest,
+	})
 	return newBlock, &micro, newRest, nil
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/miner/monitor"
 	"github.com/wavesplatform/gowaves/pkg/miner/scheduler"
 	"github.com/wavesplatform/gowaves/pkg/node/messages"
 	"github.com/wavesplatform/gowaves/pkg/node/peer_manager"
@@ -39,7 +41,7 @@ func NewMicroblockMiner(services services.Services, features Features, reward in
 	}
 }
 
-func (a *MicroblockMiner) MineKeyBlock(ctx context.Context, t proto.Timestamp, k proto.KeyPair, parent proto.BlockID, baseTarget types.BaseTarget, gs []byte, vrf []byte) (*proto.Block, proto.MiningLimits, error) {
+func (a *MicroblockMiner) MineKeyBlock(ctx context.Context, t proto.Timestamp, pk crypto.PublicKey, parent proto.BlockID, baseTarget types.BaseTarget, gs []byte, vrf []byte) (*proto.Block, proto.MiningLimits, error) {
 	nxt := proto.NxtConsensus{
 		BaseTarget:   baseTarget,
 		GenSignature: gs,
@@ -52,6 +54,21 @@ func (a *MicroblockMiner) MineKeyBlock(ctx context.Context, t proto.Timestamp, k
 		ts = proto.NewTimestampFromTime(now) - a.maxTransactionTimeForwardOffset
 	}
 
+	b, rest, err := a.mineKeyBlock(nxt, pk, parent, ts)
+	if err != nil {
+		a.services.MinerMonitor.MiningFailed(pk, err)
+		return nil, proto.MiningLimits{}, err
+	}
+	a.services.MinerMonitor.KeyBlockMined(monitor.KeyBlock{
+		ID:        b.BlockID(),
+		Parent:    b.Parent,
+		PublicKey: b.GenPublicKey,
+		Timestamp: b.Timestamp,
+	})
+	return b, rest, nil
+}
+
+func (a *MicroblockMiner) mineKeyBlock(nxt proto.NxtConsensus, pk crypto.PublicKey, parent proto.BlockID, ts proto.Timestamp) (*proto.Block, proto.MiningLimits, error) {
 	bi, err := a.state.MapR(func(info state.StateInfo) (interface{}, error) {
 		v, err := blockVersion(info)
 		if err != nil {
@@ -61,7 +78,7 @@ func (a *MicroblockMiner) MineKeyBlock(ctx context.Context, t proto.Timestamp, k
 		if err != nil {
 			return nil, err
 		}
-		b, err := MineBlock(v, nxt, k, validatedFeatured, ts, parent, a.reward, a.services.Scheme)
+		b, err := MineBlock(v, nxt, pk, a.services.Signer, validatedFeatured, ts, parent, a.reward, a.services.Scheme)
 		if err != nil {
 			return nil, err
 		}
@@ -119,12 +136,12 @@ func Run(ctx context.Context, a types.Miner, s Mine, internalCh chan<- messages.
 		case <-ctx.Done():
 			return
 		case v := <-s.Mine():
-			block, limits, err := a.MineKeyBlock(ctx, v.Timestamp, v.KeyPair, v.Parent, v.BaseTarget, v.GenSignature, v.VRF)
+			block, limits, err := a.MineKeyBlock(ctx, v.Timestamp, v.PublicKey, v.Parent, v.BaseTarget, v.GenSignature, v.VRF)
 			if err != nil {
 				zap.S().Errorf("Failed to mine key block: %v", err)
 				continue
 			}
-			internalCh <- messages.NewMinedBlockInternalMessage(block, limits, v.KeyPair, v.VRF)
+			internalCh <- messages.NewMinedBlockInternalMessage(block, limits, v.PublicKey, v.VRF)
 		}
 	}
 }
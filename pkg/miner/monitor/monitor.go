@@ -0,0 +1,238 @@
+package monitor
+
+import (
+	"sync"
+	"time"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+const (
+	// maxMicroBlocks limits the number of recently produced microblocks kept in Status.
+	maxMicroBlocks = 20
+	// subscriberBuffer is the number of events buffered for a subscriber, events are dropped for slow subscribers.
+	subscriberBuffer = 64
+)
+
+type EventType string
+
+const (
+	EventSlotsScheduled   EventType = "slots_scheduled"
+	EventKeyBlockMined    EventType = "key_block_mined"
+	EventKeyBlockSkipped  EventType = "key_block_skipped"
+	EventMicroBlockMined  EventType = "micro_block_mined"
+	EventSubscriptionLost EventType = "subscription_lost"
+)
+
+// SkipReason explains why a key block was not generated.
+type SkipReason string
+
+const (
+	SkipNoKeys                        SkipReason = "no_keys"
+	SkipNotEnoughPeers                SkipReason = "not_enough_peers"
+	SkipOutdatedChain                 SkipReason = "outdated_chain"
+	SkipInsufficientGeneratingBalance SkipReason = "insufficient_generating_balance"
+	SkipSchedulingFailed              SkipReason = "scheduling_failed"
+	SkipMiningFailed                  SkipReason = "mining_failed"
+)
+
+// Slot is the scheduled time of key block generation by the key pair.
+type Slot struct {
+	PublicKey         crypto.PublicKey   `json:"public_key"`
+	Address           proto.WavesAddress `json:"address"`
+	Timestamp         uint64             `json:"timestamp"`
+	Hit               string             `json:"hit"`
+	Delay             uint64             `json:"delay"`
+	GeneratingBalance uint64             `json:"generating_balance"`
+	BaseTarget        uint64             `json:"base_target"`
+	Parent            proto.BlockID      `json:"parent"`
+}
+
+// KeyBlockSkip describes the reason why a key block was not generated.
+// PublicKey is empty if the reason is common for all key pairs.
+type KeyBlockSkip struct {
+	Reason    SkipReason        `json:"reason"`
+	PublicKey *crypto.PublicKey `json:"public_key,omitempty"`
+	Details   string            `json:"details,omitempty"`
+}
+
+type KeyBlock struct {
+	ID        proto.BlockID    `json:"id"`
+	Parent    proto.BlockID    `json:"parent"`
+	PublicKey crypto.PublicKey `json:"public_key"`
+	Timestamp uint64           `json:"timestamp"`
+}
+
+type MicroBlock struct {
+	TotalBlockID     proto.BlockID      `json:"total_block_id"`
+	Reference        proto.BlockID      `json:"reference"`
+	PublicKey        crypto.PublicKey   `json:"public_key"`
+	TransactionCount int                `json:"transaction_count"`
+	Bytes            int                `json:"bytes"`
+	Complexity       int                `json:"complexity"`
+	RestLimits       proto.MiningLimits `json:"rest_limits"`
+}
+
+// Event is sent to subscribers on every change of mining state, only the field corresponding to Type is set.
+type Event struct {
+	Type       EventType      `json:"type"`
+	Time       time.Time      `json:"time"`
+	Slots      []Slot         `json:"slots,omitempty"`
+	Skipped    []KeyBlockSkip `json:"skipped,omitempty"`
+	KeyBlock   *KeyBlock      `json:"key_block,omitempty"`
+	MicroBlock *MicroBlock    `json:"micro_block,omitempty"`
+}
+
+// Status is the snapshot of mining state.
+type Status struct {
+	ScheduledAt  time.Time      `json:"scheduled_at"`
+	Slots        []Slot         `json:"slots"`
+	Skipped      []KeyBlockSkip `json:"skipped"`
+	LastKeyBlock *KeyBlock      `json:"last_key_block"`
+	MicroBlocks  []MicroBlock   `json:"micro_blocks"`
+}
+
+// Monitor collects the information about key blocks and microblocks production and notifies subscribers.
+// All methods are safe to call on nil Monitor, in that case nothing is collected.
+type Monitor struct {
+	mu          sync.Mutex
+	status      Status
+	subscribers map[int]chan Event
+	nextID      int
+}
+
+func NewMonitor() *Monitor {
+	return &Monitor{
+		status: Status{
+			Slots:       make([]Slot, 0),
+			Skipped:     make([]KeyBlockSkip, 0),
+			MicroBlocks: make([]MicroBlock, 0),
+		},
+		subscribers: make(map[int]chan Event),
+	}
+}
+
+// Scheduled registers the new schedule of key blocks generation and the key pairs that were not scheduled.
+func (m *Monitor) Scheduled(slots []Slot, skipped []KeyBlockSkip) {
+	if m == nil {
+		return
+	}
+	if slots == nil {
+		slots = make([]Slot, 0)
+	}
+	if skipped == nil {
+		skipped = make([]KeyBlockSkip, 0)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	m.status.ScheduledAt = now
+	m.status.Slots = slots
+	m.status.Skipped = skipped
+	m.publish(Event{Type: EventSlotsScheduled, Time: now, Slots: slots})
+	if len(skipped) > 0 {
+		m.publish(Event{Type: EventKeyBlockSkipped, Time: now, Skipped: skipped})
+	}
+}
+
+// Skipped registers that key block generation was skipped, the current schedule is dropped.
+func (m *Monitor) Skipped(skipped ...KeyBlockSkip) {
+	if m == nil || len(skipped) == 0 {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	m.status.ScheduledAt = now
+	m.status.Slots = make([]Slot, 0)
+	m.status.Skipped = skipped
+	m.publish(Event{Type: EventKeyBlockSkipped, Time: now, Skipped: skipped})
+}
+
+// MiningFailed registers that scheduled key block was not generated because of the error.
+func (m *Monitor) MiningFailed(pk crypto.PublicKey, err error) {
+	if m == nil {
+		return
+	}
+	skip := KeyBlockSkip{Reason: SkipMiningFailed, PublicKey: &pk, Details: err.Error()}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.status.Skipped = append(m.status.Skipped, skip)
+	m.publish(Event{Type: EventKeyBlockSkipped, Time: time.Now(), Skipped: []KeyBlockSkip{skip}})
+}
+
+func (m *Monitor) KeyBlockMined(kb KeyBlock) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.status.LastKeyBlock = &kb
+	m.status.MicroBlocks = make([]MicroBlock, 0)
+	m.publish(Event{Type: EventKeyBlockMined, Time: time.Now(), KeyBlock: &kb})
+}
+
+func (m *Monitor) MicroBlockMined(mb MicroBlock) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.status.MicroBlocks) >= maxMicroBlocks {
+		m.status.MicroBlocks = append(m.status.MicroBlocks[:0:0], m.status.MicroBlocks[1:]...)
+	}
+	m.status.MicroBlocks = append(m.status.MicroBlocks, mb)
+	m.publish(Event{Type: EventMicroBlockMined, Time: time.Now(), MicroBlock: &mb})
+}
+
+// Status returns the copy of current mining state.
+func (m *Monitor) Status() Status {
+	if m == nil {
+		return Status{Slots: make([]Slot, 0), Skipped: make([]KeyBlockSkip, 0), MicroBlocks: make([]MicroBlock, 0)}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := m.status
+	s.Slots = append(make([]Slot, 0, len(s.Slots)), s.Slots...)
+	s.Skipped = append(make([]KeyBlockSkip, 0, len(s.Skipped)), s.Skipped...)
+	s.MicroBlocks = append(make([]MicroBlock, 0, len(s.MicroBlocks)), s.MicroBlocks...)
+	return s
+}
+
+// Subscribe returns the channel of events and the function to cancel the subscription.
+// The channel is closed after the cancellation or if the subscriber does not keep up with events,
+// in the latter case the last event is EventSubscriptionLost.
+func (m *Monitor) Subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+	if m == nil {
+		close(ch)
+		return ch, func() {}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	id := m.nextID
+	m.nextID++
+	m.subscribers[id] = ch
+	return ch, func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if c, ok := m.subscribers[id]; ok {
+			delete(m.subscribers, id)
+			close(c)
+		}
+	}
+}
+
+func (m *Monitor) publish(e Event) {
+	for id, ch := range m.subscribers {
+		// Last place in the buffer is reserved for the notification about lost subscription.
+		if len(ch) < cap(ch)-1 {
+			ch <- e
+			continue
+		}
+		ch <- Event{Type: EventSubscriptionLost, Time: e.Time}
+		delete(m.subscribers, id)
+		close(ch)
+	}
+}
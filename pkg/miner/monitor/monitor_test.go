@@ -0,0 +1,76 @@
+package monitor
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+func TestMonitor(t *testing.T) {
+	m := NewMonitor()
+	events, cancel := m.Subscribe()
+
+	pk := crypto.PublicKey{1}
+	m.Scheduled([]Slot{{PublicKey: pk, Timestamp: 100}}, []KeyBlockSkip{{Reason: SkipInsufficientGeneratingBalance, PublicKey: &pk}})
+	m.KeyBlockMined(KeyBlock{PublicKey: pk, Timestamp: 100})
+	m.MicroBlockMined(MicroBlock{PublicKey: pk, TransactionCount: 2, RestLimits: proto.MiningLimits{MaxTxsSizeInBytes: 10}})
+	m.MiningFailed(pk, errors.New("failure"))
+	m.Skipped(KeyBlockSkip{Reason: SkipOutdatedChain})
+
+	for _, et := range []EventType{EventSlotsScheduled, EventKeyBlockSkipped, EventKeyBlockMined, EventMicroBlockMined,
+		EventKeyBlockSkipped, EventKeyBlockSkipped} {
+		e := <-events
+		assert.Equal(t, et, e.Type)
+	}
+
+	s := m.Status()
+	assert.Empty(t, s.Slots)
+	assert.Equal(t, []KeyBlockSkip{{Reason: SkipOutdatedChain}}, s.Skipped)
+	require.NotNil(t, s.LastKeyBlock)
+	assert.Equal(t, uint64(100), s.LastKeyBlock.Timestamp)
+	require.Len(t, s.MicroBlocks, 1)
+	assert.Equal(t, 2, s.MicroBlocks[0].TransactionCount)
+
+	cancel()
+	_, ok := <-events
+	assert.False(t, ok)
+	cancel()
+}
+
+func TestMonitor_SlowSubscriber(t *testing.T) {
+	m := NewMonitor()
+	events, cancel := m.Subscribe()
+	defer cancel()
+
+	for i := 0; i < subscriberBuffer+10; i++ {
+		m.MicroBlockMined(MicroBlock{TransactionCount: i})
+	}
+	var last Event
+	n := 0
+	for e := range events {
+		last = e
+		n++
+	}
+	assert.Equal(t, subscriberBuffer, n)
+	assert.Equal(t, EventSubscriptionLost, last.Type)
+	assert.Len(t, m.Status().MicroBlocks, maxMicroBlocks)
+	assert.Equal(t, subscriberBuffer+9, m.Status().MicroBlocks[maxMicroBlocks-1].TransactionCount)
+}
+
+func TestMonitor_Nil(t *testing.T) {
+	var m *Monitor
+	m.Scheduled(nil, nil)
+	m.Skipped(KeyBlockSkip{Reason: SkipNoKeys})
+	m.KeyBlockMined(KeyBlock{})
+	m.MicroBlockMined(MicroBlock{})
+	m.MiningFailed(crypto.PublicKey{}, errors.New("failure"))
+	assert.Empty(t, m.Status().Slots)
+	events, cancel := m.Subscribe()
+	defer cancel()
+	_, ok := <-events
+	assert.False(t, ok)
+}
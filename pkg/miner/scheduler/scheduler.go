@@ -1,14 +1,18 @@
 package scheduler
 
 import (
+	"fmt"
 	"sync"
 	"time"
 
 	"github.com/mr-tron/base58"
 	"github.com/pkg/errors"
 	"github.com/wavesplatform/gowaves/pkg/consensus"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/miner/monitor"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/settings"
+	"github.com/wavesplatform/gowaves/pkg/signer"
 	"github.com/wavesplatform/gowaves/pkg/state"
 	"github.com/wavesplatform/gowaves/pkg/types"
 	"github.com/wavesplatform/gowaves/pkg/util/cancellable"
@@ -18,16 +22,19 @@ import (
 )
 
 type Emit struct {
-	Timestamp    uint64
-	KeyPair      proto.KeyPair
-	GenSignature []byte
-	VRF          []byte
-	BaseTarget   types.BaseTarget
-	Parent       proto.BlockID
+	Timestamp         uint64
+	PublicKey         crypto.PublicKey
+	GenSignature      []byte
+	VRF               []byte
+	BaseTarget        types.BaseTarget
+	Parent            proto.BlockID
+	Hit               *consensus.Hit
+	Delay             uint64
+	GeneratingBalance uint64
 }
 
 type SchedulerImpl struct {
-	seeder        seeder
+	signer        types.Signer
 	mine          chan Emit
 	cancel        []func()
 	settings      *settings.BlockchainSettings
@@ -38,27 +45,31 @@ type SchedulerImpl struct {
 	tm            types.Time
 	consensus     types.MinerConsensus
 	outdatePeriod proto.Timestamp
+	monitor       *monitor.Monitor
+	// manual scheduler only calculates emits, they are sent to the miner by the owner of the scheduler.
+	manual bool
 }
 
 type internal interface {
-	schedule(state state.StateInfo, keyPairs []proto.KeyPair, schema proto.Scheme, AverageBlockDelaySeconds uint64, MinBlockTime float64, DelayDelta uint64, confirmedBlock *proto.Block, confirmedBlockHeight uint64) ([]Emit, error)
+	schedule(state state.StateInfo, keys []crypto.PublicKey, schema proto.Scheme, AverageBlockDelaySeconds uint64, MinBlockTime float64, DelayDelta uint64, confirmedBlock *proto.Block, confirmedBlockHeight uint64) ([]Emit, error)
 }
 
 type internalImpl struct {
+	signer types.Signer
 }
 
-func (a internalImpl) schedule(storage state.StateInfo, keyPairs []proto.KeyPair, schema proto.Scheme, AverageBlockDelaySeconds uint64, MinBlockTime float64, DelayDelta uint64, confirmedBlock *proto.Block, confirmedBlockHeight uint64) ([]Emit, error) {
+func (a internalImpl) schedule(storage state.StateInfo, keys []crypto.PublicKey, schema proto.Scheme, AverageBlockDelaySeconds uint64, MinBlockTime float64, DelayDelta uint64, confirmedBlock *proto.Block, confirmedBlockHeight uint64) ([]Emit, error) {
 	vrfActivated, err := storage.IsActivated(int16(settings.BlockV5))
 	if err != nil {
 		return nil, errors.Wrap(err, "failed get vrfActivated")
 	}
 	if vrfActivated {
-		return a.scheduleWithVrf(storage, keyPairs, schema, AverageBlockDelaySeconds, MinBlockTime, DelayDelta, confirmedBlock, confirmedBlockHeight)
+		return a.scheduleWithVrf(storage, keys, schema, AverageBlockDelaySeconds, MinBlockTime, DelayDelta, confirmedBlock, confirmedBlockHeight)
 	}
-	return a.scheduleWithoutVrf(storage, keyPairs, schema, AverageBlockDelaySeconds, MinBlockTime, DelayDelta, confirmedBlock, confirmedBlockHeight)
+	return a.scheduleWithoutVrf(storage, keys, schema, AverageBlockDelaySeconds, MinBlockTime, DelayDelta, confirmedBlock, confirmedBlockHeight)
 }
 
-func (a internalImpl) scheduleWithVrf(storage state.StateInfo, keyPairs []proto.KeyPair, schema proto.Scheme, AverageBlockDelaySeconds uint64, MinBlockTime float64, DelayDelta uint64, confirmedBlock *proto.Block, confirmedBlockHeight uint64) ([]Emit, error) {
+func (a internalImpl) scheduleWithVrf(storage state.StateInfo, keys []crypto.PublicKey, schema proto.Scheme, AverageBlockDelaySeconds uint64, MinBlockTime float64, DelayDelta uint64, confirmedBlock *proto.Block, confirmedBlockHeight uint64) ([]Emit, error) {
 	var greatGrandParentTimestamp proto.Timestamp = 0
 	if confirmedBlockHeight > 2 {
 		greatGrandParentHeight := confirmedBlockHeight - 2
@@ -86,11 +97,6 @@ func (a internalImpl) scheduleWithVrf(storage state.StateInfo, keyPairs []proto.
 			pos = consensus.FairPosCalculatorV1
 		}
 	}
-	gsp := consensus.NXTGenerationSignatureProvider
-	if blockV5Activated {
-		gsp = consensus.VRFGenerationSignatureProvider
-	}
-
 	heightForHit := pos.HeightForHit(confirmedBlockHeight)
 
 	zap.S().Debugf("Scheduler: topBlock: id %s, gensig: %s, topBlockHeight: %d",
@@ -98,27 +104,20 @@ func (a internalImpl) scheduleWithVrf(storage state.StateInfo, keyPairs []proto.
 	)
 
 	var out []Emit
-	for _, keyPair := range keyPairs {
-		sk := keyPair.Secret
+	for _, pk := range keys {
 		hitSourceAtHeight, err := storage.HitSourceAtHeight(heightForHit)
 		if err != nil {
 			zap.S().Errorf("Scheduler: Failed to get hit source at height %d: %v", heightForHit, err)
 			continue
 		}
-		genSig, err := gsp.GenerationSignature(sk, hitSourceAtHeight)
+		// Generation signature is the VRF proof and hit source is the VRF value since BlockV5 activation.
+		genSig, source, err := a.signer.VRF(pk, hitSourceAtHeight)
 		if err != nil {
 			zap.S().Errorf("Scheduler: Failed to schedule mining, can't get generation signature at height %d: %v",
 				heightForHit, err,
 			)
 			continue
 		}
-		source, err := gsp.HitSource(sk, hitSourceAtHeight)
-		if err != nil {
-			zap.S().Errorf("Scheduler: Failed to schedule mining, failed to get hit source at height %d: %v",
-				heightForHit, err,
-			)
-			continue
-		}
 		var vrf []byte
 		if blockV5Activated {
 			vrf = source
@@ -129,7 +128,7 @@ func (a internalImpl) scheduleWithVrf(storage state.StateInfo, keyPairs []proto.
 			continue
 		}
 
-		addr, err := keyPair.Addr(schema)
+		addr, err := proto.NewAddressFromPublicKey(schema, pk)
 		if err != nil {
 			zap.S().Errorf("Scheduler: Failed to schedule mining, failed to create address from PK: %v", err)
 			continue
@@ -168,18 +167,21 @@ func (a internalImpl) scheduleWithVrf(storage state.StateInfo, keyPairs []proto.
 		}
 
 		out = append(out, Emit{
-			Timestamp:    confirmedBlock.Timestamp + delay,
-			KeyPair:      keyPair,
-			GenSignature: genSig,
-			VRF:          vrf,
-			BaseTarget:   baseTarget,
-			Parent:       confirmedBlock.BlockID(),
+			Timestamp:         confirmedBlock.Timestamp + delay,
+			PublicKey:         pk,
+			GenSignature:      genSig,
+			VRF:               vrf,
+			BaseTarget:        baseTarget,
+			Parent:            confirmedBlock.BlockID(),
+			Hit:               hit,
+			Delay:             delay,
+			GeneratingBalance: effectiveBalance,
 		})
 	}
 	return out, nil
 }
 
-func (a internalImpl) scheduleWithoutVrf(storage state.StateInfo, keyPairs []proto.KeyPair, schema proto.Scheme, AverageBlockDelaySeconds uint64, MinBlockTime float64, DelayDelta uint64, confirmedBlock *proto.Block, confirmedBlockHeight uint64) ([]Emit, error) {
+func (a internalImpl) scheduleWithoutVrf(storage state.StateInfo, keys []crypto.PublicKey, schema proto.Scheme, AverageBlockDelaySeconds uint64, MinBlockTime float64, DelayDelta uint64, confirmedBlock *proto.Block, confirmedBlockHeight uint64) ([]Emit, error) {
 	var greatGrandParentTimestamp proto.Timestamp = 0
 	if confirmedBlockHeight > 2 {
 		greatGrandParentHeight := confirmedBlockHeight - 2
@@ -222,8 +224,7 @@ func (a internalImpl) scheduleWithoutVrf(storage state.StateInfo, keyPairs []pro
 	zap.S().Debugf("  block base target: %d", confirmedBlock.BaseTarget)
 	zap.S().Debug("Generation accounts:")
 	var out []Emit
-	for _, keyPair := range keyPairs {
-		pk := keyPair.Public
+	for _, pk := range keys {
 		genSigBlock := confirmedBlock.BlockHeader
 		genSig, err := gsp.GenerationSignature(pk, genSigBlock.GenSignature)
 		if err != nil {
@@ -285,31 +286,38 @@ func (a internalImpl) scheduleWithoutVrf(storage state.StateInfo, keyPairs []pro
 		zap.S().Debugf("    Delay: %d", int(delay))
 		zap.S().Debugf("    Timestamp: %d (%s)", int(ts), common.UnixMillisToTime(int64(ts)).String())
 		out = append(out, Emit{
-			Timestamp:    ts,
-			KeyPair:      keyPair,
-			GenSignature: genSig,
-			VRF:          nil, // because without VRF
-			BaseTarget:   baseTarget,
-			Parent:       confirmedBlock.BlockID(),
+			Timestamp:         ts,
+			PublicKey:         pk,
+			GenSignature:      genSig,
+			VRF:               nil, // because without VRF
+			BaseTarget:        baseTarget,
+			Parent:            confirmedBlock.BlockID(),
+			Hit:               hit,
+			Delay:             delay,
+			GeneratingBalance: effectiveBalance,
 		})
 	}
 	return out, nil
 }
 
-type seeder interface {
-	Seeds() [][]byte
+func NewScheduler(state state.State, sgn types.Signer, settings *settings.BlockchainSettings, tm types.Time, consensus types.MinerConsensus, minerDelay proto.Timestamp, monitor *monitor.Monitor) *SchedulerImpl {
+	return newScheduler(internalImpl{signer: sgn}, state, sgn, settings, tm, consensus, minerDelay, monitor)
 }
 
-func NewScheduler(state state.State, seeder seeder, settings *settings.BlockchainSettings, tm types.Time, consensus types.MinerConsensus, minerDelay proto.Timestamp) *SchedulerImpl {
-	return newScheduler(internalImpl{}, state, seeder, settings, tm, consensus, minerDelay)
+// NewManualScheduler creates a scheduler that calculates emits on Reschedule, but never sends them to the miner.
+// Mining is controlled by the caller, that sends chosen emits to the Mine channel. It's used in local test networks.
+func NewManualScheduler(state state.State, sgn types.Signer, settings *settings.BlockchainSettings, tm types.Time, consensus types.MinerConsensus, minerDelay proto.Timestamp, monitor *monitor.Monitor) *SchedulerImpl {
+	s := newScheduler(internalImpl{signer: sgn}, state, sgn, settings, tm, consensus, minerDelay, monitor)
+	s.manual = true
+	return s
 }
 
-func newScheduler(internal internal, state state.State, seeder seeder, settings *settings.BlockchainSettings, tm types.Time, consensus types.MinerConsensus, minerDelay proto.Timestamp) *SchedulerImpl {
-	if seeder == nil {
-		seeder = wallet.NewWallet()
+func newScheduler(internal internal, state state.State, sgn types.Signer, settings *settings.BlockchainSettings, tm types.Time, consensus types.MinerConsensus, minerDelay proto.Timestamp, monitor *monitor.Monitor) *SchedulerImpl {
+	if sgn == nil {
+		sgn = signer.NewLocal(wallet.NewWallet(), 0, nil)
 	}
 	return &SchedulerImpl{
-		seeder:        seeder,
+		signer:        sgn,
 		mine:          make(chan Emit, 1),
 		settings:      settings,
 		internal:      internal,
@@ -318,6 +326,7 @@ func newScheduler(internal internal, state state.State, seeder seeder, settings
 		tm:            tm,
 		consensus:     consensus,
 		outdatePeriod: minerDelay,
+		monitor:       monitor,
 	}
 }
 
@@ -326,15 +335,26 @@ func (a *SchedulerImpl) Mine() chan Emit {
 }
 
 func (a *SchedulerImpl) Reschedule() {
-	if len(a.seeder.Seeds()) == 0 {
+	keys, err := a.signer.PublicKeys()
+	if err != nil {
+		zap.S().Errorf("Scheduler: Failed to get public keys from signer: %v", err)
+		a.monitor.Skipped(monitor.KeyBlockSkip{Reason: monitor.SkipSchedulingFailed, Details: err.Error()})
+		return
+	}
+	if len(keys) == 0 {
 		zap.S().Debug("Scheduler: Mining is not possible because no seeds registered")
+		a.monitor.Skipped(monitor.KeyBlockSkip{Reason: monitor.SkipNoKeys})
 		return
 	}
 
-	zap.S().Debugf("Scheduler: Trying to mine with %d seeds", len(a.seeder.Seeds()))
+	zap.S().Debugf("Scheduler: Trying to mine with %d seeds", len(keys))
 
 	if !a.consensus.IsMiningAllowed() {
 		zap.S().Debug("Scheduler: Mining is not allowed because of lack of connected nodes")
+		a.monitor.Skipped(monitor.KeyBlockSkip{
+			Reason:  monitor.SkipNotEnoughPeers,
+			Details: "number of connected peers is less than min-peers-mining",
+		})
 		return
 	}
 
@@ -344,28 +364,32 @@ func (a *SchedulerImpl) Reschedule() {
 		zap.S().Debugf("Scheduler: Mining is not allowed because blockchain is too old: cur %d, block.ts %d, outdate: %d, id: %s",
 			currentTimestamp, lastKnownBlock.Timestamp, a.outdatePeriod, lastKnownBlock.ID,
 		)
+		a.monitor.Skipped(monitor.KeyBlockSkip{
+			Reason: monitor.SkipOutdatedChain,
+			Details: fmt.Sprintf("last block %s timestamp %d is older than %d ms",
+				lastKnownBlock.ID.String(), lastKnownBlock.Timestamp, a.outdatePeriod),
+		})
 		return
 	}
 
 	h, err := a.storage.Height()
 	if err != nil {
 		zap.S().Errorf("Scheduler: Failed to get state height: %v", err)
+		a.monitor.Skipped(monitor.KeyBlockSkip{Reason: monitor.SkipSchedulingFailed, Details: err.Error()})
 		return
 	}
 
 	block, err := a.storage.BlockByHeight(h)
 	if err != nil {
 		zap.S().Errorf("Scheduler: Failed to get block by height %d: %v", h, err)
+		a.monitor.Skipped(monitor.KeyBlockSkip{Reason: monitor.SkipSchedulingFailed, Details: err.Error()})
 		return
 	}
 
-	a.reschedule(block, h)
+	a.reschedule(keys, block, h)
 }
 
-func (a *SchedulerImpl) reschedule(confirmedBlock *proto.Block, confirmedBlockHeight uint64) {
-	if len(a.seeder.Seeds()) == 0 {
-		return
-	}
+func (a *SchedulerImpl) reschedule(keys []crypto.PublicKey, confirmedBlock *proto.Block, confirmedBlockHeight uint64) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
@@ -376,21 +400,27 @@ func (a *SchedulerImpl) reschedule(confirmedBlock *proto.Block, confirmedBlockHe
 	a.cancel = nil
 	a.emits = nil
 
-	keyPairs, err := makeKeyPairs(a.seeder.Seeds())
-	if err != nil {
-		zap.S().Errorf("Scheduler: Failed to make key pairs from seeds: %v", err)
-		return
-	}
-
+	var skipped []monitor.KeyBlockSkip
 	rs, err := a.storage.MapR(func(info state.StateInfo) (i interface{}, err error) {
-		return a.internal.schedule(info, keyPairs, a.settings.AddressSchemeCharacter, a.settings.AverageBlockDelaySeconds, a.settings.MinBlockTime, a.settings.DelayDelta, confirmedBlock, confirmedBlockHeight)
+		emits, err := a.internal.schedule(info, keys, a.settings.AddressSchemeCharacter, a.settings.AverageBlockDelaySeconds, a.settings.MinBlockTime, a.settings.DelayDelta, confirmedBlock, confirmedBlockHeight)
+		if err != nil {
+			return nil, err
+		}
+		emits, skipped, err = a.checkGeneratingBalances(info, emits, confirmedBlockHeight)
+		return emits, err
 	})
 	if err != nil {
 		zap.S().Errorf("Scheduler: Failed to schedule: %v", err)
+		a.monitor.Skipped(monitor.KeyBlockSkip{Reason: monitor.SkipSchedulingFailed, Details: err.Error()})
+		return
 	}
 	emits := rs.([]Emit)
 
 	a.emits = emits
+	a.monitor.Scheduled(a.slots(emits), append(skipped, unscheduled(keys, emits, skipped)...))
+	if a.manual {
+		return
+	}
 	now := proto.NewTimestampFromTime(a.tm.Now())
 	for _, emit := range emits {
 		if emit.Timestamp > now { // timestamp in future
@@ -416,20 +446,88 @@ func (a *SchedulerImpl) reschedule(confirmedBlock *proto.Block, confirmedBlockHe
 	}
 }
 
-func (a *SchedulerImpl) Emits() []Emit {
-	a.mu.Lock()
-	defer a.mu.Unlock()
-	return a.emits
+// checkGeneratingBalances removes emits of key pairs with generating balance less than required for block generation.
+func (a *SchedulerImpl) checkGeneratingBalances(info state.StateInfo, emits []Emit, confirmedBlockHeight uint64) ([]Emit, []monitor.KeyBlockSkip, error) {
+	smaller, err := info.IsActiveAtHeight(int16(settings.SmallerMinimalGeneratingBalance), confirmedBlockHeight)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed get smallerMinimalGeneratingBalance")
+	}
+	minBalance := consensus.MinimalGeneratingBalance(smaller)
+	var (
+		out     []Emit
+		skipped []monitor.KeyBlockSkip
+	)
+	for _, emit := range emits {
+		if emit.Timestamp >= a.settings.MinimalGeneratingBalanceCheckAfterTime && emit.GeneratingBalance < minBalance {
+			pk := emit.PublicKey
+			zap.S().Debugf("Scheduler: Generating balance %d of PK %q is less than required %d",
+				emit.GeneratingBalance, pk.String(), minBalance,
+			)
+			skipped = append(skipped, monitor.KeyBlockSkip{
+				Reason:    monitor.SkipInsufficientGeneratingBalance,
+				PublicKey: &pk,
+				Details:   fmt.Sprintf("generating balance %d is less than required %d", emit.GeneratingBalance, minBalance),
+			})
+			continue
+		}
+		out = append(out, emit)
+	}
+	return out, skipped, nil
 }
 
-func makeKeyPairs(seeds [][]byte) ([]proto.KeyPair, error) {
-	var out []proto.KeyPair
-	for _, bts := range seeds {
-		kp, err := proto.NewKeyPair(bts)
+func (a *SchedulerImpl) slots(emits []Emit) []monitor.Slot {
+	out := make([]monitor.Slot, 0, len(emits))
+	for _, emit := range emits {
+		addr, err := proto.NewAddressFromPublicKey(a.settings.AddressSchemeCharacter, emit.PublicKey)
 		if err != nil {
-			return nil, err
+			zap.S().Errorf("Scheduler: Failed to create address from PK %q: %v", emit.PublicKey.String(), err)
+			continue
 		}
-		out = append(out, kp)
+		var hit string
+		if emit.Hit != nil {
+			hit = emit.Hit.String()
+		}
+		out = append(out, monitor.Slot{
+			PublicKey:         emit.PublicKey,
+			Address:           addr,
+			Timestamp:         emit.Timestamp,
+			Hit:               hit,
+			Delay:             emit.Delay,
+			GeneratingBalance: emit.GeneratingBalance,
+			BaseTarget:        emit.BaseTarget,
+			Parent:            emit.Parent,
+		})
 	}
-	return out, nil
+	return out
+}
+
+// unscheduled returns skips for the keys that were neither scheduled nor already skipped,
+// the reasons of scheduling failures for such keys are logged.
+func unscheduled(keys []crypto.PublicKey, emits []Emit, skipped []monitor.KeyBlockSkip) []monitor.KeyBlockSkip {
+	known := make(map[crypto.PublicKey]struct{}, len(emits)+len(skipped))
+	for _, emit := range emits {
+		known[emit.PublicKey] = struct{}{}
+	}
+	for _, s := range skipped {
+		known[*s.PublicKey] = struct{}{}
+	}
+	var out []monitor.KeyBlockSkip
+	for _, pk := range keys {
+		if _, ok := known[pk]; ok {
+			continue
+		}
+		pk := pk
+		out = append(out, monitor.KeyBlockSkip{
+			Reason:    monitor.SkipSchedulingFailed,
+			PublicKey: &pk,
+			Details:   "see node logs for details",
+		})
+	}
+	return out
+}
+
+func (a *SchedulerImpl) Emits() []Emit {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.emits
 }
@@ -3,22 +3,88 @@ package scheduler
 import (
 	"testing"
 
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/libs/ntptime"
+	"github.com/wavesplatform/gowaves/pkg/miner/monitor"
+	"github.com/wavesplatform/gowaves/pkg/mock"
 	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/settings"
 	"github.com/wavesplatform/gowaves/pkg/state"
 )
 
 type mockInternal struct {
 }
 
-func (a mockInternal) schedule(state state.StateInfo, keyPairs []proto.KeyPair, schema proto.Scheme, AverageBlockDelaySeconds uint64, MinBlockTime float64, DelayDelta uint64, confirmedBlock *proto.Block, confirmedBlockHeight uint64) ([]Emit, error) {
+func (a mockInternal) schedule(state state.StateInfo, keys []crypto.PublicKey, schema proto.Scheme, AverageBlockDelaySeconds uint64, MinBlockTime float64, DelayDelta uint64, confirmedBlock *proto.Block, confirmedBlockHeight uint64) ([]Emit, error) {
 	return nil, nil
 }
 
 func TestSchedulerImpl_Emits(t *testing.T) {
-	sch := newScheduler(mockInternal{}, nil, nil, nil, nil, nil, 0)
+	sch := newScheduler(mockInternal{}, nil, nil, nil, nil, nil, 0, nil)
 	sch.Reschedule()
 	rs := sch.Emits()
 
 	require.EqualValues(t, []Emit([]Emit(nil)), rs)
 }
+
+type fixedInternal struct {
+	emits []Emit
+}
+
+func (a fixedInternal) schedule(state.StateInfo, []crypto.PublicKey, proto.Scheme, uint64, float64, uint64, *proto.Block, uint64) ([]Emit, error) {
+	return a.emits, nil
+}
+
+func TestManualScheduler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mock.NewMockState(ctrl)
+	st.EXPECT().MapR(gomock.Any()).DoAndReturn(func(f func(state.StateInfo) (interface{}, error)) (interface{}, error) {
+		return f(st)
+	})
+	st.EXPECT().IsActiveAtHeight(int16(settings.SmallerMinimalGeneratingBalance), uint64(1)).Return(true, nil)
+
+	pk := crypto.PublicKey{1}
+	// Emit in the past is sent to the miner immediately by the regular scheduler.
+	emit := Emit{PublicKey: pk, Timestamp: 1, GeneratingBalance: 1000 * proto.PriceConstant}
+	sch := newScheduler(fixedInternal{emits: []Emit{emit}}, st, nil, settings.MainNetSettings, ntptime.Stub{}, nil, 0, monitor.NewMonitor())
+	sch.manual = true
+	sch.reschedule([]crypto.PublicKey{pk}, &proto.Block{}, 1)
+
+	assert.Equal(t, []Emit{emit}, sch.Emits())
+	assert.Empty(t, sch.Mine())
+}
+
+func TestSchedulerImpl_checkGeneratingBalances(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mock.NewMockState(ctrl)
+	st.EXPECT().IsActiveAtHeight(int16(settings.SmallerMinimalGeneratingBalance), uint64(10)).Return(true, nil)
+
+	sch := newScheduler(mockInternal{}, nil, nil, settings.MainNetSettings, nil, nil, 0, nil)
+	rich := crypto.PublicKey{1}
+	poor := crypto.PublicKey{2}
+	failed := crypto.PublicKey{3}
+	ts := settings.MainNetSettings.MinimalGeneratingBalanceCheckAfterTime
+	emits := []Emit{
+		{PublicKey: rich, Timestamp: ts, GeneratingBalance: 1000 * proto.PriceConstant},
+		{PublicKey: poor, Timestamp: ts, GeneratingBalance: 999 * proto.PriceConstant},
+	}
+	rs, skipped, err := sch.checkGeneratingBalances(st, emits, 10)
+	require.NoError(t, err)
+	require.Len(t, rs, 1)
+	assert.Equal(t, rich, rs[0].PublicKey)
+	require.Len(t, skipped, 1)
+	assert.Equal(t, monitor.SkipInsufficientGeneratingBalance, skipped[0].Reason)
+	assert.Equal(t, poor, *skipped[0].PublicKey)
+
+	rest := unscheduled([]crypto.PublicKey{rich, poor, failed}, rs, skipped)
+	require.Len(t, rest, 1)
+	assert.Equal(t, monitor.SkipSchedulingFailed, rest[0].Reason)
+	assert.Equal(t, failed, *rest[0].PublicKey)
+}
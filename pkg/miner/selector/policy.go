@@ -0,0 +1,163 @@
+package selector
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+// Order defines how candidate transactions are prioritized.
+type Order string
+
+const (
+	// OrderFeePerByte prioritizes transactions by fee in Waves per byte of transaction.
+	OrderFeePerByte Order = "fee-per-byte"
+	// OrderFeePerComplexity prioritizes transactions by fee in Waves per byte of transaction plus
+	// the weighted estimated complexity of the invoked callable.
+	OrderFeePerComplexity Order = "fee-per-complexity"
+)
+
+const (
+	defaultComplexityWeight = 1.0
+	defaultCandidatesLimit  = 10000
+)
+
+// MinFee sets the minimal acceptable fee for transactions of given type paid in given asset.
+type MinFee struct {
+	Type  proto.TransactionType `json:"type"`
+	Asset proto.OptionalAsset   `json:"asset"`
+	Fee   uint64                `json:"fee"`
+}
+
+// Policy is a configuration of transaction selection for mining.
+type Policy struct {
+	Order Order `json:"order"`
+	// ComplexityWeight is the number of bytes that one unit of complexity is worth for OrderFeePerComplexity.
+	ComplexityWeight float64 `json:"complexity_weight"`
+	// FeeAssetsWhitelist lists sponsored assets that are accepted as fee. Empty list allows any sponsored asset.
+	FeeAssetsWhitelist []crypto.Digest `json:"fee_assets_whitelist"`
+	// MinFees are minimal fees by transaction type and fee asset on top of the blockchain minimal fees.
+	MinFees []MinFee `json:"min_fees"`
+	// CandidatesLimit is the maximal number of transactions that are taken from UTX pool for one selection.
+	CandidatesLimit int `json:"candidates_limit"`
+}
+
+func DefaultPolicy() Policy {
+	return Policy{
+		Order:            OrderFeePerByte,
+		ComplexityWeight: defaultComplexityWeight,
+		CandidatesLimit:  defaultCandidatesLimit,
+	}
+}
+
+func (p Policy) Validate() error {
+	switch p.Order {
+	case OrderFeePerByte, OrderFeePerComplexity:
+	default:
+		return errors.Errorf("unsupported order %q", p.Order)
+	}
+	if p.ComplexityWeight < 0 {
+		return errors.Errorf("negative complexity weight %f", p.ComplexityWeight)
+	}
+	if p.CandidatesLimit <= 0 {
+		return errors.Errorf("invalid candidates limit %d", p.CandidatesLimit)
+	}
+	for _, mf := range p.MinFees {
+		if mf.Type < proto.GenesisTransaction || mf.Type > proto.InvokeExpressionTransaction {
+			return errors.Errorf("invalid transaction type %d in min fees", mf.Type)
+		}
+	}
+	return nil
+}
+
+// ReadPolicy reads policy from JSON, missing values are taken from DefaultPolicy.
+func ReadPolicy(r io.Reader) (Policy, error) {
+	p := DefaultPolicy()
+	if err := json.NewDecoder(r).Decode(&p); err != nil {
+		return Policy{}, errors.Wrap(err, "failed to decode selection policy")
+	}
+	if err := p.Validate(); err != nil {
+		return Policy{}, errors.Wrap(err, "invalid selection policy")
+	}
+	return p, nil
+}
+
+// ReadPolicyFile reads policy from JSON file, DefaultPolicy is returned if path is empty.
+func ReadPolicyFile(path string) (Policy, error) {
+	if path == "" {
+		return DefaultPolicy(), nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return Policy{}, errors.Wrap(err, "failed to open selection policy file")
+	}
+	defer func() { _ = f.Close() }()
+	return ReadPolicy(f)
+}
+
+type minFeeKey struct {
+	txType proto.TransactionType
+	asset  proto.OptionalAsset
+}
+
+// rules is the policy prepared for fast checks.
+type rules struct {
+	whitelist map[crypto.Digest]struct{}
+	minFees   map[minFeeKey]uint64
+}
+
+func newRules(p Policy) rules {
+	r := rules{minFees: make(map[minFeeKey]uint64, len(p.MinFees))}
+	if len(p.FeeAssetsWhitelist) > 0 {
+		r.whitelist = make(map[crypto.Digest]struct{}, len(p.FeeAssetsWhitelist))
+		for _, id := range p.FeeAssetsWhitelist {
+			r.whitelist[id] = struct{}{}
+		}
+	}
+	for _, mf := range p.MinFees {
+		r.minFees[minFeeKey{txType: mf.Type, asset: normalizeAsset(mf.Asset)}] = mf.Fee
+	}
+	return r
+}
+
+func (r rules) check(tx proto.Transaction) SkipReason {
+	asset := feeAsset(tx)
+	if asset.Present && r.whitelist != nil {
+		if _, ok := r.whitelist[asset.ID]; !ok {
+			return SkipFeeAssetNotWhitelisted
+		}
+	}
+	if min, ok := r.minFees[minFeeKey{txType: tx.GetTypeInfo().Type, asset: asset}]; ok && tx.GetFee() < min {
+		return SkipFeeBelowPolicyMinimum
+	}
+	return ""
+}
+
+func normalizeAsset(a proto.OptionalAsset) proto.OptionalAsset {
+	if !a.Present {
+		return proto.NewOptionalAssetWaves()
+	}
+	return a
+}
+
+// feeAsset returns the asset in which the transaction fee is paid.
+func feeAsset(tx proto.Transaction) proto.OptionalAsset {
+	switch t := tx.(type) {
+	case *proto.TransferWithSig:
+		return normalizeAsset(t.FeeAsset)
+	case *proto.TransferWithProofs:
+		return normalizeAsset(t.FeeAsset)
+	case *proto.InvokeScriptWithProofs:
+		return normalizeAsset(t.FeeAsset)
+	case *proto.InvokeExpressionTransactionWithProofs:
+		return normalizeAsset(t.FeeAsset)
+	case *proto.UpdateAssetInfoWithProofs:
+		return normalizeAsset(t.FeeAsset)
+	default:
+		return proto.NewOptionalAssetWaves()
+	}
+}
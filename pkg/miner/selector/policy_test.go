@@ -0,0 +1,76 @@
+package selector
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+func TestReadPolicy(t *testing.T) {
+	p, err := ReadPolicy(strings.NewReader(`{}`))
+	require.NoError(t, err)
+	assert.Equal(t, DefaultPolicy(), p)
+
+	p, err = ReadPolicy(strings.NewReader(`{
+		"order": "fee-per-complexity",
+		"complexity_weight": 0.5,
+		"fee_assets_whitelist": ["Ft8X1v1LTa1ABafufpaCWyVj8KkaxUWE6xBhW6sNFJck"],
+		"min_fees": [{"type": 4, "asset": null, "fee": 200000}]
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, OrderFeePerComplexity, p.Order)
+	assert.Equal(t, 0.5, p.ComplexityWeight)
+	assert.Equal(t, defaultCandidatesLimit, p.CandidatesLimit)
+	require.Len(t, p.FeeAssetsWhitelist, 1)
+	assert.Equal(t, "Ft8X1v1LTa1ABafufpaCWyVj8KkaxUWE6xBhW6sNFJck", p.FeeAssetsWhitelist[0].String())
+	require.Len(t, p.MinFees, 1)
+	assert.Equal(t, MinFee{Type: proto.TransferTransaction, Asset: proto.NewOptionalAssetWaves(), Fee: 200000}, p.MinFees[0])
+
+	for _, s := range []string{
+		`{"order": "random"}`,
+		`{"complexity_weight": -1}`,
+		`{"candidates_limit": 0}`,
+		`{"min_fees": [{"type": 100, "fee": 1}]}`,
+		`[]`,
+	} {
+		_, err = ReadPolicy(strings.NewReader(s))
+		assert.Error(t, err, s)
+	}
+}
+
+func TestRulesCheck(t *testing.T) {
+	whitelisted, err := crypto.NewDigestFromBase58("Ft8X1v1LTa1ABafufpaCWyVj8KkaxUWE6xBhW6sNFJck")
+	require.NoError(t, err)
+	other, err := crypto.NewDigestFromBase58("8LQW8f7P5d5PZM7GtZEBgaqRPGSzS3DfPuiXrURJ4AJS")
+	require.NoError(t, err)
+
+	p := DefaultPolicy()
+	p.FeeAssetsWhitelist = []crypto.Digest{whitelisted}
+	p.MinFees = []MinFee{
+		{Type: proto.TransferTransaction, Fee: 200000},
+		{Type: proto.TransferTransaction, Asset: *proto.NewOptionalAssetFromDigest(whitelisted), Fee: 10},
+	}
+	r := newRules(p)
+
+	transfer := func(feeAsset proto.OptionalAsset, fee uint64) proto.Transaction {
+		return proto.NewUnsignedTransferWithProofs(2, crypto.PublicKey{}, proto.NewOptionalAssetWaves(), feeAsset,
+			0, 1, fee, proto.NewRecipientFromAddress(proto.WavesAddress{}), nil)
+	}
+	for _, test := range []struct {
+		tx     proto.Transaction
+		reason SkipReason
+	}{
+		{transfer(proto.NewOptionalAssetWaves(), 200000), ""},
+		{transfer(proto.NewOptionalAssetWaves(), 100000), SkipFeeBelowPolicyMinimum},
+		{transfer(*proto.NewOptionalAssetFromDigest(whitelisted), 10), ""},
+		{transfer(*proto.NewOptionalAssetFromDigest(whitelisted), 9), SkipFeeBelowPolicyMinimum},
+		{transfer(*proto.NewOptionalAssetFromDigest(other), 1000000), SkipFeeAssetNotWhitelisted},
+		{proto.NewUnsignedIssueWithProofs(2, proto.TestNetScheme, crypto.PublicKey{}, "asset", "", 1, 0, false, nil, 0, 1), ""},
+	} {
+		assert.Equal(t, test.reason, r.check(test.tx))
+	}
+}
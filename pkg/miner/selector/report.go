@@ -0,0 +1,89 @@
+package selector
+
+import (
+	"time"
+
+	"github.com/mr-tron/base58"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+// maxReportedSkips limits the number of skipped transactions listed in the report.
+const maxReportedSkips = 100
+
+// SkipReason explains why a candidate was not included into a microblock.
+type SkipReason string
+
+const (
+	SkipFeeAssetNotWhitelisted SkipReason = "fee_asset_not_whitelisted"
+	SkipFeeBelowPolicyMinimum  SkipReason = "fee_below_policy_minimum"
+	SkipTransactionsLimit      SkipReason = "microblock_transactions_limit"
+	SkipSizeLimit              SkipReason = "microblock_size_limit"
+	SkipValidationFailed       SkipReason = "validation_failed"
+	SkipBundleValidationFailed SkipReason = "bundle_validation_failed"
+)
+
+type SkippedTransaction struct {
+	ID     string     `json:"id"`
+	Bundle bool       `json:"bundle"`
+	Reason SkipReason `json:"reason"`
+	Error  string     `json:"error,omitempty"`
+}
+
+// Report describes the result of a transaction selection for a microblock.
+type Report struct {
+	Time                time.Time            `json:"time"`
+	Candidates          int                  `json:"candidates"`
+	Included            int                  `json:"included"`
+	IncludedBundles     int                  `json:"included_bundles"`
+	Skipped             map[SkipReason]int   `json:"skipped"`
+	SkippedTransactions []SkippedTransaction `json:"skipped_transactions"`
+
+	scheme proto.Scheme
+}
+
+func newReport(scheme proto.Scheme) *Report {
+	return &Report{
+		Time:                time.Now(),
+		Skipped:             make(map[SkipReason]int),
+		SkippedTransactions: make([]SkippedTransaction, 0),
+		scheme:              scheme,
+	}
+}
+
+// Include registers candidate transactions as included into a microblock.
+func (r *Report) Include(c Candidate) {
+	r.Candidates += len(c.Transactions)
+	r.Included += len(c.Transactions)
+	if c.Bundle {
+		r.IncludedBundles++
+	}
+}
+
+// Exclude moves previously included candidate transactions to skipped ones with the reason and an optional error.
+func (r *Report) Exclude(c Candidate, reason SkipReason, err error) {
+	r.Candidates -= len(c.Transactions)
+	r.Included -= len(c.Transactions)
+	if c.Bundle {
+		r.IncludedBundles--
+	}
+	r.Skip(c, reason, err)
+}
+
+// Skip registers candidate transactions as skipped with the reason and an optional error.
+func (r *Report) Skip(c Candidate, reason SkipReason, err error) {
+	r.Candidates += len(c.Transactions)
+	r.Skipped[reason] += len(c.Transactions)
+	for _, t := range c.Transactions {
+		if len(r.SkippedTransactions) >= maxReportedSkips {
+			return
+		}
+		st := SkippedTransaction{Bundle: c.Bundle, Reason: reason}
+		if id, idErr := t.T.GetID(r.scheme); idErr == nil {
+			st.ID = base58.Encode(id)
+		}
+		if err != nil {
+			st.Error = err.Error()
+		}
+		r.SkippedTransactions = append(r.SkippedTransactions, st)
+	}
+}
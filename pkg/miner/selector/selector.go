@@ -0,0 +1,273 @@
+package selector
+
+import (
+	"math"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride"
+	"github.com/wavesplatform/gowaves/pkg/state"
+	"github.com/wavesplatform/gowaves/pkg/types"
+	"go.uber.org/zap"
+)
+
+const feeUnit = 100000
+
+// Candidate is a single transaction or a bundle of transactions that can be included into a microblock.
+type Candidate struct {
+	Transactions types.TransactionsBundle
+	// Bundle is true if the transactions must be included all together in the given order.
+	Bundle bool
+	// Complexity is the estimated complexity of callables invoked by the transactions.
+	Complexity int
+	priority   float64
+}
+
+// Size returns the size of candidate transactions in bytes.
+func (c Candidate) Size() int {
+	return c.Transactions.Size()
+}
+
+// TransactionSelector chooses transactions from UTX pool to be packed into a microblock.
+type TransactionSelector interface {
+	// Candidates takes transactions and bundles out of UTX pool and returns them ordered by inclusion priority.
+	Candidates() []Candidate
+	// Check returns a non-empty SkipReason if the candidate is not allowed by the selection policy.
+	Check(c Candidate) SkipReason
+	// Return puts a candidate that was not included back to UTX pool.
+	Return(c Candidate)
+	// NewReport creates an empty report of selection.
+	NewReport() *Report
+	// SetLastReport stores the report of the last selection.
+	SetLastReport(r *Report)
+	// LastReport returns the report of the last selection or nil if there was no selection yet.
+	LastReport() *Report
+}
+
+type Selector struct {
+	state  state.StateInfo
+	utx    types.UtxPool
+	scheme proto.Scheme
+	policy Policy
+	rules  rules
+
+	mu   sync.Mutex
+	last *Report
+}
+
+func NewSelector(state state.StateInfo, utx types.UtxPool, scheme proto.Scheme, policy Policy) *Selector {
+	return &Selector{
+		state:  state,
+		utx:    utx,
+		scheme: scheme,
+		policy: policy,
+		rules:  newRules(policy),
+	}
+}
+
+// Candidates takes no more than CandidatesLimit transactions including transactions of bundles.
+// A bundle that doesn't fit into the rest of the limit is left in UTX pool until the next selection,
+// a bundle larger than the limit is dropped, because it can never be selected.
+func (s *Selector) Candidates() []Candidate {
+	pc := newPriorityCalculator(s.state, s.policy)
+	var candidates []Candidate
+	remaining := s.policy.CandidatesLimit
+	for remaining > 0 {
+		bundle := s.utx.PopBundle()
+		if bundle == nil {
+			break
+		}
+		if len(bundle) > s.policy.CandidatesLimit {
+			zap.S().Warnf("Bundle of %d transactions exceeds candidates limit %d and is dropped", len(bundle), s.policy.CandidatesLimit)
+			continue
+		}
+		if len(bundle) > remaining {
+			if err := s.utx.AddBundle(bundle); err != nil {
+				zap.S().Debugf("Failed to return bundle to UTX: %v", err)
+			}
+			break
+		}
+		c := Candidate{Transactions: bundle, Bundle: true}
+		c.Complexity, c.priority = pc.priority(c)
+		candidates = append(candidates, c)
+		remaining -= len(bundle)
+	}
+	for ; remaining > 0; remaining-- {
+		t := s.utx.Pop()
+		if t == nil {
+			break
+		}
+		c := Candidate{Transactions: types.TransactionsBundle{t}}
+		c.Complexity, c.priority = pc.priority(c)
+		candidates = append(candidates, c)
+	}
+	// Stable sort keeps UTX order for candidates with equal priority.
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].priority > candidates[j].priority
+	})
+	return candidates
+}
+
+func (s *Selector) Check(c Candidate) SkipReason {
+	for _, t := range c.Transactions {
+		if reason := s.rules.check(t.T); reason != "" {
+			return reason
+		}
+	}
+	return ""
+}
+
+func (s *Selector) Return(c Candidate) {
+	if c.Bundle {
+		if err := s.utx.AddBundle(c.Transactions); err != nil {
+			zap.S().Debugf("Failed to return bundle to UTX: %v", err)
+		}
+		return
+	}
+	for _, t := range c.Transactions {
+		_ = s.utx.AddWithBytes(t.T, t.B)
+	}
+}
+
+func (s *Selector) NewReport() *Report {
+	return newReport(s.scheme)
+}
+
+func (s *Selector) SetLastReport(r *Report) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.last = r
+}
+
+func (s *Selector) LastReport() *Report {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.last
+}
+
+// priorityCalculator caches state lookups for one selection.
+type priorityCalculator struct {
+	state              state.StateInfo
+	policy             Policy
+	sponsorshipCosts   map[crypto.Digest]uint64
+	estimatorVersion   int
+	estimations        map[proto.WavesAddress]ride.TreeEstimation
+	estimatorLoaded    bool
+	estimatorAvailable bool
+}
+
+func newPriorityCalculator(st state.StateInfo, policy Policy) *priorityCalculator {
+	return &priorityCalculator{
+		state:            st,
+		policy:           policy,
+		sponsorshipCosts: make(map[crypto.Digest]uint64),
+		estimations:      make(map[proto.WavesAddress]ride.TreeEstimation),
+	}
+}
+
+// priority returns the estimated complexity and the inclusion priority of the candidate.
+func (pc *priorityCalculator) priority(c Candidate) (int, float64) {
+	var (
+		fee        float64
+		complexity int
+		weight     = float64(c.Size())
+	)
+	for _, t := range c.Transactions {
+		fee += float64(pc.feeInWaves(t.T))
+		complexity += pc.complexity(t.T)
+	}
+	if pc.policy.Order == OrderFeePerComplexity {
+		weight += pc.policy.ComplexityWeight * float64(complexity)
+	}
+	if weight == 0 {
+		return complexity, 0
+	}
+	return complexity, fee / weight
+}
+
+// feeInWaves converts fee in sponsored asset to Waves using sponsorship rate of the asset.
+func (pc *priorityCalculator) feeInWaves(tx proto.Transaction) uint64 {
+	asset := feeAsset(tx)
+	if !asset.Present {
+		return tx.GetFee()
+	}
+	cost, ok := pc.sponsorshipCosts[asset.ID]
+	if !ok {
+		info, err := pc.state.FullAssetInfo(proto.AssetIDFromDigest(asset.ID))
+		if err != nil {
+			zap.S().Debugf("Failed to get info of fee asset %s: %v", asset.ID.String(), err)
+		} else {
+			cost = info.SponsorshipCost
+		}
+		pc.sponsorshipCosts[asset.ID] = cost
+	}
+	if cost == 0 {
+		return 0
+	}
+	// Fee in sponsored asset multiplied by fee unit may overflow, so it is calculated the same way as in state.
+	var fee, unit, c big.Int
+	fee.SetUint64(tx.GetFee())
+	unit.SetUint64(feeUnit)
+	c.SetUint64(cost)
+	fee.Mul(&fee, &unit)
+	fee.Quo(&fee, &c)
+	if !fee.IsUint64() {
+		return math.MaxUint64
+	}
+	return fee.Uint64()
+}
+
+// complexity returns the estimated complexity of callable function invoked by the transaction.
+func (pc *priorityCalculator) complexity(tx proto.Transaction) int {
+	invoke, ok := tx.(*proto.InvokeScriptWithProofs)
+	if !ok {
+		return 0
+	}
+	if !pc.estimatorLoaded {
+		v, err := pc.state.EstimatorVersion()
+		if err != nil {
+			zap.S().Debugf("Failed to get estimator version, complexity is not taken into account: %v", err)
+		} else {
+			pc.estimatorVersion = v
+			pc.estimatorAvailable = true
+		}
+		pc.estimatorLoaded = true
+	}
+	if !pc.estimatorAvailable {
+		return 0
+	}
+	addr, err := recipientToAddress(pc.state, invoke.ScriptRecipient)
+	if err != nil {
+		zap.S().Debugf("Failed to resolve dApp address: %v", err)
+		return 0
+	}
+	estimation, ok := pc.estimations[addr]
+	if !ok {
+		tree, err := pc.state.NewestScriptByAccount(proto.NewRecipientFromAddress(addr))
+		if err == nil {
+			estimation, err = ride.EstimateTree(tree, pc.estimatorVersion)
+		}
+		if err != nil {
+			zap.S().Debugf("Failed to estimate script of dApp %s: %v", addr.String(), err)
+		}
+		pc.estimations[addr] = estimation
+	}
+	name := invoke.FunctionCall.Name
+	if name == "" {
+		name = "default"
+	}
+	if c, ok := estimation.Functions[name]; ok {
+		return c
+	}
+	return estimation.Estimation
+}
+
+func recipientToAddress(st state.StateInfo, r proto.Recipient) (proto.WavesAddress, error) {
+	if r.Address != nil {
+		return *r.Address, nil
+	}
+	return st.AddrByAlias(*r.Alias)
+}
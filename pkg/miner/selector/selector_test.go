@@ -0,0 +1,144 @@
+package selector
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mr-tron/base58"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/miner/utxpool"
+	"github.com/wavesplatform/gowaves/pkg/mock"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+	"github.com/wavesplatform/gowaves/pkg/types"
+)
+
+func transferWithBytes(t *testing.T, feeAsset proto.OptionalAsset, fee uint64, size int, ts uint64) *types.TransactionWithBytes {
+	tx := proto.NewUnsignedTransferWithProofs(2, crypto.PublicKey{}, proto.NewOptionalAssetWaves(), feeAsset,
+		ts, 1, fee, proto.NewRecipientFromAddress(proto.WavesAddress{}), nil)
+	require.NoError(t, tx.GenerateID(proto.MainNetScheme))
+	return &types.TransactionWithBytes{T: tx, B: bytes.Repeat([]byte{1}, size)}
+}
+
+func TestSelector_Candidates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sponsored, err := crypto.NewDigestFromBase58("Ft8X1v1LTa1ABafufpaCWyVj8KkaxUWE6xBhW6sNFJck")
+	require.NoError(t, err)
+	st := mock.NewMockState(ctrl)
+	st.EXPECT().FullAssetInfo(proto.AssetIDFromDigest(sponsored)).Return(&proto.FullAssetInfo{SponsorshipCost: 10}, nil).Times(1)
+
+	utx := utxpool.New(100000, utxpool.NoOpValidator{}, settings.MainNetSettings, nil)
+	// 1000 per byte
+	cheap := transferWithBytes(t, proto.NewOptionalAssetWaves(), 100000, 100, 1)
+	// 2000 per byte
+	expensive := transferWithBytes(t, proto.NewOptionalAssetWaves(), 400000, 200, 2)
+	// 5 in sponsored asset equals to 50000 in Waves, 500 per byte
+	sponsoredTx := transferWithBytes(t, *proto.NewOptionalAssetFromDigest(sponsored), 5, 100, 3)
+	// 1500 per byte for the whole bundle
+	bundle := types.TransactionsBundle{
+		transferWithBytes(t, proto.NewOptionalAssetWaves(), 100000, 100, 4),
+		transferWithBytes(t, proto.NewOptionalAssetWaves(), 200000, 100, 5),
+	}
+	require.NoError(t, utx.AddWithBytes(cheap.T, cheap.B))
+	require.NoError(t, utx.AddWithBytes(expensive.T, expensive.B))
+	require.NoError(t, utx.AddWithBytes(sponsoredTx.T, sponsoredTx.B))
+	require.NoError(t, utx.AddBundle(bundle))
+
+	s := NewSelector(st, utx, proto.MainNetScheme, DefaultPolicy())
+	candidates := s.Candidates()
+	require.Len(t, candidates, 4)
+	assert.Equal(t, 0, utx.Count())
+
+	assert.Equal(t, expensive, candidates[0].Transactions[0])
+	assert.True(t, candidates[1].Bundle)
+	assert.Equal(t, bundle, candidates[1].Transactions)
+	assert.Equal(t, cheap, candidates[2].Transactions[0])
+	assert.Equal(t, sponsoredTx, candidates[3].Transactions[0])
+
+	for _, c := range candidates {
+		assert.Equal(t, SkipReason(""), s.Check(c))
+		s.Return(c)
+	}
+	assert.Equal(t, 5, utx.Count())
+	assert.Len(t, utx.PopBundle(), 2)
+}
+
+func TestSelector_CandidatesLimit(t *testing.T) {
+	utx := utxpool.New(100000, utxpool.NoOpValidator{}, settings.MainNetSettings, nil)
+	bundle := func(timestamps ...uint64) types.TransactionsBundle {
+		r := make(types.TransactionsBundle, len(timestamps))
+		for i, ts := range timestamps {
+			r[i] = transferWithBytes(t, proto.NewOptionalAssetWaves(), 100000, 100, ts)
+		}
+		return r
+	}
+	small, large, huge := bundle(1, 2), bundle(3, 4), bundle(5, 6, 7, 8, 9)
+	require.NoError(t, utx.AddBundle(huge))
+	require.NoError(t, utx.AddBundle(small))
+	require.NoError(t, utx.AddBundle(large))
+	single := transferWithBytes(t, proto.NewOptionalAssetWaves(), 100000, 100, 10)
+	require.NoError(t, utx.AddWithBytes(single.T, single.B))
+
+	p := DefaultPolicy()
+	p.CandidatesLimit = 3
+	s := NewSelector(nil, utx, proto.MainNetScheme, p)
+	candidates := s.Candidates()
+	// The huge bundle is dropped, the large bundle doesn't fit and is left in UTX together with the single transaction.
+	require.Len(t, candidates, 2)
+	assert.Equal(t, small, candidates[0].Transactions)
+	assert.Equal(t, single, candidates[1].Transactions[0])
+	assert.Equal(t, 2, utx.Count())
+	assert.Equal(t, large, utx.PopBundle())
+}
+
+func TestPriorityCalculator_SponsoredFeeOverflow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sponsored, err := crypto.NewDigestFromBase58("Ft8X1v1LTa1ABafufpaCWyVj8KkaxUWE6xBhW6sNFJck")
+	require.NoError(t, err)
+	st := mock.NewMockState(ctrl)
+	st.EXPECT().FullAssetInfo(proto.AssetIDFromDigest(sponsored)).Return(&proto.FullAssetInfo{SponsorshipCost: 200000}, nil).Times(1)
+
+	pc := newPriorityCalculator(st, DefaultPolicy())
+	tx := transferWithBytes(t, *proto.NewOptionalAssetFromDigest(sponsored), math.MaxUint64/2, 100, 1)
+	assert.Equal(t, uint64(math.MaxUint64/4), pc.feeInWaves(tx.T))
+	tx = transferWithBytes(t, *proto.NewOptionalAssetFromDigest(sponsored), math.MaxUint64, 100, 2)
+	assert.Equal(t, uint64(math.MaxUint64/2), pc.feeInWaves(tx.T))
+}
+
+func TestReport(t *testing.T) {
+	s := NewSelector(nil, nil, proto.MainNetScheme, DefaultPolicy())
+	require.Nil(t, s.LastReport())
+
+	r := s.NewReport()
+	tx := transferWithBytes(t, proto.NewOptionalAssetWaves(), 100000, 100, 1)
+	r.Include(Candidate{Transactions: types.TransactionsBundle{tx, tx}, Bundle: true})
+	r.Include(Candidate{Transactions: types.TransactionsBundle{tx}})
+	r.Skip(Candidate{Transactions: types.TransactionsBundle{tx}}, SkipSizeLimit, nil)
+	s.SetLastReport(r)
+
+	last := s.LastReport()
+	require.NotNil(t, last)
+	assert.Equal(t, 4, last.Candidates)
+	assert.Equal(t, 3, last.Included)
+	assert.Equal(t, 1, last.IncludedBundles)
+	assert.Equal(t, map[SkipReason]int{SkipSizeLimit: 1}, last.Skipped)
+	require.Len(t, last.SkippedTransactions, 1)
+	id, err := tx.T.GetID(proto.MainNetScheme)
+	require.NoError(t, err)
+	assert.Equal(t, SkippedTransaction{ID: base58.Encode(id), Reason: SkipSizeLimit}, last.SkippedTransactions[0])
+
+	r.Exclude(Candidate{Transactions: types.TransactionsBundle{tx, tx}, Bundle: true}, SkipValidationFailed, nil)
+	assert.Equal(t, 4, r.Candidates)
+	assert.Equal(t, 1, r.Included)
+	assert.Equal(t, 0, r.IncludedBundles)
+	assert.Equal(t, map[SkipReason]int{SkipSizeLimit: 1, SkipValidationFailed: 2}, r.Skipped)
+	assert.Len(t, r.SkippedTransactions, 3)
+}
@@ -0,0 +1,68 @@
+package utxpool
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const utxMetricsNamespace = "utx"
+
+const (
+	rejectReasonEmptyBytes   = "empty_bytes"
+	rejectReasonSizeOverflow = "size_overflow"
+	rejectReasonInvalidID    = "invalid_id"
+	rejectReasonDuplicate    = "duplicate"
+	rejectReasonValidation   = "validation"
+)
+
+var (
+	metricUtxTransactions = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: utxMetricsNamespace,
+			Name:      "transactions",
+			Help:      "Number of transactions in UTX pool.",
+		},
+	)
+
+	metricUtxSizeBytes = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: utxMetricsNamespace,
+			Name:      "size_bytes",
+			Help:      "Total size of transactions in UTX pool in bytes.",
+		},
+	)
+
+	metricUtxAdded = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: utxMetricsNamespace,
+			Name:      "transactions_added_total",
+			Help:      "Number of transactions added to UTX pool.",
+		},
+	)
+
+	metricUtxRejected = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: utxMetricsNamespace,
+			Name:      "transactions_rejected_total",
+			Help:      "Number of transactions rejected by UTX pool by rejection reason.",
+		},
+		[]string{"reason"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		metricUtxTransactions,
+		metricUtxSizeBytes,
+		metricUtxAdded,
+		metricUtxRejected,
+	)
+}
+
+func reportRejected(reason string) {
+	metricUtxRejected.WithLabelValues(reason).Inc()
+}
+
+func reportSize(count int, size uint64) {
+	metricUtxTransactions.Set(float64(count))
+	metricUtxSizeBytes.Set(float64(size))
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/mr-tron/base58"
 	"github.com/pkg/errors"
 	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/node/events"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/settings"
 	"github.com/wavesplatform/gowaves/pkg/types"
@@ -42,19 +43,25 @@ func (a *transactionsHeap) Pop() interface{} {
 type UtxImpl struct {
 	mu             sync.Mutex
 	transactions   transactionsHeap
+	bundles        []types.TransactionsBundle // bundles are kept in order of addition
+	bundledCount   int                        // number of transactions in bundles
 	transactionIds map[crypto.Digest]struct{}
 	sizeLimit      uint64 // max transaction size in bytes
 	curSize        uint64
 	validator      Validator
 	settings       *settings.BlockchainSettings
+	events         *events.Bus
 }
 
-func New(sizeLimit uint64, validator Validator, settings *settings.BlockchainSettings) *UtxImpl {
+// New creates UTX pool that publishes events about added and removed transactions on the bus.
+// Bus can be nil.
+func New(sizeLimit uint64, validator Validator, settings *settings.BlockchainSettings, bus *events.Bus) *UtxImpl {
 	return &UtxImpl{
 		transactionIds: make(map[crypto.Digest]struct{}),
 		sizeLimit:      sizeLimit,
 		validator:      validator,
 		settings:       settings,
+		events:         bus,
 	}
 }
 
@@ -62,8 +69,11 @@ func (a *UtxImpl) AllTransactions() []*types.TransactionWithBytes {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
-	res := make([]*types.TransactionWithBytes, len(a.transactions))
+	res := make([]*types.TransactionWithBytes, len(a.transactions), len(a.transactions)+a.bundledCount)
 	copy(res, a.transactions)
+	for _, bundle := range a.bundles {
+		res = append(res, bundle...)
+	}
 	return res
 }
 
@@ -99,24 +109,30 @@ func (a *UtxImpl) AddWithBytes(t proto.Transaction, b []byte) error {
 
 func (a *UtxImpl) addWithBytes(t proto.Transaction, b []byte) error {
 	if len(b) == 0 {
+		reportRejected(rejectReasonEmptyBytes)
 		return errors.New("transaction with empty bytes")
 	}
 	// exceed limit
 	if a.curSize+uint64(len(b)) > a.sizeLimit {
+		reportRejected(rejectReasonSizeOverflow)
 		return errors.Errorf("size overflow, curSize: %d, limit: %d", a.curSize, a.sizeLimit)
 	}
 	if err := t.GenerateID(a.settings.AddressSchemeCharacter); err != nil {
+		reportRejected(rejectReasonInvalidID)
 		return errors.Errorf("failed to generate ID: %v", err)
 	}
 	tID, err := t.GetID(a.settings.AddressSchemeCharacter)
 	if err != nil {
+		reportRejected(rejectReasonInvalidID)
 		return err
 	}
 	if a.exists(t) {
+		reportRejected(rejectReasonDuplicate)
 		return proto.NewInfoMsg(errors.Errorf("transaction with id %s exists", base58.Encode(tID)))
 	}
 	err = a.validator.Validate(t)
 	if err != nil {
+		reportRejected(rejectReasonValidation)
 		return err
 	}
 	tb := &types.TransactionWithBytes{
@@ -127,13 +143,107 @@ func (a *UtxImpl) addWithBytes(t proto.Transaction, b []byte) error {
 	id := makeDigest(t.GetID(a.settings.AddressSchemeCharacter))
 	a.transactionIds[id] = struct{}{}
 	a.curSize += uint64(len(b))
+	metricUtxAdded.Inc()
+	reportSize(a.count(), a.curSize)
+	a.events.Publish(events.TransactionAdded{ID: id, Transaction: t})
+	return nil
+}
+
+// AddBundle adds transactions that must be mined all together in the given order.
+// Bundle transactions are validated one after another, so each transaction may depend on the previous ones.
+func (a *UtxImpl) AddBundle(bundle types.TransactionsBundle) error {
+	if len(bundle) == 0 {
+		return errors.New("empty bundle")
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	size := uint64(bundle.Size())
+	if a.curSize+size > a.sizeLimit {
+		reportRejected(rejectReasonSizeOverflow)
+		return errors.Errorf("size overflow, curSize: %d, limit: %d", a.curSize, a.sizeLimit)
+	}
+	ids := make(map[crypto.Digest]struct{}, len(bundle))
+	txs := make([]proto.Transaction, len(bundle))
+	for i, tb := range bundle {
+		if len(tb.B) == 0 {
+			reportRejected(rejectReasonEmptyBytes)
+			return errors.Errorf("bundle transaction #%d with empty bytes", i)
+		}
+		if err := tb.T.GenerateID(a.settings.AddressSchemeCharacter); err != nil {
+			reportRejected(rejectReasonInvalidID)
+			return errors.Errorf("failed to generate ID of bundle transaction #%d: %v", i, err)
+		}
+		tID, err := tb.T.GetID(a.settings.AddressSchemeCharacter)
+		if err != nil {
+			reportRejected(rejectReasonInvalidID)
+			return err
+		}
+		id := makeDigest(tID, nil)
+		if _, ok := a.transactionIds[id]; ok {
+			reportRejected(rejectReasonDuplicate)
+			return proto.NewInfoMsg(errors.Errorf("transaction with id %s exists", base58.Encode(tID)))
+		}
+		if _, ok := ids[id]; ok {
+			reportRejected(rejectReasonDuplicate)
+			return errors.Errorf("transaction with id %s is repeated in bundle", base58.Encode(tID))
+		}
+		ids[id] = struct{}{}
+		txs[i] = tb.T
+	}
+	if err := a.validator.ValidateBundle(txs); err != nil {
+		reportRejected(rejectReasonValidation)
+		return err
+	}
+	for id := range ids {
+		a.transactionIds[id] = struct{}{}
+	}
+	a.bundles = append(a.bundles, bundle)
+	a.bundledCount += len(bundle)
+	a.curSize += size
+	metricUtxAdded.Add(float64(len(bundle)))
+	reportSize(a.count(), a.curSize)
+	for _, tb := range bundle {
+		a.events.Publish(events.TransactionAdded{
+			ID:          makeDigest(tb.T.GetID(a.settings.AddressSchemeCharacter)),
+			Transaction: tb.T,
+		})
+	}
 	return nil
 }
 
+// PopBundle removes the oldest bundle from the pool and returns it, or returns nil if there are no bundles.
+func (a *UtxImpl) PopBundle() types.TransactionsBundle {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.bundles) == 0 {
+		return nil
+	}
+	bundle := a.bundles[0]
+	a.bundles[0] = nil
+	a.bundles = a.bundles[1:]
+	for _, tb := range bundle {
+		id := makeDigest(tb.T.GetID(a.settings.AddressSchemeCharacter))
+		delete(a.transactionIds, id)
+		a.events.Publish(events.TransactionRemoved{ID: id})
+	}
+	size := uint64(bundle.Size())
+	if size > a.curSize {
+		panic(fmt.Sprintf("UtxImpl PopBundle: size of bundle %d > than current size %d", size, a.curSize))
+	}
+	a.curSize -= size
+	a.bundledCount -= len(bundle)
+	reportSize(a.count(), a.curSize)
+	return bundle
+}
+
 func (a *UtxImpl) Count() int {
 	a.mu.Lock()
 	defer a.mu.Unlock()
-	return len(a.transactions)
+	return a.count()
+}
+
+func (a *UtxImpl) count() int {
+	return len(a.transactions) + a.bundledCount
 }
 
 func makeDigest(b []byte, _ error) crypto.Digest {
@@ -169,11 +279,14 @@ func (a *UtxImpl) Pop() *types.TransactionWithBytes {
 	defer a.mu.Unlock()
 	if a.transactions.Len() > 0 {
 		tb := heap.Pop(&a.transactions).(*types.TransactionWithBytes)
-		delete(a.transactionIds, makeDigest(tb.T.GetID(a.settings.AddressSchemeCharacter)))
+		id := makeDigest(tb.T.GetID(a.settings.AddressSchemeCharacter))
+		delete(a.transactionIds, id)
+		a.events.Publish(events.TransactionRemoved{ID: id})
 		if uint64(len(tb.B)) > a.curSize {
 			panic(fmt.Sprintf("UtxImpl Pop: size of transaction %d > than current size %d", len(tb.B), a.curSize))
 		}
 		a.curSize -= uint64(len(tb.B))
+		reportSize(a.count(), a.curSize)
 		return tb
 	}
 	return nil
@@ -9,8 +9,10 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/wavesplatform/gowaves/pkg/crypto"
 	g "github.com/wavesplatform/gowaves/pkg/grpc/generated/waves"
+	"github.com/wavesplatform/gowaves/pkg/node/events"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/settings"
+	"github.com/wavesplatform/gowaves/pkg/types"
 	"github.com/wavesplatform/gowaves/pkg/util/byte_helpers"
 )
 
@@ -112,7 +114,7 @@ func id(b []byte, fee uint64) *transaction {
 }
 
 func TestTransactionPool(t *testing.T) {
-	a := New(10000, NoOpValidator{}, settings.MainNetSettings)
+	a := New(10000, NoOpValidator{}, settings.MainNetSettings, nil)
 
 	require.EqualValues(t, 0, a.CurSize())
 	// add unique by id transactions, then check them sorted
@@ -133,7 +135,7 @@ func TestTransactionPool(t *testing.T) {
 func BenchmarkTransactionPool(b *testing.B) {
 	b.ReportAllocs()
 	rand.Seed(time.Now().Unix())
-	a := New(10000, NoOpValidator{}, settings.MainNetSettings)
+	a := New(10000, NoOpValidator{}, settings.MainNetSettings, nil)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -157,7 +159,7 @@ func BenchmarkTransactionPool(b *testing.B) {
 }
 
 func TestTransactionPool_Exists(t *testing.T) {
-	a := New(10000, NoOpValidator{}, settings.MainNetSettings)
+	a := New(10000, NoOpValidator{}, settings.MainNetSettings, nil)
 
 	require.False(t, a.Exists(id([]byte{1, 2, 3}, 0)))
 
@@ -170,7 +172,7 @@ func TestTransactionPool_Exists(t *testing.T) {
 
 // check transaction not added when limit
 func TestUtxPool_Limit(t *testing.T) {
-	a := New(10, NoOpValidator{}, settings.MainNetSettings)
+	a := New(10, NoOpValidator{}, settings.MainNetSettings, nil)
 	require.Equal(t, 0, a.Len())
 
 	// added
@@ -185,14 +187,71 @@ func TestUtxPool_Limit(t *testing.T) {
 }
 
 func TestUtxImpl_AllTransactions(t *testing.T) {
-	a := New(10, NoOpValidator{}, settings.MainNetSettings)
+	a := New(10, NoOpValidator{}, settings.MainNetSettings, nil)
 	_ = a.AddWithBytes(id([]byte{1, 2, 3}, 10), bytes.Repeat([]byte{1, 2}, 5))
 	require.Len(t, a.AllTransactions(), 1)
 }
 
 func TestUtxImpl_TransactionExists(t *testing.T) {
-	a := New(10000, NoOpValidator{}, settings.MainNetSettings)
+	a := New(10000, NoOpValidator{}, settings.MainNetSettings, nil)
 	require.NoError(t, a.AddWithBytes(byte_helpers.BurnWithSig.Transaction, byte_helpers.BurnWithSig.TransactionBytes))
 	require.True(t, a.ExistsByID(byte_helpers.BurnWithSig.Transaction.ID.Bytes()))
 	require.False(t, a.ExistsByID(byte_helpers.TransferWithSig.Transaction.ID.Bytes()))
 }
+
+func TestUtxImpl_Bundles(t *testing.T) {
+	a := New(10000, NoOpValidator{}, settings.MainNetSettings, nil)
+	require.NoError(t, a.AddWithBytes(id([]byte{1}, 10), []byte{1}))
+
+	// bundle with transaction already in pool is rejected
+	require.Error(t, a.AddBundle(types.TransactionsBundle{
+		{T: id([]byte{2}, 1), B: []byte{1}},
+		{T: id([]byte{1}, 1), B: []byte{1}},
+	}))
+	// bundle with duplicated transactions is rejected
+	require.Error(t, a.AddBundle(types.TransactionsBundle{
+		{T: id([]byte{2}, 1), B: []byte{1}},
+		{T: id([]byte{2}, 1), B: []byte{1}},
+	}))
+	require.False(t, a.Exists(id([]byte{2}, 0)))
+
+	require.NoError(t, a.AddBundle(types.TransactionsBundle{
+		{T: id([]byte{2}, 1), B: []byte{1}},
+		{T: id([]byte{3}, 2), B: []byte{1, 2}},
+	}))
+	require.NoError(t, a.AddBundle(types.TransactionsBundle{
+		{T: id([]byte{4}, 5), B: []byte{1}},
+	}))
+	require.Equal(t, 4, a.Count())
+	require.Equal(t, 1, a.Len())
+	require.EqualValues(t, 5, a.CurSize())
+	require.True(t, a.Exists(id([]byte{3}, 0)))
+	require.Len(t, a.AllTransactions(), 4)
+
+	b := a.PopBundle()
+	require.Len(t, b, 2)
+	require.EqualValues(t, 3, b.Fee())
+	require.Equal(t, 3, b.Size())
+	require.False(t, a.Exists(id([]byte{2}, 0)))
+	require.Len(t, a.PopBundle(), 1)
+	require.Nil(t, a.PopBundle())
+	require.Equal(t, 1, a.Count())
+	require.EqualValues(t, 1, a.CurSize())
+}
+
+func TestTransactionPoolEvents(t *testing.T) {
+	bus := events.NewBus()
+	sub, err := bus.Subscribe("test", 10, events.TransactionAddedKind, events.TransactionRemovedKind)
+	require.NoError(t, err)
+	a := New(10000, NoOpValidator{}, settings.MainNetSettings, bus)
+
+	tx := id([]byte{1, 2, 3}, 8)
+	require.NoError(t, a.AddWithBytes(tx, []byte{1}))
+	require.Error(t, a.AddWithBytes(tx, []byte{1})) // Duplicates are not published
+	require.NotNil(t, a.Pop())
+
+	digest := makeDigest(tx.GetID(proto.MainNetScheme))
+	require.Equal(t, events.TransactionAdded{ID: digest, Transaction: tx}, <-sub.Events())
+	require.Equal(t, events.TransactionRemoved{ID: digest}, <-sub.Events())
+	require.Len(t, sub.Events(), 0)
+}
@@ -26,7 +26,7 @@ func newBulkValidator(state stateWrapper, utx types.UtxPool, tm types.Time) *bul
 }
 
 func (a bulkValidator) Validate() {
-	transactions, err := a.validate()
+	transactions, bundles, err := a.validate()
 	if err != nil {
 		zap.S().Debug(err)
 		return
@@ -34,25 +34,34 @@ func (a bulkValidator) Validate() {
 	for _, t := range transactions {
 		_ = a.utx.AddWithBytes(t.T, t.B)
 	}
+	for _, b := range bundles {
+		_ = a.utx.AddBundle(b)
+	}
 }
 
-func (a bulkValidator) validate() ([]*types.TransactionWithBytes, error) {
+func (a bulkValidator) validate() ([]*types.TransactionWithBytes, []types.TransactionsBundle, error) {
 	if a.utx.Count() == 0 {
-		return nil, nil
+		return nil, nil, nil
 	}
-	var transactions []*types.TransactionWithBytes
+	var (
+		transactions []*types.TransactionWithBytes
+		bundles      []types.TransactionsBundle
+	)
 	currentTimestamp := proto.NewTimestampFromTime(a.tm.Now())
 	lastKnownBlock := a.state.TopBlock()
 
 	_ = a.state.Map(func(s state.NonThreadSafeState) error {
 		defer s.ResetValidationList()
 
+		validate := func(t *types.TransactionWithBytes) error {
+			return s.ValidateNextTx(t.T, currentTimestamp, lastKnownBlock.Timestamp, lastKnownBlock.Version, false)
+		}
 		for {
 			t := a.utx.Pop()
 			if t == nil {
 				break
 			}
-			err := s.ValidateNextTx(t.T, currentTimestamp, lastKnownBlock.Timestamp, lastKnownBlock.Version, false)
+			err := validate(t)
 			if state.IsTxCommitmentError(err) {
 				// This should not happen in practice.
 				// Reset state, return applied transactions to UTX.
@@ -66,10 +75,38 @@ func (a bulkValidator) validate() ([]*types.TransactionWithBytes, error) {
 				transactions = append(transactions, t)
 			}
 		}
+		for {
+			bundle := a.utx.PopBundle()
+			if bundle == nil {
+				break
+			}
+			if err := validateBundle(bundle, validate); err != nil {
+				// Some transactions of the dropped bundle could be already applied to the validation list,
+				// so it has to be rebuilt from the transactions that stay in UTX.
+				s.ResetValidationList()
+				for _, tx := range transactions {
+					_ = validate(tx)
+				}
+				for _, b := range bundles {
+					_ = validateBundle(b, validate)
+				}
+				continue
+			}
+			bundles = append(bundles, bundle)
+		}
 		return nil
 	})
 
-	return transactions, nil
+	return transactions, bundles, nil
+}
+
+func validateBundle(bundle types.TransactionsBundle, validate func(*types.TransactionWithBytes) error) error {
+	for _, t := range bundle {
+		if err := validate(t); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 type noOnBulkValidator struct {
@@ -21,7 +21,7 @@ func TestBulkValidator_Validate(t *testing.T) {
 	m := NewMockstateWrapper(ctrl)
 	m.EXPECT().TopBlock().Return(emptyBlock)
 	m.EXPECT().Map(gomock.Any()).Return(nil)
-	utx := New(10000, NoOpValidator{}, settings.MainNetSettings)
+	utx := New(10000, NoOpValidator{}, settings.MainNetSettings, nil)
 	require.NoError(t, utx.AddWithBytes(byte_helpers.TransferWithSig.Transaction, byte_helpers.TransferWithSig.TransactionBytes))
 
 	validator := newBulkValidator(m, utx, tm(now))
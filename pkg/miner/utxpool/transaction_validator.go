@@ -10,6 +10,8 @@ import (
 
 type Validator interface {
 	Validate(t proto.Transaction) error
+	// ValidateBundle validates transactions one after another, taking into account changes of the previous ones.
+	ValidateBundle(bundle []proto.Transaction) error
 }
 
 type ValidatorImpl struct {
@@ -37,9 +39,30 @@ func (a *ValidatorImpl) Validate(t proto.Transaction) error {
 	})
 }
 
+func (a *ValidatorImpl) ValidateBundle(bundle []proto.Transaction) error {
+	currentTimestamp := proto.NewTimestampFromTime(a.tm.Now())
+	lastKnownBlock := a.state.TopBlock()
+	if currentTimestamp-lastKnownBlock.Timestamp > a.outdateMs {
+		return errors.New("state outdated, bundle not accepted")
+	}
+	return a.state.TxValidation(func(validation state.TxValidation) error {
+		for _, t := range bundle {
+			err := validation.ValidateNextTx(t, currentTimestamp, lastKnownBlock.Timestamp, lastKnownBlock.Version, false)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 type NoOpValidator struct {
 }
 
 func (a NoOpValidator) Validate(t proto.Transaction) error {
 	return nil
 }
+
+func (a NoOpValidator) ValidateBundle(bundle []proto.Transaction) error {
+	return nil
+}
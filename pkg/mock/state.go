@@ -11,6 +11,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	crypto "github.com/wavesplatform/gowaves/pkg/crypto"
 	proto "github.com/wavesplatform/gowaves/pkg/proto"
+	ride "github.com/wavesplatform/gowaves/pkg/ride"
 	ast "github.com/wavesplatform/gowaves/pkg/ride/ast"
 	settings "github.com/wavesplatform/gowaves/pkg/settings"
 	state "github.com/wavesplatform/gowaves/pkg/state"
@@ -148,6 +149,21 @@ func (mr *MockStateInfoMockRecorder) AddrByAlias(alias interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddrByAlias", reflect.TypeOf((*MockStateInfo)(nil).AddrByAlias), alias)
 }
 
+// AliasesByAddr mocks base method.
+func (m *MockStateInfo) AliasesByAddr(addr proto.WavesAddress) ([]proto.AliasInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AliasesByAddr", addr)
+	ret0, _ := ret[0].([]proto.AliasInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AliasesByAddr indicates an expected call of AliasesByAddr.
+func (mr *MockStateInfoMockRecorder) AliasesByAddr(addr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AliasesByAddr", reflect.TypeOf((*MockStateInfo)(nil).AliasesByAddr), addr)
+}
+
 // AllFeatures mocks base method.
 func (m *MockStateInfo) AllFeatures() ([]int16, error) {
 	m.ctrl.T.Helper()
@@ -523,6 +539,36 @@ func (mr *MockStateInfoMockRecorder) IsApprovedAtHeight(featureID, height interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsApprovedAtHeight", reflect.TypeOf((*MockStateInfo)(nil).IsApprovedAtHeight), featureID, height)
 }
 
+// LeasesByAddr mocks base method.
+func (m *MockStateInfo) LeasesByAddr(addr proto.WavesAddress, activeOnly bool) ([]crypto.Digest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LeasesByAddr", addr, activeOnly)
+	ret0, _ := ret[0].([]crypto.Digest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LeasesByAddr indicates an expected call of LeasesByAddr.
+func (mr *MockStateInfoMockRecorder) LeasesByAddr(addr, activeOnly interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeasesByAddr", reflect.TypeOf((*MockStateInfo)(nil).LeasesByAddr), addr, activeOnly)
+}
+
+// LeasingInfo mocks base method.
+func (m *MockStateInfo) LeasingInfo(leaseID crypto.Digest) (*proto.LeaseInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LeasingInfo", leaseID)
+	ret0, _ := ret[0].(*proto.LeaseInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LeasingInfo indicates an expected call of LeasingInfo.
+func (mr *MockStateInfoMockRecorder) LeasingInfo(leaseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeasingInfo", reflect.TypeOf((*MockStateInfo)(nil).LeasingInfo), leaseID)
+}
+
 // MapR mocks base method.
 func (m *MockStateInfo) MapR(arg0 func(state.StateInfo) (interface{}, error)) (interface{}, error) {
 	m.ctrl.T.Helper()
@@ -598,6 +644,21 @@ func (mr *MockStateInfoMockRecorder) NewestScriptBytesByAccount(account interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewestScriptBytesByAccount", reflect.TypeOf((*MockStateInfo)(nil).NewestScriptBytesByAccount), account)
 }
 
+// OrderFillInfo mocks base method.
+func (m *MockStateInfo) OrderFillInfo(orderID crypto.Digest) (*proto.OrderFillInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrderFillInfo", orderID)
+	ret0, _ := ret[0].(*proto.OrderFillInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OrderFillInfo indicates an expected call of OrderFillInfo.
+func (mr *MockStateInfoMockRecorder) OrderFillInfo(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrderFillInfo", reflect.TypeOf((*MockStateInfo)(nil).OrderFillInfo), orderID)
+}
+
 // ProvidesExtendedApi mocks base method.
 func (m *MockStateInfo) ProvidesExtendedApi() (bool, error) {
 	m.ctrl.T.Helper()
@@ -718,6 +779,21 @@ func (mr *MockStateInfoMockRecorder) RetrieveStringEntry(account, key interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveStringEntry", reflect.TypeOf((*MockStateInfo)(nil).RetrieveStringEntry), account, key)
 }
 
+// RewardsInfo mocks base method.
+func (m *MockStateInfo) RewardsInfo(height proto.Height) (*proto.RewardsInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RewardsInfo", height)
+	ret0, _ := ret[0].(*proto.RewardsInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RewardsInfo indicates an expected call of RewardsInfo.
+func (mr *MockStateInfoMockRecorder) RewardsInfo(height interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RewardsInfo", reflect.TypeOf((*MockStateInfo)(nil).RewardsInfo), height)
+}
+
 // ScoreAtHeight mocks base method.
 func (m *MockStateInfo) ScoreAtHeight(height proto.Height) (*big.Int, error) {
 	m.ctrl.T.Helper()
@@ -733,6 +809,21 @@ func (mr *MockStateInfoMockRecorder) ScoreAtHeight(height interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScoreAtHeight", reflect.TypeOf((*MockStateInfo)(nil).ScoreAtHeight), height)
 }
 
+// ScriptComplexitiesByAccount mocks base method.
+func (m *MockStateInfo) ScriptComplexitiesByAccount(account proto.Recipient) (map[int]ride.TreeEstimation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScriptComplexitiesByAccount", account)
+	ret0, _ := ret[0].(map[int]ride.TreeEstimation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScriptComplexitiesByAccount indicates an expected call of ScriptComplexitiesByAccount.
+func (mr *MockStateInfoMockRecorder) ScriptComplexitiesByAccount(account interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScriptComplexitiesByAccount", reflect.TypeOf((*MockStateInfo)(nil).ScriptComplexitiesByAccount), account)
+}
+
 // ScriptInfoByAccount mocks base method.
 func (m *MockStateInfo) ScriptInfoByAccount(account proto.Recipient) (*proto.ScriptInfo, error) {
 	m.ctrl.T.Helper()
@@ -1268,6 +1359,21 @@ func (mr *MockStateMockRecorder) AddrByAlias(alias interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddrByAlias", reflect.TypeOf((*MockState)(nil).AddrByAlias), alias)
 }
 
+// AliasesByAddr mocks base method.
+func (m *MockState) AliasesByAddr(addr proto.WavesAddress) ([]proto.AliasInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AliasesByAddr", addr)
+	ret0, _ := ret[0].([]proto.AliasInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AliasesByAddr indicates an expected call of AliasesByAddr.
+func (mr *MockStateMockRecorder) AliasesByAddr(addr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AliasesByAddr", reflect.TypeOf((*MockState)(nil).AliasesByAddr), addr)
+}
+
 // AllFeatures mocks base method.
 func (m *MockState) AllFeatures() ([]int16, error) {
 	m.ctrl.T.Helper()
@@ -1657,6 +1763,36 @@ func (mr *MockStateMockRecorder) IsApprovedAtHeight(featureID, height interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsApprovedAtHeight", reflect.TypeOf((*MockState)(nil).IsApprovedAtHeight), featureID, height)
 }
 
+// LeasesByAddr mocks base method.
+func (m *MockState) LeasesByAddr(addr proto.WavesAddress, activeOnly bool) ([]crypto.Digest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LeasesByAddr", addr, activeOnly)
+	ret0, _ := ret[0].([]crypto.Digest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LeasesByAddr indicates an expected call of LeasesByAddr.
+func (mr *MockStateMockRecorder) LeasesByAddr(addr, activeOnly interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeasesByAddr", reflect.TypeOf((*MockState)(nil).LeasesByAddr), addr, activeOnly)
+}
+
+// LeasingInfo mocks base method.
+func (m *MockState) LeasingInfo(leaseID crypto.Digest) (*proto.LeaseInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LeasingInfo", leaseID)
+	ret0, _ := ret[0].(*proto.LeaseInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LeasingInfo indicates an expected call of LeasingInfo.
+func (mr *MockStateMockRecorder) LeasingInfo(leaseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeasingInfo", reflect.TypeOf((*MockState)(nil).LeasingInfo), leaseID)
+}
+
 // Map mocks base method.
 func (m *MockState) Map(arg0 func(state.NonThreadSafeState) error) error {
 	m.ctrl.T.Helper()
@@ -1746,6 +1882,21 @@ func (mr *MockStateMockRecorder) NewestScriptBytesByAccount(account interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewestScriptBytesByAccount", reflect.TypeOf((*MockState)(nil).NewestScriptBytesByAccount), account)
 }
 
+// OrderFillInfo mocks base method.
+func (m *MockState) OrderFillInfo(orderID crypto.Digest) (*proto.OrderFillInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrderFillInfo", orderID)
+	ret0, _ := ret[0].(*proto.OrderFillInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OrderFillInfo indicates an expected call of OrderFillInfo.
+func (mr *MockStateMockRecorder) OrderFillInfo(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrderFillInfo", reflect.TypeOf((*MockState)(nil).OrderFillInfo), orderID)
+}
+
 // PersistAddressTransactions mocks base method.
 func (m *MockState) PersistAddressTransactions() error {
 	m.ctrl.T.Helper()
@@ -1892,6 +2043,21 @@ func (mr *MockStateMockRecorder) RetrieveStringEntry(account, key interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveStringEntry", reflect.TypeOf((*MockState)(nil).RetrieveStringEntry), account, key)
 }
 
+// RewardsInfo mocks base method.
+func (m *MockState) RewardsInfo(height proto.Height) (*proto.RewardsInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RewardsInfo", height)
+	ret0, _ := ret[0].(*proto.RewardsInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RewardsInfo indicates an expected call of RewardsInfo.
+func (mr *MockStateMockRecorder) RewardsInfo(height interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RewardsInfo", reflect.TypeOf((*MockState)(nil).RewardsInfo), height)
+}
+
 // RollbackTo mocks base method.
 func (m *MockState) RollbackTo(removalEdge proto.BlockID) error {
 	m.ctrl.T.Helper()
@@ -1935,6 +2101,21 @@ func (mr *MockStateMockRecorder) ScoreAtHeight(height interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScoreAtHeight", reflect.TypeOf((*MockState)(nil).ScoreAtHeight), height)
 }
 
+// ScriptComplexitiesByAccount mocks base method.
+func (m *MockState) ScriptComplexitiesByAccount(account proto.Recipient) (map[int]ride.TreeEstimation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScriptComplexitiesByAccount", account)
+	ret0, _ := ret[0].(map[int]ride.TreeEstimation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScriptComplexitiesByAccount indicates an expected call of ScriptComplexitiesByAccount.
+func (mr *MockStateMockRecorder) ScriptComplexitiesByAccount(account interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScriptComplexitiesByAccount", reflect.TypeOf((*MockState)(nil).ScriptComplexitiesByAccount), account)
+}
+
 // ScriptInfoByAccount mocks base method.
 func (m *MockState) ScriptInfoByAccount(account proto.Recipient) (*proto.ScriptInfo, error) {
 	m.ctrl.T.Helper()
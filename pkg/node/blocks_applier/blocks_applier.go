@@ -4,11 +4,13 @@ import (
 	"math/big"
 
 	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/node/events"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/state"
 )
 
 type innerBlocksApplier struct {
+	events *events.Bus
 }
 
 type innerState interface {
@@ -19,6 +21,7 @@ type innerState interface {
 	AddDeserializedBlocks(blocks []*proto.Block) (*proto.Block, error)
 	BlockByHeight(height proto.Height) (*proto.Block, error)
 	RollbackToHeight(height proto.Height) error
+	RollbackTo(blockID proto.BlockID) error
 }
 
 func (a *innerBlocksApplier) exists(storage innerState, block *proto.Block) (bool, error) {
@@ -84,6 +87,7 @@ func (a *innerBlocksApplier) apply(storage innerState, blocks []*proto.Block) (p
 		if err != nil {
 			return 0, err
 		}
+		a.publishBlocksApplied(currentHeight, blocks)
 		return currentHeight + proto.Height(len(blocks)), nil
 	}
 
@@ -106,6 +110,7 @@ func (a *innerBlocksApplier) apply(storage innerState, blocks []*proto.Block) (p
 	if err != nil {
 		return 0, errors.Wrapf(err, "failed to rollback to height %d", parentHeight)
 	}
+	a.events.Publish(events.Rollback{Height: parentHeight})
 	// applying new blocks
 	_, err = storage.AddDeserializedBlocks(blocks)
 	if err != nil {
@@ -114,8 +119,10 @@ func (a *innerBlocksApplier) apply(storage innerState, blocks []*proto.Block) (p
 		if err2 != nil {
 			return 0, errors.Wrap(err2, "failed rollback deserialized blocks")
 		}
+		a.publishBlocksApplied(parentHeight, rollbackBlocks)
 		return 0, errors.Wrapf(err, "failed add deserialized blocks, first block id %s", firstBlock.BlockID().String())
 	}
+	a.publishBlocksApplied(parentHeight, blocks)
 	return parentHeight + proto.Height(len(blocks)), nil
 }
 
@@ -157,20 +164,49 @@ func (a *innerBlocksApplier) applyMicro(storage innerState, block *proto.Block)
 		// return back saved blocks
 		_, err2 := storage.AddDeserializedBlocks([]*proto.Block{currentBlock})
 		if err2 != nil {
+			// The state is left rolled back to the parent block.
+			a.events.Publish(events.Rollback{Height: parentHeight})
 			return 0, errors.Wrap(err2, "failed rollback block")
 		}
 		return 0, errors.Wrapf(err, "failed apply new block '%s'", block.BlockID().String())
 	}
+	a.events.Publish(events.MicroBlockAppended{
+		Height:    currentHeight,
+		BlockID:   block.BlockID(),
+		Reference: currentBlock.BlockID(),
+	})
 	return currentHeight, nil
 }
 
+// rollbackTo rolls back the state to the block and publishes the rollback.
+func (a *innerBlocksApplier) rollbackTo(storage innerState, blockID proto.BlockID) error {
+	if err := storage.RollbackTo(blockID); err != nil {
+		return err
+	}
+	height, err := storage.Height()
+	if err != nil {
+		return err
+	}
+	a.events.Publish(events.Rollback{Height: height})
+	return nil
+}
+
+// publishBlocksApplied publishes events about blocks applied on top of the given height.
+func (a *innerBlocksApplier) publishBlocksApplied(height proto.Height, blocks []*proto.Block) {
+	for i, b := range blocks {
+		a.events.Publish(events.BlockApplied{Height: height + proto.Height(i+1), BlockID: b.BlockID()})
+	}
+}
+
 type BlocksApplier struct {
 	inner innerBlocksApplier
 }
 
-func NewBlocksApplier() *BlocksApplier {
+// NewBlocksApplier creates BlocksApplier that publishes events about applied blocks and rollbacks on the bus.
+// Bus can be nil.
+func NewBlocksApplier(bus *events.Bus) *BlocksApplier {
 	return &BlocksApplier{
-		inner: innerBlocksApplier{},
+		inner: innerBlocksApplier{events: bus},
 	}
 }
 
@@ -186,6 +222,12 @@ func (a *BlocksApplier) ApplyMicro(state state.State, block *proto.Block) (proto
 	return a.inner.applyMicro(state, block)
 }
 
+// RollbackTo rolls back the state to the block. All rollbacks of the node must be done by the applier,
+// so subscribers of events learn about them.
+func (a *BlocksApplier) RollbackTo(state state.State, blockID proto.BlockID) error {
+	return a.inner.rollbackTo(state, blockID)
+}
+
 func calcMultipleScore(blocks []*proto.Block) (*big.Int, error) {
 	score := big.NewInt(0)
 	for _, block := range blocks {
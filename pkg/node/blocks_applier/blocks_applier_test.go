@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/wavesplatform/gowaves/pkg/crypto"
 	"github.com/wavesplatform/gowaves/pkg/mock"
+	"github.com/wavesplatform/gowaves/pkg/node/events"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 )
 
@@ -102,8 +103,77 @@ func TestApply_InvalidBlockWithRollback(t *testing.T) {
 	// return blocks
 	stateMock.EXPECT().AddDeserializedBlocks([]*proto.Block{block1}).Return(nil, nil)
 
-	ba := innerBlocksApplier{}
-	_, err := ba.apply(stateMock, []*proto.Block{block2})
+	bus := events.NewBus()
+	sub, err := bus.Subscribe("test", 10)
+	require.NoError(t, err)
+	ba := innerBlocksApplier{events: bus}
+	_, err = ba.apply(stateMock, []*proto.Block{block2})
 	require.NotNil(t, err)
 	require.Equal(t, "failed add deserialized blocks, first block id sV8beveiVKCiUn9BGZRgZj7V5tRRWPMRj1V9WWzKWnigtfQyZ2eErVXHi7vyGXj5hPuaxF9sGxowZr5XuD4UAwW: error message", err.Error())
+	// Rollback and return of the previous block are published
+	require.Equal(t, events.Rollback{Height: 1}, <-sub.Events())
+	require.Equal(t, events.BlockApplied{Height: 2, BlockID: block1.BlockID()}, <-sub.Events())
+	require.Len(t, sub.Events(), 0)
+}
+
+func TestRollbackTo(t *testing.T) {
+	block1 := &proto.Block{
+		BlockHeader: proto.BlockHeader{
+			Parent: genesisId,
+			NxtConsensus: proto.NxtConsensus{
+				BaseTarget: 100,
+			},
+			BlockSignature: crypto.MustSignatureFromBase58("5z4Ny16o9ED9PG8z4LDnAmPBaQcmDztAeU3Lbz1YBM6q4971BzN71aLX5hYdxK19fpCPkA4NAPcwjyWWD68SWb1F"),
+		},
+	}
+	mockState, err := NewMockStateManager(genesis, block1)
+	require.NoError(t, err)
+
+	bus := events.NewBus()
+	sub, err := bus.Subscribe("test", 10)
+	require.NoError(t, err)
+	ba := innerBlocksApplier{events: bus}
+	require.NoError(t, ba.rollbackTo(mockState, genesisId))
+	height, err := mockState.Height()
+	require.NoError(t, err)
+	require.EqualValues(t, 1, height)
+	require.Equal(t, events.Rollback{Height: 1}, <-sub.Events())
+	require.Error(t, ba.rollbackTo(mockState, block1.BlockID()))
+	require.Len(t, sub.Events(), 0)
+}
+
+func TestApplyMicro_FailedReturnOfBlock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	block1 := &proto.Block{
+		BlockHeader: proto.BlockHeader{
+			Parent:         genesisId,
+			BlockSignature: crypto.MustSignatureFromBase58("5z4Ny16o9ED9PG8z4LDnAmPBaQcmDztAeU3Lbz1YBM6q4971BzN71aLX5hYdxK19fpCPkA4NAPcwjyWWD68SWb1F"),
+		},
+	}
+	block2 := &proto.Block{
+		BlockHeader: proto.BlockHeader{
+			Parent:         genesisId,
+			BlockSignature: crypto.MustSignatureFromBase58("sV8beveiVKCiUn9BGZRgZj7V5tRRWPMRj1V9WWzKWnigtfQyZ2eErVXHi7vyGXj5hPuaxF9sGxowZr5XuD4UAwW"),
+		},
+	}
+	stateMock := mock.NewMockState(ctrl)
+	stateMock.EXPECT().Block(block2.BlockID()).Return(nil, proto.ErrNotFound)
+	stateMock.EXPECT().Height().Return(proto.Height(2), nil)
+	stateMock.EXPECT().BlockIDToHeight(genesisId).Return(proto.Height(1), nil)
+	stateMock.EXPECT().BlockByHeight(proto.Height(2)).Return(block1, nil)
+	stateMock.EXPECT().RollbackToHeight(proto.Height(1)).Return(nil)
+	stateMock.EXPECT().AddDeserializedBlocks([]*proto.Block{block2}).Return(nil, errors.New("invalid block"))
+	stateMock.EXPECT().AddDeserializedBlocks([]*proto.Block{block1}).Return(nil, errors.New("failed to return block"))
+
+	bus := events.NewBus()
+	sub, err := bus.Subscribe("test", 10)
+	require.NoError(t, err)
+	ba := innerBlocksApplier{events: bus}
+	_, err = ba.applyMicro(stateMock, block2)
+	require.Error(t, err)
+	// The state is left at the parent block
+	require.Equal(t, events.Rollback{Height: 1}, <-sub.Events())
+	require.Len(t, sub.Events(), 0)
 }
@@ -127,8 +127,12 @@ func (a *MockStateManager) RollbackToHeight(height uint64) error {
 	return nil
 }
 
-func (a *MockStateManager) RollbackTo(_ proto.BlockID) error {
-	panic("implement me")
+func (a *MockStateManager) RollbackTo(blockID proto.BlockID) error {
+	height, ok := a.blockIDToHeight[blockID]
+	if !ok {
+		return notFound()
+	}
+	return a.RollbackToHeight(height)
 }
 
 func (a *MockStateManager) ScoreAtHeight(height uint64) (*big.Int, error) {
@@ -0,0 +1,121 @@
+package events
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultBufferSize is the default size of subscriber's buffer.
+const DefaultBufferSize = 1024
+
+// Subscription is the bounded buffered stream of events for one subscriber.
+type Subscription struct {
+	name     string
+	kinds    map[Kind]struct{} // Empty map means all kinds of events.
+	ch       chan Event
+	overflow uint32 // Set to 1 after dropping an event, reset to 0 after successful delivery.
+}
+
+// Name returns the name of subscriber used in metrics.
+func (s *Subscription) Name() string {
+	return s.name
+}
+
+// Events returns the channel of events, it's closed after unsubscription.
+func (s *Subscription) Events() <-chan Event {
+	return s.ch
+}
+
+func (s *Subscription) accepts(k Kind) bool {
+	if len(s.kinds) == 0 {
+		return true
+	}
+	_, ok := s.kinds[k]
+	return ok
+}
+
+// Bus is the in-process bus of typed node events.
+// Publishing never blocks: if subscriber's buffer is full the event is dropped for this subscriber.
+// Methods of nil Bus do nothing, so components can be used without bus.
+type Bus struct {
+	mu   sync.RWMutex
+	subs map[*Subscription]struct{}
+}
+
+func NewBus() *Bus {
+	return &Bus{subs: make(map[*Subscription]struct{})}
+}
+
+// Subscribe creates new subscription with buffer of given size to events of given kinds.
+// Subscription receives all events if no kinds are given.
+func (b *Bus) Subscribe(name string, size int, kinds ...Kind) (*Subscription, error) {
+	if b == nil {
+		return nil, errors.New("no events bus")
+	}
+	if size <= 0 {
+		return nil, errors.Errorf("invalid buffer size %d", size)
+	}
+	s := &Subscription{
+		name:  name,
+		kinds: make(map[Kind]struct{}, len(kinds)),
+		ch:    make(chan Event, size),
+	}
+	for _, k := range kinds {
+		s.kinds[k] = struct{}{}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subs[s] = struct{}{}
+	return s, nil
+}
+
+// Unsubscribe removes the subscription and closes its channel. It's safe to call it more than once.
+func (b *Bus) Unsubscribe(s *Subscription) {
+	if b == nil || s == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.subs[s]; !ok {
+		return
+	}
+	delete(b.subs, s)
+	close(s.ch)
+}
+
+// Publish delivers the event to all subscribers interested in the kind of event.
+func (b *Bus) Publish(e Event) {
+	if b == nil {
+		return
+	}
+	k := e.Kind()
+	metricEventsPublished.WithLabelValues(k.String()).Inc()
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for s := range b.subs {
+		if !s.accepts(k) {
+			continue
+		}
+		select {
+		case s.ch <- e:
+			atomic.StoreUint32(&s.overflow, 0)
+		default:
+			metricEventsDropped.WithLabelValues(s.name, k.String()).Inc()
+			if atomic.SwapUint32(&s.overflow, 1) == 0 {
+				metricSubscriberOverflows.WithLabelValues(s.name).Inc()
+			}
+		}
+	}
+}
+
+// Len returns the number of subscriptions.
+func (b *Bus) Len() int {
+	if b == nil {
+		return 0
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subs)
+}
@@ -0,0 +1,64 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+func TestBus_PublishSubscribe(t *testing.T) {
+	b := NewBus()
+	all, err := b.Subscribe("all", 10)
+	require.NoError(t, err)
+	blocks, err := b.Subscribe("blocks", 10, BlockAppliedKind, RollbackKind)
+	require.NoError(t, err)
+	require.Equal(t, 2, b.Len())
+
+	id := proto.NewBlockIDFromSignature(crypto.MustSignatureFromBase58("5W7hjPpgmmhxevCt4A7y9F8oNJ4V9w2g8jhQgx2qGmBTNsP1p1MpQeKF3cvZULwJ7vQthZfSx2BhL6TWkHSVLzvq"))
+	b.Publish(BlockApplied{Height: 10, BlockID: id})
+	b.Publish(PeerConnected{ID: "peer"})
+	b.Publish(Rollback{Height: 9})
+
+	assert.Equal(t, BlockApplied{Height: 10, BlockID: id}, <-all.Events())
+	assert.Equal(t, PeerConnected{ID: "peer"}, <-all.Events())
+	assert.Equal(t, Rollback{Height: 9}, <-all.Events())
+	assert.Equal(t, BlockApplied{Height: 10, BlockID: id}, <-blocks.Events())
+	assert.Equal(t, Rollback{Height: 9}, <-blocks.Events())
+	assert.Len(t, blocks.Events(), 0)
+
+	b.Unsubscribe(all)
+	b.Unsubscribe(all)
+	_, ok := <-all.Events()
+	assert.False(t, ok)
+	assert.Equal(t, 1, b.Len())
+}
+
+func TestBus_Overflow(t *testing.T) {
+	b := NewBus()
+	_, err := b.Subscribe("invalid", 0)
+	assert.Error(t, err)
+	s, err := b.Subscribe("slow", 2)
+	require.NoError(t, err)
+	for i := 1; i <= 5; i++ {
+		b.Publish(Rollback{Height: proto.Height(i)})
+	}
+	assert.Equal(t, Rollback{Height: 1}, <-s.Events())
+	assert.Equal(t, Rollback{Height: 2}, <-s.Events())
+	assert.Len(t, s.Events(), 0)
+	assert.Equal(t, uint32(1), s.overflow)
+	b.Publish(Rollback{Height: 6})
+	assert.Equal(t, Rollback{Height: 6}, <-s.Events())
+	assert.Equal(t, uint32(0), s.overflow)
+}
+
+func TestBus_Nil(t *testing.T) {
+	var b *Bus
+	b.Publish(Rollback{Height: 1})
+	b.Unsubscribe(nil)
+	assert.Equal(t, 0, b.Len())
+	_, err := b.Subscribe("nil", 1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,104 @@
+package events
+
+import (
+	"fmt"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+// Kind is the kind of node event.
+type Kind byte
+
+const (
+	BlockAppliedKind Kind = iota + 1
+	MicroBlockAppendedKind
+	RollbackKind
+	TransactionAddedKind
+	TransactionRemovedKind
+	PeerConnectedKind
+	PeerDisconnectedKind
+)
+
+func (k Kind) String() string {
+	switch k {
+	case BlockAppliedKind:
+		return "block_applied"
+	case MicroBlockAppendedKind:
+		return "microblock_appended"
+	case RollbackKind:
+		return "rollback"
+	case TransactionAddedKind:
+		return "transaction_added"
+	case TransactionRemovedKind:
+		return "transaction_removed"
+	case PeerConnectedKind:
+		return "peer_connected"
+	case PeerDisconnectedKind:
+		return "peer_disconnected"
+	default:
+		return fmt.Sprintf("unknown(%d)", byte(k))
+	}
+}
+
+// Event is the typed node event published on the Bus.
+type Event interface {
+	Kind() Kind
+}
+
+// BlockApplied is published after the block is applied to the state.
+type BlockApplied struct {
+	Height  proto.Height
+	BlockID proto.BlockID
+}
+
+func (BlockApplied) Kind() Kind { return BlockAppliedKind }
+
+// MicroBlockAppended is published after the microblock is appended to the last block.
+// BlockID is the ID of the resulting block, Reference is the ID of the block it replaced.
+type MicroBlockAppended struct {
+	Height    proto.Height
+	BlockID   proto.BlockID
+	Reference proto.BlockID
+}
+
+func (MicroBlockAppended) Kind() Kind { return MicroBlockAppendedKind }
+
+// Rollback is published after the state is rolled back, Height is the new height of the state.
+type Rollback struct {
+	Height proto.Height
+}
+
+func (Rollback) Kind() Kind { return RollbackKind }
+
+// TransactionAdded is published after the transaction is added to UTX pool.
+type TransactionAdded struct {
+	ID          crypto.Digest
+	Transaction proto.Transaction
+}
+
+func (TransactionAdded) Kind() Kind { return TransactionAddedKind }
+
+// TransactionRemoved is published after the transaction is removed from UTX pool.
+type TransactionRemoved struct {
+	ID crypto.Digest
+}
+
+func (TransactionRemoved) Kind() Kind { return TransactionRemovedKind }
+
+// PeerConnected is published after the connection with peer is established.
+type PeerConnected struct {
+	ID        string
+	Address   proto.TCPAddr
+	Direction string
+}
+
+func (PeerConnected) Kind() Kind { return PeerConnectedKind }
+
+// PeerDisconnected is published after the peer is disconnected.
+type PeerDisconnected struct {
+	ID      string
+	Address proto.TCPAddr
+}
+
+func (PeerDisconnected) Kind() Kind { return PeerDisconnectedKind }
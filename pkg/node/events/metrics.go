@@ -0,0 +1,44 @@
+package events
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const eventsMetricsNamespace = "events"
+
+var (
+	metricEventsPublished = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: eventsMetricsNamespace,
+			Name:      "published_total",
+			Help:      "Number of events published on the bus by event kind.",
+		},
+		[]string{"kind"},
+	)
+
+	metricEventsDropped = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: eventsMetricsNamespace,
+			Name:      "dropped_total",
+			Help:      "Number of events dropped because of subscriber's buffer overflow by subscriber and event kind.",
+		},
+		[]string{"subscriber", "kind"},
+	)
+
+	metricSubscriberOverflows = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: eventsMetricsNamespace,
+			Name:      "subscriber_overflows_total",
+			Help:      "Number of times subscriber's buffer became full, consecutive drops are counted once.",
+		},
+		[]string{"subscriber"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		metricEventsPublished,
+		metricEventsDropped,
+		metricSubscriberOverflows,
+	)
+}
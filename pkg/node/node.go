@@ -180,7 +180,7 @@ func (a *Node) Run(ctx context.Context, p peer.Parent, internalMessageCh <-chan
 		case internalMess := <-internalMessageCh:
 			switch t := internalMess.(type) {
 			case *messages.MinedBlockInternalMessage:
-				fsm, async, err = fsm.MinedBlock(t.Block, t.Limits, t.KeyPair, t.Vrf)
+				fsm, async, err = fsm.MinedBlock(t.Block, t.Limits, t.PublicKey, t.Vrf)
 			case *messages.HaltMessage:
 				fsm, async, err = fsm.Halt()
 				t.Complete()
@@ -0,0 +1,59 @@
+package peer_manager
+
+import (
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
+)
+
+const peersMetricsNamespace = "peers"
+
+const (
+	rejectReasonAlreadyConnected = "already_connected"
+	rejectReasonSuspended        = "suspended"
+	rejectReasonVersion          = "version"
+	rejectReasonNetwork          = "network"
+	rejectReasonLimit            = "limit"
+	rejectReasonDirection        = "direction"
+)
+
+var (
+	metricConnectedPeers = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: peersMetricsNamespace,
+			Name:      "connected",
+			Help:      "Number of connected peers by connection direction.",
+		},
+		[]string{"direction"},
+	)
+
+	metricConnectionsRejected = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: peersMetricsNamespace,
+			Name:      "connections_rejected_total",
+			Help:      "Number of rejected peer connections by rejection reason.",
+		},
+		[]string{"reason"},
+	)
+
+	metricSuspensions = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: peersMetricsNamespace,
+			Name:      "suspensions_total",
+			Help:      "Number of peer suspensions.",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		metricConnectedPeers,
+		metricConnectionsRejected,
+		metricSuspensions,
+	)
+}
+
+func directionLabel(d peer.Direction) string {
+	return strings.ToLower(d.String())
+}
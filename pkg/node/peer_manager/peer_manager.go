@@ -10,6 +10,7 @@ import (
 	"github.com/wavesplatform/gowaves/pkg/node/peer_manager/storage"
 
 	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/node/events"
 	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"go.uber.org/zap"
@@ -67,10 +68,13 @@ type PeerManagerImpl struct {
 	newConnectionsLimit       int
 	version                   proto.Version
 	networkName               string
+	events                    *events.Bus
 }
 
+// NewPeerManager creates PeerManagerImpl that publishes events about connected and disconnected peers on the bus.
+// Bus can be nil.
 func NewPeerManager(spawner PeerSpawner, storage PeerStorage, limitConnections int, version proto.Version,
-	networkName string, enableOutboundConnections bool, newConnectionsLimit int) *PeerManagerImpl {
+	networkName string, enableOutboundConnections bool, newConnectionsLimit int, bus *events.Bus) *PeerManagerImpl {
 
 	return &PeerManagerImpl{
 		spawner:                   spawner,
@@ -82,16 +86,19 @@ func NewPeerManager(spawner PeerSpawner, storage PeerStorage, limitConnections i
 		newConnectionsLimit:       newConnectionsLimit,
 		version:                   version,
 		networkName:               networkName,
+		events:                    bus,
 	}
 }
 
 func (a *PeerManagerImpl) NewConnection(p peer.Peer) error {
 	_, connected := a.connected(p)
 	if connected {
+		metricConnectionsRejected.WithLabelValues(rejectReasonAlreadyConnected).Inc()
 		_ = p.Close()
 		return errors.New("already connected")
 	}
 	if a.suspended(p) {
+		metricConnectionsRejected.WithLabelValues(rejectReasonSuspended).Inc()
 		_ = p.Close()
 		return errors.Errorf("peer '%s' is suspended", p.ID())
 	}
@@ -101,6 +108,7 @@ func (a *PeerManagerImpl) NewConnection(p peer.Peer) error {
 			a.version.String(),
 			p.Handshake().Version.String(),
 		)
+		metricConnectionsRejected.WithLabelValues(rejectReasonVersion).Inc()
 		a.Suspend(p, time.Now(), err.Error())
 		_ = p.Close()
 		return proto.NewInfoMsg(err)
@@ -108,6 +116,7 @@ func (a *PeerManagerImpl) NewConnection(p peer.Peer) error {
 	if p.Handshake().AppName != a.networkName {
 		err := errors.Errorf("peer '%s' has the invalid network name '%s', required '%s'",
 			p.ID(), p.Handshake().AppName, a.networkName)
+		metricConnectionsRejected.WithLabelValues(rejectReasonNetwork).Inc()
 		a.Suspend(p, time.Now(), err.Error())
 		_ = p.Close()
 		return proto.NewInfoMsg(err)
@@ -116,6 +125,7 @@ func (a *PeerManagerImpl) NewConnection(p peer.Peer) error {
 	switch p.Direction() {
 	case peer.Incoming:
 		if in >= a.limitConnections {
+			metricConnectionsRejected.WithLabelValues(rejectReasonLimit).Inc()
 			_ = p.Close()
 			return proto.NewInfoMsg(errors.New("exceed incoming connections limit"))
 		}
@@ -126,10 +136,12 @@ func (a *PeerManagerImpl) NewConnection(p peer.Peer) error {
 			_ = a.peerStorage.AddOrUpdateKnown([]storage.KnownPeer{known}, time.Now())
 		}
 		if out >= a.limitConnections {
+			metricConnectionsRejected.WithLabelValues(rejectReasonLimit).Inc()
 			_ = p.Close()
 			return proto.NewInfoMsg(errors.New("exceed outgoing connections limit"))
 		}
 	default:
+		metricConnectionsRejected.WithLabelValues(rejectReasonDirection).Inc()
 		_ = p.Close()
 		return errors.New("unknown connection direction")
 	}
@@ -167,6 +179,7 @@ func (a *PeerManagerImpl) Suspend(p peer.Peer, suspendTime time.Time, reason str
 	if err := a.peerStorage.AddSuspended([]storage.SuspendedPeer{suspended}); err != nil {
 		zap.S().Errorf("[%s] Failed to suspend peer, reason %q: %v", p.ID(), reason, err)
 	} else {
+		metricSuspensions.Inc()
 		zap.S().Debugf("[%s] Suspend peer, reason: %s ", p.ID(), reason)
 	}
 }
@@ -347,7 +360,12 @@ func (a *PeerManagerImpl) AskPeers() {
 func (a *PeerManagerImpl) Disconnect(p peer.Peer) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
-	a.active.remove(p.ID())
+	id := p.ID()
+	if info, ok := a.active.get(id); ok {
+		metricConnectedPeers.WithLabelValues(directionLabel(info.peer.Direction())).Dec()
+		a.events.Publish(events.PeerDisconnected{ID: id.String(), Address: p.RemoteAddr()})
+	}
+	a.active.remove(id)
 	_ = p.Close()
 }
 
@@ -414,6 +432,14 @@ func (a *PeerManagerImpl) addConnected(peer peer.Peer) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 	delete(a.spawned, peer.RemoteAddr().ToIpPort())
+	if _, ok := a.active.get(peer.ID()); !ok {
+		metricConnectedPeers.WithLabelValues(directionLabel(peer.Direction())).Inc()
+		a.events.Publish(events.PeerConnected{
+			ID:        peer.ID().String(),
+			Address:   peer.RemoteAddr(),
+			Direction: directionLabel(peer.Direction()),
+		})
+	}
 	a.active.add(peer)
 }
 
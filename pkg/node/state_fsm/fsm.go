@@ -3,6 +3,7 @@ package state_fsm
 import (
 	"time"
 
+	"github.com/wavesplatform/gowaves/pkg/crypto"
 	"github.com/wavesplatform/gowaves/pkg/libs/microblock_cache"
 	"github.com/wavesplatform/gowaves/pkg/miner"
 	"github.com/wavesplatform/gowaves/pkg/miner/utxpool"
@@ -24,6 +25,7 @@ type BlocksApplier interface {
 	BlockExists(state storage.State, block *proto.Block) (bool, error)
 	Apply(state storage.State, block []*proto.Block) (proto.Height, error)
 	ApplyMicro(state storage.State, block *proto.Block) (proto.Height, error)
+	RollbackTo(state storage.State, blockID proto.BlockID) error
 }
 
 type BaseInfo struct {
@@ -52,6 +54,7 @@ type BaseInfo struct {
 	types.Scheduler
 
 	microMiner *miner.MicroMiner
+	signer     types.Signer
 
 	MicroBlockCache    services.MicroBlockCache
 	MicroBlockInvCache services.MicroBlockInvCache
@@ -86,7 +89,7 @@ type FSM interface {
 	PeerError(p peer.Peer, e error) (FSM, Async, error)
 	Score(p peer.Peer, score *proto.Score) (FSM, Async, error)
 	Block(p peer.Peer, block *proto.Block) (FSM, Async, error)
-	MinedBlock(block *proto.Block, limits proto.MiningLimits, keyPair proto.KeyPair, vrf []byte) (FSM, Async, error)
+	MinedBlock(block *proto.Block, limits proto.MiningLimits, pk crypto.PublicKey, vrf []byte) (FSM, Async, error)
 
 	// BlockIDs receives signatures that was requested by GetSignatures
 	BlockIDs(peer.Peer, []proto.BlockID) (FSM, Async, error)
@@ -125,6 +128,7 @@ func NewFsm(
 		Scheduler: services.Scheduler,
 
 		microMiner: miner.NewMicroMiner(services),
+		signer:     services.Signer,
 
 		MicroBlockCache:    services.MicroBlockCache,
 		MicroBlockInvCache: microblock_cache.NewMicroblockInvCache(),
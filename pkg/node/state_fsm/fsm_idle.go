@@ -2,6 +2,7 @@ package state_fsm
 
 import (
 	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
 	"github.com/wavesplatform/gowaves/pkg/metrics"
 	"github.com/wavesplatform/gowaves/pkg/node/state_fsm/tasks"
 	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
@@ -47,8 +48,8 @@ func (a *IdleFsm) Halt() (FSM, Async, error) {
 	return HaltTransition(a.baseInfo)
 }
 
-func (a *IdleFsm) MinedBlock(block *proto.Block, limits proto.MiningLimits, keyPair proto.KeyPair, vrf []byte) (FSM, Async, error) {
-	return MinedBlockNgTransition(a.baseInfo, block, limits, keyPair, vrf)
+func (a *IdleFsm) MinedBlock(block *proto.Block, limits proto.MiningLimits, pk crypto.PublicKey, vrf []byte) (FSM, Async, error) {
+	return MinedBlockNgTransition(a.baseInfo, block, limits, pk, vrf)
 }
 
 func (a *IdleFsm) MicroBlock(_ peer.Peer, _ *proto.MicroBlock) (FSM, Async, error) {
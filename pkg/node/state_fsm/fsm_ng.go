@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
 	"github.com/wavesplatform/gowaves/pkg/metrics"
 	"github.com/wavesplatform/gowaves/pkg/miner"
 	. "github.com/wavesplatform/gowaves/pkg/node/state_fsm/tasks"
@@ -42,7 +43,7 @@ func (a *NGFsm) Task(task AsyncTask) (FSM, Async, error) {
 	case MineMicro:
 		zap.S().Debug("[NG] Generating micro-block")
 		t := task.Data.(MineMicroTaskData)
-		return a.mineMicro(t.Block, t.Limits, t.KeyPair, t.Vrf)
+		return a.mineMicro(t.Block, t.Limits, t.PublicKey, t.Vrf)
 	default:
 		return a, nil, a.Errorf(errors.Errorf("unexpected internal task '%d' with data '%+v' received by %s FSM", task.TaskType, task.Data, a.String()))
 	}
@@ -90,7 +91,7 @@ func (a *NGFsm) Score(p peer.Peer, score *proto.Score) (FSM, Async, error) {
 
 func (a *NGFsm) rollbackToStateFromCache(blockFromCache *proto.Block) error {
 	previousBlockID := blockFromCache.Parent
-	err := a.baseInfo.storage.RollbackTo(previousBlockID)
+	err := a.baseInfo.blocksApplier.RollbackTo(a.baseInfo.storage, previousBlockID)
 	if err != nil {
 		return errors.Wrapf(err, "failed to rollback to parent block '%s' of cached block '%s'",
 			previousBlockID.String(), blockFromCache.ID.String())
@@ -143,7 +144,7 @@ func (a *NGFsm) Block(peer peer.Peer, block *proto.Block) (FSM, Async, error) {
 	return NewNGFsm12(a.baseInfo), nil, nil
 }
 
-func (a *NGFsm) MinedBlock(block *proto.Block, limits proto.MiningLimits, keyPair proto.KeyPair, vrf []byte) (FSM, Async, error) {
+func (a *NGFsm) MinedBlock(block *proto.Block, limits proto.MiningLimits, pk crypto.PublicKey, vrf []byte) (FSM, Async, error) {
 	metrics.FSMKeyBlockGenerated("ng", block)
 	err := a.baseInfo.storage.Map(func(state state.NonThreadSafeState) error {
 		var err error
@@ -166,7 +167,7 @@ func (a *NGFsm) MinedBlock(block *proto.Block, limits proto.MiningLimits, keyPai
 	a.baseInfo.actions.SendScore(a.baseInfo.storage)
 	a.baseInfo.CleanUtx()
 
-	return NewNGFsm12(a.baseInfo), Tasks(NewMineMicroTask(1*time.Second, block, limits, keyPair, vrf)), nil
+	return NewNGFsm12(a.baseInfo), Tasks(NewMineMicroTask(1*time.Second, block, limits, pk, vrf)), nil
 }
 
 func (a *NGFsm) BlockIDs(_ peer.Peer, _ []proto.BlockID) (FSM, Async, error) {
@@ -206,10 +207,10 @@ func (a *NGFsm) MicroBlock(p peer.Peer, micro *proto.MicroBlock) (FSM, Async, er
 }
 
 // New microblock generated by miner
-func (a *NGFsm) mineMicro(minedBlock *proto.Block, rest proto.MiningLimits, keyPair proto.KeyPair, vrf []byte) (FSM, Async, error) {
-	block, micro, rest, err := a.baseInfo.microMiner.Micro(minedBlock, rest, keyPair)
+func (a *NGFsm) mineMicro(minedBlock *proto.Block, rest proto.MiningLimits, pk crypto.PublicKey, vrf []byte) (FSM, Async, error) {
+	block, micro, rest, err := a.baseInfo.microMiner.Micro(minedBlock, rest, pk)
 	if err == miner.NoTransactionsErr {
-		return a, Tasks(NewMineMicroTask(5*time.Second, minedBlock, rest, keyPair, vrf)), nil
+		return a, Tasks(NewMineMicroTask(5*time.Second, minedBlock, rest, pk, vrf)), nil
 	}
 	if err == miner.StateChangedErr {
 		return a, nil, a.Errorf(proto.NewInfoMsg(err))
@@ -232,7 +233,7 @@ func (a *NGFsm) mineMicro(minedBlock *proto.Block, rest proto.MiningLimits, keyP
 		micro.SenderPK,
 		block.BlockID(),
 		micro.Reference)
-	err = inv.Sign(keyPair.Secret, a.baseInfo.scheme)
+	err = a.baseInfo.signer.SignMicroBlockInv(pk, inv)
 	if err != nil {
 		return a, nil, a.Errorf(err)
 	}
@@ -252,7 +253,7 @@ func (a *NGFsm) mineMicro(minedBlock *proto.Block, rest proto.MiningLimits, keyP
 		)
 	})
 
-	return a, Tasks(NewMineMicroTask(5*time.Second, block, rest, keyPair, vrf)), nil
+	return a, Tasks(NewMineMicroTask(5*time.Second, block, rest, pk, vrf)), nil
 }
 
 // Check than microblock is appendable and append it
@@ -314,8 +315,8 @@ func (a *NGFsm) Errorf(err error) error {
 	return fsmErrorf(a, err)
 }
 
-func MinedBlockNgTransition(info BaseInfo, block *proto.Block, limits proto.MiningLimits, keyPair proto.KeyPair, vrf []byte) (FSM, Async, error) {
-	return NewNGFsm12(info).MinedBlock(block, limits, keyPair, vrf)
+func MinedBlockNgTransition(info BaseInfo, block *proto.Block, limits proto.MiningLimits, pk crypto.PublicKey, vrf []byte) (FSM, Async, error) {
+	return NewNGFsm12(info).MinedBlock(block, limits, pk, vrf)
 }
 
 type blockStatesCache struct {
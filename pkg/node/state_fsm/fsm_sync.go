@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
 	"github.com/wavesplatform/gowaves/pkg/errs"
 	"github.com/wavesplatform/gowaves/pkg/metrics"
 	"github.com/wavesplatform/gowaves/pkg/node/state_fsm/sync_internal"
@@ -163,7 +164,7 @@ func (a *SyncFsm) Block(p peer.Peer, block *proto.Block) (FSM, Async, error) {
 	return a.applyBlocks(a.baseInfo, a.conf.Now(a.baseInfo.tm), internal)
 }
 
-func (a *SyncFsm) MinedBlock(block *proto.Block, limits proto.MiningLimits, keyPair proto.KeyPair, vrf []byte) (FSM, Async, error) {
+func (a *SyncFsm) MinedBlock(block *proto.Block, limits proto.MiningLimits, pk crypto.PublicKey, vrf []byte) (FSM, Async, error) {
 	metrics.FSMKeyBlockGenerated("sync", block)
 	zap.S().Infof("New key block '%s' mined", block.ID.String())
 	_, err := a.baseInfo.blocksApplier.Apply(a.baseInfo.storage, []*proto.Block{block})
@@ -176,7 +177,7 @@ func (a *SyncFsm) MinedBlock(block *proto.Block, limits proto.MiningLimits, keyP
 	// first we should send block
 	a.baseInfo.actions.SendBlock(block)
 	a.baseInfo.actions.SendScore(a.baseInfo.storage)
-	return a, tasks.Tasks(tasks.NewMineMicroTask(5*time.Second, block, limits, keyPair, vrf)), nil
+	return a, tasks.Tasks(tasks.NewMineMicroTask(5*time.Second, block, limits, pk, vrf)), nil
 }
 
 func (a *SyncFsm) Halt() (FSM, Async, error) {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/wavesplatform/gowaves/pkg/crypto"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"go.uber.org/zap"
 )
@@ -105,10 +106,10 @@ func (PingTask) Run(ctx context.Context, output chan AsyncTask) error {
 }
 
 type MineMicroTaskData struct {
-	Block   *proto.Block
-	Limits  proto.MiningLimits
-	KeyPair proto.KeyPair
-	Vrf     []byte
+	Block     *proto.Block
+	Limits    proto.MiningLimits
+	PublicKey crypto.PublicKey
+	Vrf       []byte
 }
 
 type MineMicroTask struct {
@@ -116,17 +117,17 @@ type MineMicroTask struct {
 	MineMicroTaskData MineMicroTaskData
 }
 
-func NewMineMicroTask(timeout time.Duration, block *proto.Block, limits proto.MiningLimits, keyPair proto.KeyPair, vrf []byte) MineMicroTask {
+func NewMineMicroTask(timeout time.Duration, block *proto.Block, limits proto.MiningLimits, pk crypto.PublicKey, vrf []byte) MineMicroTask {
 	if block == nil {
 		panic("NewMineMicroTask block is nil")
 	}
 	return MineMicroTask{
 		timeout: timeout,
 		MineMicroTaskData: MineMicroTaskData{
-			Block:   block,
-			Limits:  limits,
-			KeyPair: keyPair,
-			Vrf:     vrf,
+			Block:     block,
+			Limits:    limits,
+			PublicKey: pk,
+			Vrf:       vrf,
 		},
 	}
 }
@@ -402,6 +402,15 @@ type Alias struct {
 	Alias   string
 }
 
+// AliasInfo describes the alias of an address.
+type AliasInfo struct {
+	Alias Alias
+	// Height is the height of the block with the transaction that created the alias.
+	Height uint64
+	// Disabled is set if the alias was created more than once and was disabled.
+	Disabled bool
+}
+
 // NewAliasFromString creates an Alias from its string representation. Function does not check that the result is a valid Alias.
 // String representation of an Alias should have a following format: "alias:<scheme>:<alias>". Scheme should be represented with a one-byte ASCII symbol.
 func NewAliasFromString(s string) (*Alias, error) {
@@ -338,6 +338,8 @@ func (b *BlockHeader) UnmarshalHeaderFromBinary(data []byte, scheme Scheme) (err
 			err = errors.New("invalid data size")
 		}
 	}()
+	// Capacity is limited to make slicing beyond the data length panic.
+	data = data[:len(data):len(data)]
 
 	b.Version = BlockVersion(data[0])
 	if b.Version >= ProtobufBlockVersion {
@@ -362,12 +364,10 @@ func (b *BlockHeader) UnmarshalHeaderFromBinary(data []byte, scheme Scheme) (err
 		}
 		b.TransactionCount = int(binary.BigEndian.Uint32(data[121:125]))
 		b.FeaturesCount = int(binary.BigEndian.Uint32(data[125:129]))
-		b.Features = make([]int16, b.FeaturesCount)
-		fb, err := featuresFromBinary(data[129 : 129+2*b.FeaturesCount])
+		b.Features, err = featuresFromBinary(data[129 : 129+2*b.FeaturesCount])
 		if err != nil {
 			return errors.Wrap(err, "failed to convert features from binary representation")
 		}
-		copy(b.Features, fb)
 		if b.Version >= RewardBlockVersion {
 			pos := 129 + 2*b.FeaturesCount
 			b.RewardVote = int64(binary.BigEndian.Uint64(data[pos : pos+8]))
@@ -379,6 +379,9 @@ func (b *BlockHeader) UnmarshalHeaderFromBinary(data []byte, scheme Scheme) (err
 		b.TransactionCount = int(data[121])
 		b.Features = []int16{}
 	}
+	if end := headerBinarySize(b.Version, b.FeaturesCount); len(data) != end {
+		return errors.Errorf("invalid header size %d, expected %d", len(data), end)
+	}
 	copy(b.GenPublicKey[:], data[len(data)-64-32:len(data)-64])
 	copy(b.BlockSignature[:], data[len(data)-64:])
 	if err := b.GenerateBlockID(scheme); err != nil {
@@ -387,6 +390,32 @@ func (b *BlockHeader) UnmarshalHeaderFromBinary(data []byte, scheme Scheme) (err
 	return nil
 }
 
+// headerBinarySize returns the size of the block header in binary format produced by MarshalHeaderToBinary.
+func headerBinarySize(v BlockVersion, featuresCount int) int {
+	size := 121 + crypto.PublicKeySize + crypto.SignatureSize
+	if v >= NgBlockVersion {
+		size += 8 + 2*featuresCount
+		if v >= RewardBlockVersion {
+			size += 8
+		}
+	} else {
+		size++
+	}
+	return size
+}
+
+// blockBinarySize returns the size of the block in binary format.
+func blockBinarySize(v BlockVersion, transactionBlockLength uint32, featuresCount int) int {
+	size := 121 + int(transactionBlockLength) + crypto.PublicKeySize + crypto.SignatureSize
+	if v >= NgBlockVersion {
+		size += 4 + 2*featuresCount
+		if v >= RewardBlockVersion {
+			size += 8
+		}
+	}
+	return size
+}
+
 func AppendHeaderBytesToTransactions(headerBytes, transactions []byte) ([]byte, error) {
 	headerLen := len(headerBytes)
 	if headerLen < 1 {
@@ -497,6 +526,18 @@ func (b *Block) VerifySignature(scheme Scheme) (bool, error) {
 	return crypto.Verify(b.GenPublicKey, b.BlockSignature, bb), nil
 }
 
+// BytesToSign returns the bytes of block signed by the block generator.
+func (b *Block) BytesToSign(scheme Scheme) ([]byte, error) {
+	if b.Version >= ProtobufBlockVersion {
+		return b.MarshalHeaderToProtobufWithoutSignature(scheme)
+	}
+	buf := new(bytes.Buffer)
+	if _, err := b.WriteToWithoutSignature(buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 func (b *Block) VerifyTransactionsRoot(scheme Scheme) (bool, error) {
 	// For old versions of Block always return true
 	if b.Version < ProtobufBlockVersion {
@@ -653,6 +694,8 @@ func (b *Block) UnmarshalBinary(data []byte, scheme Scheme) (err error) {
 			err = errors.New("invalid data size")
 		}
 	}()
+	// Capacity is limited to make slicing beyond the data length panic.
+	data = data[:len(data):len(data)]
 
 	b.Version = BlockVersion(data[0])
 	if b.Version >= ProtobufBlockVersion {
@@ -685,14 +728,13 @@ func (b *Block) UnmarshalBinary(data []byte, scheme Scheme) (err error) {
 		}
 		featuresStart := txEnd + 4
 		b.FeaturesCount = int(binary.BigEndian.Uint32(data[txEnd:featuresStart]))
-		b.Features = make([]int16, b.FeaturesCount)
-		fb, err := featuresFromBinary(data[featuresStart : featuresStart+uint32(2*b.FeaturesCount)])
+		featuresEnd := int(featuresStart) + 2*b.FeaturesCount
+		b.Features, err = featuresFromBinary(data[featuresStart:featuresEnd])
 		if err != nil {
 			return errors.Wrap(err, "failed to convert features from binary representation")
 		}
-		copy(b.Features, fb)
 		if b.Version >= RewardBlockVersion {
-			pos := featuresStart + uint32(2*b.FeaturesCount)
+			pos := featuresEnd
 			b.RewardVote = int64(binary.BigEndian.Uint64(data[pos : pos+8]))
 		}
 	} else {
@@ -707,6 +749,9 @@ func (b *Block) UnmarshalBinary(data []byte, scheme Scheme) (err error) {
 		}
 		b.Features = []int16{}
 	}
+	if end := blockBinarySize(b.Version, b.TransactionBlockLength, b.FeaturesCount); len(data) != end {
+		return errors.Errorf("invalid block size %d, expected %d", len(data), end)
+	}
 
 	copy(b.GenPublicKey[:], data[len(data)-64-32:len(data)-64])
 	copy(b.BlockSignature[:], data[len(data)-64:])
@@ -716,7 +761,7 @@ func (b *Block) UnmarshalBinary(data []byte, scheme Scheme) (err error) {
 	return nil
 }
 
-func (b *Block) transactionsRoot(scheme Scheme) ([]byte, error) {
+func (b *Block) transactionsTree(scheme Scheme) (*crypto.MerkleTree, error) {
 	if b.Version < ProtobufBlockVersion {
 		return nil, errors.Errorf("no transactions root prior block version %d, current version %d", ProtobufBlockVersion, b.Version)
 	}
@@ -731,9 +776,30 @@ func (b *Block) transactionsRoot(scheme Scheme) ([]byte, error) {
 		}
 		tree.Push(b)
 	}
+	return tree, nil
+}
+
+func (b *Block) transactionsRoot(scheme Scheme) ([]byte, error) {
+	tree, err := b.transactionsTree(scheme)
+	if err != nil {
+		return nil, err
+	}
 	return tree.Root().Bytes(), nil
 }
 
+// TransactionProof returns the Merkle proof of inclusion of the transaction at the given index
+// into the transactions root of the block.
+func (b *Block) TransactionProof(scheme Scheme, index int) ([]crypto.Digest, error) {
+	if index < 0 || index >= len(b.Transactions) {
+		return nil, errors.Errorf("transaction index %d is out of range [0, %d)", index, len(b.Transactions))
+	}
+	tree, err := b.transactionsTree(scheme)
+	if err != nil {
+		return nil, err
+	}
+	return tree.Proof(uint64(index))
+}
+
 func CreateBlock(transactions Transactions, timestamp Timestamp, parentID BlockID, publicKey crypto.PublicKey, nxtConsensus NxtConsensus, version BlockVersion, features []int16, rewardVote int64, scheme Scheme) (*Block, error) {
 	consensusLength := nxtConsensus.BinarySize()
 	b := &Block{
@@ -367,6 +367,21 @@ func TestEmptyBlockMarshall(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestTruncatedBlockUnmarshal(t *testing.T) {
+	for i, v := range blockTests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			decoded, err := hex.DecodeString(v.hexEncoded)
+			require.NoError(t, err)
+			// Truncated data keeps the capacity of the original slice.
+			truncated := decoded[:len(decoded)-crypto.SignatureSize]
+			var b Block
+			require.Error(t, b.UnmarshalBinary(truncated, MainNetScheme))
+			var h BlockHeader
+			require.Error(t, h.UnmarshalHeaderFromBinary(truncated, MainNetScheme))
+		})
+	}
+}
+
 func TestBlockVerifyRootHash(t *testing.T) {
 	// Waves
 	waves := NewOptionalAssetWaves()
@@ -432,6 +447,19 @@ func TestBlockVerifyRootHash(t *testing.T) {
 	require.NoError(t, err)
 	assert.True(t, ok)
 
+	for i, tx := range txs2 {
+		proofs, err := block.TransactionProof(TestNetScheme, i)
+		require.NoError(t, err)
+		mb, err := tx.MerkleBytes(TestNetScheme)
+		require.NoError(t, err)
+		tree, err := crypto.NewMerkleTree()
+		require.NoError(t, err)
+		root := tree.RebuildRoot(crypto.MustFastHash(mb), proofs, uint64(i))
+		assert.Equal(t, []byte(block.TransactionsRoot), root.Bytes())
+	}
+	_, err = block.TransactionProof(TestNetScheme, len(txs2))
+	assert.Error(t, err)
+
 	block.Transactions = txs1
 	ok, err = block.VerifySignature(TestNetScheme)
 	require.NoError(t, err)
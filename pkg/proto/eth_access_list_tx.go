@@ -203,8 +203,7 @@ func (tx *EthereumAccessListTx) marshalToFastRLP(arena *fastrlp.Arena) *fastrlp.
 }
 
 func (tx *EthereumAccessListTx) DecodeRLP(rlpData []byte) error {
-	parser := fastrlp.Parser{}
-	rlpVal, err := parser.Parse(rlpData)
+	rlpVal, err := parseFastRLP(rlpData)
 	if err != nil {
 		return err
 	}
@@ -123,8 +123,7 @@ func (tx *EthereumDynamicFeeTx) marshalToFastRLP(arena *fastrlp.Arena) *fastrlp.
 }
 
 func (tx *EthereumDynamicFeeTx) DecodeRLP(rlpData []byte) error {
-	parser := fastrlp.Parser{}
-	rlpVal, err := parser.Parse(rlpData)
+	rlpVal, err := parseFastRLP(rlpData)
 	if err != nil {
 		return err
 	}
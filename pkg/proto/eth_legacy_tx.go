@@ -100,8 +100,7 @@ func (tx *EthereumLegacyTx) marshalToFastRLP(arena *fastrlp.Arena) *fastrlp.Valu
 }
 
 func (tx *EthereumLegacyTx) DecodeRLP(rlpData []byte) error {
-	parser := fastrlp.Parser{}
-	rlpVal, err := parser.Parse(rlpData)
+	rlpVal, err := parseFastRLP(rlpData)
 	if err != nil {
 		return err
 	}
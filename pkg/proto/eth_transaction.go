@@ -524,8 +524,7 @@ func (tx *EthereumTransaction) DecodeCanonical(canonicalData []byte) error {
 	// check according to the EIP2718
 	if len(canonicalData) > 0 && canonicalData[0] > 0x7f {
 		// It's a legacy transaction.
-		parser := fastrlp.Parser{}
-		value, err := parser.Parse(canonicalData)
+		value, err := parseFastRLP(canonicalData)
 		if err != nil {
 			return errors.Wrap(err, "failed to parse canonical representation as RLP")
 		}
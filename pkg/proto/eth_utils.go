@@ -29,6 +29,18 @@ func EthereumWeiToWavelet(weiAmount *big.Int) (int64, error) {
 	return wavelets.Int64(), nil
 }
 
+// parseFastRLP parses RLP encoded data. The parser panics on some malformed length prefixes,
+// such panics are returned as errors.
+func parseFastRLP(data []byte) (v *fastrlp.Value, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("failed to parse RLP: %v", r)
+		}
+	}()
+	parser := fastrlp.Parser{}
+	return parser.Parse(data)
+}
+
 func unmarshalTransactionToFieldFastRLP(value *fastrlp.Value) (*EthereumAddress, error) {
 	toBytes, err := value.Bytes()
 	if err != nil {
@@ -0,0 +1,332 @@
+package proto
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	g "github.com/wavesplatform/gowaves/pkg/grpc/generated/waves"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// fuzzSeedBlocks is the number of mainnet blocks from state testdata used to seed the corpora.
+const fuzzSeedBlocks = 300
+
+var fuzzSeedEthereumTransactions = []string{
+	legacyTxTransfer,
+	"0x01f8630103018261a894b94f5374fce5edbc8e2a8697c15331677e6ebf0b0a825544c001a0c9519f4f2b30335884581971573fadf60c6204f59a911df35ee8a540456b2660a032f1e8e2c5dd761f9e4f88f41c8310aeaba26a8bfcdacfedfa12ec3862d37521",
+	"0x02f86b010284b6ed1ad4856e3c18e22d82520894b69f3f0f21d129d91fc739e0479196bc7f40707e8080c001a02e9ef96d454f7be05ea62c0eb0fac824b6e6161b748c3331c13d988912359ef4a04981e8f8de5be878fa908f8ab128f630caec9eacfa30a2aa06a6be91a0e7db8c",
+}
+
+// readFuzzSeedBlocks reads binary blocks from the file used by state tests.
+func readFuzzSeedBlocks(f *testing.F) [][]byte {
+	fl, err := os.Open(filepath.Join("..", "state", "testdata", "blocks-10000"))
+	require.NoError(f, err)
+	defer func() {
+		require.NoError(f, fl.Close())
+	}()
+	r := bufio.NewReader(fl)
+	sb := make([]byte, 4)
+	res := make([][]byte, 0, fuzzSeedBlocks)
+	for i := 0; i < fuzzSeedBlocks; i++ {
+		_, err := io.ReadFull(r, sb)
+		require.NoError(f, err)
+		b := make([]byte, binary.BigEndian.Uint32(sb))
+		_, err = io.ReadFull(r, b)
+		require.NoError(f, err)
+		res = append(res, b)
+	}
+	return res
+}
+
+func readFuzzSeedTransactions(f *testing.F) []Transaction {
+	var res []Transaction
+	for _, data := range readFuzzSeedBlocks(f) {
+		var b Block
+		require.NoError(f, b.UnmarshalBinary(data, MainNetScheme))
+		res = append(res, b.Transactions...)
+	}
+	return res
+}
+
+// fuzzSeedTransactions returns one transaction of each kind found in testdata blocks
+// and signed transactions of other types, which are absent in the early mainnet blocks.
+func fuzzSeedTransactions(f *testing.F) []Transaction {
+	var res []Transaction
+	kinds := make(map[TransactionTypeInfo]struct{})
+	for _, tx := range readFuzzSeedTransactions(f) {
+		if _, ok := kinds[tx.GetTypeInfo()]; ok {
+			continue
+		}
+		kinds[tx.GetTypeInfo()] = struct{}{}
+		res = append(res, tx)
+	}
+	sk, pk, err := crypto.GenerateKeyPair([]byte("fuzz seed"))
+	require.NoError(f, err)
+	addr, err := NewAddressFromPublicKey(MainNetScheme, pk)
+	require.NoError(f, err)
+	rcp := NewRecipientFromAddress(addr)
+	alias := NewAlias(MainNetScheme, "fuzzseed")
+	asset := crypto.MustFastHash([]byte("asset"))
+	waves := NewOptionalAssetWaves()
+	const ts, fee = 1650000000000, 100000
+	data := NewUnsignedDataWithProofs(2, pk, fee, ts)
+	for _, e := range []DataEntry{
+		&IntegerDataEntry{Key: "int", Value: 1},
+		&BooleanDataEntry{Key: "bool", Value: true},
+		&BinaryDataEntry{Key: "bin", Value: []byte{1, 2, 3}},
+		&StringDataEntry{Key: "str", Value: "value"},
+	} {
+		require.NoError(f, data.AppendEntry(e))
+	}
+	args := Arguments{}
+	args.Append(&IntegerArgument{Value: 1})
+	args.Append(&StringArgument{Value: "arg"})
+	call := FunctionCall{Name: "call", Arguments: args}
+	payments := ScriptPayments{{Amount: 1, Asset: *NewOptionalAssetFromDigest(asset)}}
+	script := []byte{0x00, 0x01, 0x06, 0xb7, 0x12, 0x12, 0x58}
+	signed := []interface {
+		Transaction
+		Sign(Scheme, crypto.SecretKey) error
+	}{
+		NewUnsignedIssueWithSig(pk, "asset", "description", 1000, 2, true, ts, fee),
+		NewUnsignedTransferWithSig(pk, waves, waves, ts, 1, fee, rcp, Attachment("attachment")),
+		NewUnsignedLeaseWithSig(pk, NewRecipientFromAlias(*alias), 1, fee, ts),
+		NewUnsignedCreateAliasWithSig(pk, *alias, fee, ts),
+		NewUnsignedIssueWithProofs(2, MainNetScheme, pk, "asset", "description", 1000, 2, false, script, ts, fee),
+		NewUnsignedTransferWithProofs(2, pk, *NewOptionalAssetFromDigest(asset), waves, ts, 1, fee, rcp, nil),
+		NewUnsignedReissueWithProofs(2, MainNetScheme, pk, asset, 1, true, ts, fee),
+		NewUnsignedBurnWithProofs(2, MainNetScheme, pk, asset, 1, ts, fee),
+		NewUnsignedLeaseCancelWithProofs(2, MainNetScheme, pk, asset, fee, ts),
+		NewUnsignedMassTransferWithProofs(1, pk, waves, []MassTransferEntry{{Recipient: rcp, Amount: 1}}, fee, ts, nil),
+		data,
+		NewUnsignedSetScriptWithProofs(1, MainNetScheme, pk, script, fee, ts),
+		NewUnsignedSponsorshipWithProofs(1, pk, asset, 1, fee, ts),
+		NewUnsignedSetAssetScriptWithProofs(1, MainNetScheme, pk, asset, script, fee, ts),
+		NewUnsignedInvokeScriptWithProofs(1, MainNetScheme, pk, rcp, call, payments, waves, fee, ts),
+		NewUnsignedUpdateAssetInfoWithProofs(1, MainNetScheme, asset, pk, "asset", "description", ts, waves, fee),
+	}
+	for _, tx := range signed {
+		require.NoError(f, tx.Sign(MainNetScheme, sk))
+		res = append(res, tx)
+	}
+	return res
+}
+
+func FuzzBytesToTransaction(f *testing.F) {
+	for _, tx := range fuzzSeedTransactions(f) {
+		if tx.GetTypeInfo().Type == UpdateAssetInfoTransaction {
+			continue // Has no binary format.
+		}
+		data, err := tx.MarshalBinary()
+		require.NoError(f, err)
+		f.Add(data)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		tx1, err := BytesToTransaction(data, MainNetScheme)
+		if err != nil {
+			return
+		}
+		data1, err := tx1.MarshalBinary()
+		require.NoError(t, err)
+		tx2, err := BytesToTransaction(data1, MainNetScheme)
+		require.NoError(t, err)
+		require.Equal(t, tx1, tx2)
+	})
+}
+
+func FuzzSignedTxFromProtobuf(f *testing.F) {
+	for _, tx := range fuzzSeedTransactions(f) {
+		data, err := tx.MarshalSignedToProtobuf(MainNetScheme)
+		require.NoError(f, err)
+		f.Add(data)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var pb g.SignedTransaction
+		if err := protobuf.Unmarshal(data, &pb); err != nil {
+			return
+		}
+		tx1, err := SignedTxFromProtobuf(data)
+		if err != nil {
+			return
+		}
+		scheme := Scheme(pb.GetWavesTransaction().GetChainId())
+		data1, err := tx1.MarshalSignedToProtobuf(scheme)
+		require.NoError(t, err)
+		tx2, err := SignedTxFromProtobuf(data1)
+		require.NoError(t, err)
+		require.Equal(t, tx1, tx2)
+	})
+}
+
+func FuzzBlockUnmarshalBinary(f *testing.F) {
+	for _, data := range readFuzzSeedBlocks(f) {
+		f.Add(data)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var b1 Block
+		if err := b1.UnmarshalBinary(data, MainNetScheme); err != nil {
+			return
+		}
+		data1, err := b1.MarshalBinary()
+		require.NoError(t, err)
+		var b2 Block
+		require.NoError(t, b2.UnmarshalBinary(data1, MainNetScheme))
+		require.Equal(t, b1, b2)
+	})
+}
+
+func FuzzBlockUnmarshalFromProtobuf(f *testing.F) {
+	for _, data := range readFuzzSeedBlocks(f) {
+		var b Block
+		require.NoError(f, b.UnmarshalBinary(data, MainNetScheme))
+		pb, err := b.MarshalToProtobuf(MainNetScheme)
+		require.NoError(f, err)
+		f.Add(pb)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var pb g.Block
+		if err := protobuf.Unmarshal(data, &pb); err != nil {
+			return
+		}
+		var b1 Block
+		if err := b1.UnmarshalFromProtobuf(data); err != nil {
+			return
+		}
+		data1, err := b1.MarshalToProtobuf(Scheme(pb.GetHeader().GetChainId()))
+		require.NoError(t, err)
+		var b2 Block
+		require.NoError(t, b2.UnmarshalFromProtobuf(data1))
+		require.Equal(t, b1, b2)
+	})
+}
+
+// fuzzSeedMicroBlocks makes microblocks of both binary versions with transactions of the testdata blocks.
+func fuzzSeedMicroBlocks(f *testing.F) []MicroBlock {
+	var res []MicroBlock
+	for _, data := range readFuzzSeedBlocks(f) {
+		var b Block
+		require.NoError(f, b.UnmarshalBinary(data, MainNetScheme))
+		if len(b.Transactions) == 0 {
+			continue
+		}
+		for _, v := range []BlockVersion{NgBlockVersion, ProtobufBlockVersion} {
+			mb := MicroBlock{
+				VersionField:          byte(v),
+				Reference:             b.Parent,
+				TotalResBlockSigField: b.BlockSignature,
+				TotalBlockID:          b.ID,
+				TransactionCount:      uint32(len(b.Transactions)),
+				Transactions:          b.Transactions,
+				SenderPK:              b.GenPublicKey,
+				Signature:             b.BlockSignature,
+			}
+			if v >= ProtobufBlockVersion {
+				mb.Reference = NewBlockIDFromDigest(crypto.MustFastHash(data))
+			}
+			res = append(res, mb)
+		}
+	}
+	return res
+}
+
+func FuzzMicroBlockUnmarshalBinary(f *testing.F) {
+	for _, mb := range fuzzSeedMicroBlocks(f) {
+		data, err := mb.MarshalBinary(MainNetScheme)
+		require.NoError(f, err)
+		f.Add(data)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var mb1 MicroBlock
+		if err := mb1.UnmarshalBinary(data, MainNetScheme); err != nil {
+			return
+		}
+		data1, err := mb1.MarshalBinary(MainNetScheme)
+		require.NoError(t, err)
+		var mb2 MicroBlock
+		require.NoError(t, mb2.UnmarshalBinary(data1, MainNetScheme))
+		require.Equal(t, mb1, mb2)
+	})
+}
+
+func FuzzMicroBlockUnmarshalFromProtobuf(f *testing.F) {
+	for _, mb := range fuzzSeedMicroBlocks(f) {
+		data, err := mb.MarshalToProtobuf(MainNetScheme)
+		require.NoError(f, err)
+		f.Add(data)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var mb1 MicroBlock
+		if err := mb1.UnmarshalFromProtobuf(data); err != nil {
+			return
+		}
+		data1, err := mb1.MarshalToProtobuf(MainNetScheme)
+		require.NoError(t, err)
+		var mb2 MicroBlock
+		require.NoError(t, mb2.UnmarshalFromProtobuf(data1))
+		require.Equal(t, mb1, mb2)
+	})
+}
+
+func FuzzUnmarshalMessage(f *testing.F) {
+	blocks := readFuzzSeedBlocks(f)
+	for _, data := range blocks {
+		var b Block
+		require.NoError(f, b.UnmarshalBinary(data, MainNetScheme))
+		messages := []Message{
+			&BlockMessage{BlockBytes: data},
+			&GetBlockMessage{BlockID: b.ID},
+			&GetSignaturesMessage{Signatures: []crypto.Signature{b.BlockSignature}},
+			&SignaturesMessage{Signatures: []crypto.Signature{b.BlockSignature}},
+			&GetBlockIdsMessage{Blocks: []BlockID{b.ID, b.Parent}},
+			&BlockIdsMessage{Blocks: []BlockID{b.ID, b.Parent}},
+		}
+		for _, tx := range b.Transactions {
+			txb, err := tx.MarshalBinary()
+			require.NoError(f, err)
+			messages = append(messages, &TransactionMessage{Transaction: txb})
+		}
+		for _, m := range messages {
+			mb, err := m.MarshalBinary()
+			require.NoError(f, err)
+			f.Add(mb)
+		}
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		m1, err := UnmarshalMessage(data)
+		if err != nil {
+			return
+		}
+		data1, err := m1.MarshalBinary()
+		require.NoError(t, err)
+		m2, err := UnmarshalMessage(data1)
+		require.NoError(t, err)
+		require.Equal(t, m1, m2)
+	})
+}
+
+func FuzzEthereumTransactionDecodeCanonical(f *testing.F) {
+	for _, s := range fuzzSeedEthereumTransactions {
+		data, err := DecodeFromHexString(s)
+		require.NoError(f, err)
+		f.Add(data)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var tx1 EthereumTransaction
+		if err := tx1.DecodeCanonical(data); err != nil {
+			return
+		}
+		data1, err := tx1.EncodeCanonical()
+		require.NoError(t, err)
+		var tx2 EthereumTransaction
+		require.NoError(t, tx2.DecodeCanonical(data1))
+		data2, err := tx2.EncodeCanonical()
+		require.NoError(t, err)
+		require.Equal(t, data1, data2)
+	})
+}
@@ -81,3 +81,25 @@ func (ai *AssetInfo) ToProtobuf() *g.AssetInfoResponse {
 		TotalVolume: int64(ai.Quantity),
 	}
 }
+
+// RewardsInfo describes the block reward and the state of monetary policy voting at some height.
+type RewardsInfo struct {
+	Height              Height
+	TotalWavesAmount    uint64
+	CurrentReward       uint64
+	MinIncrement        uint64
+	Term                uint64
+	NextCheck           Height
+	VotingIntervalStart Height
+	VotingInterval      uint64
+	VotingThreshold     uint64
+	VotesIncrease       uint32
+	VotesDecrease       uint32
+}
+
+// OrderFillInfo describes how the order is filled by exchange transactions.
+type OrderFillInfo struct {
+	FilledAmount uint64
+	FilledFee    uint64
+	Transactions []crypto.Digest
+}
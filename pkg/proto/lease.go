@@ -1,8 +1,18 @@
 package proto
 
+import "github.com/wavesplatform/gowaves/pkg/crypto"
+
 type LeaseInfo struct {
 	IsActive    bool
 	LeaseAmount uint64
 	Recipient   WavesAddress
 	Sender      WavesAddress
+	// Height is the height of the block with the transaction that created the lease.
+	Height uint64
+	// OriginTransactionID is the ID of Lease transaction or Invoke transaction that created the lease.
+	OriginTransactionID *crypto.Digest
+	// CancelHeight and CancelTransactionID are set if the lease was canceled by LeaseCancel transaction
+	// or Invoke transaction.
+	CancelHeight        uint64
+	CancelTransactionID *crypto.Digest
 }
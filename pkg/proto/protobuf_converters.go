@@ -532,7 +532,7 @@ func (c *ProtobufConverter) functionCall(data []byte) FunctionCall {
 	// FIXME: The following block fixes the bug introduced in Scala implementation of gRPC
 	// It should be removed after the release of fix.
 	var d []byte
-	if data[0] == 1 && data[3] == 9 {
+	if len(data) > 3 && data[0] == 1 && data[3] == 9 {
 		d = make([]byte, len(data)-2)
 		d[0] = data[0]
 		copy(d[1:], data[3:])
@@ -1245,6 +1245,8 @@ func (c *ProtobufConverter) signedTransaction(stx *g.SignedTransaction) (Transac
 			return nil, err
 		}
 		return tx, nil
+	case nil:
+		return nil, errors.New("empty signed transaction")
 	default:
 		panic(errors.Errorf(
 			"BUG, CREATE REPORT: unsupported protobuf signed transaction variant type %T.",
@@ -1262,6 +1264,9 @@ func (c *ProtobufConverter) ethereumTransaction(canonicalEthTx []byte) (Transact
 }
 
 func (c *ProtobufConverter) MicroBlock(mb *g.SignedMicroBlock) (MicroBlock, error) {
+	if mb.MicroBlock == nil {
+		return MicroBlock{}, errors.New("empty micro block")
+	}
 	txs, err := c.SignedTransactions(mb.MicroBlock.Transactions)
 	if err != nil {
 		return MicroBlock{}, err
@@ -1340,6 +1345,9 @@ func (c *ProtobufConverter) consensus(header *g.Block_Header) NxtConsensus {
 }
 
 func (c *ProtobufConverter) BlockHeader(block *g.Block) (BlockHeader, error) {
+	if block.Header == nil {
+		return BlockHeader{}, errors.New("empty block header")
+	}
 	features := c.features(block.Header.FeatureVotes)
 	consensus := c.consensus(block.Header)
 	v := BlockVersion(c.byte(block.Header.Version))
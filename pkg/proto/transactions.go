@@ -301,8 +301,15 @@ func BytesToTransaction(tx []byte, scheme Scheme) (Transaction, error) {
 }
 
 func BytesToTransactions(count int, txs []byte, scheme Scheme) ([]Transaction, error) {
+	// Each transaction is prefixed with 4 bytes of its size.
+	if count < 0 || count > len(txs)/4 {
+		return nil, errors.Errorf("invalid transactions count %d for %d bytes", count, len(txs))
+	}
 	res := make([]Transaction, count)
 	for i := 0; i < count; i++ {
+		if len(txs) < 4 {
+			return nil, errors.New("invalid tx size: exceeds bytes slice bounds")
+		}
 		n := int(binary.BigEndian.Uint32(txs[0:4]))
 		if n+4 > len(txs) {
 			return nil, errors.New("invalid tx size: exceeds bytes slice bounds")
@@ -315,6 +322,9 @@ func BytesToTransactions(count int, txs []byte, scheme Scheme) ([]Transaction, e
 		res[i] = tx
 		txs = txs[4+n:]
 	}
+	if len(txs) != 0 {
+		return nil, errors.Errorf("%d unexpected bytes after transactions", len(txs))
+	}
 	return res, nil
 }
 
@@ -1074,6 +1084,9 @@ func (i *Issue) UnmarshalBinary(data []byte) error {
 		return errors.Wrap(err, "failed to unmarshal Description")
 	}
 	data = data[2+len(i.Description):]
+	if l := len(data); l < 8+1+1+8+8 {
+		return errors.Errorf("%d is not enough bytes for the rest of Issue", l)
+	}
 	i.Quantity = binary.BigEndian.Uint64(data)
 	data = data[8:]
 	i.Decimals = data[0]
@@ -1266,6 +1279,9 @@ func (tr *Transfer) UnmarshalBinary(data []byte) error {
 	if tr.FeeAsset.Present {
 		data = data[crypto.DigestSize:]
 	}
+	if l := len(data); l < 8+8+8+2 {
+		return errors.Errorf("%d bytes is not enough for the rest of Transfer body", l)
+	}
 	tr.Timestamp = binary.BigEndian.Uint64(data)
 	data = data[8:]
 	tr.Amount = binary.BigEndian.Uint64(data)
@@ -1574,6 +1590,9 @@ func (l *Lease) UnmarshalBinary(data []byte) error {
 		return errors.Wrap(err, "failed to unmarshal lease from bytes")
 	}
 	data = data[l.Recipient.len:]
+	if n := len(data); n < 8+8+8 {
+		return errors.Errorf("not enough data for the rest of lease, received %d", n)
+	}
 	l.Amount = binary.BigEndian.Uint64(data)
 	data = data[8:]
 	l.Fee = binary.BigEndian.Uint64(data)
@@ -1727,10 +1746,16 @@ func (ca *CreateAlias) UnmarshalBinary(data []byte) error {
 	data = data[crypto.PublicKeySize:]
 	al := binary.BigEndian.Uint16(data)
 	data = data[2:]
+	if l := len(data); l < int(al)+16 {
+		return errors.Errorf("not enough data for CreateAlias, expected %d bytes of alias, fee and timestamp, received %d", int(al)+16, l)
+	}
 	err := ca.Alias.UnmarshalBinary(data[:al])
 	if err != nil {
 		return errors.Wrap(err, "failed to unmarshal CreateAlias from bytes")
 	}
+	if l := aliasFixedSize + len(ca.Alias.Alias); l != int(al) {
+		return errors.Errorf("invalid alias size %d, expected %d", al, l)
+	}
 	data = data[al:]
 	ca.Fee = binary.BigEndian.Uint64(data)
 	data = data[8:]
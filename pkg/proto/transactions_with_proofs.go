@@ -270,6 +270,9 @@ func (tx *IssueWithProofs) bodyUnmarshalBinary(data []byte) error {
 		return errors.Wrapf(err, message, "Description")
 	}
 	data = data[2+len(tx.Description):]
+	if l := len(data); l < 8+1+1+8+8+1 {
+		return errors.Errorf("not enough data for the rest of IssueWithProofs transaction %d", l)
+	}
 	tx.Quantity = binary.BigEndian.Uint64(data)
 	data = data[8:]
 	tx.Decimals = data[0]
@@ -293,6 +296,9 @@ func (tx *IssueWithProofs) bodyUnmarshalBinary(data []byte) error {
 		if err != nil {
 			return errors.Wrapf(err, message, "Script")
 		}
+		if len(s) == 0 {
+			return errors.Errorf(message+": empty script", "Script")
+		}
 		tx.Script = s
 	}
 	return nil
@@ -2634,6 +2640,9 @@ func (e *MassTransferEntry) UnmarshalBinary(data []byte) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to unmarshal MassTransferEntry from bytes")
 	}
+	if l := len(data); l < e.Recipient.len+8 {
+		return errors.Errorf("not enough data to unmarshal MassTransferEntry from byte, expected %d, received %d bytes", e.Recipient.len+8, l)
+	}
 	e.Amount = binary.BigEndian.Uint64(data[e.Recipient.len:])
 	return nil
 }
@@ -2818,11 +2827,11 @@ func (tx *MassTransferWithProofs) BodyMarshalBinary() ([]byte, error) {
 }
 
 func (tx *MassTransferWithProofs) bodyUnmarshalBinary(data []byte) error {
-	tx.Type = TransactionType(data[0])
-	tx.Version = data[1]
 	if l := len(data); l < massTransferWithProofsMinLen {
 		return errors.Errorf("not enough data for MassTransferWithProofs transaction, expected not less then %d, received %d", massTransferWithProofsMinLen, l)
 	}
+	tx.Type = TransactionType(data[0])
+	tx.Version = data[1]
 	if tx.Type != MassTransferTransaction {
 		return errors.Errorf("unexpected transaction type %d for MassTransferWithProofs transaction", tx.Type)
 	}
@@ -2837,6 +2846,9 @@ func (tx *MassTransferWithProofs) bodyUnmarshalBinary(data []byte) error {
 	if tx.Asset.Present {
 		data = data[crypto.DigestSize:]
 	}
+	if l := len(data); l < 2 {
+		return errors.Errorf("not enough data for transfers count of MassTransferWithProofs transaction, received %d", l)
+	}
 	n := int(binary.BigEndian.Uint16(data))
 	data = data[2:]
 	var entries []MassTransferEntry
@@ -2850,6 +2862,9 @@ func (tx *MassTransferWithProofs) bodyUnmarshalBinary(data []byte) error {
 		entries = append(entries, e)
 	}
 	tx.Transfers = entries
+	if l := len(data); l < 8+8 {
+		return errors.Errorf("not enough data for timestamp and fee of MassTransferWithProofs transaction, received %d", l)
+	}
 	tx.Timestamp = binary.BigEndian.Uint64(data)
 	data = data[8:]
 	tx.Fee = binary.BigEndian.Uint64(data)
@@ -3193,11 +3208,11 @@ func (tx *DataWithProofs) BodyMarshalBinary() ([]byte, error) {
 }
 
 func (tx *DataWithProofs) bodyUnmarshalBinary(data []byte) error {
-	tx.Type = TransactionType(data[0])
-	tx.Version = data[1]
 	if l := len(data); l < dataWithProofsFixedBodyLen {
 		return errors.Errorf("not enough data for DataWithProofs transaction, expected not less then %d, received %d", dataWithProofsFixedBodyLen, l)
 	}
+	tx.Type = TransactionType(data[0])
+	tx.Version = data[1]
 	if tx.Type != DataTransaction {
 		return errors.Errorf("unexpected transaction type %d for DataWithProofs transaction", tx.Type)
 	}
@@ -3241,6 +3256,9 @@ func (tx *DataWithProofs) bodyUnmarshalBinary(data []byte) error {
 			return errors.Wrap(err, "failed to unmarshal DataWithProofs transaction body from bytes")
 		}
 	}
+	if l := len(data); l < 8+8 {
+		return errors.Errorf("not enough data for timestamp and fee of DataWithProofs transaction, received %d", l)
+	}
 	tx.Timestamp = binary.BigEndian.Uint64(data)
 	data = data[8:]
 	tx.Fee = binary.BigEndian.Uint64(data)
@@ -3562,9 +3580,15 @@ func (tx *SetScriptWithProofs) bodyUnmarshalBinary(data []byte) error {
 		if err != nil {
 			return errors.Wrap(err, "failed to unmarshal SetScriptWithProofs transaction body from bytes")
 		}
+		if len(s) == 0 {
+			return errors.New("failed to unmarshal SetScriptWithProofs transaction body from bytes: empty script")
+		}
 		tx.Script = s
 		data = data[2+len(s):]
 	}
+	if l := len(data); l < 8+8 {
+		return errors.Errorf("not enough data for fee and timestamp of SetScriptWithProofs transaction, received %d", l)
+	}
 	tx.Fee = binary.BigEndian.Uint64(data)
 	data = data[8:]
 	tx.Timestamp = binary.BigEndian.Uint64(data)
@@ -3921,6 +3945,9 @@ func (tx *SponsorshipWithProofs) UnmarshalBinary(data []byte, scheme Scheme) err
 	if err != nil {
 		return errors.Wrap(err, "failed to unmarshal SponsorshipWithProofs transaction from bytes")
 	}
+	if tx.Version != 1 {
+		return errors.Errorf("unexpected transaction version %d in body, expected %d", tx.Version, 1)
+	}
 	bl := sponsorshipWithProofsBodyLen
 	data = data[bl:]
 	var p ProofsV1
@@ -4155,6 +4182,9 @@ func (tx *SetAssetScriptWithProofs) bodyUnmarshalBinary(data []byte) error {
 		if err != nil {
 			return errors.Wrap(err, "failed to unmarshal SetAssetScriptWithProofs transaction body from bytes")
 		}
+		if len(s) == 0 {
+			return errors.New("failed to unmarshal SetAssetScriptWithProofs transaction body from bytes: empty script")
+		}
 		tx.Script = s
 	}
 	return nil
@@ -4542,6 +4572,9 @@ func (tx *InvokeScriptWithProofs) bodyUnmarshalBinary(data []byte) error {
 	}
 	tx.Payments = payments
 	data = data[payments.BinarySize():]
+	if l := len(data); l < 8 {
+		return errors.Errorf("not enough data for fee of InvokeScriptWithProofs transaction, received %d", l)
+	}
 	tx.Fee = binary.BigEndian.Uint64(data)
 	data = data[8:]
 	var asset OptionalAsset
@@ -4551,6 +4584,9 @@ func (tx *InvokeScriptWithProofs) bodyUnmarshalBinary(data []byte) error {
 	}
 	tx.FeeAsset = asset
 	data = data[asset.BinarySize():]
+	if l := len(data); l < 8 {
+		return errors.Errorf("not enough data for timestamp of InvokeScriptWithProofs transaction, received %d", l)
+	}
 	tx.Timestamp = binary.BigEndian.Uint64(data)
 	return nil
 }
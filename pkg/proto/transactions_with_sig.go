@@ -193,6 +193,9 @@ func (tx *IssueWithSig) UnmarshalBinary(data []byte, scheme Scheme) error {
 	}
 	data = data[1:]
 	var s crypto.Signature
+	if l := len(data); l < crypto.SignatureSize {
+		return errors.Errorf("not enough data for signature of IssueWithSig transaction, received %d", l)
+	}
 	copy(s[:], data[:crypto.SignatureSize])
 	tx.Signature = &s
 	data = data[crypto.SignatureSize:]
@@ -470,6 +473,9 @@ func (tx *TransferWithSig) UnmarshalBinary(data []byte, scheme Scheme) error {
 	}
 	data = data[1:]
 	var s crypto.Signature
+	if l := len(data); l < crypto.SignatureSize {
+		return errors.Errorf("not enough data for signature of TransferWithSig transaction, received %d", l)
+	}
 	copy(s[:], data[:crypto.SignatureSize])
 	tx.Signature = &s
 	data = data[crypto.SignatureSize:]
@@ -728,6 +734,9 @@ func (tx *ReissueWithSig) UnmarshalBinary(data []byte, scheme Scheme) error {
 	}
 	data = data[1:]
 	var s crypto.Signature
+	if l := len(data); l < crypto.SignatureSize {
+		return errors.Errorf("not enough data for signature of ReissueWithSig transaction, received %d", l)
+	}
 	copy(s[:], data[:crypto.SignatureSize])
 	tx.Signature = &s
 	data = data[crypto.SignatureSize:]
@@ -1424,6 +1433,9 @@ func (tx *ExchangeWithSig) UnmarshalBinary(data []byte, scheme Scheme) error {
 	}
 	data = data[bl:]
 	var s crypto.Signature
+	if l := len(data); l < crypto.SignatureSize {
+		return errors.Errorf("not enough data for signature of ExchangeWithSig transaction, received %d", l)
+	}
 	copy(s[:], data[:crypto.SignatureSize])
 	tx.Signature = &s
 	if err := tx.GenerateID(scheme); err != nil {
@@ -1665,6 +1677,9 @@ func (tx *LeaseWithSig) UnmarshalBinary(data []byte, scheme Scheme) error {
 	bl := leaseWithSigBodyLen + tx.Recipient.len
 	data = data[bl:]
 	var s crypto.Signature
+	if l := len(data); l < crypto.SignatureSize {
+		return errors.Errorf("not enough data for signature of LeaseWithSig transaction, received %d", l)
+	}
 	copy(s[:], data[:crypto.SignatureSize])
 	tx.Signature = &s
 	if err := tx.GenerateID(scheme); err != nil {
@@ -1896,6 +1911,9 @@ func (tx *LeaseCancelWithSig) UnmarshalBinary(data []byte, scheme Scheme) error
 	}
 	data = data[leaseCancelWithSigBodyLen:]
 	var s crypto.Signature
+	if l := len(data); l < crypto.SignatureSize {
+		return errors.Errorf("not enough data for signature of LeaseCancelWithSig transaction, received %d", l)
+	}
 	copy(s[:], data[:crypto.SignatureSize])
 	tx.Signature = &s
 	if err := tx.GenerateID(scheme); err != nil {
@@ -2127,6 +2145,9 @@ func (tx *CreateAliasWithSig) UnmarshalBinary(data []byte, scheme Scheme) error
 	bl := createAliasWithSigFixedBodyLen + len(tx.Alias.Alias)
 	data = data[bl:]
 	var s crypto.Signature
+	if l := len(data); l < crypto.SignatureSize {
+		return errors.Errorf("not enough data for signature of CreateAliasWithSig transaction, received %d", l)
+	}
 	copy(s[:], data[:crypto.SignatureSize])
 	tx.Signature = &s
 	if err := tx.GenerateID(scheme); err != nil {
@@ -1995,11 +1995,17 @@ func (p *ProofsV1) UnmarshalBinary(data []byte) error {
 	}
 	data = data[2:]
 	for i := 0; i < n; i++ {
+		if l := len(data); l < 2 {
+			return errors.Errorf("not enough data for proof #%d of ProofsV1, received %d bytes", i, l)
+		}
 		el := binary.BigEndian.Uint16(data)
 		if el > proofMaxSize {
 			return errors.Errorf("proof size %d bytes exceeds maximum allowed %d", el, proofMaxSize)
 		}
 		data = data[2:]
+		if l := len(data); l < int(el) {
+			return errors.Errorf("not enough data for proof #%d of ProofsV1, expected %d, received %d", i, el, l)
+		}
 		pr := make([]byte, el)
 		copy(pr, data[0:el])
 		data = data[el:]
@@ -2928,6 +2934,8 @@ type ScriptInfo struct {
 	Bytes      []byte
 	Base64     string
 	Complexity uint64
+	// VerifierComplexity is the complexity of the verifier function of account script.
+	VerifierComplexity uint64
 }
 
 func (s *ScriptInfo) ToProtobuf() *pb.ScriptData {
@@ -3137,6 +3145,9 @@ func (a *Arguments) UnmarshalBinary(data []byte) error {
 	n := binary.BigEndian.Uint32(data[:4])
 	data = data[4:]
 	for i := 0; i < int(n); i++ {
+		if len(data) == 0 {
+			return errors.Errorf("not enough bytes for %d Arguments", n)
+		}
 		var arg Argument
 		var err error
 		switch ArgumentValueType(data[0]) {
@@ -3743,12 +3754,19 @@ func (p *ScriptPayment) UnmarshalBinary(data []byte) error {
 	if l := len(data[2:]); l < size {
 		return errors.Errorf("%d is not enough bytes for ScriptPayment", l)
 	}
-	p.Amount = binary.BigEndian.Uint64(data[2:10])
+	if size < 8+1 {
+		return errors.Errorf("invalid ScriptPayment size %d", size)
+	}
+	data = data[2 : 2+size]
+	p.Amount = binary.BigEndian.Uint64(data[:8])
 	var a OptionalAsset
-	err := a.UnmarshalBinary(data[10:])
+	err := a.UnmarshalBinary(data[8:])
 	if err != nil {
 		return errors.Wrap(err, "failed to deserialize ScriptPayment from bytes")
 	}
+	if as := 8 + a.BinarySize(); as != size {
+		return errors.Errorf("invalid ScriptPayment size %d, expected %d", size, as)
+	}
 	p.Asset = a
 	return nil
 }
@@ -11,23 +11,14 @@ import (
 	"github.com/wavesplatform/gowaves/pkg/ride/ast"
 )
 
+// Compile translates the tree of script into the bytecode of VM.
+// Scripts that can't be compiled should be evaluated by the tree evaluator.
 func Compile(tree *ast.Tree) (RideScript, error) {
-	fCheck, err := selectFunctionChecker(tree.LibVersion)
-	if err != nil {
-		return nil, errors.Wrap(err, "compile")
-	}
-	cCheck, err := selectConstantsChecker(tree.LibVersion)
-	if err != nil {
-		return nil, errors.Wrap(err, "compile")
-	}
 	c := &compiler{
-		constants:     newRideConstants(),
-		checkFunction: fCheck,
-		checkConstant: cCheck,
-		values:        make([]rideValue, 0),
-		functions:     make([]*localFunction, 0),
-		declarations:  make([]rideDeclaration, 0),
-		patcher:       newPatcher(),
+		code:      new(bytes.Buffer),
+		constants: newRideConstants(),
+		nameIDs:   make(map[string]uint16),
+		nativeIDs: make(map[string]uint16),
 	}
 	if tree.IsDApp() {
 		return c.compileDAppScript(tree)
@@ -35,449 +26,427 @@ func Compile(tree *ast.Tree) (RideScript, error) {
 	return c.compileSimpleScript(tree)
 }
 
+// block is a piece of code that is compiled after the code of entry points: the body of user function or
+// the expression of value. The position of compiled block is passed to the set function.
+type block struct {
+	node ast.Node
+	set  func(int)
+}
+
 type compiler struct {
-	constants     *rideConstants
-	checkFunction func(string) (uint16, bool)
-	checkConstant func(string) (uint16, bool)
-	values        []rideValue
-	functions     []*localFunction
-	declarations  []rideDeclaration
-	patcher       *patcher
-	callable      *rideCallable
+	code         *bytes.Buffer
+	constants    *rideConstants
+	names        []string
+	nameIDs      map[string]uint16
+	natives      []string
+	nativeIDs    map[string]uint16
+	functions    []function
+	declarations []declaration
+	blocks       []block
 }
 
 func (c *compiler) compileSimpleScript(tree *ast.Tree) (*SimpleScript, error) {
-	bb := new(bytes.Buffer)
-	err := c.compile(bb, tree.Verifier)
-	if err != nil {
+	if err := c.compile(tree.Verifier); err != nil {
 		return nil, err
 	}
-	bb.WriteByte(OpHalt)
-	for _, d := range c.declarations {
-		pos := bb.Len()
-		c.patcher.setOrigin(d.buffer(), pos)
-		bb.Write(patchedCode(d.buffer(), pos))
-		bb.WriteByte(OpReturn)
-		for _, ref := range d.references() {
-			c.patcher.setPosition(ref, uint16(pos))
-		}
-	}
-	code := bb.Bytes()
-	patches, err := c.patcher.get()
+	c.code.WriteByte(OpHalt)
+	p, err := c.program(tree.LibVersion)
 	if err != nil {
 		return nil, err
 	}
-	for pos, addr := range patches {
-		binary.BigEndian.PutUint16(code[pos:], addr)
-	}
-	return &SimpleScript{
-		LibVersion: tree.LibVersion,
-		EntryPoint: 0,
-		Code:       bb.Bytes(),
-		Constants:  c.constants.items,
-	}, nil
+	return &SimpleScript{program: *p, EntryPoint: 0}, nil
 }
 
 func (c *compiler) compileDAppScript(tree *ast.Tree) (*DAppScript, error) {
-	// Compile global declarations.
-	// Each declaration goes to the declaration stack with code compiled in its own buffer.
-	functions := make(map[string]callable)
-	bb := new(bytes.Buffer)
 	for _, node := range tree.Declarations {
-		err := c.compile(bb, node)
-		if err != nil {
+		if err := c.declare(node); err != nil {
 			return nil, err
 		}
 	}
-	for _, n := range tree.Functions {
-		fn, ok := n.(*ast.FunctionDeclarationNode)
+	var verifier *callable
+	if tree.HasVerifier() {
+		fn, ok := tree.Verifier.(*ast.FunctionDeclarationNode)
 		if !ok {
-			return nil, errors.Errorf("invalid node type %T", n)
-		}
-		c.callable = &rideCallable{
-			name:      fn.Name,
-			parameter: fn.InvocationParameter,
+			return nil, errors.Errorf("invalid node type for DApp's verifier '%T'", tree.Verifier)
 		}
-		err := c.compile(bb, fn)
+		v, err := c.callable(fn)
 		if err != nil {
 			return nil, err
 		}
+		verifier = &v
 	}
-	if tree.HasVerifier() {
-		v, ok := tree.Verifier.(*ast.FunctionDeclarationNode)
+	entryPoints := make(map[string]callable, len(tree.Functions))
+	for _, node := range tree.Functions {
+		fn, ok := node.(*ast.FunctionDeclarationNode)
 		if !ok {
-			return nil, errors.Errorf("invalid node type for DApp's verifier '%T'", tree.Verifier)
+			return nil, errors.Errorf("invalid node type for DApp's callable '%T'", node)
 		}
-		c.callable = &rideCallable{
-			name:      "", // Verifier has empty name
-			parameter: v.InvocationParameter,
+		if _, ok := entryPoints[fn.Name]; ok { // The tree evaluator calls the first function with the name
+			continue
 		}
-		err := c.compile(bb, v)
+		f, err := c.callable(fn)
 		if err != nil {
 			return nil, err
 		}
-		functions[""] = callable{
-			entryPoint:    0,
-			parameterName: v.InvocationParameter,
-		}
+		entryPoints[fn.Name] = f
 	}
-	// All declarations go here after verifier and public functions
-	for _, d := range c.declarations {
-		pos := bb.Len()
-		c.patcher.setOrigin(d.buffer(), pos)
-		bb.Write(patchedCode(d.buffer(), pos))
-		if c := d.callable(); c != nil {
-			bb.WriteByte(OpHalt)
-			// Add reference to entry point
-			functions[c.name] = callable{
-				entryPoint:    pos,
-				parameterName: c.parameter,
-			}
-		} else {
-			bb.WriteByte(OpReturn)
+	p, err := c.program(tree.LibVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &DAppScript{program: *p, Declarations: c.declarations, Verifier: verifier, EntryPoints: entryPoints}, nil
+}
+
+func (c *compiler) declare(node ast.Node) error {
+	switch n := node.(type) {
+	case *ast.AssignmentNode:
+		id, err := c.name(n.Name)
+		if err != nil {
+			return err
 		}
-		for _, ref := range d.references() {
-			c.patcher.setPosition(ref, uint16(pos))
+		i := len(c.declarations)
+		c.declarations = append(c.declarations, declaration{id: id})
+		c.blocks = append(c.blocks, block{node: n.Expression, set: func(pos int) { c.declarations[i].expression = pos }})
+		return nil
+	case *ast.FunctionDeclarationNode:
+		id, err := c.function(n)
+		if err != nil {
+			return err
 		}
+		c.declarations = append(c.declarations, declaration{function: true, id: id})
+		return nil
+	default:
+		return errors.Errorf("unexpected declaration type '%T'", node)
 	}
-	code := bb.Bytes()
-	patches, err := c.patcher.get()
+}
+
+func (c *compiler) callable(fn *ast.FunctionDeclarationNode) (callable, error) {
+	parameter, err := c.name(fn.InvocationParameter)
+	if err != nil {
+		return callable{}, err
+	}
+	arguments, err := c.arguments(fn.Arguments)
+	if err != nil {
+		return callable{}, err
+	}
+	entryPoint := c.code.Len()
+	if err := c.compile(fn.Body); err != nil {
+		return callable{}, err
+	}
+	c.code.WriteByte(OpHalt)
+	return callable{entryPoint: entryPoint, parameter: parameter, arguments: arguments}, nil
+}
+
+// program compiles the queued blocks and builds the tables of names, constants and functions.
+func (c *compiler) program(v ast.LibraryVersion) (*program, error) {
+	for len(c.blocks) > 0 {
+		b := c.blocks[0]
+		c.blocks = c.blocks[1:]
+		b.set(c.code.Len())
+		if err := c.compile(b.node); err != nil {
+			return nil, err
+		}
+		c.code.WriteByte(OpReturn)
+	}
+	if uint64(c.code.Len()) > math.MaxUint32 {
+		return nil, errors.New("max size of code reached")
+	}
+	globals, err := c.globals(v)
 	if err != nil {
 		return nil, err
 	}
-	for pos, addr := range patches {
-		binary.BigEndian.PutUint16(code[pos:], addr)
+	natives, err := c.nativeFunctions(v)
+	if err != nil {
+		return nil, err
 	}
-	return &DAppScript{
-		LibVersion:  tree.LibVersion,
-		Code:        bb.Bytes(),
-		Constants:   c.constants.items,
-		EntryPoints: functions,
+	return &program{
+		LibVersion: v,
+		Code:       c.code.Bytes(),
+		Constants:  c.constants.items,
+		names:      c.names,
+		globals:    globals,
+		functions:  c.functions,
+		natives:    natives,
 	}, nil
 }
 
-func (c *compiler) compile(bb *bytes.Buffer, node ast.Node) error {
+// globals returns constructors of global constants by name ID, as they declared in the tree evaluator.
+func (c *compiler) globals(v ast.LibraryVersion) ([]rideConstructor, error) {
+	names, err := selectConstantNames(v)
+	if err != nil {
+		return nil, err
+	}
+	check, err := selectConstantsChecker(v)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := selectConstants(v)
+	if err != nil {
+		return nil, err
+	}
+	globals := make([]rideConstructor, len(c.names))
+	for _, n := range names {
+		cid, ok := check(n)
+		if !ok {
+			return nil, errors.Errorf("unknown constant '%s'", n)
+		}
+		if id, ok := c.nameIDs[n]; ok {
+			globals[id] = provider(int(cid))
+		}
+	}
+	return globals, nil
+}
+
+func (c *compiler) nativeFunctions(v ast.LibraryVersion) ([]native, error) {
+	var functions [2]func(string) (rideFunction, bool)
+	var costs [2]map[string]int
+	for i := range functions {
+		fs, err := selectFunctionsByName(v, i == 1)
+		if err != nil {
+			return nil, err
+		}
+		functions[i] = fs
+		cs, err := selectEvaluationCostsProvider(v, i+1)
+		if err != nil {
+			return nil, err
+		}
+		costs[i] = cs
+	}
+	natives := make([]native, len(c.natives))
+	for id, name := range c.natives {
+		n := native{name: name}
+		for i := range functions {
+			if f, ok := functions[i](name); ok {
+				n.functions[i] = f
+			}
+			n.costs[i], n.hasCost[i] = costs[i][name]
+		}
+		natives[id] = n
+	}
+	return natives, nil
+}
+
+func (c *compiler) compile(node ast.Node) error {
 	switch n := node.(type) {
 	case *ast.LongNode:
-		return c.longNode(bb, n)
+		return c.constant(rideInt(n.Value))
 	case *ast.BytesNode:
-		return c.bytesNode(bb, n)
+		return c.constant(rideBytes(n.Value))
 	case *ast.StringNode:
-		return c.stringNode(bb, n)
+		return c.constant(rideString(n.Value))
 	case *ast.BooleanNode:
-		return c.booleanNode(bb, n)
+		if n.Value {
+			c.code.WriteByte(OpTrue)
+		} else {
+			c.code.WriteByte(OpFalse)
+		}
+		return nil
 	case *ast.ConditionalNode:
-		return c.conditionalNode(bb, n)
+		return c.conditionalNode(n)
 	case *ast.AssignmentNode:
-		return c.assignmentNode(bb, n)
+		return c.assignmentNode(n)
 	case *ast.ReferenceNode:
-		return c.referenceNode(bb, n)
+		id, err := c.name(n.Name)
+		if err != nil {
+			return err
+		}
+		c.code.WriteByte(OpRef)
+		c.code.Write(encode(id))
+		return nil
 	case *ast.FunctionDeclarationNode:
-		return c.functionDeclarationNode(bb, n)
+		return c.functionDeclarationNode(n)
 	case *ast.FunctionCallNode:
-		return c.callNode(bb, n)
+		return c.callNode(n)
 	case *ast.PropertyNode:
-		return c.propertyNode(bb, n)
+		return c.propertyNode(n)
 	default:
 		return errors.Errorf("unexpected node type '%T'", node)
 	}
 }
 
-func (c *compiler) longNode(bb *bytes.Buffer, node *ast.LongNode) error {
-	cid, err := c.constants.put(rideInt(node.Value))
+func (c *compiler) constant(value rideType) error {
+	id, err := c.constants.put(value)
 	if err != nil {
 		return err
 	}
-	bb.WriteByte(OpPush)
-	bb.Write(encode(cid))
+	c.code.WriteByte(OpPush)
+	c.code.Write(encode(id))
 	return nil
 }
 
-func (c *compiler) bytesNode(bb *bytes.Buffer, node *ast.BytesNode) error {
-	cid, err := c.constants.put(rideBytes(node.Value))
-	if err != nil {
+func (c *compiler) conditionalNode(node *ast.ConditionalNode) error {
+	c.code.WriteByte(OpCondition)
+	if err := c.compile(node.Condition); err != nil {
 		return err
 	}
-	bb.WriteByte(OpPush)
-	bb.Write(encode(cid))
-	return nil
-}
-
-func (c *compiler) stringNode(bb *bytes.Buffer, node *ast.StringNode) error {
-	cid, err := c.constants.put(rideString(node.Value))
-	if err != nil {
+	c.code.WriteByte(OpJumpIfFalse)
+	otherwise := c.position()
+	if err := c.compile(node.TrueExpression); err != nil {
 		return err
 	}
-	bb.WriteByte(OpPush)
-	bb.Write(encode(cid))
-	return nil
-}
-
-func (c *compiler) booleanNode(bb *bytes.Buffer, node *ast.BooleanNode) error {
-	if node.Value {
-		bb.WriteByte(OpTrue)
-	} else {
-		bb.WriteByte(OpFalse)
+	c.code.WriteByte(OpJump)
+	end := c.position()
+	c.patch(otherwise)
+	if err := c.compile(node.FalseExpression); err != nil {
+		return err
 	}
+	c.patch(end)
+	c.code.WriteByte(OpEndCondition)
 	return nil
 }
 
-func (c *compiler) conditionalNode(bb *bytes.Buffer, node *ast.ConditionalNode) error {
-	err := c.compile(bb, node.Condition)
+func (c *compiler) assignmentNode(node *ast.AssignmentNode) error {
+	id, err := c.name(node.Name)
 	if err != nil {
 		return err
 	}
-	bb.WriteByte(OpJumpIfFalse)
-	otherwise := bb.Len()
-	bb.Write([]byte{0xff, 0xff})
-
-	// Truthy branch
-	bb.WriteByte(OpPop) // Remove condition result from stack
-
-	err = c.compile(bb, node.TrueExpression)
-	if err != nil {
+	c.code.WriteByte(OpLet)
+	c.code.Write(encode(id))
+	expression := c.position()
+	c.blocks = append(c.blocks, block{node: node.Expression, set: func(pos int) { c.patchTo(expression, pos) }})
+	if err := c.compile(node.Block); err != nil {
 		return err
 	}
-
-	bb.WriteByte(OpJump)
-	end := bb.Len()
-	bb.Write([]byte{0xff, 0xff})
-
-	// Patch jump to alternative branch
-	code := bb.Bytes()
-	binary.BigEndian.PutUint16(code[otherwise:], uint16(bb.Len()))
-
-	// Alternative branch
-	bb.WriteByte(OpPop) // Remove condition result from stack
-	err = c.compile(bb, node.FalseExpression)
-	if err != nil {
-		return err
-	}
-
-	// Patch jump to the end of alternative branch
-	code = bb.Bytes()
-	binary.BigEndian.PutUint16(code[end:], uint16(bb.Len()))
-
+	c.code.WriteByte(OpEndLet)
 	return nil
 }
 
-func (c *compiler) assignmentNode(bb *bytes.Buffer, node *ast.AssignmentNode) error {
-	err := c.pushGlobalValue(node.Name, node.Expression, c.callable)
+func (c *compiler) functionDeclarationNode(node *ast.FunctionDeclarationNode) error {
+	id, err := c.function(node)
 	if err != nil {
 		return err
 	}
-	if node.Block != nil {
-		err = c.compile(bb, node.Block)
-		if err != nil {
-			return err
-		}
-		err = c.popValue()
-		if err != nil {
-			return err
-		}
-	} else {
-		err = c.peekValue()
-		if err != nil {
-			return err
-		}
+	c.code.WriteByte(OpFunction)
+	c.code.Write(encode(id))
+	if err := c.compile(node.Block); err != nil {
+		return err
 	}
+	c.code.WriteByte(OpEndFunction)
 	return nil
 }
 
-func (c *compiler) referenceNode(bb *bytes.Buffer, node *ast.ReferenceNode) error {
-	if id, ok := c.checkConstant(node.Name); ok {
-		//Globally declared constant
-		bb.WriteByte(OpGlobal)
-		bb.Write(encode(id))
-		return nil
-	} else {
-		v, err := c.lookupValue(node.Name)
-		if err != nil {
-			return err
-		}
-		switch value := v.(type) {
-		case *localValue:
-			bb.WriteByte(OpLoadLocal)
-			bb.Write(encode(value.position))
-			return nil
-		case *globalValue:
-			bb.WriteByte(OpLoad)
-			value.refer(c.patcher.add(bb))
-			bb.Write([]byte{0xff, 0xff})
-			return nil
-		default:
-			return errors.Errorf("unexpected value type '%T'", value)
-		}
+func (c *compiler) callNode(node *ast.FunctionCallNode) error {
+	if len(node.Arguments) > math.MaxUint16 {
+		return errors.New("max number of arguments reached")
 	}
-}
-
-func (c *compiler) functionDeclarationNode(bb *bytes.Buffer, node *ast.FunctionDeclarationNode) error {
-	var args []string
-	if c.callable != nil { // DApp callable function
-		args = make([]string, len(node.Arguments)+1)
-		args[0] = c.callable.parameter
-		copy(args[1:], node.Arguments)
-	} else {
-		args = node.Arguments
+	if node.Function == nil {
+		return errors.New("empty function of call")
 	}
-	err := c.pushFunction(node.Name, args, node.Body, c.callable)
+	nid, err := c.native(node.Function.Name())
 	if err != nil {
 		return err
 	}
-	if node.Block != nil {
-		err = c.compile(bb, node.Block)
-		if err != nil {
-			return err
-		}
-		err = c.popFunction()
-		if err != nil {
-			return err
-		}
-	} else {
-		err = c.peekFunction()
+	switch node.Function.(type) {
+	case ast.NativeFunction:
+		c.code.WriteByte(OpNativeCall)
+		c.code.Write(encode(nid))
+	case ast.UserFunction:
+		id, err := c.name(node.Function.Name())
 		if err != nil {
 			return err
 		}
+		c.code.WriteByte(OpUserCall)
+		c.code.Write(encode(id))
+		c.code.Write(encode(nid))
+	default:
+		return errors.Errorf("unexpected function type '%T'", node.Function)
 	}
-	return nil
-}
-
-func (c *compiler) callNode(bb *bytes.Buffer, node *ast.FunctionCallNode) error {
 	for _, arg := range node.Arguments {
-		err := c.compile(bb, arg)
-		if err != nil {
+		c.code.WriteByte(OpArgument)
+		if err := c.compile(arg); err != nil {
 			return err
 		}
 	}
-	cnt := encode(uint16(len(node.Arguments)))
-
-	if id, ok := c.checkFunction(node.Function.Name()); ok {
-		//External function
-		bb.WriteByte(OpExternalCall)
-		bb.Write(encode(id))
-		bb.Write(cnt)
-	} else {
-		//Internal function
-		decl, err := c.lookupFunction(node.Function.Name())
-		if err != nil {
-			return err
-		}
-		bb.WriteByte(OpCall)
-		decl.call(c.patcher.add(bb))
-		bb.Write([]byte{0xff, 0xff})
-		bb.Write(cnt)
-	}
+	c.code.WriteByte(OpCall)
+	c.code.Write(encode(uint16(len(node.Arguments))))
 	return nil
 }
 
-func (c *compiler) propertyNode(bb *bytes.Buffer, node *ast.PropertyNode) error {
-	err := c.compile(bb, node.Object)
+func (c *compiler) propertyNode(node *ast.PropertyNode) error {
+	id, err := c.name(node.Name)
 	if err != nil {
 		return err
 	}
-	id, err := c.constants.put(rideString(node.Name))
-	if err != nil {
+	c.code.WriteByte(OpObject)
+	c.code.Write(encode(id))
+	if err := c.compile(node.Object); err != nil {
 		return err
 	}
-	bb.WriteByte(OpProperty)
-	bb.Write(encode(id))
+	c.code.WriteByte(OpProperty)
 	return nil
 }
 
-func (c *compiler) pushGlobalValue(name string, node ast.Node, annex *rideCallable) error {
-	d := newGlobalValue(name, annex)
-	c.callable = nil
-	c.values = append(c.values, d)
-	return c.compile(d.bb, node)
-}
-
-func (c *compiler) popValue() error {
-	l := len(c.values)
-	if l == 0 {
-		return errors.New("failed to pop value from empty stack")
+// function adds the user function to the table of functions, its body is compiled later.
+func (c *compiler) function(node *ast.FunctionDeclarationNode) (uint16, error) {
+	if len(c.functions) >= math.MaxUint16 {
+		return 0, errors.New("max number of functions reached")
 	}
-	var v rideValue
-	v, c.values = c.values[l-1], c.values[:l-1]
-	if d, ok := v.(rideDeclaration); ok {
-		c.declarations = append(c.declarations, d)
-	}
-	return nil
-}
-
-func (c *compiler) peekValue() error {
-	l := len(c.values)
-	if l == 0 {
-		return errors.New("failed to peek value from empty stack")
+	name, err := c.name(node.Name)
+	if err != nil {
+		return 0, err
 	}
-	v := c.values[l-1]
-	if d, ok := v.(rideDeclaration); ok {
-		c.declarations = append(c.declarations, d)
+	arguments, err := c.arguments(node.Arguments)
+	if err != nil {
+		return 0, err
 	}
-	return nil
+	id := len(c.functions)
+	c.functions = append(c.functions, function{name: name, arguments: arguments})
+	c.blocks = append(c.blocks, block{node: node.Body, set: func(pos int) { c.functions[id].body = pos }})
+	return uint16(id), nil
 }
 
-func (c *compiler) lookupValue(name string) (rideValue, error) {
-	for i := len(c.values) - 1; i >= 0; i-- {
-		if c.values[i].id() == name {
-			return c.values[i], nil
+func (c *compiler) arguments(names []string) ([]uint16, error) {
+	r := make([]uint16, len(names))
+	for i, n := range names {
+		id, err := c.name(n)
+		if err != nil {
+			return nil, err
 		}
+		r[i] = id
 	}
-	return nil, errors.Errorf("value '%s' is not declared", name)
+	return r, nil
 }
 
-func (c *compiler) pushFunction(name string, args []string, node ast.Node, annex *rideCallable) error {
-	d := newLocalFunction(name, args, annex)
-	c.functions = append(c.functions, d)
-	for i, a := range args {
-		c.values = append(c.values, newLocalValue(a, i))
+func (c *compiler) name(name string) (uint16, error) {
+	if id, ok := c.nameIDs[name]; ok {
+		return id, nil
 	}
-	c.callable = nil
-	err := c.compile(d.bb, node)
-	if err != nil {
-		return err
+	if len(c.names) >= math.MaxUint16 {
+		return 0, errors.New("max number of names reached")
 	}
-	for range args {
-		err := c.popValue()
-		if err != nil {
-			return err
-		}
-	}
-	return nil
+	id := uint16(len(c.names))
+	c.names = append(c.names, name)
+	c.nameIDs[name] = id
+	return id, nil
 }
 
-func (c *compiler) popFunction() error {
-	l := len(c.functions)
-	if l == 0 {
-		return errors.New("failed to pop function from empty stack")
+func (c *compiler) native(name string) (uint16, error) {
+	if id, ok := c.nativeIDs[name]; ok {
+		return id, nil
 	}
-	var f *localFunction
-	f, c.functions = c.functions[l-1], c.functions[:l-1]
-	c.declarations = append(c.declarations, f)
-	return nil
+	if len(c.natives) >= math.MaxUint16 {
+		return 0, errors.New("max number of native functions reached")
+	}
+	id := uint16(len(c.natives))
+	c.natives = append(c.natives, name)
+	c.nativeIDs[name] = id
+	return id, nil
 }
 
-func (c *compiler) peekFunction() error {
-	l := len(c.functions)
-	if l == 0 {
-		return errors.New("failed to peek function from empty stack")
-	}
-	f := c.functions[l-1]
-	c.declarations = append(c.declarations, f)
-	return nil
+// position reserves the place for position in code and returns its offset.
+func (c *compiler) position() int {
+	p := c.code.Len()
+	c.code.Write([]byte{0xff, 0xff, 0xff, 0xff})
+	return p
 }
 
-func (c *compiler) lookupFunction(name string) (*localFunction, error) {
-	for i := len(c.functions) - 1; i >= 0; i-- {
-		if c.functions[i].name == name {
-			return c.functions[i], nil
-		}
-	}
-	return nil, errors.Errorf("function '%s' is not declared", name)
+// patch sets the current position of code to the reserved place.
+func (c *compiler) patch(offset int) {
+	c.patchTo(offset, c.code.Len())
 }
 
-type rideCallable struct {
-	name      string
-	parameter string
+func (c *compiler) patchTo(offset, pos int) {
+	binary.BigEndian.PutUint32(c.code.Bytes()[offset:], uint32(pos))
 }
 
 type rideConstants struct {
@@ -522,196 +491,8 @@ func (c *rideConstants) append(value rideType) (uint16, error) {
 	return uint16(len(c.items) - 1), nil
 }
 
-type rideDeclaration interface {
-	buffer() *bytes.Buffer
-	references() []int
-	callable() *rideCallable
-}
-
-type rideValue interface {
-	id() string
-}
-
-type localValue struct {
-	name     string
-	position uint16
-}
-
-func newLocalValue(name string, pos int) *localValue {
-	return &localValue{
-		name:     name,
-		position: uint16(pos),
-	}
-}
-
-func (v *localValue) id() string {
-	return v.name
-}
-
-type globalValue struct {
-	name   string
-	annex  *rideCallable
-	bb     *bytes.Buffer
-	usages []int
-	using  []*globalValue
-}
-
-func newGlobalValue(name string, annex *rideCallable) *globalValue {
-	return &globalValue{
-		name:   name,
-		annex:  annex,
-		bb:     new(bytes.Buffer),
-		usages: make([]int, 0),
-		using:  make([]*globalValue, 0),
-	}
-}
-
-func (v *globalValue) buffer() *bytes.Buffer {
-	return v.bb
-}
-
-func (v *globalValue) references() []int {
-	return v.usages
-}
-
-func (v *globalValue) id() string {
-	return v.name
-}
-
-func (v *globalValue) refer(patch int) {
-	v.usages = append(v.usages, patch)
-}
-
-func (v *globalValue) callable() *rideCallable {
-	return v.annex
-}
-
-type localFunction struct {
-	name  string
-	annex *rideCallable
-	bb    *bytes.Buffer
-	calls []int
-	args  []string
-}
-
-func newLocalFunction(name string, args []string, annex *rideCallable) *localFunction {
-	return &localFunction{
-		name:  name,
-		annex: annex,
-		bb:    new(bytes.Buffer),
-		calls: make([]int, 0),
-		args:  args,
-	}
-}
-
-func (f *localFunction) buffer() *bytes.Buffer {
-	return f.bb
-}
-
-func (f *localFunction) references() []int {
-	return f.calls
-}
-
-func (f *localFunction) call(pos int) {
-	f.calls = append(f.calls, pos)
-}
-
-func (f *localFunction) callable() *rideCallable {
-	return f.annex
-}
-
-type patch struct {
-	id     int
-	origin int
-	pos    int
-	addr   uint16
-}
-
-type patcher struct {
-	count   int
-	buffers map[*bytes.Buffer][]patch
-}
-
-func newPatcher() *patcher {
-	return &patcher{
-		count:   0,
-		buffers: make(map[*bytes.Buffer][]patch),
-	}
-}
-
-func (p *patcher) add(bb *bytes.Buffer) int {
-	pt := patch{
-		id:  p.count,
-		pos: bb.Len(),
-	}
-	if bps, ok := p.buffers[bb]; ok {
-		p.buffers[bb] = append(bps, pt)
-	} else {
-		p.buffers[bb] = []patch{pt}
-	}
-	p.count++
-	return pt.id
-}
-
-func (p *patcher) setOrigin(bb *bytes.Buffer, origin int) {
-	if ps, ok := p.buffers[bb]; ok {
-		for i := range ps {
-			ps[i].origin = origin
-		}
-	}
-}
-
-func (p *patcher) setPosition(id int, addr uint16) {
-	for _, v := range p.buffers {
-		for i := range v {
-			if v[i].id == id {
-				v[i].addr = addr
-			}
-		}
-	}
-}
-
-func (p *patcher) get() (map[int]uint16, error) {
-	r := make(map[int]uint16)
-	for _, v := range p.buffers {
-		for i := range v {
-			abs := v[i].origin + v[i].pos
-			if _, ok := r[abs]; ok {
-				return nil, errors.Errorf("duplicate patch at position %d", abs)
-			}
-			r[abs] = v[i].addr
-		}
-	}
-	return r, nil
-}
-
 func encode(v uint16) []byte {
 	b := make([]byte, 2)
 	binary.BigEndian.PutUint16(b, v)
 	return b
 }
-
-func patchedCode(bb *bytes.Buffer, origin int) []byte {
-	bts := bb.Bytes()
-	i := 0
-	for i < bb.Len() {
-		switch bts[i] {
-		case OpJumpIfFalse, OpJump:
-			addr := bts[i+1 : i+3]
-			update(addr, origin)
-			i += 3
-		case OpPush, OpProperty, OpLoad, OpLoadLocal, OpGlobal:
-			i += 3
-		case OpCall, OpExternalCall:
-			i += 5
-		default:
-			i++
-		}
-	}
-	return bts
-}
-
-func update(b []byte, n int) {
-	v := binary.BigEndian.Uint16(b)
-	binary.BigEndian.PutUint16(b, uint16(int(v)+n))
-}
@@ -0,0 +1,82 @@
+package ride
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const rideMetricsNamespace = "ride"
+
+const (
+	scriptKindVerifier = "verifier"
+	scriptKindCallable = "callable"
+	// scriptKindVerifierVM and scriptKindCallableVM are the kinds of scripts evaluated on the bytecode VM.
+	scriptKindVerifierVM = "verifier_vm"
+	scriptKindCallableVM = "callable_vm"
+
+	executionResultAllowed = "allowed"
+	executionResultDenied  = "denied"
+	executionResultFailed  = "failed"
+)
+
+var (
+	metricScriptExecutions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: rideMetricsNamespace,
+			Name:      "script_executions_total",
+			Help:      "Number of RIDE script executions by script kind and execution result.",
+		},
+		[]string{"kind", "result"},
+	)
+
+	metricScriptComplexity = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: rideMetricsNamespace,
+			Name:      "script_complexity",
+			Help:      "Spent complexity of RIDE script executions by script kind.",
+			Buckets:   []float64{10, 50, 100, 200, 500, 1000, 2000, 4000, 10000, 26000, 52000},
+		},
+		[]string{"kind"},
+	)
+
+	metricScriptDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: rideMetricsNamespace,
+			Name:      "script_execution_duration_seconds",
+			Help:      "Duration of RIDE script executions by script kind.",
+			Buckets:   prometheus.ExponentialBuckets(0.00005, 2, 16),
+		},
+		[]string{"kind"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		metricScriptExecutions,
+		metricScriptComplexity,
+		metricScriptDuration,
+	)
+}
+
+// observeExecution reports the result, spent complexity and duration of a script execution started at start.
+func observeExecution(kind string, start time.Time, r Result, err error) {
+	var (
+		result     string
+		complexity int
+	)
+	switch {
+	case err != nil:
+		result = executionResultFailed
+		complexity = EvaluationErrorSpentComplexity(err)
+	case r.Result():
+		result = executionResultAllowed
+		complexity = r.Complexity()
+	default:
+		result = executionResultDenied
+		complexity = r.Complexity()
+	}
+	metricScriptExecutions.WithLabelValues(kind, result).Inc()
+	metricScriptComplexity.WithLabelValues(kind).Observe(float64(complexity))
+	metricScriptDuration.WithLabelValues(kind).Observe(time.Since(start).Seconds())
+}
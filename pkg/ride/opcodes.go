@@ -1,22 +1,28 @@
 package ride
 
 // Operation code is 1 byte.
-// Parameter is 2 bytes length
+// Parameter is 2 bytes length, positions in code are 4 bytes length.
+// Operations that start evaluation of a node of the tree check the complexity overflow like the tree evaluator does.
 
 const (
-	OpHalt         byte = iota //00 - Halts program execution. No parameters.
-	OpReturn                   //01 - Returns from declaration to stored position. No parameters.
+	OpHalt         byte = iota //00 - Halts program execution, the result is on top of the stack. No parameters.
+	OpReturn                   //01 - Returns from user function or expression of a value. No parameters.
 	OpPush                     //02 - Put constant on stack. One parameter: constant ID.
-	OpPop                      //03 - Removes value from stack. No parameters.
-	OpTrue                     //04 - Put True value on stack. No parameters.
-	OpFalse                    //05 - Put False value on stack. No parameters.
-	OpJump                     //06 - Moves instruction pointer to new position. One parameter: new position.
-	OpJumpIfFalse              //07 - Moves instruction pointer to new position if value on stack is False. One parameter: new position.
-	OpProperty                 //08 - Puts value of object's property on stack. One parameter: constant ID that holds name of the property.
-	OpExternalCall             //09 - Call a standard library function. Two parameters: function ID, number of arguments.
-	OpCall                     //0a - Call a function declared at given address. Two parameters: position of function declaration, number of arguments.
-	OpGlobal                   //0b - Load global constant. One parameter: global constant ID.
-	OpLoad                     //0c - Evaluates an expression that declared at address. One parameter: position of declaration.
-	OpLoadLocal                //0d - Load an argument of function call on stack. One parameter: argument number.
-	OpRef                      //0e - Put reference to expression/function on stack. One parameter: position of declaration.
+	OpTrue                     //03 - Put True value on stack. No parameters.
+	OpFalse                    //04 - Put False value on stack. No parameters.
+	OpJump                     //05 - Moves instruction pointer to new position. One parameter: new position.
+	OpCondition                //06 - Starts evaluation of a conditional expression. No parameters.
+	OpJumpIfFalse              //07 - Pops condition and moves instruction pointer to new position if it is False. One parameter: new position.
+	OpEndCondition             //08 - Ends evaluation of a conditional expression. No parameters.
+	OpLet                      //09 - Declares a value in the current scope. Two parameters: name ID, position of value expression.
+	OpEndLet                   //0a - Removes the last declared value from the current scope. No parameters.
+	OpFunction                 //0b - Declares a user function. One parameter: function ID.
+	OpEndFunction              //0c - Removes the last declared user function. No parameters.
+	OpRef                      //0d - Puts value on stack, evaluates its expression if necessary. One parameter: name ID.
+	OpObject                   //0e - Starts evaluation of an object to get property on it. One parameter: name ID of the property.
+	OpProperty                 //0f - Replaces object on stack with its property. No parameters.
+	OpNativeCall               //10 - Starts call of a standard library function. One parameter: native function ID.
+	OpUserCall                 //11 - Starts call of a user function, or a standard library function if there is no such user function. Two parameters: name ID, native function ID.
+	OpArgument                 //12 - Starts evaluation of the next argument of the call. No parameters.
+	OpCall                     //13 - Calls function with arguments on stack. One parameter: number of arguments.
 )
@@ -1,103 +1,149 @@
 package ride
 
 import (
-	"github.com/pkg/errors"
+	"time"
+
+	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/ride/ast"
 )
 
-type callable struct {
-	entryPoint    int
-	parameterName string
-}
-
+// RideScript is a script compiled to the bytecode of VM.
+// The VM evaluates scripts exactly as the tree evaluator does, including the spent complexity and errors.
 type RideScript interface {
 	Run(env environment) (Result, error)
 	code() []byte
 }
 
-type SimpleScript struct {
+// function is a compiled declaration of user function.
+type function struct {
+	name      uint16
+	arguments []uint16
+	body      int
+}
+
+// native is a standard library function, it is looked up by name like the tree evaluator does.
+// Functions are selected by availability of invocations, costs are selected by version of evaluator.
+type native struct {
+	name      string
+	functions [2]rideFunction
+	costs     [2]int
+	hasCost   [2]bool
+}
+
+// declaration is a global declaration of DApp, either a user function or a value.
+type declaration struct {
+	function   bool
+	id         uint16
+	expression int
+}
+
+// callable is an entry point of DApp.
+type callable struct {
+	entryPoint int
+	parameter  uint16
+	arguments  []uint16
+}
+
+type program struct {
 	LibVersion ast.LibraryVersion
-	EntryPoint int
 	Code       []byte
 	Constants  []rideType
+	names      []string
+	globals    []rideConstructor
+	functions  []function
+	natives    []native
 }
 
-func (s *SimpleScript) Run(env environment) (Result, error) {
-	fs, err := selectFunctions(s.LibVersion)
-	if err != nil {
-		return nil, RuntimeError.Wrap(err, "simple script execution failed")
-	}
-	gcs, err := selectConstants(s.LibVersion)
-	if err != nil {
-		return nil, RuntimeError.Wrap(err, "simple script execution failed")
+func (p *program) code() []byte {
+	return p.Code
+}
+
+// CallVerifierVM evaluates the verifier of the compiled script on the bytecode VM.
+// Panics of the VM are recovered and returned as errors.
+func CallVerifierVM(env environment, script RideScript) (r Result, err error) {
+	defer func(start time.Time) { observeExecution(scriptKindVerifierVM, start, r, err) }(time.Now())
+	defer func() {
+		if p := recover(); p != nil {
+			r = nil
+			err = RuntimeError.Errorf("bytecode VM panic: %v", p)
+		}
+	}()
+	return script.Run(env)
+}
+
+// CallFunctionVM evaluates the callable function of the compiled script on the bytecode VM.
+// Internal invocations of other DApps are evaluated by the tree evaluator.
+// Panics of the VM are recovered and returned as errors.
+func CallFunctionVM(env environment, script RideScript, name string, args proto.Arguments) (r Result, err error) {
+	defer func(start time.Time) { observeExecution(scriptKindCallableVM, start, r, err) }(time.Now())
+	defer func() {
+		if p := recover(); p != nil {
+			r = nil
+			err = RuntimeError.Errorf("bytecode VM panic: %v", p)
+		}
+	}()
+	if name == "" {
+		name = "default"
 	}
-	np, err := selectFunctionNameProvider(s.LibVersion)
+	arguments, err := convertProtoArguments(args)
 	if err != nil {
-		return nil, RuntimeError.Wrap(err, "simple script execution failed")
+		return nil, EvaluationFailure.Wrapf(err, "failed to call function '%s'", name)
 	}
-	m := vm{
-		env:          env,
-		code:         s.Code,
-		ip:           0,
-		constants:    s.Constants,
-		functions:    fs,
-		globals:      gcs,
-		stack:        make([]rideType, 0, 2),
-		calls:        make([]frame, 0, 2),
-		functionName: np,
+	s, ok := script.(*DAppScript)
+	if !ok {
+		return nil, EvaluationFailure.Wrapf(
+			EvaluationFailure.Errorf("unable to call function '%s' on simple script", name),
+			"failed to call function '%s'", name,
+		)
 	}
-	r, err := m.run()
+	m, err := s.functionVM(env, name, arguments)
 	if err != nil {
-		return nil, errors.Wrap(err, "simple script execution failed")
+		return nil, EvaluationFailure.Wrapf(err, "failed to call function '%s'", name)
 	}
-	return r, nil
+	r, err = m.evaluate()
+	return completeFunctionResult(env, s.LibVersion, name, m.cc.complexity(), r, err)
+}
+
+type SimpleScript struct {
+	program
+	EntryPoint int
 }
 
-func (s *SimpleScript) code() []byte {
-	return s.Code
+func (s *SimpleScript) Run(env environment) (Result, error) {
+	m := newVM(env, &s.program, false, s.EntryPoint)
+	return m.evaluate()
 }
 
 type DAppScript struct {
-	LibVersion  ast.LibraryVersion
-	Code        []byte
-	Constants   []rideType
-	EntryPoints map[string]callable
+	program
+	Declarations []declaration
+	Verifier     *callable
+	EntryPoints  map[string]callable
 }
 
 func (s *DAppScript) Run(env environment) (Result, error) {
-	if _, ok := s.EntryPoints[""]; !ok {
-		return nil, errors.Errorf("no verifier")
+	if s.Verifier == nil {
+		return nil, RuntimeError.Wrap(EvaluationFailure.New("no verifier declaration"), "failed to call verifier")
 	}
-	fs, err := selectFunctions(s.LibVersion)
-	if err != nil {
-		return nil, errors.Wrap(err, "script execution failed")
-	}
-	gcs, err := selectConstants(s.LibVersion)
-	if err != nil {
-		return nil, errors.Wrap(err, "script execution failed")
-	}
-	np, err := selectFunctionNameProvider(s.LibVersion)
-	if err != nil {
-		return nil, errors.Wrap(err, "script execution failed")
+	m := newVM(env, &s.program, false, s.Verifier.entryPoint)
+	m.declare(s.Declarations)
+	m.setParameter(s.Verifier.parameter, newTx)
+	return m.evaluate()
+}
+
+func (s *DAppScript) functionVM(env environment, name string, args []rideType) (*vm, error) {
+	c, ok := s.EntryPoints[name]
+	if !ok {
+		return nil, EvaluationFailure.Errorf("function '%s' not found", name)
 	}
-	m := vm{
-		env:          env,
-		code:         s.Code,
-		ip:           0,
-		constants:    s.Constants,
-		functions:    fs,
-		globals:      gcs,
-		stack:        make([]rideType, 0, 2),
-		calls:        make([]frame, 0, 2),
-		functionName: np,
+	if l := len(args); l != len(c.arguments) {
+		return nil, EvaluationFailure.Errorf("invalid arguments count %d for function '%s'", l, name)
 	}
-	r, err := m.run()
-	if err != nil {
-		return nil, errors.Wrap(err, "script execution failed")
+	m := newVM(env, &s.program, true, c.entryPoint)
+	m.declare(s.Declarations)
+	m.setParameter(c.parameter, newInvocation)
+	for i, arg := range args {
+		m.values = append(m.values, frameValue{name: c.arguments[i], value: arg, expression: noExpression})
 	}
-	return r, nil
-}
-
-func (s *DAppScript) code() []byte {
-	return s.Code
+	return m, nil
 }
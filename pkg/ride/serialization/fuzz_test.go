@@ -0,0 +1,55 @@
+package serialization
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/ride/ast"
+)
+
+var fuzzSeedCommentsRegex = regexp.MustCompile(`\s*#.*\n?`)
+
+// withCheckSum appends the checksum to the script body, so the fuzzer is not wasted on invalid checksums.
+func withCheckSum(t testing.TB, body []byte) []byte {
+	d, err := crypto.SecureHash(body)
+	require.NoError(t, err)
+	return append(body[:len(body):len(body)], d[:4]...)
+}
+
+func serializeTree(tree *ast.Tree) ([]byte, error) {
+	if tree.LibVersion < ast.LibV6 {
+		return SerializeTreeV1(tree)
+	}
+	return SerializeTreeV2(tree)
+}
+
+func FuzzParse(f *testing.F) {
+	files, err := filepath.Glob(filepath.Join("..", "..", "state", "testdata", "scripts", "*.base64"))
+	require.NoError(f, err)
+	for _, fn := range files {
+		b, err := os.ReadFile(fn)
+		require.NoError(f, err)
+		s := strings.TrimSpace(fuzzSeedCommentsRegex.ReplaceAllString(string(b), ""))
+		src, err := base64.StdEncoding.DecodeString(s)
+		require.NoError(f, err)
+		f.Add(src[:len(src)-4])
+	}
+	f.Fuzz(func(t *testing.T, body []byte) {
+		tree1, err := Parse(withCheckSum(t, body))
+		if err != nil {
+			return
+		}
+		data1, err := serializeTree(tree1)
+		require.NoError(t, err)
+		tree2, err := Parse(data1)
+		require.NoError(t, err)
+		tree1.Digest, tree2.Digest = [32]byte{}, [32]byte{}
+		require.Equal(t, tree1, tree2)
+	})
+}
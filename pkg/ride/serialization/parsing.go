@@ -98,12 +98,12 @@ func (p *parser) parseDApp() (*ast.Tree, error) {
 	}
 	tree.Meta = m
 
-	n, err := p.readInt(p.r)
+	n, err := p.readCount()
 	if err != nil {
 		return nil, err
 	}
 	declarations := make([]ast.Node, n)
-	for i := 0; i < int(n); i++ {
+	for i := 0; i < n; i++ {
 		d, err := p.readDeclaration()
 		if err != nil {
 			return nil, err
@@ -112,12 +112,12 @@ func (p *parser) parseDApp() (*ast.Tree, error) {
 	}
 	tree.Declarations = declarations
 
-	n, err = p.readInt(p.r)
+	n, err = p.readCount()
 	if err != nil {
 		return nil, err
 	}
 	functions := make([]ast.Node, n)
-	for i := 0; i < int(n); i++ {
+	for i := 0; i < n; i++ {
 		invocationParameter, err := p.readString()
 		if err != nil {
 			return nil, err
@@ -139,11 +139,11 @@ func (p *parser) parseDApp() (*ast.Tree, error) {
 	}
 	tree.Functions = functions
 
-	n, err = p.readInt(p.r)
+	vn, err := p.readInt(p.r)
 	if err != nil {
 		return nil, err
 	}
-	if n != 0 {
+	if vn != 0 {
 		invocationParameter, err := p.readString()
 		if err != nil {
 			return nil, err
@@ -266,11 +266,10 @@ func (p *parser) parseNext() (ast.Node, error) {
 		if err != nil {
 			return nil, err
 		}
-		argumentsCount, err := p.readInt(p.r)
+		ac, err := p.readCount()
 		if err != nil {
 			return nil, err
 		}
-		ac := int(argumentsCount)
 		arguments := make([]ast.Node, ac)
 		for i := 0; i < ac; i++ {
 			arg, err := p.parseNext()
@@ -302,8 +301,21 @@ func (p *parser) parseNext() (ast.Node, error) {
 	}
 }
 
-func (p *parser) readBytes() ([]byte, error) {
+// readCount reads the number of elements or bytes that follow. Every element takes at least one byte,
+// so the number is checked against the rest of the script before any allocation.
+func (p *parser) readCount() (int, error) {
 	n, err := p.readInt(p.r)
+	if err != nil {
+		return 0, err
+	}
+	if n < 0 || int(n) > p.r.Len() {
+		return 0, errors.Errorf("invalid number of elements %d, %d bytes left", n, p.r.Len())
+	}
+	return int(n), nil
+}
+
+func (p *parser) readBytes() ([]byte, error) {
+	n, err := p.readCount()
 	if err != nil {
 		return nil, err
 	}
@@ -365,11 +377,10 @@ func (p *parser) readDeclaration() (ast.Node, error) {
 		if err != nil {
 			return nil, err
 		}
-		argumentsCount, err := p.readInt(p.r)
+		ac, err := p.readCount()
 		if err != nil {
 			return nil, err
 		}
-		ac := int(argumentsCount)
 		arguments := make([]string, ac)
 		for i := 0; i < ac; i++ {
 			arg, err := p.readString()
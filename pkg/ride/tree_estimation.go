@@ -7,6 +7,9 @@ import (
 	"github.com/wavesplatform/gowaves/pkg/ride/ast"
 )
 
+// MaxEstimatorVersion is the latest version of tree estimator.
+const MaxEstimatorVersion = 4
+
 type TreeEstimation struct {
 	Estimation int            `cbor:"0,keyasint"`
 	Verifier   int            `cbor:"1,keyasint,omitempty"`
@@ -1,12 +1,15 @@
 package ride
 
 import (
+	"time"
+
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/ride/ast"
 	"github.com/wavesplatform/gowaves/pkg/types"
 )
 
-func CallVerifier(env environment, tree *ast.Tree) (Result, error) {
+func CallVerifier(env environment, tree *ast.Tree) (r Result, err error) {
+	defer func(start time.Time) { observeExecution(scriptKindVerifier, start, r, err) }(time.Now())
 	e, err := treeVerifierEvaluator(env, tree)
 	if err != nil {
 		return nil, RuntimeError.Wrap(err, "failed to call verifier")
@@ -14,7 +17,8 @@ func CallVerifier(env environment, tree *ast.Tree) (Result, error) {
 	return e.evaluate()
 }
 
-func CallFunction(env environment, tree *ast.Tree, name string, args proto.Arguments) (Result, error) {
+func CallFunction(env environment, tree *ast.Tree, name string, args proto.Arguments) (r Result, err error) {
+	defer func(start time.Time) { observeExecution(scriptKindCallable, start, r, err) }(time.Now())
 	if name == "" {
 		name = "default"
 	}
@@ -29,6 +33,11 @@ func CallFunction(env environment, tree *ast.Tree, name string, args proto.Argum
 	// After that instruction script/function is executed,
 	// so result of the execution and spent complexity should be considered outside.
 	rideResult, err := e.evaluate()
+	return completeFunctionResult(env, tree.LibVersion, name, e.complexity(), rideResult, err)
+}
+
+// completeFunctionResult adds the complexity and actions of internal invocations to the result of callable function.
+func completeFunctionResult(env environment, v ast.LibraryVersion, name string, complexity int, rideResult Result, err error) (Result, error) {
 	if err != nil {
 		// Evaluation failed we have to return a DAppResult that contains spent execution complexity
 		// Produced actions are not stored for failed transactions, no need to return them here
@@ -38,23 +47,23 @@ func CallFunction(env environment, tree *ast.Tree, name string, args proto.Argum
 				et.Wrap(err, "unhandled error"),
 				// Error was not handled in wrapped state properly,
 				// so we need to add both complexity from current evaluation and from internal invokes
-				e.complexity()+wrappedStateComplexity(env.state()),
+				complexity+wrappedStateComplexity(env.state()),
 			)
 		}
-		return nil, EvaluationErrorAddComplexity(err, e.complexity()+wrappedStateComplexity(env.state()))
+		return nil, EvaluationErrorAddComplexity(err, complexity+wrappedStateComplexity(env.state()))
 	}
 	dAppResult, ok := rideResult.(DAppResult)
 	if !ok { // Unexpected result type
 		return nil, EvaluationErrorAddComplexity(
 			EvaluationFailure.Errorf("invalid result of call function '%s'", name),
 			// New error, both complexities should be added
-			e.complexity()+wrappedStateComplexity(env.state()),
+			complexity+wrappedStateComplexity(env.state()),
 		)
 	}
-	if tree.LibVersion < ast.LibV5 { // Shortcut because no wrapped state before version 5
+	if v < ast.LibV5 { // Shortcut because no wrapped state before version 5
 		return rideResult, nil
 	}
-	maxChainInvokeComplexity, err := maxChainInvokeComplexityByVersion(ast.LibraryVersion(tree.LibVersion))
+	maxChainInvokeComplexity, err := maxChainInvokeComplexityByVersion(v)
 	if err != nil {
 		return nil, EvaluationFailure.Errorf("failed to get max chain invoke complexity: %v", err)
 	}
@@ -64,7 +73,7 @@ func CallFunction(env environment, tree *ast.Tree, name string, args proto.Argum
 	if dAppResult.complexity > maxChainInvokeComplexity {
 		return nil, EvaluationErrorAddComplexity(
 			RuntimeError.Errorf("evaluation complexity %d exceeds %d limit for library version %d",
-				dAppResult.complexity, maxChainInvokeComplexity, tree.LibVersion,
+				dAppResult.complexity, maxChainInvokeComplexity, v,
 			),
 			maxChainInvokeComplexity,
 		)
@@ -289,32 +289,36 @@ func (e *treeEvaluator) evaluate() (Result, error) {
 	if err != nil {
 		return nil, err // Evaluation failed somehow, then result just an error
 	}
+	return evaluationResult(e.env, r, e.complexity())
+}
 
+// evaluationResult converts the value of evaluated script to the result of evaluation.
+func evaluationResult(env environment, r rideType, complexity int) (Result, error) {
 	switch res := r.(type) {
 	case rideBoolean:
-		return ScriptResult{res: bool(res), complexity: e.complexity()}, nil
+		return ScriptResult{res: bool(res), complexity: complexity}, nil
 	case rideScriptResult, rideWriteSet, rideTransferSet:
-		a, err := objectToActions(e.env, res)
+		a, err := objectToActions(env, res)
 		if err != nil {
 			return nil, EvaluationFailure.Wrap(err, "failed to convert evaluation result")
 		}
-		return DAppResult{actions: a, complexity: e.complexity()}, nil
+		return DAppResult{actions: a, complexity: complexity}, nil
 	case rideList:
 		var actions []proto.ScriptAction
 		for _, item := range res {
-			a, err := convertToAction(e.env, item)
+			a, err := convertToAction(env, item)
 			if err != nil {
 				return nil, EvaluationFailure.Wrap(err, "failed to convert evaluation result")
 			}
 			actions = append(actions, a)
 		}
-		return DAppResult{actions: actions, complexity: e.complexity()}, nil
+		return DAppResult{actions: actions, complexity: complexity}, nil
 	case tuple2:
 		var actions []proto.ScriptAction
 		switch resAct := res.el1.(type) {
 		case rideList:
 			for _, item := range resAct {
-				a, err := convertToAction(e.env, item)
+				a, err := convertToAction(env, item)
 				if err != nil {
 					return nil, EvaluationFailure.Wrap(err, "failed to convert evaluation result")
 				}
@@ -323,7 +327,7 @@ func (e *treeEvaluator) evaluate() (Result, error) {
 		default:
 			return nil, EvaluationFailure.Errorf("unexpected result type '%T'", r)
 		}
-		return DAppResult{actions: actions, param: res.el2, complexity: e.complexity()}, nil
+		return DAppResult{actions: actions, param: res.el2, complexity: complexity}, nil
 	default:
 		return nil, EvaluationFailure.Errorf("unexpected result type '%T'", r)
 	}
@@ -2,223 +2,485 @@ package ride
 
 import (
 	"encoding/binary"
-
-	"github.com/pkg/errors"
 )
 
-type frame struct {
-	function bool
-	back     int
-	args     []rideType
+// noExpression marks a value of scope that has no expression to evaluate.
+const noExpression = -1
+
+// frameValue is a value of scope. The expression of value is evaluated on the first reference to it.
+type frameValue struct {
+	name       uint16
+	value      rideType
+	expression int
 }
 
-func newExpressionFrame(pos int) frame {
-	return frame{
-		back: pos,
-	}
+type userFunction struct {
+	function *function
+	sp       int
 }
 
-func newFunctionFrame(pos int, args []rideType) frame {
-	return frame{
-		function: true,
-		back:     pos,
-		args:     args,
-	}
+// Kinds of records about nodes of the tree under evaluation.
+const (
+	recordCondition byte = iota
+	recordLet
+	recordFunction
+	recordReference
+	recordProperty
+	recordNativeCall
+	recordUserCall
+)
+
+// Phases of evaluation of nodes. Conditions are evaluated in the first phase, objects of properties and arguments
+// of calls too. The second phase is the evaluation of branches, getting of property or call of function.
+// The third phase is the evaluation of user function body.
+const (
+	phaseFirst byte = iota
+	phaseSecond
+	phaseBody
+)
+
+// record describes a node of the tree which evaluation is not finished. Records keep everything that tree evaluator
+// does after evaluation of the node: they add complexity and push the call stack of error.
+type record struct {
+	kind       byte
+	phase      byte
+	name       uint16
+	argument   int
+	native     uint16
+	function   *function
+	sp         int
+	cl         int
+	complexity int
+	position   int
+	back       int
 }
 
+// vm evaluates the bytecode like the tree evaluator walks the tree. The scope of values is a stack of frames,
+// a new frame is created for each call of user function. Values of the current frame and frames below the
+// closure level of called function are visible.
 type vm struct {
 	env          environment
 	code         []byte
 	ip           int
 	constants    []rideType
-	functions    func(int) rideFunction
-	globals      func(int) rideConstructor
+	names        []string
+	functions    []function
+	natives      []native
+	invocation   int
+	ev           int
+	cc           complexityCalculator
+	globals      []rideConstructor
+	cache        []rideType
+	parameter    uint16
+	constructor  rideConstructor
 	stack        []rideType
-	calls        []frame
-	functionName func(int) string
+	values       []frameValue
+	frames       []int
+	cl           int
+	userFunction []userFunction
+	records      []record
 }
 
-func (m *vm) run() (Result, error) {
-	if m.stack != nil {
-		m.stack = m.stack[0:0]
+func newVM(env environment, p *program, invocation bool, entryPoint int) *vm {
+	m := &vm{
+		env:       env,
+		code:      p.Code,
+		ip:        entryPoint,
+		constants: p.Constants,
+		names:     p.names,
+		functions: p.functions,
+		natives:   p.natives,
+		cc:        &complexityCalculatorV1{},
+		globals:   p.globals,
+		cache:     make([]rideType, len(p.names)),
+		stack:     make([]rideType, 0, 8),
+		values:    make([]frameValue, 0, 8),
+		frames:    make([]int, 1, 4),
+		records:   make([]record, 0, 8),
 	}
-	if m.calls != nil {
-		m.calls = m.calls[0:0]
+	if invocation {
+		m.invocation = 1
+	}
+	if env.rideV6Activated() {
+		m.ev = 1
+		m.cc = &complexityCalculatorV2{}
+	}
+	return m
+}
+
+func (m *vm) declare(declarations []declaration) {
+	for _, d := range declarations {
+		if d.function {
+			m.userFunction = append(m.userFunction, userFunction{function: &m.functions[d.id], sp: len(m.frames)})
+			continue
+		}
+		m.values = append(m.values, frameValue{name: d.id, expression: d.expression})
+	}
+}
+
+// setParameter sets the constructor of the invocation parameter, it replaces a global constant with the same name.
+func (m *vm) setParameter(name uint16, constructor rideConstructor) {
+	m.parameter = name
+	m.constructor = constructor
+}
+
+func (m *vm) evaluate() (Result, error) {
+	r, err := m.run()
+	if err != nil {
+		return nil, err
 	}
-	m.ip = 0
-	for m.ip < len(m.code) {
+	return evaluationResult(m.env, r, m.cc.complexity())
+}
+
+func (m *vm) run() (rideType, error) {
+	for {
 		op := m.code[m.ip]
 		m.ip++
 		switch op {
+		case OpHalt:
+			return m.pop(), nil
+		case OpReturn:
+			m.ret()
 		case OpPush:
-			m.push(m.constant())
-		case OpPop:
-			_, err := m.pop()
-			if err != nil {
-				return nil, errors.Wrap(err, "failed to pop value")
+			id := m.arg16()
+			if err := m.enter(); err != nil {
+				return nil, err
 			}
+			m.push(m.constants[id])
 		case OpTrue:
+			if err := m.enter(); err != nil {
+				return nil, err
+			}
 			m.push(rideBoolean(true))
 		case OpFalse:
+			if err := m.enter(); err != nil {
+				return nil, err
+			}
 			m.push(rideBoolean(false))
 		case OpJump:
-			pos := m.arg16()
-			m.ip = pos
+			m.ip = m.arg32()
+		case OpCondition:
+			if err := m.enter(); err != nil {
+				return nil, err
+			}
+			m.records = append(m.records, record{kind: recordCondition})
 		case OpJumpIfFalse:
-			pos := m.arg16()
-			v, ok := m.current().(rideBoolean)
+			pos := m.arg32()
+			m.top().phase = phaseSecond
+			c, ok := m.pop().(rideBoolean)
 			if !ok {
-				return nil, errors.Errorf("not a boolean value '%v' of type '%T'", m.current(), m.current())
+				return nil, m.fail(RuntimeError.New("conditional is not a boolean"))
 			}
-			if !v {
+			if !c {
 				m.ip = pos
 			}
+		case OpEndCondition:
+			m.records = m.records[:len(m.records)-1]
+			m.cc.addConditionalComplexity()
+		case OpLet:
+			name := m.arg16()
+			pos := m.arg32()
+			if err := m.enter(); err != nil {
+				return nil, err
+			}
+			m.values = append(m.values, frameValue{name: uint16(name), expression: pos})
+			m.records = append(m.records, record{kind: recordLet, name: uint16(name)})
+		case OpEndLet:
+			m.values = m.values[:len(m.values)-1]
+			m.records = m.records[:len(m.records)-1]
+		case OpFunction:
+			id := m.arg16()
+			if err := m.enter(); err != nil {
+				return nil, err
+			}
+			f := &m.functions[id]
+			m.userFunction = append(m.userFunction, userFunction{function: f, sp: len(m.frames)})
+			m.records = append(m.records, record{kind: recordFunction, name: f.name})
+		case OpEndFunction:
+			m.userFunction = m.userFunction[:len(m.userFunction)-1]
+			m.records = m.records[:len(m.records)-1]
+		case OpRef:
+			name := uint16(m.arg16())
+			if err := m.enter(); err != nil {
+				return nil, err
+			}
+			if err := m.reference(name); err != nil {
+				return nil, err
+			}
+		case OpObject:
+			name := uint16(m.arg16())
+			if err := m.enter(); err != nil {
+				return nil, err
+			}
+			m.records = append(m.records, record{kind: recordProperty, name: name})
 		case OpProperty:
-			obj, err := m.pop()
+			r := m.top()
+			r.phase = phaseSecond
+			v, err := m.pop().get(m.names[r.name])
 			if err != nil {
-				return nil, errors.Wrap(err, "failed to get object")
+				return nil, m.fail(err)
 			}
-			prop := m.constant()
-			p, ok := prop.(rideString)
-			if !ok {
-				return nil, errors.Errorf("invalid property name type '%s'", prop.instanceOf())
+			m.records = m.records[:len(m.records)-1]
+			m.cc.addPropertyComplexity()
+			m.push(v)
+		case OpNativeCall:
+			id := uint16(m.arg16())
+			if err := m.enter(); err != nil {
+				return nil, err
 			}
-			v, err := obj.get(string(p))
-			if err != nil {
+			if err := m.startNativeCall(id); err != nil {
 				return nil, err
 			}
-			m.push(v)
-		case OpCall:
-			pos := m.arg16()
-			cnt := m.arg16()
-			in := make([]rideType, cnt)
-			for i := cnt - 1; i >= 0; i-- {
-				v, err := m.pop()
-				if err != nil {
-					return nil, errors.Wrapf(err, "failed to call function at position %d", pos)
-				}
-				in[i] = v
+		case OpUserCall:
+			name := uint16(m.arg16())
+			id := uint16(m.arg16())
+			if err := m.enter(); err != nil {
+				return nil, err
 			}
-			frame := newFunctionFrame(m.ip, in) // Creating new function frame with return position
-			m.calls = append(m.calls, frame)
-			m.ip = pos // Continue to function
-		case OpExternalCall:
-			// Before calling external function all parameters must be evaluated and placed on stack
-			id := m.arg16()
-			cnt := m.arg16()
-			in := make([]rideType, cnt) // Prepare input parameters for external call
-			for i := cnt - 1; i >= 0; i-- {
-				v, err := m.pop()
-				if err != nil {
-					return nil, errors.Wrapf(err, "failed to call external function '%s'", m.functionName(id))
-				}
-				in[i] = v
+			if uf, ok := m.lookupFunction(name); ok {
+				m.records = append(m.records, record{kind: recordUserCall, name: name, function: uf.function, sp: uf.sp})
+				continue
 			}
-			fn := m.functions(id)
-			if fn == nil {
-				return nil, errors.Errorf("external function '%s' not implemented", m.functionName(id))
+			if err := m.startNativeCall(id); err != nil {
+				return nil, err
 			}
-			res, err := fn(m.env, in...)
-			if err != nil {
+		case OpArgument:
+			m.top().argument++
+		case OpCall:
+			cnt := m.arg16()
+			if err := m.call(cnt); err != nil {
 				return nil, err
 			}
-			m.push(res)
-		case OpLoad: // Evaluate expression behind a LET declaration
-			pos := m.arg16()
-			frame := newExpressionFrame(m.ip) // Creating new function frame with return position
-			m.calls = append(m.calls, frame)
-			m.ip = pos // Continue to expression
-		case OpLoadLocal:
-			n := m.arg16()
-			for i := len(m.calls) - 1; i >= 0; i-- {
+		default:
+			return nil, m.fail(EvaluationFailure.Errorf("unknown code %#x", op))
+		}
+	}
+}
+
+// enter checks the complexity overflow on start of evaluation of a node, as the tree evaluator does.
+func (m *vm) enter() error {
+	if m.cc.overflow() {
+		return m.fail(RuntimeError.New("evaluation complexity overflow"))
+	}
+	return nil
+}
+
+func (m *vm) reference(name uint16) error {
+	i, ok := m.lookupValue(name)
+	if !ok {
+		m.cc.addReferenceComplexity()
+		if v, ok := m.constant(name); ok {
+			m.push(v)
+			return nil
+		}
+		return m.fail(RuntimeError.Errorf("value '%s' not found", m.names[name]))
+	}
+	v := m.values[i]
+	if v.value != nil {
+		m.push(v.value)
+		m.cc.addReferenceComplexity()
+		return nil
+	}
+	if v.expression == noExpression {
+		m.cc.addReferenceComplexity()
+		return m.fail(RuntimeError.Errorf("scope value '%s' is empty", m.names[name]))
+	}
+	m.records = append(m.records, record{kind: recordReference, name: name, position: i, back: m.ip})
+	m.ip = v.expression
+	return nil
+}
+
+func (m *vm) startNativeCall(id uint16) error {
+	n := &m.natives[id]
+	if n.functions[m.invocation] == nil {
+		return m.fail(EvaluationFailure.Errorf("failed to find system function '%s'", n.name))
+	}
+	if !n.hasCost[m.ev] {
+		return m.fail(EvaluationFailure.Errorf("failed to get cost of system function '%s'", n.name))
+	}
+	m.records = append(m.records, record{kind: recordNativeCall, native: id})
+	return nil
+}
+
+func (m *vm) call(cnt int) error {
+	r := m.top()
+	args := make([]rideType, cnt)
+	copy(args, m.stack[len(m.stack)-cnt:])
+	m.stack = m.stack[:len(m.stack)-cnt]
+	if r.kind == recordNativeCall {
+		r.phase = phaseSecond
+		n := &m.natives[r.native]
+		res, err := n.functions[m.invocation](m.env, args...)
+		if err != nil {
+			return m.fail(err)
+		}
+		m.records = m.records[:len(m.records)-1]
+		m.cc.addNativeFunctionComplexity(n.costs[m.ev])
+		m.push(res)
+		return nil
+	}
+	r.phase = phaseSecond
+	r.complexity = m.cc.complexity()
+	f := r.function
+	if len(args) != len(f.arguments) {
+		return m.fail(RuntimeError.Errorf("mismatched arguments number of user function '%s'", m.names[r.name]))
+	}
+	r.phase = phaseBody
+	m.frames = append(m.frames, len(m.values))
+	for i, arg := range args {
+		m.values = append(m.values, frameValue{name: f.arguments[i], value: arg, expression: noExpression})
+	}
+	r.cl, m.cl = m.cl, r.sp
+	r.back = m.ip
+	m.ip = f.body
+	return nil
+}
 
+// ret finishes evaluation of user function body or expression of value, the result stays on stack.
+func (m *vm) ret() {
+	r := m.top()
+	m.ip = r.back
+	if r.kind == recordReference {
+		if v := &m.values[r.position]; v.name == r.name && v.value == nil {
+			v.value = m.stack[len(m.stack)-1]
+		}
+		m.records = m.records[:len(m.records)-1]
+		m.cc.addReferenceComplexity()
+		return
+	}
+	m.values = m.values[:m.frames[len(m.frames)-1]]
+	m.frames = m.frames[:len(m.frames)-1]
+	m.cl = r.cl
+	initial := r.complexity
+	m.records = m.records[:len(m.records)-1]
+	if initial == m.cc.complexity() {
+		m.cc.addAdditionalUserFunctionComplexity()
+	}
+}
+
+// fail finishes evaluation of all nodes with the error, the complexity of nodes is added and the call stack of
+// error is filled as the tree evaluator does.
+func (m *vm) fail(err error) error {
+	for i := len(m.records) - 1; i >= 0; i-- {
+		r := &m.records[i]
+		switch r.kind {
+		case recordCondition:
+			if r.phase == phaseFirst {
+				err = EvaluationErrorPush(err, "failed to estimate the condition of if")
 			}
-			l := len(m.calls)
-			if l == 0 {
-				return nil, errors.New("failed to load argument on stack")
+			m.cc.addConditionalComplexity()
+		case recordLet:
+			err = EvaluationErrorPush(err, "failed to evaluate block after declaration of variable '%s'", m.names[r.name])
+		case recordFunction:
+			err = EvaluationErrorPush(err, "failed to evaluate block after declaration of function '%s'", m.names[r.name])
+		case recordReference:
+			err = EvaluationErrorPush(err, "failed to evaluate expression of scope value '%s'", m.names[r.name])
+			m.cc.addReferenceComplexity()
+		case recordProperty:
+			if r.phase == phaseFirst {
+				err = EvaluationErrorPush(err, "failed to evaluate an object to get property '%s' on it", m.names[r.name])
+			} else {
+				err = EvaluationErrorPush(err, "failed to get property '%s'", m.names[r.name])
 			}
-			frame := m.calls[l-1]
-			if l := len(frame.args); l < n+1 {
-				return nil, errors.New("invalid arguments count")
+			m.cc.addPropertyComplexity()
+		case recordNativeCall:
+			n := &m.natives[r.native]
+			if r.phase == phaseFirst {
+				err = EvaluationErrorPush(err, "failed to materialize argument %d", r.argument)
 			}
-			m.push(frame.args[n])
-		case OpReturn:
-			l := len(m.calls)
-			var f frame
-			f, m.calls = m.calls[l-1], m.calls[:l-1]
-			m.ip = f.back
-		case OpHalt:
-			if len(m.stack) > 0 {
-				v, err := m.pop()
-				if err != nil {
-					return nil, errors.Wrap(err, "failed to get result value")
+			err = EvaluationErrorPush(err, "failed to call system function '%s'", n.name)
+			m.cc.addNativeFunctionComplexity(n.costs[m.ev])
+		case recordUserCall:
+			switch r.phase {
+			case phaseFirst:
+				err = EvaluationErrorPush(err, "failed to materialize argument %d", r.argument)
+				err = EvaluationErrorPush(err, "failed to evaluate function '%s' body", m.names[r.name])
+			case phaseSecond:
+				if r.complexity == m.cc.complexity() {
+					m.cc.addAdditionalUserFunctionComplexity()
 				}
-				switch tv := v.(type) {
-				case rideBoolean:
-					return ScriptResult{res: bool(tv)}, nil
-				default:
-					return nil, errors.Errorf("unexpected result value '%v' of type '%T'", v, v)
+			case phaseBody:
+				err = EvaluationErrorPush(err, "failed to evaluate function '%s' body", m.names[r.name])
+				if r.complexity == m.cc.complexity() {
+					m.cc.addAdditionalUserFunctionComplexity()
 				}
 			}
-			return nil, errors.New("no result after script execution")
-		case OpGlobal:
-			id := m.arg16()
-			constructor := m.globals(id)
-			v := constructor(m.env)
-			m.push(v)
-		default:
-			return nil, errors.Errorf("unknown code %#x", op)
 		}
 	}
-	return nil, errors.New("broken code")
+	m.records = m.records[:0]
+	return err
 }
 
-func (m *vm) push(v rideType) {
-	m.stack = append(m.stack, v)
+// lookupValue returns the position of visible value with the name.
+func (m *vm) lookupValue(name uint16) (int, bool) {
+	top := len(m.frames) - 1
+	for i := len(m.values) - 1; i >= m.frames[top]; i-- {
+		if m.values[i].name == name {
+			return i, true
+		}
+	}
+	for f := m.cl - 1; f >= 0; f-- {
+		end := len(m.values)
+		if f < top {
+			end = m.frames[f+1]
+		}
+		for i := end - 1; i >= m.frames[f]; i-- {
+			if m.values[i].name == name {
+				return i, true
+			}
+		}
+	}
+	return 0, false
 }
 
-func (m *vm) pop() (rideType, error) {
-	if len(m.stack) == 0 {
-		return nil, errors.New("empty callStack")
+func (m *vm) lookupFunction(name uint16) (userFunction, bool) {
+	for i := len(m.userFunction) - 1; i >= 0; i-- {
+		if uf := m.userFunction[i]; uf.function.name == name {
+			return uf, true
+		}
 	}
-	value := m.stack[len(m.stack)-1]
-	m.stack = m.stack[:len(m.stack)-1]
-	return value, nil
+	return userFunction{}, false
 }
 
-func (m *vm) current() rideType {
-	return m.stack[len(m.stack)-1]
+// constant returns the global constant or the invocation parameter, constants are created once per evaluation.
+func (m *vm) constant(name uint16) (rideType, bool) {
+	if v := m.cache[name]; v != nil {
+		return v, true
+	}
+	c := m.globals[name]
+	if m.constructor != nil && name == m.parameter {
+		c = m.constructor
+	}
+	if c == nil {
+		return nil, false
+	}
+	v := c(m.env)
+	m.cache[name] = v
+	return v, true
+}
+
+func (m *vm) top() *record {
+	return &m.records[len(m.records)-1]
+}
+
+func (m *vm) push(v rideType) {
+	m.stack = append(m.stack, v)
+}
+
+func (m *vm) pop() rideType {
+	v := m.stack[len(m.stack)-1]
+	m.stack = m.stack[:len(m.stack)-1]
+	return v
 }
 
 func (m *vm) arg16() int {
-	//TODO: add check
 	res := binary.BigEndian.Uint16(m.code[m.ip : m.ip+2])
 	m.ip += 2
 	return int(res)
 }
 
-func (m *vm) constant() rideType {
-	//TODO: add check
-	return m.constants[m.arg16()]
+func (m *vm) arg32() int {
+	res := binary.BigEndian.Uint32(m.code[m.ip : m.ip+4])
+	m.ip += 4
+	return int(res)
 }
-
-//func (m *vm) scope() (*frame, int) {
-//	n := len(m.calls) - 1
-//	if n < 0 {
-//		return nil, n
-//	}
-//	return &m.calls[n], n
-//}
-
-//func (m *vm) resolve(name string) (int, error) {
-//	_ = name
-//	//TODO: implement
-//	return 0, errors.New("not implemented")
-//}
-
-//func (m *vm) returnPosition() int {
-//	if l := len(m.calls); l > 0 {
-//		return m.calls[l-1].back
-//	}
-//	return len(m.code)
-//}
@@ -2,9 +2,13 @@ package services
 
 import (
 	"github.com/wavesplatform/gowaves/pkg/libs/runner"
+	"github.com/wavesplatform/gowaves/pkg/miner/monitor"
+	"github.com/wavesplatform/gowaves/pkg/miner/selector"
+	"github.com/wavesplatform/gowaves/pkg/node/events"
 	"github.com/wavesplatform/gowaves/pkg/node/messages"
 	"github.com/wavesplatform/gowaves/pkg/node/peer_manager"
 	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/settings"
 	"github.com/wavesplatform/gowaves/pkg/state"
 	"github.com/wavesplatform/gowaves/pkg/types"
 )
@@ -13,6 +17,7 @@ type BlocksApplier interface {
 	BlockExists(state state.State, block *proto.Block) (bool, error)
 	Apply(state state.State, block []*proto.Block) (proto.Height, error)
 	ApplyMicro(state state.State, block *proto.Block) (proto.Height, error)
+	RollbackTo(state state.State, blockID proto.BlockID) error
 }
 
 type MicroBlockCache interface {
@@ -37,8 +42,13 @@ type Services struct {
 	LoggableRunner  runner.LogRunner
 	Time            types.Time
 	Wallet          types.EmbeddedWallet
+	Signer          types.Signer
 	MicroBlockCache MicroBlockCache
 	InternalChannel chan messages.InternalMessage
 	MinPeersMining  int
 	SkipMessageList *messages.SkipMessageList
+	TxSelector      selector.TransactionSelector
+	MinerMonitor    *monitor.Monitor
+	Events          *events.Bus
+	VoteFeatures    []settings.Feature
 }
@@ -0,0 +1,145 @@
+package signer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+// maxGuardRecords is the number of the latest signed key blocks remembered by guard.
+const maxGuardRecords = 1000
+
+type guardKey struct {
+	PublicKey crypto.PublicKey
+	Parent    proto.BlockID
+}
+
+type guardRecord struct {
+	PublicKey crypto.PublicKey `json:"publicKey"`
+	Parent    proto.BlockID    `json:"parent"`
+	KeyBlock  crypto.Digest    `json:"keyBlock"`
+}
+
+// Guard remembers key blocks signed by each key and prevents signing of a different key block on top of the same
+// parent. Blocks are compared without transactions, so the resulting blocks of microblocks built on the signed key
+// block are allowed. Records are kept in memory or in the file, if the path is provided.
+type Guard struct {
+	mu      sync.Mutex
+	path    string
+	records []guardRecord
+	index   map[guardKey]crypto.Digest
+}
+
+// NewGuard creates in-memory guard.
+func NewGuard() *Guard {
+	return &Guard{index: make(map[guardKey]crypto.Digest)}
+}
+
+// NewFileGuard creates guard that stores signed key blocks in the file at path and loads the existing records.
+func NewFileGuard(path string) (*Guard, error) {
+	g := NewGuard()
+	g.path = path
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return g, nil
+		}
+		return nil, errors.Wrap(err, "failed to read signed blocks file")
+	}
+	var records []guardRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse signed blocks file '%s'", path)
+	}
+	for _, r := range records {
+		g.add(r)
+	}
+	return g, nil
+}
+
+// Check returns ErrDoubleSign if another key block with the same generator and parent was signed before,
+// otherwise the block is remembered.
+func (g *Guard) Check(scheme proto.Scheme, block *proto.Block) error {
+	d, err := keyBlockDigest(scheme, block)
+	if err != nil {
+		return err
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	k := guardKey{PublicKey: block.GenPublicKey, Parent: block.Parent}
+	if signed, ok := g.index[k]; ok {
+		if signed != d {
+			return errors.Wrapf(ErrDoubleSign, "parent %s", block.Parent.String())
+		}
+		return nil
+	}
+	g.add(guardRecord{PublicKey: k.PublicKey, Parent: k.Parent, KeyBlock: d})
+	if err := g.save(); err != nil {
+		// The block is not signed, so it's forgotten to allow the retry.
+		g.records = g.records[:len(g.records)-1]
+		delete(g.index, k)
+		return err
+	}
+	return nil
+}
+
+func (g *Guard) add(r guardRecord) {
+	g.records = append(g.records, r)
+	g.index[guardKey{PublicKey: r.PublicKey, Parent: r.Parent}] = r.KeyBlock
+	if len(g.records) > maxGuardRecords {
+		old := g.records[0]
+		delete(g.index, guardKey{PublicKey: old.PublicKey, Parent: old.Parent})
+		g.records = append(g.records[:0], g.records[1:]...)
+	}
+}
+
+func (g *Guard) save() error {
+	if g.path == "" {
+		return nil
+	}
+	data, err := json.Marshal(g.records)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal signed blocks")
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(g.path), filepath.Base(g.path)+".*")
+	if err != nil {
+		return errors.Wrap(err, "failed to save signed blocks")
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return errors.Wrap(err, "failed to save signed blocks")
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return errors.Wrap(err, "failed to save signed blocks")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "failed to save signed blocks")
+	}
+	if err := os.Rename(tmp.Name(), g.path); err != nil {
+		return errors.Wrap(err, "failed to save signed blocks")
+	}
+	return nil
+}
+
+// keyBlockDigest calculates the hash of block header without transactions, it's the same for the key block and
+// all blocks extended with microblocks.
+func keyBlockDigest(scheme proto.Scheme, block *proto.Block) (crypto.Digest, error) {
+	h := block.BlockHeader
+	h.TransactionBlockLength = 0
+	h.TransactionCount = 0
+	h.TransactionsRoot = nil
+	h.BlockSignature = crypto.Signature{}
+	h.ID = proto.BlockID{}
+	kb := proto.Block{BlockHeader: h}
+	data, err := kb.BytesToSign(scheme)
+	if err != nil {
+		return crypto.Digest{}, errors.Wrap(err, "failed to serialize key block")
+	}
+	return crypto.FastHash(data)
+}
@@ -0,0 +1,36 @@
+//go:build !windows
+// +build !windows
+
+package signer
+
+import (
+	"net"
+	"os"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// Listen creates the Unix socket of signer address which only the owner of signer process is allowed to connect to.
+// The stale socket left by the previous run is removed.
+func Listen(address string) (net.Listener, error) {
+	path, err := SocketPath(address)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "failed to remove stale socket")
+	}
+	// The socket is created with restricted permissions, so there is no moment when others can connect to it.
+	mask := syscall.Umask(0177)
+	l, err := net.Listen(network, path)
+	syscall.Umask(mask)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to listen on '%s'", address)
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		_ = l.Close()
+		return nil, errors.Wrap(err, "failed to set permissions of socket")
+	}
+	return l, nil
+}
@@ -0,0 +1,15 @@
+//go:build windows
+// +build windows
+
+package signer
+
+import (
+	"net"
+
+	"github.com/pkg/errors"
+)
+
+// Listen is not supported, because permissions of Unix socket can't be restricted on Windows.
+func Listen(string) (net.Listener, error) {
+	return nil, errors.New("signer is not supported on Windows")
+}
@@ -0,0 +1,140 @@
+// Package signer provides implementations of types.Signer. Local signer uses secret keys of node's wallet, remote
+// signer forwards signing requests to a separate signer process which keeps secret keys out of the node.
+package signer
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+var (
+	ErrUnknownPublicKey = errors.New("no secret key for public key")
+	ErrDoubleSign       = errors.New("another block with the same parent was already signed")
+	ErrNotSupported     = errors.New("operation is not supported by remote signer")
+)
+
+type seeder interface {
+	Seeds() [][]byte
+}
+
+// Local signs with the keys generated from wallet seeds. Seeds are requested on every call, so the keys of wallet
+// loaded after the start are available immediately.
+type Local struct {
+	seeder seeder
+	scheme proto.Scheme
+	guard  *Guard
+	mu     sync.Mutex
+}
+
+// NewLocal creates signer over wallet seeds. Blocks are checked for double signing with given guard,
+// a new in-memory guard is used if guard is nil.
+func NewLocal(seeder seeder, scheme proto.Scheme, guard *Guard) *Local {
+	if guard == nil {
+		guard = NewGuard()
+	}
+	return &Local{seeder: seeder, scheme: scheme, guard: guard}
+}
+
+func (l *Local) PublicKeys() ([]crypto.PublicKey, error) {
+	seeds := l.seeder.Seeds()
+	r := make([]crypto.PublicKey, 0, len(seeds))
+	for _, s := range seeds {
+		_, pk, err := crypto.GenerateKeyPair(s)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate key pair")
+		}
+		r = append(r, pk)
+	}
+	return r, nil
+}
+
+func (l *Local) SignBlock(pk crypto.PublicKey, block *proto.Block) error {
+	if block.GenPublicKey != pk {
+		return errors.Errorf("block generator %s differs from signer %s", block.GenPublicKey.String(), pk.String())
+	}
+	sk, err := l.secretKey(pk)
+	if err != nil {
+		return err
+	}
+	// Checking and signing must be atomic, otherwise two different blocks could pass the check concurrently.
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.guard.Check(l.scheme, block); err != nil {
+		return err
+	}
+	return block.Sign(l.scheme, sk)
+}
+
+func (l *Local) SignMicroBlock(pk crypto.PublicKey, micro *proto.MicroBlock) error {
+	if micro.SenderPK != pk {
+		return errors.Errorf("microblock sender %s differs from signer %s", micro.SenderPK.String(), pk.String())
+	}
+	sk, err := l.secretKey(pk)
+	if err != nil {
+		return err
+	}
+	return micro.Sign(l.scheme, sk)
+}
+
+func (l *Local) SignMicroBlockInv(pk crypto.PublicKey, inv *proto.MicroBlockInv) error {
+	if inv.PublicKey != pk {
+		return errors.Errorf("microblock inv sender %s differs from signer %s", inv.PublicKey.String(), pk.String())
+	}
+	sk, err := l.secretKey(pk)
+	if err != nil {
+		return err
+	}
+	return inv.Sign(sk, l.scheme)
+}
+
+func (l *Local) VRF(pk crypto.PublicKey, message []byte) ([]byte, []byte, error) {
+	sk, err := l.secretKey(pk)
+	if err != nil {
+		return nil, nil, err
+	}
+	proof, err := crypto.SignVRF(sk, message)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to calculate VRF proof")
+	}
+	return proof, crypto.ComputeVRF(sk, message), nil
+}
+
+func (l *Local) SignTransaction(pk crypto.PublicKey, tx proto.Transaction) error {
+	sk, err := l.secretKey(pk)
+	if err != nil {
+		return err
+	}
+	return tx.Sign(l.scheme, sk)
+}
+
+func (l *Local) SignText(pk crypto.PublicKey, message []byte) (crypto.Signature, error) {
+	sk, err := l.secretKey(pk)
+	if err != nil {
+		return crypto.Signature{}, err
+	}
+	return crypto.Sign(sk, message)
+}
+
+func (l *Local) SharedKey(pk, other crypto.PublicKey, prefix []byte) ([]byte, error) {
+	sk, err := l.secretKey(pk)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.SharedKey(sk, other, prefix)
+}
+
+func (l *Local) secretKey(pk crypto.PublicKey) (crypto.SecretKey, error) {
+	for _, s := range l.seeder.Seeds() {
+		sk, public, err := crypto.GenerateKeyPair(s)
+		if err != nil {
+			return crypto.SecretKey{}, errors.Wrap(err, "failed to generate key pair")
+		}
+		if public == pk {
+			return sk, nil
+		}
+	}
+	return crypto.SecretKey{}, errors.Wrap(ErrUnknownPublicKey, pk.String())
+}
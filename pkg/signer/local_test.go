@@ -0,0 +1,119 @@
+package signer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/wallet"
+)
+
+const testScheme = proto.TestNetScheme
+
+var testSeed = []byte("signer test seed")
+
+func testPublicKey(t *testing.T) crypto.PublicKey {
+	_, pk, err := crypto.GenerateKeyPair(testSeed)
+	require.NoError(t, err)
+	return pk
+}
+
+func createBlock(t *testing.T, version proto.BlockVersion, pk crypto.PublicKey, parent proto.BlockID, ts uint64, txs proto.Transactions) *proto.Block {
+	nxt := proto.NxtConsensus{BaseTarget: 153722867, GenSignature: crypto.MustBytesFromBase58("11111111111111111111111111111111")}
+	b, err := proto.CreateBlock(txs, ts, parent, pk, nxt, version, []int16{14}, -1, testScheme)
+	require.NoError(t, err)
+	require.NoError(t, b.SetTransactionsRootIfPossible(testScheme))
+	return b
+}
+
+func createTransaction(t *testing.T, pk crypto.PublicKey) *proto.TransferWithProofs {
+	rcp := proto.NewRecipientFromAddress(proto.MustAddressFromPublicKey(testScheme, pk))
+	return proto.NewUnsignedTransferWithProofs(2, pk, proto.NewOptionalAssetWaves(), proto.NewOptionalAssetWaves(),
+		1650000000000, 100000000, 100000, rcp, nil)
+}
+
+func TestLocalSignBlock(t *testing.T) {
+	pk := testPublicKey(t)
+	parent := proto.NewBlockIDFromSignature(crypto.Signature{1})
+	for _, version := range []proto.BlockVersion{proto.RewardBlockVersion, proto.ProtobufBlockVersion} {
+		s := NewLocal(wallet.Stub{S: [][]byte{testSeed}}, testScheme, nil)
+
+		keyBlock := createBlock(t, version, pk, parent, 1650000000000, nil)
+		require.NoError(t, s.SignBlock(pk, keyBlock))
+		ok, err := keyBlock.VerifySignature(testScheme)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		// Signing of the same block again is allowed
+		require.NoError(t, s.SignBlock(pk, keyBlock))
+
+		tx := createTransaction(t, pk)
+		require.NoError(t, s.SignTransaction(pk, tx))
+		totalBlock := createBlock(t, version, pk, parent, 1650000000000, proto.Transactions{tx})
+		require.NoError(t, s.SignBlock(pk, totalBlock))
+		ok, err = totalBlock.VerifySignature(testScheme)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		otherBlock := createBlock(t, version, pk, parent, 1650000000001, nil)
+		err = s.SignBlock(pk, otherBlock)
+		assert.Equal(t, ErrDoubleSign, errors.Cause(err))
+		otherTotalBlock := createBlock(t, version, pk, parent, 1650000000001, proto.Transactions{tx})
+		err = s.SignBlock(pk, otherTotalBlock)
+		assert.Equal(t, ErrDoubleSign, errors.Cause(err))
+
+		otherParent := proto.NewBlockIDFromSignature(crypto.Signature{2})
+		require.NoError(t, s.SignBlock(pk, createBlock(t, version, pk, otherParent, 1650000000001, nil)))
+	}
+}
+
+func TestLocalUnknownKey(t *testing.T) {
+	s := NewLocal(wallet.Stub{}, testScheme, nil)
+	pks, err := s.PublicKeys()
+	require.NoError(t, err)
+	assert.Empty(t, pks)
+	pk := testPublicKey(t)
+	err = s.SignTransaction(pk, createTransaction(t, pk))
+	assert.Equal(t, ErrUnknownPublicKey, errors.Cause(err))
+	_, _, err = s.VRF(pk, []byte("message"))
+	assert.Equal(t, ErrUnknownPublicKey, errors.Cause(err))
+	_, err = s.SignText(pk, []byte("message"))
+	assert.Equal(t, ErrUnknownPublicKey, errors.Cause(err))
+}
+
+func TestLocalSignTextAndSharedKey(t *testing.T) {
+	otherSeed := []byte("other signer test seed")
+	s := NewLocal(wallet.Stub{S: [][]byte{testSeed, otherSeed}}, testScheme, nil)
+	pk := testPublicKey(t)
+	_, otherPK, err := crypto.GenerateKeyPair(otherSeed)
+	require.NoError(t, err)
+
+	sig, err := s.SignText(pk, []byte("message"))
+	require.NoError(t, err)
+	assert.True(t, crypto.Verify(pk, sig, []byte("message")))
+
+	k1, err := s.SharedKey(pk, otherPK, []byte("waves"))
+	require.NoError(t, err)
+	k2, err := s.SharedKey(otherPK, pk, []byte("waves"))
+	require.NoError(t, err)
+	assert.Equal(t, k1, k2)
+}
+
+func TestFileGuard(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "signed.json")
+	pk := testPublicKey(t)
+	parent := proto.NewBlockIDFromSignature(crypto.Signature{1})
+
+	g, err := NewFileGuard(path)
+	require.NoError(t, err)
+	require.NoError(t, g.Check(testScheme, createBlock(t, proto.ProtobufBlockVersion, pk, parent, 1650000000000, nil)))
+
+	g, err = NewFileGuard(path)
+	require.NoError(t, err)
+	err = g.Check(testScheme, createBlock(t, proto.ProtobufBlockVersion, pk, parent, 1650000000001, nil))
+	assert.Equal(t, ErrDoubleSign, errors.Cause(err))
+	assert.NoError(t, g.Check(testScheme, createBlock(t, proto.ProtobufBlockVersion, pk, parent, 1650000000000, nil)))
+}
@@ -0,0 +1,299 @@
+package signer
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/rpc"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/types"
+	"go.uber.org/zap"
+)
+
+const (
+	serviceName = "Signer"
+	network     = "unix"
+)
+
+// Request is the message of remote signer protocol. Signed objects are passed in their binary form and decoded
+// by the signer process, so it signs only valid blocks and transactions.
+type Request struct {
+	PublicKey crypto.PublicKey
+	Version   byte
+	Data      []byte
+}
+
+type PublicKeysReply struct {
+	PublicKeys []crypto.PublicKey
+}
+
+type SignatureReply struct {
+	Signature crypto.Signature
+}
+
+type VRFReply struct {
+	Proof []byte
+	Value []byte
+}
+
+// service exposes signer to remote clients.
+type service struct {
+	signer types.Signer
+	scheme proto.Scheme
+}
+
+func (s *service) PublicKeys(_ Request, reply *PublicKeysReply) error {
+	pks, err := s.signer.PublicKeys()
+	if err != nil {
+		return err
+	}
+	reply.PublicKeys = pks
+	return nil
+}
+
+func (s *service) SignBlock(req Request, reply *SignatureReply) error {
+	b := new(proto.Block)
+	var err error
+	if proto.BlockVersion(req.Version) >= proto.ProtobufBlockVersion {
+		err = b.UnmarshalFromProtobuf(req.Data)
+	} else {
+		err = b.UnmarshalBinary(req.Data, s.scheme)
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to decode block")
+	}
+	if err := s.signer.SignBlock(req.PublicKey, b); err != nil {
+		return err
+	}
+	reply.Signature = b.BlockSignature
+	return nil
+}
+
+func (s *service) SignMicroBlock(req Request, reply *SignatureReply) error {
+	m := new(proto.MicroBlock)
+	if err := m.UnmarshalBinary(req.Data, s.scheme); err != nil {
+		return errors.Wrap(err, "failed to decode microblock")
+	}
+	if err := s.signer.SignMicroBlock(req.PublicKey, m); err != nil {
+		return err
+	}
+	reply.Signature = m.Signature
+	return nil
+}
+
+func (s *service) SignMicroBlockInv(req Request, reply *SignatureReply) error {
+	inv := new(proto.MicroBlockInv)
+	if err := inv.UnmarshalBinary(req.Data); err != nil {
+		return errors.Wrap(err, "failed to decode microblock inv")
+	}
+	if err := s.signer.SignMicroBlockInv(req.PublicKey, inv); err != nil {
+		return err
+	}
+	reply.Signature = inv.Signature
+	return nil
+}
+
+func (s *service) VRF(req Request, reply *VRFReply) error {
+	proof, value, err := s.signer.VRF(req.PublicKey, req.Data)
+	if err != nil {
+		return err
+	}
+	reply.Proof, reply.Value = proof, value
+	return nil
+}
+
+// Serve accepts connections on the listener and serves signing requests with given signer until the context is done.
+func Serve(ctx context.Context, l net.Listener, signer types.Signer, scheme proto.Scheme) error {
+	srv := rpc.NewServer()
+	if err := srv.RegisterName(serviceName, &service{signer: signer, scheme: scheme}); err != nil {
+		return errors.Wrap(err, "failed to register signer service")
+	}
+	go func() {
+		<-ctx.Done()
+		_ = l.Close()
+	}()
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return errors.Wrap(err, "failed to accept connection")
+		}
+		zap.S().Debugf("Signer: Connection from %s", conn.RemoteAddr().String())
+		go srv.ServeConn(conn)
+	}
+}
+
+// Remote is the client of remote signer.
+type Remote struct {
+	path   string
+	scheme proto.Scheme
+	mu     sync.Mutex
+	client *rpc.Client
+}
+
+// NewRemote connects to the signer process. The address is a path of Unix socket in the form 'unix:/path/to/socket'.
+func NewRemote(address string, scheme proto.Scheme) (*Remote, error) {
+	path, err := SocketPath(address)
+	if err != nil {
+		return nil, err
+	}
+	c, err := rpc.Dial(network, path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to signer at '%s'", address)
+	}
+	return &Remote{path: path, scheme: scheme, client: c}, nil
+}
+
+// SocketPath returns the path of Unix socket of signer address. Signer protocol has no authentication,
+// so it is served only over Unix socket with access restricted by file permissions, other networks are refused.
+func SocketPath(address string) (string, error) {
+	if !strings.HasPrefix(address, network+":") {
+		return "", errors.Errorf("invalid signer address '%s', only Unix socket in form 'unix:/path/to/socket' is supported", address)
+	}
+	path := strings.TrimPrefix(strings.TrimPrefix(address, network+":"), "//")
+	if path == "" {
+		return "", errors.Errorf("invalid signer address '%s', empty socket path", address)
+	}
+	return path, nil
+}
+
+func (r *Remote) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.client.Close()
+}
+
+func (r *Remote) PublicKeys() ([]crypto.PublicKey, error) {
+	var reply PublicKeysReply
+	if err := r.call("PublicKeys", Request{}, &reply); err != nil {
+		return nil, err
+	}
+	return reply.PublicKeys, nil
+}
+
+func (r *Remote) SignBlock(pk crypto.PublicKey, block *proto.Block) error {
+	var (
+		data []byte
+		err  error
+	)
+	if block.Version >= proto.ProtobufBlockVersion {
+		data, err = block.MarshalToProtobuf(r.scheme)
+	} else {
+		data, err = block.MarshalBinary()
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to encode block")
+	}
+	var reply SignatureReply
+	if err := r.call("SignBlock", Request{PublicKey: pk, Version: byte(block.Version), Data: data}, &reply); err != nil {
+		return err
+	}
+	block.BlockSignature = reply.Signature
+	return nil
+}
+
+func (r *Remote) SignMicroBlock(pk crypto.PublicKey, micro *proto.MicroBlock) error {
+	data, err := micro.MarshalBinary(r.scheme)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode microblock")
+	}
+	var reply SignatureReply
+	if err := r.call("SignMicroBlock", Request{PublicKey: pk, Data: data}, &reply); err != nil {
+		return err
+	}
+	micro.Signature = reply.Signature
+	return nil
+}
+
+func (r *Remote) SignMicroBlockInv(pk crypto.PublicKey, inv *proto.MicroBlockInv) error {
+	data, err := inv.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "failed to encode microblock inv")
+	}
+	var reply SignatureReply
+	if err := r.call("SignMicroBlockInv", Request{PublicKey: pk, Data: data}, &reply); err != nil {
+		return err
+	}
+	inv.Signature = reply.Signature
+	return nil
+}
+
+func (r *Remote) VRF(pk crypto.PublicKey, message []byte) ([]byte, []byte, error) {
+	var reply VRFReply
+	if err := r.call("VRF", Request{PublicKey: pk, Data: message}, &reply); err != nil {
+		return nil, nil, err
+	}
+	return reply.Proof, reply.Value, nil
+}
+
+// SignTransaction is not supported, the signer process signs only blocks, microblocks and VRF messages of the generator.
+// Otherwise, anyone who could reach the node's signer could spend the funds of generator accounts.
+func (r *Remote) SignTransaction(crypto.PublicKey, proto.Transaction) error {
+	return errors.Wrap(ErrNotSupported, "transaction signing")
+}
+
+// SignText is not supported, because signatures of arbitrary messages could be used as signatures of transactions.
+func (r *Remote) SignText(crypto.PublicKey, []byte) (crypto.Signature, error) {
+	return crypto.Signature{}, errors.Wrap(ErrNotSupported, "text signing")
+}
+
+// SharedKey is not supported, the signer process holds only the keys of block generation.
+func (r *Remote) SharedKey(crypto.PublicKey, crypto.PublicKey, []byte) ([]byte, error) {
+	return nil, errors.Wrap(ErrNotSupported, "shared key derivation")
+}
+
+// call invokes the method of signer, the connection is reestablished once if the signer was restarted.
+func (r *Remote) call(method string, args, reply interface{}) error {
+	r.mu.Lock()
+	c := r.client
+	r.mu.Unlock()
+	err := c.Call(serviceName+"."+method, args, reply)
+	if err == rpc.ErrShutdown || err == io.ErrUnexpectedEOF {
+		c, err = r.reconnect(c)
+		if err != nil {
+			return err
+		}
+		err = c.Call(serviceName+"."+method, args, reply)
+	}
+	return remoteError(err)
+}
+
+func (r *Remote) reconnect(broken *rpc.Client) (*rpc.Client, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.client != broken {
+		return r.client, nil // Already reconnected by another call
+	}
+	c, err := rpc.Dial(network, r.path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to reconnect to signer")
+	}
+	_ = broken.Close()
+	r.client = c
+	return c, nil
+}
+
+// remoteError restores known errors of signer from their messages.
+func remoteError(err error) error {
+	se, ok := err.(rpc.ServerError)
+	if !ok {
+		return err
+	}
+	msg := string(se)
+	for _, known := range []error{ErrDoubleSign, ErrUnknownPublicKey} {
+		if msg == known.Error() {
+			return known
+		}
+		if strings.HasSuffix(msg, ": "+known.Error()) {
+			return errors.Wrap(known, strings.TrimSuffix(msg, ": "+known.Error()))
+		}
+	}
+	return err
+}
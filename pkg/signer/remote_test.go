@@ -0,0 +1,122 @@
+package signer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/wallet"
+)
+
+func TestRemote(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	path := filepath.Join(t.TempDir(), "signer.sock")
+	l, err := Listen("unix:" + path)
+	require.NoError(t, err)
+	fi, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+	done := make(chan error, 1)
+	go func() {
+		done <- Serve(ctx, l, NewLocal(wallet.Stub{S: [][]byte{testSeed}}, testScheme, nil), testScheme)
+	}()
+
+	r, err := NewRemote("unix:"+path, testScheme)
+	require.NoError(t, err)
+	defer func() { _ = r.Close() }()
+
+	pk := testPublicKey(t)
+	pks, err := r.PublicKeys()
+	require.NoError(t, err)
+	assert.Equal(t, []crypto.PublicKey{pk}, pks)
+
+	tx := createTransaction(t, pk)
+	err = r.SignTransaction(pk, tx)
+	assert.Equal(t, ErrNotSupported, errors.Cause(err))
+	require.NoError(t, NewLocal(wallet.Stub{S: [][]byte{testSeed}}, testScheme, nil).SignTransaction(pk, tx))
+
+	parent := proto.NewBlockIDFromSignature(crypto.Signature{1})
+	for _, version := range []proto.BlockVersion{proto.RewardBlockVersion, proto.ProtobufBlockVersion} {
+		keyBlock := createBlock(t, version, pk, parent, 1650000000000, nil)
+		require.NoError(t, r.SignBlock(pk, keyBlock))
+		ok, err := keyBlock.VerifySignature(testScheme)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		require.NoError(t, keyBlock.GenerateBlockID(testScheme))
+
+		totalBlock := createBlock(t, version, pk, parent, 1650000000000, proto.Transactions{tx})
+		require.NoError(t, r.SignBlock(pk, totalBlock))
+		require.NoError(t, totalBlock.GenerateBlockID(testScheme))
+		micro := &proto.MicroBlock{
+			VersionField:          byte(version),
+			SenderPK:              pk,
+			Transactions:          proto.Transactions{tx},
+			TransactionCount:      1,
+			Reference:             keyBlock.BlockID(),
+			TotalResBlockSigField: totalBlock.BlockSignature,
+			TotalBlockID:          totalBlock.BlockID(),
+		}
+		require.NoError(t, r.SignMicroBlock(pk, micro))
+		ok, err = micro.VerifySignature(testScheme)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		inv := proto.NewUnsignedMicroblockInv(pk, totalBlock.BlockID(), keyBlock.BlockID())
+		require.NoError(t, r.SignMicroBlockInv(pk, inv))
+		ok, err = inv.Verify(testScheme)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		err = r.SignBlock(pk, createBlock(t, version, pk, parent, 1650000000010, nil))
+		assert.Equal(t, ErrDoubleSign, errors.Cause(err))
+		parent = keyBlock.BlockID()
+	}
+
+	msg := crypto.MustBytesFromBase58("11111111111111111111111111111111")
+	proof, value, err := r.VRF(pk, msg)
+	require.NoError(t, err)
+	ok, expected, err := crypto.VerifyVRF(pk, msg, proof)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, expected, value)
+
+	_, otherPK, err := crypto.GenerateKeyPair([]byte("other seed"))
+	require.NoError(t, err)
+	_, _, err = r.VRF(otherPK, msg)
+	assert.Equal(t, ErrUnknownPublicKey, errors.Cause(err))
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestSocketPath(t *testing.T) {
+	for _, test := range []struct {
+		address string
+		path    string
+		valid   bool
+	}{
+		{"unix:/tmp/signer.sock", "/tmp/signer.sock", true},
+		{"unix:///tmp/signer.sock", "/tmp/signer.sock", true},
+		{"unix:", "", false},
+		{"127.0.0.1:6870", "", false},
+		{"tcp:127.0.0.1:6870", "", false},
+		{"/tmp/signer.sock", "", false},
+	} {
+		path, err := SocketPath(test.address)
+		if test.valid {
+			require.NoError(t, err, test.address)
+			assert.Equal(t, test.path, path)
+		} else {
+			assert.Error(t, err, test.address)
+		}
+	}
+	_, err := NewRemote("127.0.0.1:6870", testScheme)
+	assert.Error(t, err)
+}
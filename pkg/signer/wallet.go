@@ -0,0 +1,30 @@
+package signer
+
+import (
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/types"
+)
+
+// Wallet is the embedded wallet of the node which keys are held by the signer. Seeds are never available to the node,
+// so the wallet can't be loaded.
+type Wallet struct {
+	signer types.Signer
+}
+
+func NewWallet(signer types.Signer) *Wallet {
+	return &Wallet{signer: signer}
+}
+
+func (w *Wallet) SignTransactionWith(pk crypto.PublicKey, tx proto.Transaction) error {
+	return w.signer.SignTransaction(pk, tx)
+}
+
+func (w *Wallet) Load([]byte) error {
+	return errors.New("wallet keys are held by the remote signer")
+}
+
+func (w *Wallet) Seeds() [][]byte {
+	return nil
+}
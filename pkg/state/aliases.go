@@ -2,6 +2,7 @@ package state
 
 import (
 	"bytes"
+	"encoding/binary"
 	"io"
 
 	"github.com/pkg/errors"
@@ -12,7 +13,10 @@ import (
 
 var errAliasDisabled = errors.New("alias was stolen and is now disabled")
 
-const aliasRecordSize = 1 + proto.WavesAddressSize
+const (
+	aliasRecordSize         = 1 + proto.WavesAddressSize
+	disabledAliasRecordSize = 1
+)
 
 type aliasRecordForStateHashes struct {
 	addr  *proto.WavesAddress
@@ -69,29 +73,100 @@ func (r *aliasRecord) unmarshalBinary(data []byte) error {
 	return nil
 }
 
-type aliases struct {
-	db      keyvalue.IterableKeyVal
-	dbBatch keyvalue.Batch
-	hs      *historyStorage
+// disabledAliasRecord marks alias as disabled.
+// It is stored in history storage, so disabling is rolled back together with the block it happened at.
+type disabledAliasRecord struct {
+	disabled bool
+}
+
+func (r *disabledAliasRecord) marshalBinary() ([]byte, error) {
+	res := make([]byte, disabledAliasRecordSize)
+	proto.PutBool(res, r.disabled)
+	return res, nil
+}
+
+func (r *disabledAliasRecord) unmarshalBinary(data []byte) error {
+	if len(data) != disabledAliasRecordSize {
+		return errInvalidDataSize
+	}
+	var err error
+	r.disabled, err = proto.Bool(data)
+	return err
+}
+
+// addressAlias is an alias created by address and the height of the block with creating transaction.
+type addressAlias struct {
+	alias  string
+	height uint64
+}
+
+// addressAliasesRecord is the record of reverse index from address to all aliases created by it.
+type addressAliasesRecord struct {
+	aliases []addressAlias
+}
 
-	disabled map[string]bool
+func (r *addressAliasesRecord) marshalBinary() ([]byte, error) {
+	size := 0
+	for _, a := range r.aliases {
+		size += 8 + 2 + len(a.alias)
+	}
+	res := make([]byte, size)
+	pos := 0
+	for _, a := range r.aliases {
+		binary.BigEndian.PutUint64(res[pos:pos+8], a.height)
+		pos += 8
+		proto.PutStringWithUInt16Len(res[pos:], a.alias)
+		pos += 2 + len(a.alias)
+	}
+	return res, nil
+}
+
+func (r *addressAliasesRecord) unmarshalBinary(data []byte) error {
+	var aliases []addressAlias
+	for pos := 0; pos < len(data); {
+		if len(data[pos:]) < 8 {
+			return errInvalidDataSize
+		}
+		height := binary.BigEndian.Uint64(data[pos : pos+8])
+		pos += 8
+		alias, err := proto.StringWithUInt16Len(data[pos:])
+		if err != nil {
+			return err
+		}
+		pos += 2 + len(alias)
+		aliases = append(aliases, addressAlias{alias: alias, height: height})
+	}
+	r.aliases = aliases
+	return nil
+}
+
+func (r *addressAliasesRecord) contains(alias string) bool {
+	for _, a := range r.aliases {
+		if a.alias == alias {
+			return true
+		}
+	}
+	return false
+}
+
+type aliases struct {
+	hs     *historyStorage
+	scheme proto.Scheme
 
 	calculateHashes bool
 	hasher          *stateHasher
 }
 
-func newAliases(db keyvalue.IterableKeyVal, dbBatch keyvalue.Batch, hs *historyStorage, calcHashes bool) *aliases {
+func newAliases(hs *historyStorage, scheme proto.Scheme, calcHashes bool) *aliases {
 	return &aliases{
-		db:              db,
-		dbBatch:         dbBatch,
 		hs:              hs,
-		disabled:        make(map[string]bool),
+		scheme:          scheme,
 		calculateHashes: calcHashes,
 		hasher:          newStateHasher(),
 	}
 }
 
-func (a *aliases) createAlias(aliasStr string, info *aliasInfo, blockID proto.BlockID) error {
+func (a *aliases) createAlias(aliasStr string, info *aliasInfo, height uint64, blockID proto.BlockID) error {
 	key := aliasKey{aliasStr}
 	keyBytes := key.bytes()
 	keyStr := string(keyBytes)
@@ -109,7 +184,87 @@ func (a *aliases) createAlias(aliasStr string, info *aliasInfo, blockID proto.Bl
 			return err
 		}
 	}
-	return a.hs.addNewEntry(alias, keyBytes, recordBytes, blockID)
+	if err := a.hs.addNewEntry(alias, keyBytes, recordBytes, blockID); err != nil {
+		return err
+	}
+	return a.addAddressAlias(info.addr, addressAlias{alias: aliasStr, height: height}, blockID)
+}
+
+func (a *aliases) addAddressAlias(addr proto.WavesAddress, al addressAlias, blockID proto.BlockID) error {
+	key := addressAliasesKey{addressID: addr.ID()}
+	keyBytes := key.bytes()
+	record, err := a.newestAddressAliasesRecord(keyBytes)
+	if err != nil {
+		return err
+	}
+	if record.contains(al.alias) {
+		return nil
+	}
+	record.aliases = append(record.aliases, al)
+	recordBytes, err := record.marshalBinary()
+	if err != nil {
+		return err
+	}
+	return a.hs.addNewEntry(addressAliases, keyBytes, recordBytes, blockID)
+}
+
+func (a *aliases) newestAddressAliasesRecord(key []byte) (*addressAliasesRecord, error) {
+	recordBytes, err := a.hs.newestTopEntryData(key)
+	if err == keyvalue.ErrNotFound || err == errEmptyHist {
+		return &addressAliasesRecord{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var record addressAliasesRecord
+	if err := record.unmarshalBinary(recordBytes); err != nil {
+		return nil, errors.Errorf("failed to unmarshal record: %v", err)
+	}
+	return &record, nil
+}
+
+func (a *aliases) addressAliasesRecord(key []byte) (*addressAliasesRecord, error) {
+	recordBytes, err := a.hs.topEntryData(key)
+	if err == keyvalue.ErrNotFound || err == errEmptyHist {
+		return &addressAliasesRecord{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var record addressAliasesRecord
+	if err := record.unmarshalBinary(recordBytes); err != nil {
+		return nil, errors.Errorf("failed to unmarshal record: %v", err)
+	}
+	return &record, nil
+}
+
+// aliasesByAddr returns aliases that were created by the address and still belong to it.
+// Aliases that were stolen by another address are skipped, disabled aliases are returned marked as disabled.
+func (a *aliases) aliasesByAddr(addr proto.WavesAddress) ([]proto.AliasInfo, error) {
+	key := addressAliasesKey{addressID: addr.ID()}
+	record, err := a.addressAliasesRecord(key.bytes())
+	if err != nil {
+		return nil, err
+	}
+	res := make([]proto.AliasInfo, 0, len(record.aliases))
+	for _, al := range record.aliases {
+		aliasKey := aliasKey{alias: al.alias}
+		aliasRecord, err := a.recordByAlias(aliasKey.bytes())
+		if err != nil {
+			return nil, err
+		}
+		if aliasRecord.info.addr != addr {
+			continue
+		}
+		disabled, err := a.isDisabled(al.alias)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, proto.AliasInfo{
+			Alias:    *proto.NewAlias(a.scheme, al.alias),
+			Height:   al.height,
+			Disabled: disabled,
+		})
+	}
+	return res, nil
 }
 
 func (a *aliases) exists(aliasStr string) bool {
@@ -121,15 +276,28 @@ func (a *aliases) exists(aliasStr string) bool {
 }
 
 func (a *aliases) newestIsDisabled(aliasStr string) (bool, error) {
-	if _, ok := a.disabled[aliasStr]; ok {
-		return true, nil
-	}
-	return a.isDisabled(aliasStr)
+	key := disabledAliasKey{alias: aliasStr}
+	recordBytes, err := a.hs.newestTopEntryData(key.bytes())
+	return a.disabledFromRecordBytes(recordBytes, err)
 }
 
 func (a *aliases) isDisabled(aliasStr string) (bool, error) {
 	key := disabledAliasKey{alias: aliasStr}
-	return a.db.Has(key.bytes())
+	recordBytes, err := a.hs.topEntryData(key.bytes())
+	return a.disabledFromRecordBytes(recordBytes, err)
+}
+
+func (a *aliases) disabledFromRecordBytes(recordBytes []byte, err error) (bool, error) {
+	if err == keyvalue.ErrNotFound || err == errEmptyHist {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	var record disabledAliasRecord
+	if err := record.unmarshalBinary(recordBytes); err != nil {
+		return false, errors.Errorf("failed to unmarshal record: %v", err)
+	}
+	return record.disabled, nil
 }
 
 func (a *aliases) newestAddrByAlias(aliasStr string) (*proto.WavesAddress, error) {
@@ -188,55 +356,68 @@ func (a *aliases) addrByAlias(aliasStr string) (*proto.WavesAddress, error) {
 	return &record.info.addr, nil
 }
 
-func (a *aliases) disableStolenAliases() error {
-	// TODO: this action can not be rolled back now, do we need it?
-	iter, err := a.hs.newNewestTopEntryIterator(alias)
+// disableStolenAliases disables all aliases that were created more than once.
+// Disabling is stored as a part of the block, so it is reverted on rollback of the block.
+func (a *aliases) disableStolenAliases(blockID proto.BlockID) error {
+	stolen, err := a.stolenAliases()
+	if err != nil {
+		return err
+	}
+	r := disabledAliasRecord{disabled: true}
+	recordBytes, err := r.marshalBinary()
 	if err != nil {
 		return err
 	}
+	for _, aliasStr := range stolen {
+		zap.S().Debugf("Forbidding stolen alias %s", aliasStr)
+		key := disabledAliasKey{alias: aliasStr}
+		if err := a.hs.addNewEntry(disabledAlias, key.bytes(), recordBytes, blockID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *aliases) stolenAliases() ([]string, error) {
+	iter, err := a.hs.newNewestTopEntryIterator(alias)
+	if err != nil {
+		return nil, err
+	}
 	defer func() {
 		iter.Release()
 		if err := iter.Error(); err != nil {
 			zap.S().Fatalf("Iterator error: %v", err)
 		}
 	}()
+	var stolen []string
 	for iter.Next() {
 		keyBytes := iter.Key()
 		recordBytes := iter.Value()
 		var record aliasRecord
 		if err := record.unmarshalBinary(recordBytes); err != nil {
-			return errors.Errorf("failed to unmarshal record: %v", err)
+			return nil, errors.Errorf("failed to unmarshal record: %v", err)
 		}
 		var key aliasKey
 		if err := key.unmarshal(keyBytes); err != nil {
-			return err
+			return nil, err
 		}
 		if record.info.stolen {
-			zap.S().Debugf("Forbidding stolen alias %s", key.alias)
-			a.disabled[key.alias] = true
+			stolen = append(stolen, key.alias)
 		}
 	}
-	return nil
+	return stolen, nil
 }
 
 func (a *aliases) prepareHashes() error {
 	return a.hasher.stop()
 }
 
-func (a *aliases) flush() {
-	for alias := range a.disabled {
-		disabledKey := disabledAliasKey{alias}
-		a.dbBatch.Put(disabledKey.bytes(), void)
-	}
-}
-
 func (a *aliases) reset() {
-	a.disabled = make(map[string]bool)
 	a.hasher.reset()
 }
 
 func (a *aliases) disabledAliases() (map[string]struct{}, error) {
-	iter, err := a.db.NewKeyIterator([]byte{disabledAliasKeyPrefix})
+	iter, err := a.hs.newNewestTopEntryIterator(disabledAlias)
 	if err != nil {
 		return nil, err
 	}
@@ -250,11 +431,16 @@ func (a *aliases) disabledAliases() (map[string]struct{}, error) {
 	for iter.Next() {
 		keyBytes := iter.Key()
 		var key disabledAliasKey
-		err := key.unmarshal(keyBytes)
+		if err := key.unmarshal(keyBytes); err != nil {
+			return nil, err
+		}
+		disabled, err := a.disabledFromRecordBytes(iter.Value(), nil)
 		if err != nil {
 			return nil, err
 		}
-		als[key.alias] = struct{}{}
+		if disabled {
+			als[key.alias] = struct{}{}
+		}
 	}
 	return als, nil
 }
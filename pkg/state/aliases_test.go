@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 )
 
@@ -15,7 +16,7 @@ func TestCreateAlias(t *testing.T) {
 	aliasAddr, err := proto.NewAddressFromString(addr0)
 	assert.NoError(t, err, "NewAddressFromString() failed")
 	inf := &aliasInfo{false, aliasAddr}
-	err = to.entities.aliases.createAlias(aliasStr, inf, blockID0)
+	err = to.entities.aliases.createAlias(aliasStr, inf, 1, blockID0)
 	assert.NoError(t, err, "createAlias() failed")
 	addr, err := to.entities.aliases.newestAddrByAlias(aliasStr)
 	assert.NoError(t, err, "newestAddrByAlias() failed")
@@ -34,11 +35,11 @@ func TestDisableStolenAliases(t *testing.T) {
 	aliasAddr, err := proto.NewAddressFromString(addr0)
 	assert.NoError(t, err, "NewAddressFromString() failed")
 	inf := &aliasInfo{true, aliasAddr}
-	err = to.entities.aliases.createAlias(aliasStr, inf, blockID0)
+	err = to.entities.aliases.createAlias(aliasStr, inf, 1, blockID0)
 	assert.NoError(t, err, "createAlias() failed")
 	to.flush(t)
 
-	err = to.entities.aliases.disableStolenAliases()
+	err = to.entities.aliases.disableStolenAliases(blockID0)
 	assert.NoError(t, err, "disableStolenAliases() failed")
 	to.flush(t)
 	disabled, err := to.entities.aliases.isDisabled(aliasStr)
@@ -50,3 +51,53 @@ func TestDisableStolenAliases(t *testing.T) {
 	_, err = to.entities.aliases.newestAddrByAlias(aliasStr)
 	assert.Equal(t, errAliasDisabled, err)
 }
+
+func TestAliasesByAddr(t *testing.T) {
+	to := createStorageObjects(t, true)
+
+	addr, err := proto.NewAddressFromString(addr0)
+	require.NoError(t, err, "NewAddressFromString() failed")
+	thief, err := proto.NewAddressFromString(addr1)
+	require.NoError(t, err, "NewAddressFromString() failed")
+
+	to.addBlock(t, blockID0)
+	err = to.entities.aliases.createAlias("first", &aliasInfo{false, addr}, 1, blockID0)
+	require.NoError(t, err, "createAlias() failed")
+	err = to.entities.aliases.createAlias("second", &aliasInfo{false, addr}, 1, blockID0)
+	require.NoError(t, err, "createAlias() failed")
+	to.flush(t)
+	to.addBlock(t, blockID1)
+	err = to.entities.aliases.createAlias("second", &aliasInfo{true, thief}, 2, blockID1)
+	require.NoError(t, err, "createAlias() failed")
+	to.flush(t)
+
+	scheme := proto.MainNetScheme
+	aliases, err := to.entities.aliases.aliasesByAddr(addr)
+	require.NoError(t, err, "aliasesByAddr() failed")
+	assert.Equal(t, []proto.AliasInfo{{Alias: *proto.NewAlias(scheme, "first"), Height: 1}}, aliases)
+	aliases, err = to.entities.aliases.aliasesByAddr(thief)
+	require.NoError(t, err, "aliasesByAddr() failed")
+	assert.Equal(t, []proto.AliasInfo{{Alias: *proto.NewAlias(scheme, "second"), Height: 2}}, aliases)
+
+	err = to.entities.aliases.disableStolenAliases(blockID1)
+	require.NoError(t, err, "disableStolenAliases() failed")
+	to.flush(t)
+	aliases, err = to.entities.aliases.aliasesByAddr(thief)
+	require.NoError(t, err, "aliasesByAddr() failed")
+	assert.Equal(t, []proto.AliasInfo{{Alias: *proto.NewAlias(scheme, "second"), Height: 2, Disabled: true}}, aliases)
+
+	// Rollback removes stealing, disabling and the reverse index entry of the thief.
+	to.rollbackBlock(t, blockID1)
+	disabled, err := to.entities.aliases.isDisabled("second")
+	require.NoError(t, err, "isDisabled() failed")
+	assert.False(t, disabled)
+	aliases, err = to.entities.aliases.aliasesByAddr(addr)
+	require.NoError(t, err, "aliasesByAddr() failed")
+	assert.Equal(t, []proto.AliasInfo{
+		{Alias: *proto.NewAlias(scheme, "first"), Height: 1},
+		{Alias: *proto.NewAlias(scheme, "second"), Height: 1},
+	}, aliases)
+	aliases, err = to.entities.aliases.aliasesByAddr(thief)
+	require.NoError(t, err, "aliasesByAddr() failed")
+	assert.Empty(t, aliases)
+}
@@ -1,10 +1,13 @@
 package state
 
 import (
+	"fmt"
 	"math/big"
 	"runtime"
+	"strings"
 
 	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/ride"
 	"github.com/wavesplatform/gowaves/pkg/ride/ast"
 
 	"github.com/pkg/errors"
@@ -75,8 +78,13 @@ type StateInfo interface {
 	AllFeatures() ([]int16, error)
 	EstimatorVersion() (int, error)
 
+	// RewardsInfo returns block reward and monetary policy voting information at given height.
+	RewardsInfo(height proto.Height) (*proto.RewardsInfo, error)
+
 	// Aliases.
 	AddrByAlias(alias proto.Alias) (proto.WavesAddress, error)
+	// AliasesByAddr returns aliases created by the address that still belong to it, including disabled ones.
+	AliasesByAddr(addr proto.WavesAddress) ([]proto.AliasInfo, error)
 
 	// Accounts data storage.
 	RetrieveEntries(account proto.Recipient) ([]proto.DataEntry, error)
@@ -103,11 +111,20 @@ type StateInfo interface {
 	// Script information.
 	ScriptInfoByAccount(account proto.Recipient) (*proto.ScriptInfo, error)
 	ScriptInfoByAsset(assetID proto.AssetID) (*proto.ScriptInfo, error)
+	// ScriptComplexitiesByAccount returns stored complexities of account script by estimator versions.
+	ScriptComplexitiesByAccount(account proto.Recipient) (map[int]ride.TreeEstimation, error)
 	NewestScriptByAccount(account proto.Recipient) (*ast.Tree, error)
 	NewestScriptBytesByAccount(account proto.Recipient) (proto.Script, error)
 
 	// Leases.
 	IsActiveLeasing(leaseID crypto.Digest) (bool, error)
+	LeasingInfo(leaseID crypto.Digest) (*proto.LeaseInfo, error)
+	// LeasesByAddr returns IDs of leases sent or received by the address, only active leases are returned if activeOnly is set.
+	LeasesByAddr(addr proto.WavesAddress, activeOnly bool) ([]crypto.Digest, error)
+
+	// Exchange orders.
+	// OrderFillInfo returns filled volumes of the order and IDs of exchange transactions that filled it.
+	OrderFillInfo(orderID crypto.Digest) (*proto.OrderFillInfo, error)
 
 	// Invoke results.
 	InvokeResultByID(invokeID crypto.Digest) (*proto.ScriptResult, error)
@@ -199,7 +216,43 @@ func NewState(dataDir string, amend bool, params StateParams, settings *settings
 type StorageParams struct {
 	OffsetLen       int
 	HeaderOffsetLen int
-	DbParams        keyvalue.KeyValParams
+	// Compression of the block storage files, it must match the compression the state was created with.
+	// Use ConvertBlockStorage to change the compression of an existing state.
+	Compression BlockCompression
+	DbParams    keyvalue.KeyValParams
+}
+
+// BlockCompression is the compression of headers and transactions in the block storage files.
+type BlockCompression byte
+
+const (
+	// NoBlockCompression stores headers and transactions as is.
+	NoBlockCompression BlockCompression = iota
+	// SnappyBlockCompression compresses every header and transactions of every block with snappy.
+	SnappyBlockCompression
+)
+
+func (c BlockCompression) String() string {
+	switch c {
+	case NoBlockCompression:
+		return "none"
+	case SnappyBlockCompression:
+		return "snappy"
+	default:
+		return fmt.Sprintf("unknown(%d)", byte(c))
+	}
+}
+
+// ParseBlockCompression returns BlockCompression by its name: "none" or "snappy".
+func ParseBlockCompression(s string) (BlockCompression, error) {
+	switch strings.ToLower(s) {
+	case "none", "":
+		return NoBlockCompression, nil
+	case "snappy":
+		return SnappyBlockCompression, nil
+	default:
+		return 0, errors.Errorf("unknown block compression %q", s)
+	}
 }
 
 func DefaultStorageParams() StorageParams {
@@ -230,9 +283,13 @@ func DefaultTestingStorageParams() StorageParams {
 
 // ValidationParams are validation parameters.
 // VerificationGoroutinesNum specifies how many goroutines will be run for verification of transactions and blocks signatures.
+// BatchSignatureVerification enables verification of signatures in batches, it speeds up import of blocks, but
+// a batch may accept a signature with a malformed R point, see crypto.BatchVerifier. It is strictly for the import
+// of trusted blockchain files and must never be enabled for a node which applies blocks received from the network.
 type ValidationParams struct {
-	VerificationGoroutinesNum int
-	Time                      types.Time
+	VerificationGoroutinesNum  int
+	BatchSignatureVerification bool
+	Time                       types.Time
 }
 
 type StateParams struct {
@@ -244,6 +301,49 @@ type StateParams struct {
 	ProvideExtendedApi bool
 	// BuildStateHashes enables building and storing state hashes by height.
 	BuildStateHashes bool
+	// RideEngine selects the engine used to evaluate account and asset verifiers and invoked scripts.
+	RideEngine RideEngine
+}
+
+// RideEngine is the engine used to evaluate RIDE scripts.
+type RideEngine byte
+
+const (
+	// TreeRideEngine evaluates scripts with the tree evaluator.
+	TreeRideEngine RideEngine = iota
+	// VMRideEngine evaluates scripts on the bytecode VM where it is possible.
+	// The tree evaluator is used for scripts that fail to compile and for invocations of other DApps from scripts.
+	VMRideEngine
+	// ShadowRideEngine evaluates scripts with the tree evaluator and repeats the evaluation on the bytecode VM.
+	// Results of the tree evaluator are used, differences of results, actions, errors and complexity are logged.
+	ShadowRideEngine
+)
+
+func (e RideEngine) String() string {
+	switch e {
+	case TreeRideEngine:
+		return "tree"
+	case VMRideEngine:
+		return "vm"
+	case ShadowRideEngine:
+		return "shadow"
+	default:
+		return fmt.Sprintf("unknown(%d)", byte(e))
+	}
+}
+
+// ParseRideEngine returns RideEngine by its name: "tree", "vm" or "shadow".
+func ParseRideEngine(s string) (RideEngine, error) {
+	switch strings.ToLower(s) {
+	case "tree", "":
+		return TreeRideEngine, nil
+	case "vm":
+		return VMRideEngine, nil
+	case "shadow":
+		return ShadowRideEngine, nil
+	default:
+		return 0, errors.Errorf("unknown RIDE engine %q", s)
+	}
 }
 
 func DefaultStateParams() StateParams {
@@ -60,8 +60,9 @@ func newTxAppender(
 	settings *settings.BlockchainSettings,
 	stateDB *stateDB,
 	atx *addressTransactions,
+	engine RideEngine,
 ) (*txAppender, error) {
-	sc, err := newScriptCaller(state, stor, settings)
+	sc, err := newScriptCaller(state, stor, settings, engine)
 	if err != nil {
 		return nil, err
 	}
@@ -636,6 +637,7 @@ func (a *txAppender) applyAllDiffs() error {
 
 func (a *txAppender) moveChangesToHistoryStorage() error {
 	changes := a.diffStor.allChanges()
+	metricDiffChanges.Observe(float64(len(changes)))
 	a.diffStor.reset()
 	return a.diffApplier.applyBalancesChanges(changes)
 }
@@ -0,0 +1,96 @@
+package state
+
+import (
+	"encoding/binary"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+)
+
+const txFrameSize = 8 + 8
+
+// blockCodec compresses headers and transactions of blocks in the block storage files.
+type blockCodec interface {
+	encode(src []byte) []byte
+	decode(src []byte) ([]byte, error)
+}
+
+type snappyCodec struct{}
+
+func (snappyCodec) encode(src []byte) []byte {
+	return snappy.Encode(nil, src)
+}
+
+func (snappyCodec) decode(src []byte) ([]byte, error) {
+	return snappy.Decode(nil, src)
+}
+
+// newBlockCodec returns nil codec for uncompressed storage.
+func newBlockCodec(compression BlockCompression) (blockCodec, error) {
+	switch compression {
+	case NoBlockCompression:
+		return nil, nil
+	case SnappyBlockCompression:
+		return snappyCodec{}, nil
+	default:
+		return nil, errors.Errorf("unsupported block compression %s", compression)
+	}
+}
+
+// txFrame is the position of compressed transactions of a block.
+// Transactions of a block are compressed together and written to the blockchain file as a single frame.
+// Offsets of transactions in blockMeta and txInfo remain offsets in the uncompressed sequence of transactions,
+// so they grow monotonically and a transaction is read by decompressing the frame of its block.
+// Frames are stored by height in a separate file.
+type txFrame struct {
+	// txEndOffset is the end of block's transactions in the uncompressed sequence of transactions.
+	txEndOffset uint64
+	// fileEndOffset is the end of the frame in the blockchain file.
+	fileEndOffset uint64
+}
+
+func (f *txFrame) bytes() []byte {
+	buf := make([]byte, txFrameSize)
+	binary.BigEndian.PutUint64(buf[:8], f.txEndOffset)
+	binary.BigEndian.PutUint64(buf[8:16], f.fileEndOffset)
+	return buf
+}
+
+func (f *txFrame) unmarshal(data []byte) error {
+	if len(data) != txFrameSize {
+		return errInvalidDataSize
+	}
+	f.txEndOffset = binary.BigEndian.Uint64(data[:8])
+	f.fileEndOffset = binary.BigEndian.Uint64(data[8:16])
+	return nil
+}
+
+// txFrameCache keeps the last decompressed frame, transactions of a block are often read one after another.
+type txFrameCache struct {
+	mu     sync.Mutex
+	height uint64
+	start  uint64
+	txs    []byte
+}
+
+func (c *txFrameCache) get(height uint64) (uint64, []byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.txs == nil || c.height != height {
+		return 0, nil, false
+	}
+	return c.start, c.txs, true
+}
+
+func (c *txFrameCache) put(height, start uint64, txs []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.height = height
+	c.start = start
+	c.txs = txs
+}
+
+func (c *txFrameCache) reset() {
+	c.put(0, 0, nil)
+}
@@ -0,0 +1,211 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/importer"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+)
+
+func blockStorageSize(t testing.TB, dataDir string) int64 {
+	var size int64
+	for _, name := range []string{blockchainFileName, headersFileName, blockchainFramesFileName} {
+		info, err := os.Stat(filepath.Join(dataDir, blocksStorDir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		require.NoError(t, err)
+		size += info.Size()
+	}
+	return size
+}
+
+func TestCompressedRollback(t *testing.T) {
+	to := createStorageObjectsWithCompression(t, true, SnappyBlockCompression)
+	blocks, err := readBlocksFromTestPath(blocksNumber)
+	require.NoError(t, err)
+	for i := range blocks {
+		to.addRealBlock(t, &blocks[i])
+	}
+	// Read transaction by offset to fill the frame cache, then replace the block at the same height.
+	rollbackHeight := uint64(blocksNumber - 2)
+	for len(blocks[rollbackHeight].Transactions) == 0 || len(blocks[rollbackHeight+1].Transactions) == 0 {
+		rollbackHeight--
+	}
+	removed := blocks[rollbackHeight]
+	txID, err := removed.Transactions[0].GetID(proto.MainNetScheme)
+	require.NoError(t, err)
+	info, err := to.rw.transactionInfoByID(txID)
+	require.NoError(t, err)
+	tx, err := to.rw.readTransactionByOffset(info.offset)
+	require.NoError(t, err)
+	assert.Equal(t, removed.Transactions[0], tx)
+
+	require.NoError(t, to.rw.rollback(rollbackHeight))
+	assert.Equal(t, rollbackHeight, to.rw.height)
+	_, err = to.rw.readTransactionByOffset(info.offset)
+	assert.Error(t, err)
+	for i := range blocks[:rollbackHeight] {
+		block, err := to.rw.readBlock(blocks[i].BlockID())
+		require.NoError(t, err)
+		assert.Equal(t, blocks[i], *block)
+	}
+
+	// Add blocks with other transactions at removed heights.
+	replacement := blocks[rollbackHeight+1]
+	replacement.Parent = blocks[rollbackHeight-1].BlockID()
+	require.NoError(t, replacement.GenerateBlockID(proto.MainNetScheme))
+	to.addRealBlock(t, &replacement)
+	block, err := to.rw.readBlock(replacement.BlockID())
+	require.NoError(t, err)
+	assert.Equal(t, replacement, *block)
+	tx, err = to.rw.readTransactionByOffset(info.offset)
+	require.NoError(t, err)
+	assert.Equal(t, replacement.Transactions[0], tx)
+}
+
+func TestCompressionIncompatibility(t *testing.T) {
+	dataDir := t.TempDir()
+	params := DefaultTestingStateParams()
+	params.Compression = SnappyBlockCompression
+	manager, err := newStateManager(dataDir, true, params, settings.MainNetSettings)
+	require.NoError(t, err)
+	require.NoError(t, manager.Close())
+
+	_, err = newStateManager(dataDir, true, DefaultTestingStateParams(), settings.MainNetSettings)
+	assert.Error(t, err)
+}
+
+func TestConvertBlockStorage(t *testing.T) {
+	blocksPath, err := blocksPath()
+	require.NoError(t, err)
+	dataDir := t.TempDir()
+	params := DefaultTestingStateParams()
+	height := uint64(500)
+
+	manager, err := newStateManager(dataDir, true, params, settings.MainNetSettings)
+	require.NoError(t, err)
+	require.NoError(t, importer.ApplyFromFile(manager, blocksPath, height-1, 1))
+	blocks := make([]*proto.Block, height)
+	for h := uint64(1); h <= height; h++ {
+		blocks[h-1], err = manager.BlockByHeight(h)
+		require.NoError(t, err)
+	}
+	require.NoError(t, manager.Close())
+	uncompressedSize := blockStorageSize(t, dataDir)
+
+	checkBlocks := func(params StateParams, importMore bool) {
+		manager, err := newStateManager(dataDir, true, params, settings.MainNetSettings)
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, manager.Close())
+		}()
+		for h := uint64(1); h <= height; h++ {
+			block, err := manager.BlockByHeight(h)
+			require.NoError(t, err)
+			assert.Equal(t, blocks[h-1], block)
+			for _, tx := range block.Transactions {
+				id, err := tx.GetID(proto.MainNetScheme)
+				require.NoError(t, err)
+				stored, err := manager.TransactionByID(id)
+				require.NoError(t, err)
+				assert.Equal(t, tx, stored)
+			}
+		}
+		if importMore {
+			require.NoError(t, importer.ApplyFromFile(manager, blocksPath, 100, height))
+			require.NoError(t, manager.RollbackToHeight(height))
+		}
+	}
+
+	params.Compression = SnappyBlockCompression
+	require.NoError(t, ConvertBlockStorage(dataDir, params.StorageParams, SnappyBlockCompression))
+	assert.Less(t, blockStorageSize(t, dataDir), uncompressedSize)
+	checkBlocks(params, true)
+
+	params.Compression = NoBlockCompression
+	require.NoError(t, ConvertBlockStorage(dataDir, params.StorageParams, NoBlockCompression))
+	assert.Equal(t, uncompressedSize, blockStorageSize(t, dataDir))
+	checkBlocks(params, false)
+}
+
+func TestRecoverConversion(t *testing.T) {
+	to := createStorageObjects(t, true)
+	dir := t.TempDir()
+	tmpDir := filepath.Join(dir, conversionDir)
+	write := func(path, content string) {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+	check := func(name, content string) {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		assert.Equal(t, content, string(data))
+	}
+	write(filepath.Join(dir, blockchainFileName), "old blockchain")
+	write(filepath.Join(dir, headersFileName), "old headers")
+
+	// Conversion interrupted before the database update leaves the old files.
+	require.NoError(t, os.Mkdir(tmpDir, 0750))
+	write(filepath.Join(tmpDir, blockchainFileName), "new blockchain")
+	require.NoError(t, recoverConversion(dir, to.db))
+	assert.NoDirExists(t, tmpDir)
+	check(blockchainFileName, "old blockchain")
+
+	// Conversion interrupted after headers were moved is finished.
+	require.NoError(t, os.Mkdir(tmpDir, 0750))
+	write(filepath.Join(tmpDir, blockchainFileName), "new blockchain")
+	write(filepath.Join(tmpDir, blockchainFramesFileName), "new frames")
+	write(filepath.Join(dir, headersFileName), "new headers")
+	require.NoError(t, to.db.Put(conversionKeyBytes, []byte{byte(SnappyBlockCompression)}))
+	require.NoError(t, recoverConversion(dir, to.db))
+	assert.NoDirExists(t, tmpDir)
+	check(blockchainFileName, "new blockchain")
+	check(headersFileName, "new headers")
+	check(blockchainFramesFileName, "new frames")
+	has, err := to.db.Has(conversionKeyBytes)
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	// Frames file is removed on conversion to uncompressed storage.
+	require.NoError(t, to.db.Put(conversionKeyBytes, []byte{byte(NoBlockCompression)}))
+	require.NoError(t, recoverConversion(dir, to.db))
+	assert.NoFileExists(t, filepath.Join(dir, blockchainFramesFileName))
+	check(blockchainFileName, "new blockchain")
+}
+
+// BenchmarkImportCompression measures import speed and the size of block storage files with different compressions.
+func BenchmarkImportCompression(b *testing.B) {
+	const height = 2000
+	blocksPath, err := blocksPath()
+	require.NoError(b, err)
+	for _, compression := range []BlockCompression{NoBlockCompression, SnappyBlockCompression} {
+		b.Run(compression.String(), func(b *testing.B) {
+			params := DefaultTestingStateParams()
+			params.Compression = compression
+			var size int64
+			var elapsed time.Duration
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				dataDir := b.TempDir()
+				manager, err := newStateManager(dataDir, true, params, settings.MainNetSettings)
+				require.NoError(b, err)
+				b.StartTimer()
+				start := time.Now()
+				require.NoError(b, importer.ApplyFromFile(manager, blocksPath, height, 1))
+				elapsed += time.Since(start)
+				b.StopTimer()
+				require.NoError(b, manager.Close())
+				size = blockStorageSize(b, dataDir)
+				b.StartTimer()
+			}
+			b.ReportMetric(float64(height*b.N)/elapsed.Seconds(), "blocks/s")
+			b.ReportMetric(float64(size), "storage-bytes")
+		})
+	}
+}
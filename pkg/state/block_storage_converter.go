@@ -0,0 +1,291 @@
+package state
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/keyvalue"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"go.uber.org/zap"
+)
+
+const (
+	conversionDir            = "conversion"
+	conversionLogInterval    = 100000
+	blockchainFileName       = "blockchain"
+	headersFileName          = "headers"
+	blockchainFramesFileName = "blockchain_frames"
+)
+
+var conversionKeyBytes = []byte{rwConversionKeyPrefix}
+
+// blockStorageWriter writes headers and transactions of blocks to new block storage files.
+type blockStorageWriter struct {
+	codec blockCodec
+
+	blockchain, headers, txFrames          *os.File
+	blockchainBuf, headersBuf, txFramesBuf *bufio.Writer
+
+	blockchainLen, blockchainFileLen, headersLen uint64
+}
+
+func newBlockStorageWriter(dir string, codec blockCodec) (*blockStorageWriter, error) {
+	w := &blockStorageWriter{codec: codec}
+	var err error
+	if w.blockchain, err = os.Create(filepath.Join(dir, blockchainFileName)); err != nil {
+		return nil, err
+	}
+	w.blockchainBuf = bufio.NewWriter(w.blockchain)
+	if w.headers, err = os.Create(filepath.Join(dir, headersFileName)); err != nil {
+		return nil, err
+	}
+	w.headersBuf = bufio.NewWriter(w.headers)
+	if codec != nil {
+		if w.txFrames, err = os.Create(filepath.Join(dir, blockchainFramesFileName)); err != nil {
+			return nil, err
+		}
+		w.txFramesBuf = bufio.NewWriter(w.txFrames)
+	}
+	return w, nil
+}
+
+// writeBlock writes uncompressed header and transactions bytes and returns the new header offsets.
+func (w *blockStorageWriter) writeBlock(headerBytes, txsBytes []byte) (uint64, uint64, error) {
+	if w.codec != nil {
+		headerBytes = w.codec.encode(headerBytes)
+	}
+	if _, err := w.headersBuf.Write(headerBytes); err != nil {
+		return 0, 0, err
+	}
+	headerStart := w.headersLen
+	w.headersLen += uint64(len(headerBytes))
+	w.blockchainLen += uint64(len(txsBytes))
+	if w.codec == nil {
+		if _, err := w.blockchainBuf.Write(txsBytes); err != nil {
+			return 0, 0, err
+		}
+		w.blockchainFileLen = w.blockchainLen
+		return headerStart, w.headersLen, nil
+	}
+	if len(txsBytes) != 0 {
+		frameBytes := w.codec.encode(txsBytes)
+		if _, err := w.blockchainBuf.Write(frameBytes); err != nil {
+			return 0, 0, err
+		}
+		w.blockchainFileLen += uint64(len(frameBytes))
+	}
+	frame := txFrame{txEndOffset: w.blockchainLen, fileEndOffset: w.blockchainFileLen}
+	if _, err := w.txFramesBuf.Write(frame.bytes()); err != nil {
+		return 0, 0, err
+	}
+	return headerStart, w.headersLen, nil
+}
+
+func (w *blockStorageWriter) close() error {
+	files := []*os.File{w.blockchain, w.headers, w.txFrames}
+	bufs := []*bufio.Writer{w.blockchainBuf, w.headersBuf, w.txFramesBuf}
+	for i, f := range files {
+		if f == nil {
+			continue
+		}
+		if err := bufs[i].Flush(); err != nil {
+			return err
+		}
+		if err := f.Sync(); err != nil {
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ConvertBlockStorage rewrites block storage files of the state in dataDir with the given compression.
+// Offsets of transactions don't depend on compression, so only block metas are updated in the database.
+// The state must not be opened by other processes. New files are written to a separate directory, then
+// new block metas, the compression and the conversion marker are stored at once, and the files replace
+// the original ones. If the conversion is interrupted before the database update, the original storage is kept,
+// otherwise the files are moved in place by recoverConversion() when the state is opened.
+func ConvertBlockStorage(dataDir string, params StorageParams, compression BlockCompression) (err error) {
+	codec, err := newBlockCodec(compression)
+	if err != nil {
+		return err
+	}
+	dbDir := filepath.Join(dataDir, keyvalueDir)
+	if _, err := os.Stat(dbDir); err != nil {
+		return errors.Wrap(err, "failed to find state database")
+	}
+	blockStorageDir := filepath.Join(dataDir, blocksStorDir)
+	params.DbParams.BloomFilterParams.Store.WithPath(filepath.Join(blockStorageDir, "bloom"))
+	db, err := keyvalue.NewKeyVal(dbDir, params.DbParams)
+	if err != nil {
+		return errors.Wrap(err, "failed to open db")
+	}
+	dbBatch, err := db.NewBatch()
+	if err != nil {
+		return errors.Wrap(err, "failed to create db batch")
+	}
+	stateDB, err := newStateDB(db, dbBatch, StateParams{StorageParams: params})
+	if err != nil {
+		return errors.Wrap(err, "failed to create stateDB")
+	}
+	defer func() {
+		if closeErr := stateDB.close(); closeErr != nil && err == nil {
+			err = errors.Wrap(closeErr, "failed to close db")
+		}
+	}()
+	info, err := stateDB.stateInfo()
+	if err != nil {
+		return err
+	}
+	if int(info.Version) != StateVersion {
+		return errors.Errorf("incompatible storage version %d; current state supports only %d", info.Version, StateVersion)
+	}
+	// Scheme is not used, blocks are copied without unmarshalling.
+	// Block read writer also finishes the previous conversion if it was interrupted.
+	rw, err := newBlockReadWriter(blockStorageDir, params.OffsetLen, params.HeaderOffsetLen, info.BlockCompression, stateDB, proto.MainNetScheme)
+	if err != nil {
+		return errors.Wrap(err, "failed to open block storage")
+	}
+	current := info.BlockCompression
+	if current == compression {
+		zap.S().Infof("Block storage is already stored with compression '%s'", compression)
+		return rw.close()
+	}
+	tmpDir := filepath.Join(blockStorageDir, conversionDir)
+	if err := os.RemoveAll(tmpDir); err != nil {
+		return err
+	}
+	if err := os.Mkdir(tmpDir, 0750); err != nil {
+		return err
+	}
+	if err := copyBlocks(rw, tmpDir, codec); err != nil {
+		_ = rw.close()
+		return err
+	}
+	if err := rw.close(); err != nil {
+		return err
+	}
+	info.BlockCompression = compression
+	infoBytes, err := info.marshalBinary()
+	if err != nil {
+		return err
+	}
+	dbBatch.Put(stateInfoKeyBytes, infoBytes)
+	dbBatch.Put(conversionKeyBytes, []byte{byte(compression)})
+	if err := db.Flush(dbBatch); err != nil {
+		return errors.Wrap(err, "failed to update block metas")
+	}
+	// From this moment recoverConversion() finishes conversion after a crash.
+	if err := finishConversion(blockStorageDir, db, compression); err != nil {
+		return errors.Wrap(err, "failed to replace block storage files")
+	}
+	zap.S().Infof("Block storage is converted from '%s' to '%s'", current, compression)
+	return nil
+}
+
+// recoverConversion finishes conversion of block storage interrupted by a crash after the database was updated,
+// or removes new files of the conversion interrupted before. It must be called before block storage files are opened.
+func recoverConversion(dir string, db keyvalue.KeyValue) error {
+	has, err := db.Has(conversionKeyBytes)
+	if err != nil {
+		return err
+	}
+	if !has {
+		// Old files are still valid, new ones are not referenced by the database.
+		return os.RemoveAll(filepath.Join(dir, conversionDir))
+	}
+	data, err := db.Get(conversionKeyBytes)
+	if err != nil {
+		return err
+	}
+	if len(data) != 1 {
+		return errInvalidDataSize
+	}
+	zap.S().Info("Finishing interrupted conversion of block storage")
+	return finishConversion(dir, db, BlockCompression(data[0]))
+}
+
+// finishConversion moves new files of block storage in place and removes the conversion marker.
+// Files moved before are skipped, so it can be repeated after a crash.
+func finishConversion(dir string, db keyvalue.KeyValue, compression BlockCompression) error {
+	tmpDir := filepath.Join(dir, conversionDir)
+	names := []string{blockchainFileName, headersFileName}
+	if compression != NoBlockCompression {
+		names = append(names, blockchainFramesFileName)
+	} else if err := os.Remove(filepath.Join(dir, blockchainFramesFileName)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for _, name := range names {
+		newPath := filepath.Join(tmpDir, name)
+		if _, err := os.Stat(newPath); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+		if err := os.Rename(newPath, filepath.Join(dir, name)); err != nil {
+			return err
+		}
+	}
+	if err := os.RemoveAll(tmpDir); err != nil {
+		return err
+	}
+	return db.Delete(conversionKeyBytes)
+}
+
+// copyBlocks writes all blocks of rw to new files in dir and puts updated block metas to the db batch.
+func copyBlocks(rw *blockReadWriter, dir string, codec blockCodec) error {
+	w, err := newBlockStorageWriter(dir, codec)
+	if err != nil {
+		return err
+	}
+	protobufHeadersStart := uint64(0)
+	for height := uint64(1); height <= rw.height; height++ {
+		if rw.protobufActivated && height-1 == rw.protobufAfterHeight {
+			protobufHeadersStart = w.headersLen
+		}
+		blockID, err := rw.blockIDByHeight(height)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get block ID at height %d", height)
+		}
+		meta, err := rw.blockMeta(blockID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get block meta at height %d", height)
+		}
+		headerBytes, err := rw.readHeaderBytes(meta.headerStartOffset, meta.headerEndOffset)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read header at height %d", height)
+		}
+		txsBytes, err := rw.readBlockchain(meta.txStartOffset, meta.txEndOffset)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read transactions at height %d", height)
+		}
+		if w.blockchainLen != meta.txStartOffset {
+			return errors.Errorf("transactions at height %d start at %d, expected %d", height, meta.txStartOffset, w.blockchainLen)
+		}
+		meta.headerStartOffset, meta.headerEndOffset, err = w.writeBlock(headerBytes, txsBytes)
+		if err != nil {
+			return err
+		}
+		key := blockOffsetKey{blockID: blockID}
+		rw.dbBatch.Put(key.bytes(), meta.bytes())
+		if height%conversionLogInterval == 0 {
+			zap.S().Infof("Converted %d of %d blocks", height, rw.height)
+		}
+	}
+	if rw.protobufActivated {
+		if rw.protobufAfterHeight == rw.height {
+			protobufHeadersStart = w.headersLen
+		}
+		rw.storeProtobufInfo(&protobufInfo{
+			protobufTxStart:      rw.protobufTxStart,
+			protobufHeadersStart: protobufHeadersStart,
+			protobufAfterHeight:  rw.protobufAfterHeight,
+		})
+	}
+	return w.close()
+}
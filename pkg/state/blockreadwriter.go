@@ -3,6 +3,7 @@ package state
 import (
 	"bufio"
 	"encoding/binary"
+	"io"
 	"os"
 	"path/filepath"
 	"sync"
@@ -138,7 +139,7 @@ type blockReadWriter struct {
 
 	scheme proto.Scheme
 
-	// Series of transactions.
+	// Series of transactions, or series of compressed transactions of blocks if codec is set.
 	blockchain *os.File
 	// Series of BlockHeader.
 	headers *os.File
@@ -171,6 +172,17 @@ type blockReadWriter struct {
 	protobufTxStart, protobufHeadersStart uint64
 	protobufAfterHeight                   uint64
 
+	// Compression-related stuff, codec is nil for uncompressed storage.
+	// Headers are compressed one by one, so header offsets are offsets in the headers file.
+	// Transactions are compressed by blocks, blockchainLen is the length of uncompressed transactions,
+	// and blockchainFileLen is the size of the blockchain file.
+	codec             blockCodec
+	txFrames          *os.File
+	txFramesBuf       *bufio.Writer
+	blockchainFileLen uint64
+	curBlockTxs       []byte
+	frameCache        txFrameCache
+
 	mtx sync.RWMutex
 }
 
@@ -195,14 +207,22 @@ func newBlockReadWriter(
 	dir string,
 	offsetLen int,
 	headerOffsetLen int,
+	compression BlockCompression,
 	stateDB *stateDB,
 	scheme proto.Scheme,
 ) (*blockReadWriter, error) {
-	blockchain, blockchainSize, err := openOrCreateForAppending(filepath.Join(dir, "blockchain"))
+	codec, err := newBlockCodec(compression)
 	if err != nil {
 		return nil, err
 	}
-	headers, headersSize, err := openOrCreateForAppending(filepath.Join(dir, "headers"))
+	if err := recoverConversion(dir, stateDB.db); err != nil {
+		return nil, errors.Wrap(err, "failed to recover block storage conversion")
+	}
+	blockchain, blockchainSize, err := openOrCreateForAppending(filepath.Join(dir, blockchainFileName))
+	if err != nil {
+		return nil, err
+	}
+	headers, headersSize, err := openOrCreateForAppending(filepath.Join(dir, headersFileName))
 	if err != nil {
 		return nil, err
 	}
@@ -243,6 +263,13 @@ func newBlockReadWriter(
 		offsetLen:         offsetLen,
 		headerOffsetLen:   headerOffsetLen,
 		height:            height,
+		codec:             codec,
+		blockchainFileLen: blockchainSize,
+	}
+	if codec != nil {
+		if err := rw.openTxFrames(dir); err != nil {
+			return nil, err
+		}
 	}
 	if err := rw.loadProtobufInfo(); err != nil {
 		return nil, err
@@ -253,6 +280,25 @@ func newBlockReadWriter(
 	return rw, nil
 }
 
+func (rw *blockReadWriter) openTxFrames(dir string) error {
+	txFrames, txFramesSize, err := openOrCreateForAppending(filepath.Join(dir, blockchainFramesFileName))
+	if err != nil {
+		return err
+	}
+	rw.txFrames = txFrames
+	rw.txFramesBuf = bufio.NewWriter(txFrames)
+	// Length of transactions is restored from the last frame, syncWithDb() truncates the files to the state height anyway.
+	rw.blockchainLen = 0
+	if framesNum := txFramesSize / txFrameSize; framesNum > 0 {
+		frame, err := rw.txFrameByHeight(framesNum)
+		if err != nil {
+			return err
+		}
+		rw.blockchainLen = frame.txEndOffset
+	}
+	return nil
+}
+
 func (rw *blockReadWriter) syncFiles() error {
 	if err := rw.blockchain.Sync(); err != nil {
 		return err
@@ -263,6 +309,11 @@ func (rw *blockReadWriter) syncFiles() error {
 	if err := rw.blockHeight2ID.Sync(); err != nil {
 		return err
 	}
+	if rw.txFrames != nil {
+		if err := rw.txFrames.Sync(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -283,6 +334,11 @@ func (rw *blockReadWriter) finishBlock(blockID proto.BlockID) error {
 	rw.mtx.Lock()
 	defer rw.mtx.Unlock()
 	rw.addingBlock = false
+	if rw.codec != nil {
+		if err := rw.writeTxFrame(); err != nil {
+			return err
+		}
+	}
 	rw.curBlockMeta.txEndOffset = rw.blockchainLen
 	rw.curBlockMeta.headerEndOffset = rw.headersLen
 	rw.curBlockMeta.height = rw.height + 1
@@ -292,6 +348,23 @@ func (rw *blockReadWriter) finishBlock(blockID proto.BlockID) error {
 	return nil
 }
 
+// writeTxFrame compresses transactions of the current block and writes them as a single frame.
+func (rw *blockReadWriter) writeTxFrame() error {
+	if len(rw.curBlockTxs) != 0 {
+		frameBytes := rw.codec.encode(rw.curBlockTxs)
+		if _, err := rw.blockchainBuf.Write(frameBytes); err != nil {
+			return err
+		}
+		rw.blockchainFileLen += uint64(len(frameBytes))
+		rw.curBlockTxs = rw.curBlockTxs[:0]
+	}
+	frame := txFrame{txEndOffset: rw.blockchainLen, fileEndOffset: rw.blockchainFileLen}
+	if _, err := rw.txFramesBuf.Write(frame.bytes()); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (rw *blockReadWriter) marshalTransaction(tx proto.Transaction) ([]byte, error) {
 	var txBytes []byte
 	var err error
@@ -361,10 +434,15 @@ func (rw *blockReadWriter) writeTransaction(tx proto.Transaction, failed bool) e
 	if rw.blockchainLen > rw.offsetEnd {
 		return errors.Errorf("offset overflow: %d > %d", rw.blockchainLen, rw.offsetEnd)
 	}
-	// Write transaction itself.
+	// Write transaction itself, compressed transactions are written when the block is finished.
+	if rw.codec != nil {
+		rw.curBlockTxs = append(rw.curBlockTxs, txBytes...)
+		return nil
+	}
 	if _, err := rw.blockchainBuf.Write(txBytes); err != nil {
 		return err
 	}
+	rw.blockchainFileLen = rw.blockchainLen
 	return nil
 }
 
@@ -392,6 +470,9 @@ func (rw *blockReadWriter) writeBlockHeader(header *proto.BlockHeader) error {
 	if err != nil {
 		return err
 	}
+	if rw.codec != nil {
+		headerBytes = rw.codec.encode(headerBytes)
+	}
 	if _, err := rw.headersBuf.Write(headerBytes); err != nil {
 		return err
 	}
@@ -558,16 +639,118 @@ func (rw *blockReadWriter) newestTransactionInfoByID(txID []byte) (txInfo, error
 }
 
 func (rw *blockReadWriter) readTransactionSize(offset uint64) (uint32, error) {
-	sizeBytes := make([]byte, 4)
-	n, err := rw.blockchain.ReadAt(sizeBytes, int64(offset))
+	sizeBytes, err := rw.readBlockchain(offset, offset+4)
 	if err != nil {
 		return 0, err
-	} else if n != 4 {
-		return 0, errors.New("ReadAt did not read 4 bytes")
 	}
 	return binary.BigEndian.Uint32(sizeBytes), nil
 }
 
+// readBlockchain reads uncompressed transactions bytes between offsets.
+// For compressed storage the bytes must belong to transactions of a single block.
+func (rw *blockReadWriter) readBlockchain(start, end uint64) ([]byte, error) {
+	res := make([]byte, end-start)
+	if len(res) == 0 {
+		return res, nil
+	}
+	if rw.codec == nil {
+		n, err := rw.blockchain.ReadAt(res, int64(start))
+		if err != nil {
+			return nil, err
+		} else if n != len(res) {
+			return nil, errors.New("ReadAt did not read the whole range")
+		}
+		return res, nil
+	}
+	height, err := rw.txFrameHeight(start)
+	if err != nil {
+		return nil, err
+	}
+	frameStart, txs, err := rw.readTxFrame(height)
+	if err != nil {
+		return nil, err
+	}
+	if start < frameStart || end > frameStart+uint64(len(txs)) {
+		return nil, errors.Errorf("range [%d, %d) is out of block at height %d", start, end, height)
+	}
+	copy(res, txs[start-frameStart:end-frameStart])
+	return res, nil
+}
+
+func (rw *blockReadWriter) txFrameByHeight(height uint64) (txFrame, error) {
+	if height == 0 {
+		return txFrame{}, nil
+	}
+	frameBytes := make([]byte, txFrameSize)
+	n, err := rw.txFrames.ReadAt(frameBytes, int64((height-1)*txFrameSize))
+	if err != nil {
+		return txFrame{}, err
+	} else if n != txFrameSize {
+		return txFrame{}, errors.New("ReadAt did not read the whole frame")
+	}
+	var frame txFrame
+	if err := frame.unmarshal(frameBytes); err != nil {
+		return txFrame{}, err
+	}
+	return frame, nil
+}
+
+// txFrameHeight returns the height of the block whose transactions contain the offset.
+// Frames that are not flushed yet can't be read, they are treated as frames after the offset.
+func (rw *blockReadWriter) txFrameHeight(offset uint64) (uint64, error) {
+	low, high := uint64(1), rw.height+1
+	for low < high {
+		mid := low + (high-low)/2
+		frame, err := rw.txFrameByHeight(mid)
+		if err != nil && err != io.EOF {
+			return 0, err
+		}
+		if err == io.EOF || frame.txEndOffset > offset {
+			high = mid
+		} else {
+			low = mid + 1
+		}
+	}
+	if low > rw.height {
+		return 0, io.EOF
+	}
+	return low, nil
+}
+
+// readTxFrame returns the offset of block's transactions and decompressed transactions of the block.
+func (rw *blockReadWriter) readTxFrame(height uint64) (uint64, []byte, error) {
+	if start, txs, ok := rw.frameCache.get(height); ok {
+		return start, txs, nil
+	}
+	prev, err := rw.txFrameByHeight(height - 1)
+	if err != nil {
+		return 0, nil, err
+	}
+	frame, err := rw.txFrameByHeight(height)
+	if err != nil {
+		return 0, nil, err
+	}
+	if frame.fileEndOffset < prev.fileEndOffset || frame.txEndOffset < prev.txEndOffset {
+		return 0, nil, errors.Errorf("invalid frame at height %d", height)
+	}
+	frameBytes := make([]byte, frame.fileEndOffset-prev.fileEndOffset)
+	n, err := rw.blockchain.ReadAt(frameBytes, int64(prev.fileEndOffset))
+	if err != nil {
+		return 0, nil, err
+	} else if n != len(frameBytes) {
+		return 0, nil, errors.New("ReadAt did not read the whole frame")
+	}
+	txs, err := rw.codec.decode(frameBytes)
+	if err != nil {
+		return 0, nil, errors.Wrapf(err, "failed to decompress transactions at height %d", height)
+	}
+	if uint64(len(txs)) != frame.txEndOffset-prev.txEndOffset {
+		return 0, nil, errors.Errorf("invalid size of decompressed transactions at height %d", height)
+	}
+	rw.frameCache.put(height, prev.txEndOffset, txs)
+	return prev.txEndOffset, txs, nil
+}
+
 func (rw *blockReadWriter) readNewestTransaction(txID []byte) (proto.Transaction, bool, error) {
 	rw.mtx.RLock()
 	defer rw.mtx.RUnlock()
@@ -668,12 +851,9 @@ func (rw *blockReadWriter) readBlock(blockID proto.BlockID) (*proto.Block, error
 	}
 	blockStart := blockMeta.txStartOffset
 	blockEnd := blockMeta.txEndOffset
-	blockBytes := make([]byte, blockEnd-blockStart)
-	n, err := rw.blockchain.ReadAt(blockBytes, int64(blockStart))
+	blockBytes, err := rw.readBlockchain(blockStart, blockEnd)
 	if err != nil {
 		return nil, err
-	} else if n != len(blockBytes) {
-		return nil, errors.New("ReadAt did not read the whole block")
 	}
 	var res proto.Transactions
 	if rw.isProtobufTxOffset(blockStart) {
@@ -721,16 +901,11 @@ func (rw *blockReadWriter) cleanIDs(removalEdge proto.BlockID) error {
 	// Clean transaction IDs.
 	readPos := blockMeta.txEndOffset
 	for readPos < rw.blockchainLen {
-		txSizeBytes := make([]byte, 4)
-		if _, err := rw.blockchain.ReadAt(txSizeBytes, int64(readPos)); err != nil {
+		txSize, err := rw.readTransactionSize(readPos)
+		if err != nil {
 			return err
 		}
-		txSize := binary.BigEndian.Uint32(txSizeBytes)
 		readPos += 4
-		txBytes := make([]byte, txSize)
-		if _, err := rw.blockchain.ReadAt(txBytes, int64(readPos)); err != nil {
-			return err
-		}
 		tx, err := rw.txByBounds(readPos, readPos+uint64(txSize))
 		if err != nil {
 			return err
@@ -750,11 +925,33 @@ func (rw *blockReadWriter) truncate(newHeight, newBlockchainLen, newHeadersLen u
 	rw.mtx.Lock()
 	defer rw.mtx.Unlock()
 
+	newBlockchainFileLen := newBlockchainLen
+	if rw.codec != nil {
+		frame, err := rw.txFrameByHeight(newHeight)
+		if err != nil {
+			return err
+		}
+		if frame.txEndOffset != newBlockchainLen {
+			return errors.Errorf("frame at height %d ends at %d, expected %d", newHeight, frame.txEndOffset, newBlockchainLen)
+		}
+		newBlockchainFileLen = frame.fileEndOffset
+		// Remove frames.
+		newFramesLen := int64(newHeight * txFrameSize)
+		if err := rw.txFrames.Truncate(newFramesLen); err != nil {
+			return err
+		}
+		if _, err := rw.txFrames.Seek(newFramesLen, 0); err != nil {
+			return err
+		}
+		rw.txFramesBuf.Reset(rw.txFrames)
+		rw.curBlockTxs = rw.curBlockTxs[:0]
+		rw.frameCache.reset()
+	}
 	// Remove transactions.
-	if err := rw.blockchain.Truncate(int64(newBlockchainLen)); err != nil {
+	if err := rw.blockchain.Truncate(int64(newBlockchainFileLen)); err != nil {
 		return err
 	}
-	if _, err := rw.blockchain.Seek(int64(newBlockchainLen), 0); err != nil {
+	if _, err := rw.blockchain.Seek(int64(newBlockchainFileLen), 0); err != nil {
 		return err
 	}
 	// Remove headers.
@@ -783,6 +980,7 @@ func (rw *blockReadWriter) truncate(newHeight, newBlockchainLen, newHeadersLen u
 	// Decrease counters.
 	rw.height = newHeight
 	rw.blockchainLen = newBlockchainLen
+	rw.blockchainFileLen = newBlockchainFileLen
 	rw.headersLen = newHeadersLen
 	// Reset buffers.
 	rw.blockchainBuf.Reset(rw.blockchain)
@@ -817,6 +1015,10 @@ func (rw *blockReadWriter) reset() {
 	rw.height2IDCache = make(map[uint64]proto.BlockID)
 	rw.blockHeight2IDBuf.Reset(rw.blockHeight2ID)
 	rw.blockInfo = make(map[proto.BlockID]blockMeta)
+	if rw.codec != nil {
+		rw.txFramesBuf.Reset(rw.txFrames)
+		rw.curBlockTxs = rw.curBlockTxs[:0]
+	}
 }
 
 func (rw *blockReadWriter) flush() error {
@@ -829,6 +1031,11 @@ func (rw *blockReadWriter) flush() error {
 	if err := rw.blockHeight2IDBuf.Flush(); err != nil {
 		return err
 	}
+	if rw.codec != nil {
+		if err := rw.txFramesBuf.Flush(); err != nil {
+			return err
+		}
+	}
 	if err := rw.syncFiles(); err != nil {
 		return err
 	}
@@ -925,6 +1132,16 @@ func (rw *blockReadWriter) headerFromBytes(headerBytes []byte, protobuf bool) (*
 }
 
 func (rw *blockReadWriter) headerByBounds(start, end uint64) (*proto.BlockHeader, error) {
+	headerBytes, err := rw.readHeaderBytes(start, end)
+	if err != nil {
+		return nil, err
+	}
+	protobuf := rw.isProtobufHeaderOffset(start)
+	return rw.headerFromBytes(headerBytes, protobuf)
+}
+
+// readHeaderBytes reads uncompressed header bytes stored between offsets.
+func (rw *blockReadWriter) readHeaderBytes(start, end uint64) ([]byte, error) {
 	if end <= start {
 		return nil, errors.New("invalid bounds")
 	}
@@ -935,8 +1152,13 @@ func (rw *blockReadWriter) headerByBounds(start, end uint64) (*proto.BlockHeader
 	} else if n != len(headerBytes) {
 		return nil, errors.New("did not read the whole header")
 	}
-	protobuf := rw.isProtobufHeaderOffset(start)
-	return rw.headerFromBytes(headerBytes, protobuf)
+	if rw.codec != nil {
+		headerBytes, err = rw.codec.decode(headerBytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decompress header")
+		}
+	}
+	return headerBytes, nil
 }
 
 func (rw *blockReadWriter) txFromBytes(txBytes []byte, protobuf bool) (proto.Transaction, error) {
@@ -950,12 +1172,9 @@ func (rw *blockReadWriter) txByBounds(start, end uint64) (proto.Transaction, err
 	if end <= start {
 		return nil, errors.New("invalid bounds")
 	}
-	txBytes := make([]byte, end-start)
-	n, err := rw.blockchain.ReadAt(txBytes, int64(start))
+	txBytes, err := rw.readBlockchain(start, end)
 	if err != nil {
 		return nil, err
-	} else if n != len(txBytes) {
-		return nil, errors.New("did not read the whole tx")
 	}
 	protobuf := rw.isProtobufTxOffset(start)
 	return rw.txFromBytes(txBytes, protobuf)
@@ -983,5 +1202,10 @@ func (rw *blockReadWriter) close() error {
 	if err := rw.blockHeight2ID.Close(); err != nil {
 		return err
 	}
+	if rw.txFrames != nil {
+		if err := rw.txFrames.Close(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
@@ -254,7 +254,14 @@ func TestSimpleReadWrite(t *testing.T) {
 }
 
 func TestSimultaneousReadWrite(t *testing.T) {
-	to := createStorageObjects(t, true)
+	for _, compression := range []BlockCompression{NoBlockCompression, SnappyBlockCompression} {
+		t.Run(compression.String(), func(t *testing.T) {
+			testSimultaneousReadWrite(t, createStorageObjectsWithCompression(t, true, compression))
+		})
+	}
+}
+
+func testSimultaneousReadWrite(t *testing.T, to *testStorageObjects) {
 
 	blocks, err := readBlocksFromTestPath(blocksNumber)
 	if err != nil {
@@ -342,7 +349,14 @@ func TestReadNewest(t *testing.T) {
 }
 
 func TestSimultaneousReadDelete(t *testing.T) {
-	to := createStorageObjects(t, true)
+	for _, compression := range []BlockCompression{NoBlockCompression, SnappyBlockCompression} {
+		t.Run(compression.String(), func(t *testing.T) {
+			testSimultaneousReadDelete(t, createStorageObjectsWithCompression(t, true, compression))
+		})
+	}
+}
+
+func testSimultaneousReadDelete(t *testing.T, to *testStorageObjects) {
 
 	blocks, err := readBlocksFromTestPath(blocksNumber)
 	if err != nil {
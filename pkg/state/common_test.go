@@ -354,6 +354,10 @@ type testStorageObjects struct {
 }
 
 func createStorageObjects(t *testing.T, amend bool) *testStorageObjects {
+	return createStorageObjectsWithCompression(t, amend, NoBlockCompression)
+}
+
+func createStorageObjectsWithCompression(t *testing.T, amend bool, compression BlockCompression) *testStorageObjects {
 	db, err := keyvalue.NewKeyVal(t.TempDir(), defaultTestKeyValParams())
 	require.NoError(t, err)
 	// no need to close db because stateDB closes it
@@ -361,13 +365,15 @@ func createStorageObjects(t *testing.T, amend bool) *testStorageObjects {
 	dbBatch, err := db.NewBatch()
 	require.NoError(t, err)
 
-	stateDB, err := newStateDB(db, dbBatch, DefaultTestingStateParams())
+	params := DefaultTestingStateParams()
+	params.Compression = compression
+	stateDB, err := newStateDB(db, dbBatch, params)
 	require.NoError(t, err)
 	t.Cleanup(func() {
 		assert.NoError(t, stateDB.close())
 	})
 
-	rw, err := newBlockReadWriter(t.TempDir(), 8, 8, stateDB, proto.MainNetScheme)
+	rw, err := newBlockReadWriter(t.TempDir(), 8, 8, compression, stateDB, proto.MainNetScheme)
 	require.NoError(t, err)
 	t.Cleanup(func() {
 		assert.NoError(t, rw.close())
@@ -0,0 +1,68 @@
+package state
+
+import (
+	"container/list"
+
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/ride"
+)
+
+// compiledScript is the result of compilation of RIDE script to bytecode.
+// Compilation errors are cached too, so scripts that can't be compiled are not compiled again.
+type compiledScript struct {
+	script ride.RideScript
+	err    error
+}
+
+type compiledScriptsCacheEntry struct {
+	key   crypto.Digest
+	value compiledScript
+}
+
+// compiledScriptsCache is the LRU cache of compiled RIDE scripts.
+// Unlike scripts AST cache it's keyed by the hash of script bytes, so it doesn't need to be cleared
+// on script updates or rollbacks.
+type compiledScriptsCache struct {
+	maxSize int
+	items   map[crypto.Digest]*list.Element
+	order   *list.List // The newest entries are in the front.
+}
+
+func newCompiledScriptsCache(maxSize int) (*compiledScriptsCache, error) {
+	if maxSize <= 0 {
+		return nil, errors.Errorf("cache size must be > 0")
+	}
+	return &compiledScriptsCache{
+		maxSize: maxSize,
+		items:   make(map[crypto.Digest]*list.Element),
+		order:   list.New(),
+	}, nil
+}
+
+func (c *compiledScriptsCache) get(key crypto.Digest) (compiledScript, bool) {
+	e, ok := c.items[key]
+	if !ok {
+		return compiledScript{}, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*compiledScriptsCacheEntry).value, true
+}
+
+func (c *compiledScriptsCache) set(key crypto.Digest, value compiledScript) {
+	if e, ok := c.items[key]; ok {
+		e.Value.(*compiledScriptsCacheEntry).value = value
+		c.order.MoveToFront(e)
+		return
+	}
+	if c.order.Len() >= c.maxSize {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*compiledScriptsCacheEntry).key)
+	}
+	c.items[key] = c.order.PushFront(&compiledScriptsCacheEntry{key: key, value: value})
+}
+
+func (c *compiledScriptsCache) len() int {
+	return c.order.Len()
+}
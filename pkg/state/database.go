@@ -24,6 +24,8 @@ type stateInfo struct {
 	Amend              bool   `cbor:"1,keyasint,omitemtpy"`
 	HasExtendedApiData bool   `cbor:"2,keyasint,omitemtpy"`
 	HasStateHashes     bool   `cbor:"3,keyasint,omitemtpy"`
+	// BlockCompression is the compression of the block storage files, states created without it are uncompressed.
+	BlockCompression BlockCompression `cbor:"4,keyasint,omitemtpy"`
 }
 
 func (inf *stateInfo) marshalBinary() ([]byte, error) {
@@ -46,6 +48,7 @@ func saveStateInfo(db keyvalue.KeyValue, params StateParams) error {
 		Version:            StateVersion,
 		HasExtendedApiData: params.StoreExtendedApiData,
 		HasStateHashes:     params.BuildStateHashes,
+		BlockCompression:   params.Compression,
 	}
 	return putStateInfoToDB(db, info)
 }
@@ -363,6 +366,15 @@ func (s *stateDB) stateStoresApiData() (bool, error) {
 	return info.HasExtendedApiData, nil
 }
 
+// blockCompression returns the compression of the block storage files.
+func (s *stateDB) blockCompression() (BlockCompression, error) {
+	info, err := s.stateInfo()
+	if err != nil {
+		return 0, err
+	}
+	return info.BlockCompression, nil
+}
+
 func (s *stateDB) calculateNewRollbackMinHeight(newHeight uint64) (uint64, error) {
 	prevRollbackMinHeight, err := s.getRollbackMinHeight()
 	if err != nil {
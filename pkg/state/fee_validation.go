@@ -52,12 +52,26 @@ type assetParams struct {
 	reissuable bool
 }
 
-func isNFT(features featuresState, params assetParams) (bool, error) {
+// ActivationChecker reports whether the feature is activated.
+type ActivationChecker interface {
+	IsActivated(featureID int16) (bool, error)
+}
+
+// newestFeatures checks activation of features including ones activated in the not yet flushed blocks.
+type newestFeatures struct {
+	features featuresState
+}
+
+func (f newestFeatures) IsActivated(featureID int16) (bool, error) {
+	return f.features.newestIsActivated(featureID)
+}
+
+func isNFT(features ActivationChecker, params assetParams) (bool, error) {
 	nftAsset := params.quantity == 1 && params.decimals == 0 && !params.reissuable
 	if !nftAsset {
 		return false, nil
 	}
-	nftActivated, err := features.newestIsActivated(int16(settings.ReduceNFTFee))
+	nftActivated, err := features.IsActivated(int16(settings.ReduceNFTFee))
 	if err != nil {
 		return false, err
 	}
@@ -65,6 +79,11 @@ func isNFT(features featuresState, params assetParams) (bool, error) {
 }
 
 func minFeeInUnits(params *feeValidationParams, tx proto.Transaction) (uint64, error) {
+	return MinFeeInUnits(newestFeatures{params.stor.features}, params.settings.AddressSchemeCharacter, tx)
+}
+
+// MinFeeInUnits returns the minimal fee of the transaction in FeeUnit without extra fee for scripts.
+func MinFeeInUnits(features ActivationChecker, scheme proto.Scheme, tx proto.Transaction) (uint64, error) {
 	txType := tx.GetTypeInfo().Type
 	baseFee, ok := feeConstants[txType]
 	if !ok {
@@ -82,7 +101,7 @@ func minFeeInUnits(params *feeValidationParams, tx proto.Transaction) (uint64, e
 		default:
 			return 0, errors.New("failed to convert interface to Issue transaction")
 		}
-		nft, err := isNFT(params.stor.features, asset)
+		nft, err := isNFT(features, asset)
 		if err != nil {
 			return 0, err
 		}
@@ -101,18 +120,15 @@ func minFeeInUnits(params *feeValidationParams, tx proto.Transaction) (uint64, e
 		if !ok {
 			return 0, errors.New("failed to convert interface to DataTransaction")
 		}
-		smartAccountsActive, err := params.stor.features.newestIsActivated(int16(settings.SmartAccounts))
+		smartAccountsActive, err := features.IsActivated(int16(settings.SmartAccounts))
 		if err != nil {
 			return 0, err
 		}
-		isRideV6Activated, err := params.stor.features.newestIsActivated(int16(settings.RideV6))
+		isRideV6Activated, err := features.IsActivated(int16(settings.RideV6))
 		if err != nil {
 			return 0, err
 		}
-		var (
-			scheme         = params.settings.AddressSchemeCharacter
-			dtxBytesForFee int
-		)
+		var dtxBytesForFee int
 		switch {
 		case isRideV6Activated:
 			dtxBytesForFee = dtx.Entries.PayloadSize()
@@ -136,7 +152,7 @@ func minFeeInUnits(params *feeValidationParams, tx proto.Transaction) (uint64, e
 		}
 		fee += uint64((dtxBytesForFee - 1) / 1024)
 	case proto.ReissueTransaction, proto.SponsorshipTransaction:
-		blockV5Activated, err := params.stor.features.newestIsActivated(int16(settings.BlockV5))
+		blockV5Activated, err := features.IsActivated(int16(settings.BlockV5))
 		if err != nil {
 			return 0, err
 		}
@@ -144,7 +160,7 @@ func minFeeInUnits(params *feeValidationParams, tx proto.Transaction) (uint64, e
 			return fee / 1000, nil
 		}
 	case proto.SetScriptTransaction:
-		isRideV6Activated, err := params.stor.features.newestIsActivated(int16(settings.RideV6))
+		isRideV6Activated, err := features.IsActivated(int16(settings.RideV6))
 		if err != nil {
 			return 0, err
 		}
@@ -192,6 +208,12 @@ func newTxCosts(smartAssets, smartAccounts uint64, isRideV5Activated bool, compl
 	}
 }
 
+// ScriptsExtraFee returns the extra fee for smart assets and smart account used by the transaction.
+// Complexity is the complexity of the account verifier.
+func ScriptsExtraFee(smartAssets, smartAccounts uint64, isRideV5Activated bool, complexity int, isAccountScripted bool) uint64 {
+	return newTxCosts(smartAssets, smartAccounts, isRideV5Activated, complexity, isAccountScripted).total
+}
+
 // toString is mostly added for integration tests compatibility with Scala.
 func (tc *txCosts) toString() string {
 	if tc.smartAccounts == 0 && tc.smartAssets == 0 {
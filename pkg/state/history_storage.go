@@ -39,6 +39,11 @@ const (
 	hitSource
 	feeDistr
 	accountOriginalEstimatorVersion
+	disabledAlias
+	addressAliases
+	orderTransactionsNum
+	orderTransactions
+	addressLeases
 )
 
 type blockchainEntityProperties struct {
@@ -187,6 +192,35 @@ var properties = map[blockchainEntity]blockchainEntityProperties{
 		needToCut:    true,
 		fixedSize:    false,
 	},
+	disabledAlias: {
+		needToFilter: true,
+		needToCut:    true,
+		fixedSize:    true,
+		recordSize:   disabledAliasRecordSize + 4,
+	},
+	addressAliases: {
+		needToFilter: true,
+		needToCut:    true,
+		fixedSize:    false,
+	},
+	orderTransactionsNum: {
+		needToFilter: true,
+		needToCut:    true,
+		fixedSize:    true,
+		recordSize:   orderTransactionsNumRecordSize + 4,
+	},
+	orderTransactions: {
+		needToFilter: true,
+		needToCut:    true,
+		fixedSize:    true,
+		recordSize:   orderTransactionRecordSize + 4,
+	},
+	addressLeases: {
+		needToFilter: true,
+		needToCut:    true,
+		fixedSize:    true,
+		recordSize:   1 + 4,
+	},
 }
 
 type historyEntry struct {
@@ -220,7 +220,7 @@ func (ia *invokeApplier) countIssuedAssets(actions []proto.ScriptAction) (uint64
 		switch a := action.(type) {
 		case *proto.IssueScriptAction:
 			assetParams := assetParams{a.Quantity, a.Decimals, a.Reissuable}
-			nft, err := isNFT(ia.stor.features, assetParams)
+			nft, err := isNFT(newestFeatures{ia.stor.features}, assetParams)
 			if err != nil {
 				return 0, err
 			}
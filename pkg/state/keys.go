@@ -20,9 +20,11 @@ const (
 	leaseKeySize            = 1 + crypto.DigestSize
 	aliasKeySize            = 1 + 2 + proto.AliasMaxLength
 	disabledAliasKeySize    = 1 + 2 + proto.AliasMaxLength
+	addressAliasesKeySize   = 1 + proto.AddressIDSize
 	approvedFeaturesKeySize = 1 + 2
 	votesFeaturesKeySize    = 1 + 2
 	invokeResultKeySize     = 1 + crypto.DigestSize
+	addressLeaseKeySize     = 1 + proto.AddressIDSize + crypto.DigestSize
 )
 
 // Primary prefixes for storage keys
@@ -120,6 +122,21 @@ const (
 
 	// Hit source data.
 	hitSourceKeyPrefix
+
+	// Aliases created by address.
+	addressAliasesKeyPrefix
+
+	// Number of exchange transactions by order ID.
+	orderTransactionsNumKeyPrefix
+
+	// Marks block storage conversion which files are not moved in place yet.
+	rwConversionKeyPrefix
+
+	// Leases by sender and recipient addresses.
+	addressLeaseKeyPrefix
+
+	// Exchange transactions by order ID and index of transaction.
+	orderTransactionKeyPrefix
 )
 
 var (
@@ -131,6 +148,16 @@ func prefixByEntity(entity blockchainEntity) ([]byte, error) {
 	switch entity {
 	case alias:
 		return []byte{aliasKeyPrefix}, nil
+	case disabledAlias:
+		return []byte{disabledAliasKeyPrefix}, nil
+	case addressAliases:
+		return []byte{addressAliasesKeyPrefix}, nil
+	case orderTransactionsNum:
+		return []byte{orderTransactionsNumKeyPrefix}, nil
+	case orderTransactions:
+		return []byte{orderTransactionKeyPrefix}, nil
+	case addressLeases:
+		return []byte{addressLeaseKeyPrefix}, nil
 	case asset:
 		return []byte{assetHistKeyPrefix}, nil
 	case lease:
@@ -482,6 +509,37 @@ func (k *ordersVolumeKey) bytes() []byte {
 	return buf
 }
 
+type orderTransactionsNumKey struct {
+	orderId []byte
+}
+
+func (k *orderTransactionsNumKey) bytes() []byte {
+	buf := make([]byte, 1+len(k.orderId))
+	buf[0] = orderTransactionsNumKeyPrefix
+	copy(buf[1:], k.orderId)
+	return buf
+}
+
+type orderTransactionKey struct {
+	orderId []byte
+	index   uint32
+}
+
+func (k *orderTransactionKey) orderPrefix() []byte {
+	buf := make([]byte, 1+len(k.orderId))
+	buf[0] = orderTransactionKeyPrefix
+	copy(buf[1:], k.orderId)
+	return buf
+}
+
+func (k *orderTransactionKey) bytes() []byte {
+	buf := make([]byte, 1+len(k.orderId)+4)
+	buf[0] = orderTransactionKeyPrefix
+	copy(buf[1:], k.orderId)
+	binary.BigEndian.PutUint32(buf[1+len(k.orderId):], k.index)
+	return buf
+}
+
 type blocksInfoKey struct {
 	blockID proto.BlockID
 }
@@ -494,6 +552,49 @@ func (k *blocksInfoKey) bytes() []byte {
 	return buf
 }
 
+type addressAliasesKey struct {
+	addressID proto.AddressID
+}
+
+func (k *addressAliasesKey) bytes() []byte {
+	buf := make([]byte, addressAliasesKeySize)
+	buf[0] = addressAliasesKeyPrefix
+	copy(buf[1:], k.addressID[:])
+	return buf
+}
+
+type addressLeaseKey struct {
+	addressID proto.AddressID
+	leaseID   crypto.Digest
+}
+
+func (k *addressLeaseKey) addressPrefix() []byte {
+	buf := make([]byte, 1+proto.AddressIDSize)
+	buf[0] = addressLeaseKeyPrefix
+	copy(buf[1:], k.addressID[:])
+	return buf
+}
+
+func (k *addressLeaseKey) bytes() []byte {
+	buf := make([]byte, addressLeaseKeySize)
+	buf[0] = addressLeaseKeyPrefix
+	copy(buf[1:], k.addressID[:])
+	copy(buf[1+proto.AddressIDSize:], k.leaseID[:])
+	return buf
+}
+
+func (k *addressLeaseKey) unmarshal(data []byte) error {
+	if len(data) != addressLeaseKeySize {
+		return errInvalidDataSize
+	}
+	if data[0] != addressLeaseKeyPrefix {
+		return errInvalidPrefix
+	}
+	copy(k.addressID[:], data[1:1+proto.AddressIDSize])
+	copy(k.leaseID[:], data[1+proto.AddressIDSize:])
+	return nil
+}
+
 type accountStorAddrToNumKey struct {
 	addressID proto.AddressID
 }
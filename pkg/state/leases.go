@@ -56,6 +56,19 @@ func (l leasing) isActive() bool {
 	return l.Status == LeaseActive
 }
 
+func (l leasing) toLeaseInfo() *proto.LeaseInfo {
+	return &proto.LeaseInfo{
+		IsActive:            l.isActive(),
+		LeaseAmount:         l.Amount,
+		Recipient:           l.Recipient,
+		Sender:              l.Sender,
+		Height:              l.Height,
+		OriginTransactionID: l.OriginTransactionID,
+		CancelHeight:        l.CancelHeight,
+		CancelTransactionID: l.CancelTransactionID,
+	}
+}
+
 type leases struct {
 	hs *historyStorage
 
@@ -267,9 +280,55 @@ func (l *leases) addLeasing(id crypto.Digest, leasing *leasing, blockID proto.Bl
 	if err := l.hs.addNewEntry(lease, keyBytes, recordBytes, blockID); err != nil {
 		return err
 	}
+	return l.addAddressLeases(id, leasing, blockID)
+}
+
+// addAddressLeases updates the index of leases by sender and recipient with the status of lease.
+func (l *leases) addAddressLeases(id crypto.Digest, leasing *leasing, blockID proto.BlockID) error {
+	active := byte(0)
+	if leasing.isActive() {
+		active = byte(1)
+	}
+	addresses := []proto.WavesAddress{leasing.Sender}
+	if leasing.Recipient != leasing.Sender {
+		addresses = append(addresses, leasing.Recipient)
+	}
+	for _, addr := range addresses {
+		key := addressLeaseKey{addressID: addr.ID(), leaseID: id}
+		if err := l.hs.addNewEntry(addressLeases, key.bytes(), []byte{active}, blockID); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// leasesByAddr returns IDs of stable leases which were sent or received by the address.
+func (l *leases) leasesByAddr(addr proto.AddressID, activeOnly bool) ([]crypto.Digest, error) {
+	key := addressLeaseKey{addressID: addr}
+	iter, err := l.hs.newTopEntryIteratorByPrefix(key.addressPrefix())
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		iter.Release()
+		if err := iter.Error(); err != nil {
+			zap.S().Fatalf("Iterator error: %v", err)
+		}
+	}()
+
+	var res []crypto.Digest
+	for iter.Next() {
+		if activeOnly && keyvalue.SafeValue(iter)[0] != 1 {
+			continue
+		}
+		if err := key.unmarshal(keyvalue.SafeKey(iter)); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal address lease key")
+		}
+		res = append(res, key.leaseID)
+	}
+	return res, nil
+}
+
 func (l *leases) addLeasingUncertain(id crypto.Digest, leasing *leasing) {
 	l.uncertainLeases[id] = leasing
 }
@@ -163,3 +163,52 @@ func TestCancelLeasing(t *testing.T) {
 	assert.NoError(t, err, "failed to get leasing info")
 	assert.Equal(t, resLeasing, r, "invalid leasing record after cancellation")
 }
+
+func TestLeasesByAddr(t *testing.T) {
+	to := createLeases(t)
+
+	to.stor.addBlock(t, blockID0)
+	senderStr := "3PNXHYoWp83VaWudq9ds9LpS5xykWuJHiHp"
+	sender, err := proto.NewAddressFromString(senderStr)
+	assert.NoError(t, err, "failed to create address from string")
+	leaseID, err := crypto.NewDigestFromBytes(bytes.Repeat([]byte{0xff}, crypto.DigestSize))
+	assert.NoError(t, err, "failed to create digest from bytes")
+	r := createLease(t, senderStr, leaseID)
+	recipient := r.Recipient
+	err = to.leases.addLeasing(leaseID, r, blockID0)
+	assert.NoError(t, err, "failed to add leasing")
+	// The lease to itself is indexed once.
+	selfLeaseID, err := crypto.NewDigestFromBytes(bytes.Repeat([]byte{0xaa}, crypto.DigestSize))
+	assert.NoError(t, err, "failed to create digest from bytes")
+	err = to.leases.addLeasing(selfLeaseID, createLease(t, recipient.String(), selfLeaseID), blockID0)
+	assert.NoError(t, err, "failed to add leasing")
+	to.stor.flush(t)
+
+	ids, err := to.leases.leasesByAddr(sender.ID(), true)
+	assert.NoError(t, err, "leasesByAddr() failed")
+	assert.Equal(t, []crypto.Digest{leaseID}, ids)
+	ids, err = to.leases.leasesByAddr(recipient.ID(), true)
+	assert.NoError(t, err, "leasesByAddr() failed")
+	assert.ElementsMatch(t, []crypto.Digest{leaseID, selfLeaseID}, ids)
+
+	to.stor.addBlock(t, blockID1)
+	err = to.leases.cancelLeasing(leaseID, blockID1, to.stor.rw.height, nil)
+	assert.NoError(t, err, "failed to cancel leasing")
+	to.stor.flush(t)
+
+	ids, err = to.leases.leasesByAddr(sender.ID(), true)
+	assert.NoError(t, err, "leasesByAddr() failed")
+	assert.Empty(t, ids)
+	ids, err = to.leases.leasesByAddr(sender.ID(), false)
+	assert.NoError(t, err, "leasesByAddr() failed")
+	assert.Equal(t, []crypto.Digest{leaseID}, ids)
+	ids, err = to.leases.leasesByAddr(recipient.ID(), true)
+	assert.NoError(t, err, "leasesByAddr() failed")
+	assert.Equal(t, []crypto.Digest{selfLeaseID}, ids)
+
+	// Cancellation is reverted by rollback.
+	to.stor.rollbackBlock(t, blockID1)
+	ids, err = to.leases.leasesByAddr(recipient.ID(), true)
+	assert.NoError(t, err, "leasesByAddr() failed")
+	assert.ElementsMatch(t, []crypto.Digest{leaseID, selfLeaseID}, ids)
+}
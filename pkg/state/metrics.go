@@ -0,0 +1,91 @@
+package state
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const stateMetricsNamespace = "state"
+
+var (
+	metricHeight = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: stateMetricsNamespace,
+			Name:      "height",
+			Help:      "Height of the last applied block.",
+		},
+	)
+
+	metricBlocksApplied = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: stateMetricsNamespace,
+			Name:      "blocks_applied_total",
+			Help:      "Number of blocks applied to the state.",
+		},
+	)
+
+	metricBlockApplyDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: stateMetricsNamespace,
+			Name:      "block_apply_duration_seconds",
+			Help:      "Duration of block validation and transactions application, excluding batch flush.",
+			Buckets:   prometheus.ExponentialBuckets(0.0005, 2, 16),
+		},
+	)
+
+	metricBlocksBatchDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: stateMetricsNamespace,
+			Name:      "blocks_batch_duration_seconds",
+			Help:      "Duration of applying a batch of blocks, including flush to the database.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 16),
+		},
+	)
+
+	metricBlocksBatchSize = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: stateMetricsNamespace,
+			Name:      "blocks_batch_size",
+			Help:      "Number of blocks in applied batches.",
+			Buckets:   []float64{1, 2, 5, 10, 25, 50, 100, 250, 500, 1000},
+		},
+	)
+
+	metricDiffChanges = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: stateMetricsNamespace,
+			Name:      "diff_balance_changes",
+			Help:      "Number of balance changes applied from a batch of blocks or a UTX validation.",
+			Buckets:   prometheus.ExponentialBuckets(1, 4, 12),
+		},
+	)
+
+	metricFlushDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: stateMetricsNamespace,
+			Name:      "flush_duration_seconds",
+			Help:      "Duration of flushing state changes to the database.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 16),
+		},
+	)
+
+	metricRollbacks = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: stateMetricsNamespace,
+			Name:      "rollbacks_total",
+			Help:      "Number of state rollbacks.",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		metricHeight,
+		metricBlocksApplied,
+		metricBlockApplyDuration,
+		metricBlocksBatchDuration,
+		metricBlocksBatchSize,
+		metricDiffChanges,
+		metricFlushDuration,
+		metricRollbacks,
+	)
+}
@@ -11,6 +11,7 @@ import (
 const (
 	blockRewardRecordSize = 8
 	rewardVotesRecordSize = 4 + 4
+	genesisHeight         = 1
 )
 
 var (
@@ -180,3 +181,73 @@ func isStartOfTerm(height, activation uint64, settings settings.FunctionalitySet
 	start := activation + (diff/settings.BlockRewardTerm)*settings.BlockRewardTerm
 	return height == start
 }
+
+// rewardAtHeight returns the value of block reward record at given height.
+// Note that the record updated at the end of the term is used starting from the next block.
+func (m *monetaryPolicy) rewardAtHeight(height uint64) (uint64, error) {
+	var record blockRewardRecord
+	b, err := m.hs.entryDataAtHeight(blockRewardKeyBytes, height)
+	if err == keyvalue.ErrNotFound || err == errEmptyHist || (err == nil && b == nil) {
+		return m.settings.InitialBlockReward, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if err := record.unmarshalBinary(b); err != nil {
+		return 0, err
+	}
+	return record.reward, nil
+}
+
+func (m *monetaryPolicy) votesAtHeight(height uint64) (rewardVotesRecord, error) {
+	var record rewardVotesRecord
+	b, err := m.hs.entryDataAtHeight(rewardVotesKeyBytes, height)
+	if err == keyvalue.ErrNotFound || err == errEmptyHist || (err == nil && b == nil) {
+		return record, nil
+	}
+	if err != nil {
+		return record, err
+	}
+	if err := record.unmarshalBinary(b); err != nil {
+		return record, err
+	}
+	return record, nil
+}
+
+// blockRewardAtHeight returns the reward paid for the block at given height.
+func (m *monetaryPolicy) blockRewardAtHeight(height, activation uint64) (uint64, error) {
+	if height <= activation {
+		return m.settings.InitialBlockReward, nil
+	}
+	return m.rewardAtHeight(height - 1)
+}
+
+// totalRewards returns the sum of rewards paid for blocks from activation height up to given height inclusively.
+// Reward changes only at the terms boundaries, so it's enough to get reward once per term.
+// Genesis block is never rewarded, even if the feature is preactivated.
+func (m *monetaryPolicy) totalRewards(height, activation uint64) (uint64, error) {
+	if height < activation {
+		return 0, nil
+	}
+	term := m.settings.BlockRewardTerm
+	var total uint64
+	for start := activation; start <= height; start += term {
+		end := start + term - 1
+		if end > height {
+			end = height
+		}
+		from := start
+		if from <= genesisHeight {
+			from = genesisHeight + 1
+		}
+		if from > end {
+			continue
+		}
+		reward, err := m.blockRewardAtHeight(start, activation)
+		if err != nil {
+			return 0, err
+		}
+		total += reward * (end - from + 1)
+	}
+	return total, nil
+}
@@ -158,6 +158,57 @@ func TestFinishRewardVoting(t *testing.T) {
 	}
 }
 
+func TestRewardsAtHeight(t *testing.T) {
+	sets := *settings.MainNetSettings
+	sets.FunctionalitySettings.BlockRewardTerm = 5
+	sets.FunctionalitySettings.BlockRewardVotingPeriod = 2
+	mo, storage := createTestObjects(t, &sets)
+
+	const activation = 1
+	initial := sets.InitialBlockReward
+	increased := initial + sets.BlockRewardIncrement
+	ids := genRandBlockIds(t, 12)
+	for i, id := range ids {
+		h := uint64(i + 1)
+		storage.addBlock(t, id)
+		err := mo.vote(int64(increased), h, activation, id)
+		require.NoError(t, err)
+		_, end := blockRewardTermBoundaries(h, activation, sets.FunctionalitySettings)
+		if h == end {
+			err = mo.updateBlockReward(h, id)
+			require.NoError(t, err)
+		}
+		storage.flush(t)
+	}
+	for _, test := range []struct {
+		height   uint64
+		reward   uint64
+		increase uint32
+		total    uint64
+	}{
+		{1, initial, 0, 0},
+		{2, initial, 0, initial},
+		{4, initial, 1, 3 * initial},
+		{5, initial, 2, 4 * initial},
+		{6, increased, 0, 4*initial + increased},
+		{10, increased, 0, 4*initial + 5*increased},
+		{11, increased, 0, 4*initial + 6*increased},
+		{12, increased, 0, 4*initial + 7*increased},
+	} {
+		msg := fmt.Sprintf("height %d", test.height)
+		reward, err := mo.blockRewardAtHeight(test.height, activation)
+		require.NoError(t, err, msg)
+		assert.Equal(t, test.reward, reward, msg)
+		votes, err := mo.votesAtHeight(test.height)
+		require.NoError(t, err, msg)
+		assert.Equal(t, test.increase, votes.increase, msg)
+		assert.Equal(t, uint32(0), votes.decrease, msg)
+		total, err := mo.totalRewards(test.height, activation)
+		require.NoError(t, err, msg)
+		assert.Equal(t, test.total, total, msg)
+	}
+}
+
 func createTestObjects(t *testing.T, sets *settings.BlockchainSettings) (*monetaryPolicy, *testStorageObjects) {
 	storage := createStorageObjects(t, true)
 	mp := newMonetaryPolicy(storage.hs, sets)
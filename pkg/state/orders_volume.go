@@ -4,11 +4,16 @@ import (
 	"encoding/binary"
 
 	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/keyvalue"
 	"github.com/wavesplatform/gowaves/pkg/proto"
+	"go.uber.org/zap"
 )
 
 const (
-	orderVolumeRecordSize = 8 + 8
+	orderVolumeRecordSize          = 8 + 8
+	orderTransactionsNumRecordSize = 4
+	orderTransactionRecordSize     = crypto.DigestSize
 )
 
 type orderVolumeRecord struct {
@@ -53,6 +58,20 @@ func (ov *ordersVolumes) newestVolumeById(orderId []byte) (*orderVolumeRecord, e
 	return &record, nil
 }
 
+// volumeByID returns the volume of the order as of the last fully applied block.
+func (ov *ordersVolumes) volumeByID(orderId []byte) (*orderVolumeRecord, error) {
+	key := ordersVolumeKey{orderId}
+	recordBytes, err := ov.hs.topEntryData(key.bytes())
+	if err != nil {
+		return nil, err
+	}
+	var record orderVolumeRecord
+	if err := record.unmarshalBinary(recordBytes); err != nil {
+		return nil, errors.Errorf("failed to unmarshal order volume record: %v\n", err)
+	}
+	return &record, nil
+}
+
 func (ov *ordersVolumes) addNewRecord(orderId []byte, record *orderVolumeRecord, blockID proto.BlockID) error {
 	recordBytes, err := record.marshalBinary()
 	if err != nil {
@@ -99,3 +118,61 @@ func (ov *ordersVolumes) newestFilledAmount(orderId []byte) (uint64, error) {
 	}
 	return volume.amountFilled, nil
 }
+
+// newestTransactionsNum returns the number of exchange transactions filled the order including not flushed ones.
+func (ov *ordersVolumes) newestTransactionsNum(orderId []byte) (uint32, error) {
+	key := orderTransactionsNumKey{orderId}
+	recordBytes, err := ov.hs.newestTopEntryData(key.bytes())
+	if err == keyvalue.ErrNotFound || err == errEmptyHist {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if len(recordBytes) != orderTransactionsNumRecordSize {
+		return 0, errInvalidDataSize
+	}
+	return binary.BigEndian.Uint32(recordBytes), nil
+}
+
+// transactions returns IDs of exchange transactions filled the order as of the last fully applied block.
+// Transactions go in the order of their application.
+func (ov *ordersVolumes) transactions(orderId []byte) ([]crypto.Digest, error) {
+	key := orderTransactionKey{orderId: orderId}
+	iter, err := ov.hs.newTopEntryIteratorByPrefix(key.orderPrefix())
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		iter.Release()
+		if err := iter.Error(); err != nil {
+			zap.S().Fatalf("Iterator error: %v", err)
+		}
+	}()
+
+	var ids []crypto.Digest
+	for iter.Next() {
+		id, err := crypto.NewDigestFromBytes(keyvalue.SafeValue(iter))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal order transaction record")
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// addTransaction appends the exchange transaction to the transactions of the order.
+func (ov *ordersVolumes) addTransaction(orderId []byte, txID crypto.Digest, blockID proto.BlockID) error {
+	num, err := ov.newestTransactionsNum(orderId)
+	if err != nil {
+		return err
+	}
+	key := orderTransactionKey{orderId: orderId, index: num}
+	if err := ov.hs.addNewEntry(orderTransactions, key.bytes(), txID.Bytes(), blockID); err != nil {
+		return err
+	}
+	numBytes := make([]byte, orderTransactionsNumRecordSize)
+	binary.BigEndian.PutUint32(numBytes, num+1)
+	numKey := orderTransactionsNumKey{orderId}
+	return ov.hs.addNewEntry(orderTransactionsNum, numKey.bytes(), numBytes, blockID)
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/wavesplatform/gowaves/pkg/crypto"
 )
 
@@ -68,3 +69,39 @@ func TestIncreaseFilledAmount(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, firstAmount+secondAmount, filledAmount)
 }
+
+func TestOrderTransactions(t *testing.T) {
+	to := createOrdersVolumeStorageObjects(t)
+
+	orderId := bytes.Repeat([]byte{0xff}, crypto.DigestSize)
+	tx1 := crypto.MustFastHash([]byte("tx1"))
+	tx2 := crypto.MustFastHash([]byte("tx2"))
+	tx3 := crypto.MustFastHash([]byte("tx3"))
+
+	to.stor.addBlock(t, blockID0)
+	err := to.ordersVolumes.addTransaction(orderId, tx1, blockID0)
+	require.NoError(t, err)
+	num, err := to.ordersVolumes.newestTransactionsNum(orderId)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), num)
+	ids, err := to.ordersVolumes.transactions(orderId)
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+	to.stor.flush(t)
+
+	to.stor.addBlock(t, blockID1)
+	err = to.ordersVolumes.addTransaction(orderId, tx2, blockID1)
+	require.NoError(t, err)
+	err = to.ordersVolumes.addTransaction(orderId, tx3, blockID1)
+	require.NoError(t, err)
+	to.stor.flush(t)
+	ids, err = to.ordersVolumes.transactions(orderId)
+	require.NoError(t, err)
+	assert.Equal(t, []crypto.Digest{tx1, tx2, tx3}, ids)
+
+	to.stor.rollbackBlock(t, blockID1)
+	to.stor.flush(t)
+	ids, err = to.ordersVolumes.transactions(orderId)
+	require.NoError(t, err)
+	assert.Equal(t, []crypto.Digest{tx1}, ids)
+}
@@ -1,6 +1,10 @@
 package state
 
 import (
+	"fmt"
+	"reflect"
+	"strings"
+
 	"github.com/mr-tron/base58/base58"
 	"github.com/pkg/errors"
 	"github.com/wavesplatform/gowaves/pkg/crypto"
@@ -10,6 +14,7 @@ import (
 	"github.com/wavesplatform/gowaves/pkg/ride/ast"
 	"github.com/wavesplatform/gowaves/pkg/settings"
 	"github.com/wavesplatform/gowaves/pkg/types"
+	"go.uber.org/zap"
 )
 
 type scriptCaller struct {
@@ -18,6 +23,9 @@ type scriptCaller struct {
 	stor     *blockchainEntitiesStorage
 	settings *settings.BlockchainSettings
 
+	engine   RideEngine
+	compiled *compiledScriptsCache
+
 	totalComplexity    uint64
 	recentTxComplexity uint64
 }
@@ -26,14 +34,119 @@ func newScriptCaller(
 	state types.SmartState,
 	stor *blockchainEntitiesStorage,
 	settings *settings.BlockchainSettings,
+	engine RideEngine,
 ) (*scriptCaller, error) {
+	compiled, err := newCompiledScriptsCache(maxCacheSize)
+	if err != nil {
+		return nil, err
+	}
 	return &scriptCaller{
 		state:    state,
 		stor:     stor,
 		settings: settings,
+		engine:   engine,
+		compiled: compiled,
 	}, nil
 }
 
+// callVerifier evaluates the verifier of the script with the engine selected for the caller.
+// Functions scriptBytes and subject are called only when the compiled script is needed or the engines mismatch.
+func (a *scriptCaller) callVerifier(
+	env *ride.EvaluationEnvironment,
+	tree *ast.Tree,
+	scriptBytes func() (proto.Script, error),
+	subject func() string,
+) (ride.Result, error) {
+	switch a.engine {
+	case VMRideEngine:
+		program, ok := a.compiledScript(tree, scriptBytes, subject)
+		if !ok {
+			return ride.CallVerifier(env, tree)
+		}
+		return ride.CallVerifierVM(env, program)
+	case ShadowRideEngine:
+		r, err := ride.CallVerifier(env, tree)
+		if program, ok := a.compiledScript(tree, scriptBytes, subject); ok {
+			vmr, vmErr := ride.CallVerifierVM(env, program)
+			if d := rideResultsDifference(r, err, vmr, vmErr); d != "" {
+				zap.S().Warnf("RIDE engines mismatch on %s: %s", subject(), d)
+			}
+		}
+		return r, err
+	default:
+		return ride.CallVerifier(env, tree)
+	}
+}
+
+// compiledScript returns the script compiled to bytecode from cache or compiles it.
+// It returns false if the script can't be compiled, in that case the tree evaluator should be used.
+func (a *scriptCaller) compiledScript(tree *ast.Tree, scriptBytes func() (proto.Script, error), subject func() string) (ride.RideScript, bool) {
+	b, err := scriptBytes()
+	if err != nil {
+		zap.S().Debugf("Failed to get script bytes of %s: %v", subject(), err)
+		return nil, false
+	}
+	key, err := crypto.FastHash(b)
+	if err != nil {
+		zap.S().Debugf("Failed to calculate script hash of %s: %v", subject(), err)
+		return nil, false
+	}
+	cs, ok := a.compiled.get(key)
+	if !ok {
+		cs.script, cs.err = ride.Compile(tree)
+		if cs.err != nil {
+			zap.S().Debugf("Failed to compile script of %s: %v", subject(), cs.err)
+		}
+		a.compiled.set(key, cs)
+	}
+	return cs.script, cs.err == nil
+}
+
+// rideResultsDifference describes the difference between results of evaluation of the same script by the tree
+// evaluator and the bytecode VM. It returns empty string if there is no difference.
+// Failed evaluations are compared by error messages and spent complexity.
+func rideResultsDifference(tr ride.Result, trErr error, vmr ride.Result, vmErr error) string {
+	var diffs []string
+	switch {
+	case trErr != nil && vmErr != nil:
+		if trErr.Error() != vmErr.Error() {
+			diffs = append(diffs, fmt.Sprintf("error %q != %q", trErr.Error(), vmErr.Error()))
+		}
+		trc, vmc := ride.EvaluationErrorSpentComplexity(trErr), ride.EvaluationErrorSpentComplexity(vmErr)
+		if trc != vmc {
+			diffs = append(diffs, fmt.Sprintf("spent complexity %d != %d", trc, vmc))
+		}
+		return strings.Join(diffs, ", ")
+	case trErr != nil:
+		return fmt.Sprintf("tree evaluator failed with error %q, but VM returned result %t", trErr.Error(), vmr.Result())
+	case vmErr != nil:
+		return fmt.Sprintf("VM failed with error %q, but tree evaluator returned result %t", vmErr.Error(), tr.Result())
+	}
+	if tr.Result() != vmr.Result() {
+		diffs = append(diffs, fmt.Sprintf("result %t != %t", tr.Result(), vmr.Result()))
+	}
+	if d := actionsDifference(tr.ScriptActions(), vmr.ScriptActions()); d != "" {
+		diffs = append(diffs, d)
+	}
+	if tr.Complexity() != vmr.Complexity() {
+		diffs = append(diffs, fmt.Sprintf("complexity %d != %d", tr.Complexity(), vmr.Complexity()))
+	}
+	return strings.Join(diffs, ", ")
+}
+
+// actionsDifference describes the first difference between actions produced by the tree evaluator and the VM.
+func actionsDifference(tr, vm []proto.ScriptAction) string {
+	for i := 0; i < len(tr) && i < len(vm); i++ {
+		if !reflect.DeepEqual(tr[i], vm[i]) {
+			return fmt.Sprintf("action #%d %+v != %+v", i+1, tr[i], vm[i])
+		}
+	}
+	if len(tr) != len(vm) {
+		return fmt.Sprintf("actions count %d != %d", len(tr), len(vm))
+	}
+	return ""
+}
+
 // callAccountScriptWithOrder calls account script. This method must not be called for proto.EthereumAddress.
 func (a *scriptCaller) callAccountScriptWithOrder(order proto.Order, lastBlockInfo *proto.BlockInfo, info *fallibleValidationParams) error {
 	senderAddr, err := order.GetSender(a.settings.AddressSchemeCharacter)
@@ -65,7 +178,11 @@ func (a *scriptCaller) callAccountScriptWithOrder(order proto.Order, lastBlockIn
 	if err != nil {
 		return errors.Wrap(err, "failed to convert order")
 	}
-	r, err := ride.CallVerifier(env, tree)
+	r, err := a.callVerifier(env, tree, func() (proto.Script, error) {
+		return a.stor.scriptsStorage.newestScriptBytesByAddr(senderWavesAddr)
+	}, func() string {
+		return fmt.Sprintf("account script of '%s' on order '%s'", senderWavesAddr.String(), base58.Encode(id))
+	})
 	if err != nil {
 		return errors.Errorf("account script on order '%s' thrown error with message: %s", base58.Encode(id), err.Error())
 	}
@@ -117,7 +234,11 @@ func (a *scriptCaller) callAccountScriptWithTx(tx proto.Transaction, params *app
 	if err != nil {
 		return errors.Wrapf(err, "failed to call account script on transaction '%s'", base58.Encode(id))
 	}
-	r, err := ride.CallVerifier(env, tree)
+	r, err := a.callVerifier(env, tree, func() (proto.Script, error) {
+		return a.stor.scriptsStorage.newestScriptBytesByAddr(senderWavesAddr)
+	}, func() string {
+		return fmt.Sprintf("account script of '%s' on transaction '%s'", senderWavesAddr.String(), base58.Encode(id))
+	})
 	if err != nil {
 		return errors.Errorf("account script on transaction '%s' failed with error: %v", base58.Encode(id), err.Error())
 	}
@@ -161,7 +282,11 @@ func (a *scriptCaller) callAssetScriptCommon(env *ride.EvaluationEnvironment, as
 		env.SetThisFromFullAssetInfo(assetInfo)
 	}
 	env.SetLastBlock(params.blockInfo)
-	r, err := ride.CallVerifier(env, tree)
+	r, err := a.callVerifier(env, tree, func() (proto.Script, error) {
+		return a.stor.scriptsStorage.newestScriptBytesByAsset(proto.AssetIDFromDigest(assetID))
+	}, func() string {
+		return fmt.Sprintf("script of asset '%s'", assetID.String())
+	})
 	if err != nil {
 		return nil, errs.NewTransactionNotAllowedByScript(err.Error(), assetID.Bytes())
 	}
@@ -205,86 +330,80 @@ func (a *scriptCaller) callAssetScript(tx proto.Transaction, assetID crypto.Dige
 	return a.callAssetScriptCommon(env, assetID, params)
 }
 
-func (a *scriptCaller) invokeFunction(tree *ast.Tree, tx proto.Transaction, info *fallibleValidationParams, scriptAddress proto.WavesAddress) (ride.Result, error) {
+// invocation is a call of script function by the invoke transaction.
+type invocation struct {
+	functionName      string
+	functionArguments proto.Arguments
+	defaultFunction   bool
+	expression        bool
+}
+
+// call evaluates the invocation with the tree evaluator.
+func (i invocation) call(env *ride.EvaluationEnvironment, tree *ast.Tree) (ride.Result, error) {
+	if i.expression {
+		return ride.CallVerifier(env, tree)
+	}
+	return ride.CallFunction(env, tree, i.functionName, i.functionArguments)
+}
+
+// callVM evaluates the invocation with the bytecode VM.
+func (i invocation) callVM(env *ride.EvaluationEnvironment, script ride.RideScript) (ride.Result, error) {
+	if i.expression {
+		return ride.CallVerifierVM(env, script)
+	}
+	return ride.CallFunctionVM(env, script, i.functionName, i.functionArguments)
+}
+
+// invokeEnvironment creates the environment to evaluate the script invoked by the transaction.
+// Since V5 the environment wraps the state to collect changes of evaluation, so each evaluation requires its own environment.
+func (a *scriptCaller) invokeEnvironment(tree *ast.Tree, tx proto.Transaction, info *fallibleValidationParams, scriptAddress proto.WavesAddress) (*ride.EvaluationEnvironment, invocation, error) {
 	env, err := ride.NewEnvironment(a.settings.AddressSchemeCharacter, a.state, a.settings.InternalInvokePaymentsValidationAfterHeight, info.blockV5Activated, info.rideV6Activated)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create RIDE environment")
+		return nil, invocation{}, errors.Wrap(err, "failed to create RIDE environment")
 	}
 	env.SetThisFromAddress(scriptAddress)
 	env.SetLastBlock(info.blockInfo)
 	env.SetTimestamp(tx.GetTimestamp())
 	err = env.SetTransaction(tx)
 	if err != nil {
-		return nil, err
+		return nil, invocation{}, err
 	}
 	env.ChooseSizeCheck(tree.LibVersion)
 	env.ChooseTakeString(info.rideV5Activated)
 	env.ChooseMaxDataEntriesSize(info.rideV5Activated)
 
 	var (
-		functionName      string
-		functionArguments proto.Arguments
-		defaultFunction   bool
-		payments          proto.ScriptPayments
-		sender            proto.WavesAddress
-		r                 ride.Result
+		inv      invocation
+		payments proto.ScriptPayments
+		sender   proto.WavesAddress
 	)
 	switch transaction := tx.(type) {
 	case *proto.InvokeScriptWithProofs:
 		err = env.SetInvoke(tx, tree.LibVersion)
 		if err != nil {
-			return nil, err
+			return nil, invocation{}, err
 		}
 		payments = transaction.Payments
 		sender, err = proto.NewAddressFromPublicKey(a.settings.AddressSchemeCharacter, transaction.SenderPK)
 		if err != nil {
-			return nil, err
+			return nil, invocation{}, err
 		}
-		functionName = transaction.FunctionCall.Name
-		functionArguments = transaction.FunctionCall.Arguments
-		defaultFunction = transaction.FunctionCall.Default
-
-		// Since V5 we have to create environment with wrapped state to which we put attached payments
-		if tree.LibVersion >= ast.LibV5 {
-			env, err = ride.NewEnvironmentWithWrappedState(env, payments, sender, proto.IsProtobufTx(tx), tree.LibVersion)
-			if err != nil {
-				return nil, errors.Wrapf(err, "failed to create RIDE environment with wrapped state")
-			}
+		inv = invocation{
+			functionName:      transaction.FunctionCall.Name,
+			functionArguments: transaction.FunctionCall.Arguments,
+			defaultFunction:   transaction.FunctionCall.Default,
 		}
 
-		r, err = ride.CallFunction(env, tree, functionName, functionArguments)
-		if err != nil {
-			if appendErr := a.appendFunctionComplexity(ride.EvaluationErrorSpentComplexity(err), scriptAddress, functionName, defaultFunction, info); appendErr != nil {
-				return nil, appendErr
-			}
-			return nil, err
-		}
 	case *proto.InvokeExpressionTransactionWithProofs:
 		err = env.SetInvoke(tx, tree.LibVersion)
 		if err != nil {
-			return nil, err
+			return nil, invocation{}, err
 		}
 		sender, err = proto.NewAddressFromPublicKey(a.settings.AddressSchemeCharacter, transaction.SenderPK)
 		if err != nil {
-			return nil, err
-		}
-		functionName = ""
-
-		// Since V5 we have to create environment with wrapped state to which we put attached payments
-		if tree.LibVersion >= ast.LibV5 {
-			env, err = ride.NewEnvironmentWithWrappedState(env, payments, sender, proto.IsProtobufTx(tx), tree.LibVersion)
-			if err != nil {
-				return nil, errors.Wrapf(err, "failed to create RIDE environment with wrapped state")
-			}
-		}
-
-		r, err = ride.CallVerifier(env, tree)
-		if err != nil {
-			if appendErr := a.appendFunctionComplexity(ride.EvaluationErrorSpentComplexity(err), scriptAddress, functionName, defaultFunction, info); appendErr != nil {
-				return nil, appendErr
-			}
-			return nil, err
+			return nil, invocation{}, err
 		}
+		inv = invocation{expression: true}
 
 	case *proto.EthereumTransaction:
 		abiPayments := transaction.TxKind.DecodedData().Payments
@@ -298,41 +417,90 @@ func (a *scriptCaller) invokeFunction(tree *ast.Tree, tx proto.Transaction, info
 
 		err = env.SetEthereumInvoke(transaction, tree.LibVersion, scriptPayments)
 		if err != nil {
-			return nil, err
+			return nil, invocation{}, err
 		}
 		sender, err = transaction.WavesAddressFrom(a.settings.AddressSchemeCharacter)
 		if err != nil {
-			return nil, errors.Errorf("failed to get waves address from ethereum transaction %v", err)
+			return nil, invocation{}, errors.Errorf("failed to get waves address from ethereum transaction %v", err)
 		}
 		decodedData := transaction.TxKind.DecodedData()
-		functionName = decodedData.Name
 		arguments, err := ride.ConvertDecodedEthereumArgumentsToProtoArguments(decodedData.Inputs)
 		if err != nil {
-			return nil, errors.Errorf("failed to convert ethereum arguments, %v", err)
+			return nil, invocation{}, errors.Errorf("failed to convert ethereum arguments, %v", err)
 		}
-		functionArguments = arguments
-		defaultFunction = true
-		// Since V5 we have to create environment with wrapped state to which we put attached payments
-		if tree.LibVersion >= ast.LibV5 {
-			env, err = ride.NewEnvironmentWithWrappedState(env, payments, sender, proto.IsProtobufTx(tx), tree.LibVersion)
-			if err != nil {
-				return nil, errors.Wrapf(err, "failed to create RIDE environment with wrapped state")
-			}
+		inv = invocation{
+			functionName:      decodedData.Name,
+			functionArguments: arguments,
+			defaultFunction:   true,
 		}
 
-		r, err = ride.CallFunction(env, tree, functionName, functionArguments)
+	default:
+		return nil, invocation{}, errors.Errorf("failed to invoke function: unexpected type of transaction (%T)", transaction)
+	}
+
+	// Since V5 we have to create environment with wrapped state to which we put attached payments
+	if tree.LibVersion >= ast.LibV5 {
+		env, err = ride.NewEnvironmentWithWrappedState(env, payments, sender, proto.IsProtobufTx(tx), tree.LibVersion)
 		if err != nil {
-			if appendErr := a.appendFunctionComplexity(ride.EvaluationErrorSpentComplexity(err), scriptAddress, functionName, defaultFunction, info); appendErr != nil {
-				return nil, appendErr
-			}
-			return nil, err
+			return nil, invocation{}, errors.Wrapf(err, "failed to create RIDE environment with wrapped state")
 		}
+	}
+	return env, inv, nil
+}
 
-	default:
-		return nil, errors.Errorf("failed to invoke function: unexpected type of transaction (%T)", transaction)
+// invokeFunction evaluates the script invoked by the transaction with the engine selected for the caller.
+// In shadow mode the VM evaluates the invocation in a separate environment, so the changes of the wrapped state
+// made by the tree evaluator are not affected.
+func (a *scriptCaller) invokeFunction(tree *ast.Tree, tx proto.Transaction, info *fallibleValidationParams, scriptAddress proto.WavesAddress) (ride.Result, error) {
+	env, inv, err := a.invokeEnvironment(tree, tx, info, scriptAddress)
+	if err != nil {
+		return nil, err
+	}
+	scriptBytes := func() (proto.Script, error) {
+		if e, ok := tx.(*proto.InvokeExpressionTransactionWithProofs); ok {
+			return proto.Script(e.Expression), nil
+		}
+		return a.stor.scriptsStorage.newestScriptBytesByAddr(scriptAddress)
+	}
+	subject := func() string {
+		id, err := tx.GetID(a.settings.AddressSchemeCharacter)
+		if err != nil {
+			return fmt.Sprintf("script of '%s' on invocation of function '%s'", scriptAddress.String(), inv.functionName)
+		}
+		return fmt.Sprintf("script of '%s' on transaction '%s'", scriptAddress.String(), base58.Encode(id))
 	}
 
-	if err := a.appendFunctionComplexity(r.Complexity(), scriptAddress, functionName, defaultFunction, info); err != nil {
+	var r ride.Result
+	switch a.engine {
+	case VMRideEngine:
+		if program, ok := a.compiledScript(tree, scriptBytes, subject); ok {
+			r, err = inv.callVM(env, program)
+		} else {
+			r, err = inv.call(env, tree)
+		}
+	case ShadowRideEngine:
+		r, err = inv.call(env, tree)
+		if program, ok := a.compiledScript(tree, scriptBytes, subject); ok {
+			shadowEnv, _, envErr := a.invokeEnvironment(tree, tx, info, scriptAddress)
+			if envErr != nil {
+				zap.S().Warnf("Failed to create RIDE environment for VM on %s: %v", subject(), envErr)
+				break
+			}
+			vmr, vmErr := inv.callVM(shadowEnv, program)
+			if d := rideResultsDifference(r, err, vmr, vmErr); d != "" {
+				zap.S().Warnf("RIDE engines mismatch on %s: %s", subject(), d)
+			}
+		}
+	default:
+		r, err = inv.call(env, tree)
+	}
+	if err != nil {
+		if appendErr := a.appendFunctionComplexity(ride.EvaluationErrorSpentComplexity(err), scriptAddress, inv.functionName, inv.defaultFunction, info); appendErr != nil {
+			return nil, appendErr
+		}
+		return nil, err
+	}
+	if err := a.appendFunctionComplexity(r.Complexity(), scriptAddress, inv.functionName, inv.defaultFunction, info); err != nil {
 		return nil, err
 	}
 	return r, nil
@@ -0,0 +1,233 @@
+package state
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/mr-tron/base58/base58"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/importer"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/proto/ethabi"
+	"github.com/wavesplatform/gowaves/pkg/ride"
+	"github.com/wavesplatform/gowaves/pkg/ride/ast"
+	"github.com/wavesplatform/gowaves/pkg/ride/serialization"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+)
+
+func TestParseRideEngine(t *testing.T) {
+	for _, engine := range []RideEngine{TreeRideEngine, VMRideEngine, ShadowRideEngine} {
+		e, err := ParseRideEngine(engine.String())
+		require.NoError(t, err)
+		assert.Equal(t, engine, e)
+	}
+	e, err := ParseRideEngine("")
+	require.NoError(t, err)
+	assert.Equal(t, TreeRideEngine, e)
+	_, err = ParseRideEngine("jit")
+	assert.Error(t, err)
+}
+
+func TestCompiledScriptsCache(t *testing.T) {
+	_, err := newCompiledScriptsCache(0)
+	assert.Error(t, err)
+
+	c, err := newCompiledScriptsCache(2)
+	require.NoError(t, err)
+	k1, k2, k3 := crypto.MustFastHash([]byte{1}), crypto.MustFastHash([]byte{2}), crypto.MustFastHash([]byte{3})
+	c.set(k1, compiledScript{err: errors.New("first")})
+	c.set(k2, compiledScript{err: errors.New("second")})
+	_, ok := c.get(k1) // Makes the first entry the newest one.
+	require.True(t, ok)
+	c.set(k3, compiledScript{err: errors.New("third")})
+	assert.Equal(t, 2, c.len())
+	_, ok = c.get(k2)
+	assert.False(t, ok)
+	v, ok := c.get(k1)
+	require.True(t, ok)
+	assert.EqualError(t, v.err, "first")
+	v, ok = c.get(k3)
+	require.True(t, ok)
+	assert.EqualError(t, v.err, "third")
+}
+
+func testRideEnvironment(t *testing.T) *ride.EvaluationEnvironment {
+	state := &AnotherMockSmartState{
+		AddingBlockHeightFunc: func() (uint64, error) {
+			return 1000, nil
+		},
+	}
+	env, err := ride.NewEnvironment(proto.TestNetScheme, state, 0, false, false)
+	require.NoError(t, err)
+	return env
+}
+
+func TestScriptCallerCompiledScript(t *testing.T) {
+	src, err := base64.StdEncoding.DecodeString("AwQAAAABaQAAAAAAAAAwOQQAAAABcwIAAAAFMTIzNDUJAAAAAAAAAgkAAaQAAAABBQAAAAFpBQAAAAFz1B1iCw==")
+	require.NoError(t, err)
+	tree, err := serialization.Parse(src)
+	require.NoError(t, err)
+	sc, err := newScriptCaller(nil, nil, nil, VMRideEngine)
+	require.NoError(t, err)
+
+	calls := 0
+	scriptBytes := func() (proto.Script, error) {
+		calls++
+		return src, nil
+	}
+	subject := func() string { return "test script" }
+	program, ok := sc.compiledScript(tree, scriptBytes, subject)
+	require.True(t, ok)
+	r, err := ride.CallVerifierVM(testRideEnvironment(t), program)
+	require.NoError(t, err)
+	assert.True(t, r.Result())
+	tr, err := ride.CallVerifier(testRideEnvironment(t), tree)
+	require.NoError(t, err)
+	assert.Equal(t, tr.Complexity(), r.Complexity())
+	cached, ok := sc.compiledScript(tree, scriptBytes, subject)
+	require.True(t, ok)
+	assert.Same(t, program, cached)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 1, sc.compiled.len())
+
+	_, ok = sc.compiledScript(tree, func() (proto.Script, error) { return nil, errors.New("no script") }, subject)
+	assert.False(t, ok)
+}
+
+func TestRideResultsDifference(t *testing.T) {
+	src, err := base64.StdEncoding.DecodeString("AwQAAAABaQAAAAAAAAAwOQQAAAABcwIAAAAFMTIzNDUJAAAAAAAAAgkAAaQAAAABBQAAAAFpBQAAAAFz1B1iCw==")
+	require.NoError(t, err)
+	tree, err := serialization.Parse(src)
+	require.NoError(t, err)
+	program, err := ride.Compile(tree)
+	require.NoError(t, err)
+	r, err := ride.CallVerifierVM(testRideEnvironment(t), program)
+	require.NoError(t, err)
+
+	assert.Empty(t, rideResultsDifference(r, nil, r, nil))
+	assert.Empty(t, rideResultsDifference(nil, errors.New("failure"), nil, errors.New("failure")))
+	assert.Equal(t, `error "tree" != "vm"`, rideResultsDifference(nil, errors.New("tree"), nil, errors.New("vm")))
+	assert.Equal(t, "spent complexity 1 != 2", rideResultsDifference(
+		nil, ride.EvaluationErrorAddComplexity(ride.UserError.New("failure"), 1),
+		nil, ride.EvaluationErrorAddComplexity(ride.UserError.New("failure"), 2),
+	))
+	assert.Contains(t, rideResultsDifference(nil, errors.New("tree"), r, nil), "tree evaluator failed")
+	assert.Contains(t, rideResultsDifference(r, nil, nil, errors.New("vm")), "VM failed")
+
+	src, err = base64.StdEncoding.DecodeString("AQfeYll6") // false
+	require.NoError(t, err)
+	tree, err = serialization.Parse(src)
+	require.NoError(t, err)
+	program, err = ride.Compile(tree)
+	require.NoError(t, err)
+	f, err := ride.CallVerifierVM(testRideEnvironment(t), program)
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("result true != false, complexity %d != %d", r.Complexity(), f.Complexity()),
+		rideResultsDifference(r, nil, f, nil))
+}
+
+func TestActionsDifference(t *testing.T) {
+	a := &proto.DataEntryScriptAction{Entry: &proto.IntegerDataEntry{Key: "int", Value: 1}}
+	b := &proto.DataEntryScriptAction{Entry: &proto.IntegerDataEntry{Key: "int", Value: 2}}
+	assert.Empty(t, actionsDifference([]proto.ScriptAction{a, b}, []proto.ScriptAction{a, b}))
+	assert.Equal(t, "actions count 2 != 1", actionsDifference([]proto.ScriptAction{a, b}, []proto.ScriptAction{a}))
+	assert.Contains(t, actionsDifference([]proto.ScriptAction{a, a}, []proto.ScriptAction{a, b}), "action #2")
+}
+
+func TestScriptCallerInvokeFunctionEngines(t *testing.T) {
+	/*
+		{-# STDLIB_VERSION 4 #-}
+		{-# CONTENT_TYPE DAPP #-}
+		{-# SCRIPT_TYPE ACCOUNT #-}
+		@Callable(i)
+		func call(number: Int) = {
+		  [
+		    IntegerEntry("int", number)
+		  ]
+		}
+	*/
+	src, err := base64.StdEncoding.DecodeString("AAIEAAAAAAAAAAcIAhIDCgEBAAAAAAAAAAEAAAABaQEAAAAEY2FsbAAAAAEAAAAGbnVtYmVyCQAETAAAAAIJAQAAAAxJbnRlZ2VyRW50cnkAAAACAgAAAANpbnQFAAAABm51bWJlcgUAAAADbmlsAAAAAE5VO+E=")
+	require.NoError(t, err)
+	storage := &mockScriptStorageState{
+		newestScriptByAddrFunc: func(addr proto.WavesAddress) (*ast.Tree, error) {
+			return serialization.Parse(src)
+		},
+		newestScriptBytesByAddrFunc: func(addr proto.WavesAddress) (proto.Script, error) {
+			return src, nil
+		},
+	}
+	state := &AnotherMockSmartState{
+		AddingBlockHeightFunc: func() (uint64, error) {
+			return 1000, nil
+		},
+	}
+	txAppender := defaultTxAppender(t, storage, state, nil, proto.TestNetScheme)
+	senderPK, err := proto.NewEthereumPublicKeyFromHexString("c4f926702fee2456ac5f3d91c9b7aa578ff191d0792fa80b6e65200f2485d9810a89c1bb5830e6618119fb3f2036db47fac027f7883108cbc7b2953539b9cb53")
+	require.NoError(t, err)
+	recipientBytes, err := base58.Decode("3PFpqr7wTCBu68sSqU7vVv9pttYRjQjGFbv")
+	require.NoError(t, err)
+	recipientEth := proto.BytesToEthereumAddress(recipientBytes)
+	txData := defaultEthereumLegacyTxData(1000000000000000, &recipientEth, nil, 500000, proto.TestNetScheme)
+	decodedData := defaultDecodedData("call", []ethabi.DecodedArg{{Value: ethabi.Int(10)}}, nil)
+	tx := proto.NewEthereumTransaction(txData, proto.NewEthereumInvokeScriptTxKind(decodedData), &crypto.Digest{}, &senderPK, 0)
+	scriptAddress, tree := applyScript(t, &tx, storage)
+	info := &fallibleValidationParams{appendTxParams: defaultAppendTxParams()}
+	info.rideV5Activated = true
+
+	expected := []proto.ScriptAction{&proto.DataEntryScriptAction{Entry: &proto.IntegerDataEntry{Key: "int", Value: 10}}}
+	var complexity uint64
+	for _, engine := range []RideEngine{TreeRideEngine, VMRideEngine, ShadowRideEngine} {
+		sc, err := newScriptCaller(state, txAppender.ia.sc.stor, txAppender.ia.sc.settings, engine)
+		require.NoError(t, err)
+		r, err := sc.invokeFunction(tree, &tx, info, scriptAddress)
+		require.NoError(t, err, engine.String())
+		assert.Equal(t, expected, r.ScriptActions(), engine.String())
+		if engine == TreeRideEngine {
+			assert.Equal(t, 0, sc.compiled.len())
+			complexity = sc.recentTxComplexity
+		} else {
+			assert.Equal(t, 1, sc.compiled.len(), engine.String())
+		}
+		assert.NotZero(t, sc.recentTxComplexity, engine.String())
+		assert.Equal(t, complexity, sc.recentTxComplexity, engine.String())
+	}
+}
+
+// BenchmarkImportRideEngines measures import speed of MainNet blocks with different RIDE engines.
+// Scripts appear on MainNet far beyond the blocks of test data, so the benchmark imports blocks from the file
+// set by GOWAVES_MAINNET_BLOCKS, the number of blocks to import is set by GOWAVES_MAINNET_HEIGHT.
+func BenchmarkImportRideEngines(b *testing.B) {
+	blocksPath, ok := os.LookupEnv("GOWAVES_MAINNET_BLOCKS")
+	if !ok {
+		b.Skip("GOWAVES_MAINNET_BLOCKS is not set")
+	}
+	height, err := strconv.ParseUint(os.Getenv("GOWAVES_MAINNET_HEIGHT"), 10, 64)
+	require.NoError(b, err, "GOWAVES_MAINNET_HEIGHT must be set to the number of blocks to import")
+	for _, engine := range []RideEngine{TreeRideEngine, VMRideEngine} {
+		b.Run(engine.String(), func(b *testing.B) {
+			params := DefaultTestingStateParams()
+			params.RideEngine = engine
+			var elapsed time.Duration
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				manager, err := newStateManager(b.TempDir(), true, params, settings.MainNetSettings)
+				require.NoError(b, err)
+				b.StartTimer()
+				start := time.Now()
+				require.NoError(b, importer.ApplyFromFile(manager, blocksPath, height, 1))
+				elapsed += time.Since(start)
+				b.StopTimer()
+				require.NoError(b, manager.Close())
+				b.StartTimer()
+			}
+			b.ReportMetric(float64(height*uint64(b.N))/elapsed.Seconds(), "blocks/s")
+		})
+	}
+}
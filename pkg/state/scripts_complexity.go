@@ -6,6 +6,7 @@ import (
 	"github.com/fxamacker/cbor/v2"
 	"github.com/pkg/errors"
 	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/keyvalue"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/ride"
 )
@@ -48,6 +49,39 @@ func (sc *scriptsComplexity) originalEstimatorVersion(addr proto.Address) (int,
 	return int(record.Version), nil
 }
 
+func (sc *scriptsComplexity) originalEstimatorVersionFromDB(addr proto.Address) (int, error) {
+	key := accountOriginalEstimatorVersionKey{addr.ID()}
+	recordBytes, err := sc.hs.topEntryData(key.bytes())
+	if err != nil {
+		return 0, err
+	}
+	record := new(estimatorVersionRecord)
+	if err := cbor.Unmarshal(recordBytes, record); err != nil {
+		return 0, errors.Wrap(err, "failed to unmarshal original estimator version record")
+	}
+	return int(record.Version), nil
+}
+
+// scriptComplexitiesByAddress returns stored complexities of account script by estimator versions.
+// Complexities are stored starting from the version of estimator that was active when the script was set.
+func (sc *scriptsComplexity) scriptComplexitiesByAddress(addr proto.Address) (map[int]ride.TreeEstimation, error) {
+	original, err := sc.originalEstimatorVersionFromDB(addr)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int]ride.TreeEstimation)
+	for ev := original; ev <= maxEstimatorVersion; ev++ {
+		est, err := sc.scriptComplexityByAddress(addr, ev)
+		if err == keyvalue.ErrNotFound || err == errEmptyHist {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		res[ev] = *est
+	}
+	return res, nil
+}
+
 func (sc *scriptsComplexity) newestOriginalScriptComplexityByAddr(addr proto.WavesAddress) (*ride.TreeEstimation, error) {
 	ev, err := sc.originalEstimatorVersion(addr)
 	if err != nil {
@@ -71,6 +71,9 @@ func TestSaveComplexityForAddr(t *testing.T) {
 	res, err = to.scriptsComplexity.newestOriginalScriptComplexityByAddr(addr)
 	require.NoError(t, err)
 	assert.Equal(t, est1, *res)
+	all, err := to.scriptsComplexity.scriptComplexitiesByAddress(addr)
+	require.NoError(t, err)
+	assert.Equal(t, estimations, all)
 }
 
 func TestSaveComplexityForAsset(t *testing.T) {
@@ -341,7 +341,12 @@ func (ss *scriptsStorage) scriptBytesByAsset(assetID proto.AssetID) (proto.Scrip
 	return ss.scriptBytesByKey(key.bytes())
 }
 
+// newestScriptBytesByAsset returns bytes of the same script as newestScriptByAsset does,
+// so uncertain asset scripts are checked first here too.
 func (ss *scriptsStorage) newestScriptBytesByAsset(assetID proto.AssetID) (proto.Script, error) {
+	if r, ok := ss.uncertainAssetScripts[assetID]; ok {
+		return r.scriptDBItem.script, nil
+	}
 	key := assetScriptKey{assetID}
 	return ss.newestScriptBytesByKey(key.bytes())
 }
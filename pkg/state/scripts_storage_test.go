@@ -223,6 +223,9 @@ func TestSetAssetScript(t *testing.T) {
 	scriptAst, err = to.scriptsStorage.newestScriptByAsset(shortAssetID)
 	assert.NoError(t, err, "newestScriptByAsset() failed")
 	assert.Equal(t, testGlobal.scriptAst, scriptAst)
+	scriptBytes, err := to.scriptsStorage.newestScriptBytesByAsset(shortAssetID)
+	assert.NoError(t, err, "newestScriptBytesByAsset() failed")
+	assert.Equal(t, proto.Script(testGlobal.scriptBytes), scriptBytes)
 	to.scriptsStorage.dropUncertain()
 	_, err = to.scriptsStorage.newestScriptByAsset(shortAssetID)
 	assert.EqualError(t, err, proto.ErrNotFound.Error(), "newestScriptByAsset() failed")
@@ -208,6 +208,11 @@ func (s *sponsoredAssets) wavesToSponsoredAsset(assetID proto.AssetID, wavesAmou
 	if err != nil {
 		return 0, err
 	}
+	return WavesToSponsoredAsset(wavesAmount, cost)
+}
+
+// WavesToSponsoredAsset converts the fee in Waves to the fee in sponsored asset with the given minimal sponsored asset fee.
+func WavesToSponsoredAsset(wavesAmount, cost uint64) (uint64, error) {
 	if cost == 0 || wavesAmount == 0 {
 		return 0, nil
 	}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/mr-tron/base58"
 	"github.com/pkg/errors"
@@ -18,6 +19,7 @@ import (
 	"github.com/wavesplatform/gowaves/pkg/errs"
 	"github.com/wavesplatform/gowaves/pkg/keyvalue"
 	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride"
 	"github.com/wavesplatform/gowaves/pkg/ride/ast"
 	"github.com/wavesplatform/gowaves/pkg/settings"
 	"github.com/wavesplatform/gowaves/pkg/types"
@@ -78,7 +80,7 @@ func newBlockchainEntitiesStorage(hs *historyStorage, sets *settings.BlockchainS
 	features := newFeatures(rw, hs.db, hs, sets, settings.FeaturesInfo)
 	return &blockchainEntitiesStorage{
 		hs,
-		newAliases(hs.db, hs.dbBatch, hs, calcHashes),
+		newAliases(hs, sets.AddressSchemeCharacter, calcHashes),
 		assets,
 		newLeases(hs, calcHashes),
 		newScores(hs),
@@ -210,7 +212,6 @@ func (s *blockchainEntitiesStorage) reset() {
 }
 
 func (s *blockchainEntitiesStorage) flush() error {
-	s.aliases.flush()
 	if err := s.hs.flush(); err != nil {
 		return err
 	}
@@ -242,6 +243,13 @@ func checkCompatibility(stateDB *stateDB, params StateParams) error {
 	if params.BuildStateHashes != hasDataForHashes {
 		return errors.Errorf("state hashes incompatibility: state stores: %v; want: %v", hasDataForHashes, params.BuildStateHashes)
 	}
+	compression, err := stateDB.blockCompression()
+	if err != nil {
+		return errors.Errorf("blockCompression: %v", err)
+	}
+	if params.Compression != compression {
+		return errors.Errorf("block compression incompatibility: state stores: %s; want: %s; convert the block storage first", compression, params.Compression)
+	}
 	return nil
 }
 
@@ -369,6 +377,8 @@ type stateManager struct {
 
 	// Specifies how many goroutines will be run for verification of transactions and blocks signatures.
 	verificationGoroutinesNum int
+	// Number of signatures verified at once by each verification goroutine, zero disables batch verification.
+	verificationBatchSize int
 
 	newBlocks *newBlocks
 }
@@ -418,6 +428,7 @@ func newStateManager(dataDir string, amend bool, params StateParams, settings *s
 		blockStorageDir,
 		params.OffsetLen,
 		params.HeaderOffsetLen,
+		params.Compression,
 		stateDB,
 		settings.AddressSchemeCharacter,
 	)
@@ -460,9 +471,12 @@ func newStateManager(dataDir string, amend bool, params StateParams, settings *s
 		verificationGoroutinesNum: params.VerificationGoroutinesNum,
 		newBlocks:                 newNewBlocks(rw, settings),
 	}
+	if params.BatchSignatureVerification {
+		state.verificationBatchSize = defaultVerificationBatchSize
+	}
 	// Set fields which depend on state.
 	// Consensus validator is needed to check block headers.
-	appender, err := newTxAppender(state, rw, stor, settings, stateDB, atx)
+	appender, err := newTxAppender(state, rw, stor, settings, stateDB, atx, params.RideEngine)
 	if err != nil {
 		return nil, wrapErr(Other, err)
 	}
@@ -565,7 +579,7 @@ func (s *stateManager) Map(func(State) error) error {
 func (s *stateManager) addGenesisBlock() error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	chans := launchVerifier(ctx, s.verificationGoroutinesNum, s.settings.AddressSchemeCharacter)
+	chans := launchVerifier(ctx, s.verificationGoroutinesNum, s.verificationBatchSize, s.settings.AddressSchemeCharacter)
 
 	if err := s.addNewBlock(s.genesis, nil, chans, 0); err != nil {
 		return err
@@ -702,13 +716,7 @@ func (s *stateManager) NewestLeasingInfo(id crypto.Digest) (*proto.LeaseInfo, er
 	if err != nil {
 		return nil, err
 	}
-	leaseInfo := proto.LeaseInfo{
-		Sender:      leaseFromStore.Sender,
-		Recipient:   leaseFromStore.Recipient,
-		IsActive:    leaseFromStore.isActive(),
-		LeaseAmount: leaseFromStore.Amount,
-	}
-	return &leaseInfo, nil
+	return leaseFromStore.toLeaseInfo(), nil
 }
 
 func (s *stateManager) NewestScriptPKByAddr(addr proto.WavesAddress) (crypto.PublicKey, error) {
@@ -1071,6 +1079,7 @@ func (s *stateManager) reset() {
 }
 
 func (s *stateManager) flush() error {
+	defer func(start time.Time) { metricFlushDuration.Observe(time.Since(start).Seconds()) }(time.Now())
 	if err := s.rw.flush(); err != nil {
 		return err
 	}
@@ -1224,7 +1233,7 @@ func (s *stateManager) blockchainHeightAction(blockchainHeight uint64, lastBlock
 		return err
 	}
 	if resetStolenAliases {
-		if err := s.stor.aliases.disableStolenAliases(); err != nil {
+		if err := s.stor.aliases.disableStolenAliases(lastBlock); err != nil {
 			return err
 		}
 	}
@@ -1343,6 +1352,7 @@ func (s *stateManager) addBlocks() (*proto.Block, error) {
 	if blocksNumber == 0 {
 		return nil, wrapErr(InvalidInputError, errors.New("no blocks provided"))
 	}
+	batchStart := time.Now()
 
 	// Read some useful values for later.
 	lastAppliedBlock, err := s.topBlock()
@@ -1357,7 +1367,7 @@ func (s *stateManager) addBlocks() (*proto.Block, error) {
 	headers := make([]proto.BlockHeader, blocksNumber)
 
 	// Launch verifier that checks signatures of blocks and transactions.
-	chans := launchVerifier(ctx, s.verificationGoroutinesNum, s.settings.AddressSchemeCharacter)
+	chans := launchVerifier(ctx, s.verificationGoroutinesNum, s.verificationBatchSize, s.settings.AddressSchemeCharacter)
 
 	var ids []proto.BlockID
 	pos := 0
@@ -1396,9 +1406,11 @@ func (s *stateManager) addBlocks() (*proto.Block, error) {
 			return nil, err
 		}
 		// Save block to storage, check its transactions, create and save balance diffs for its transactions.
+		blockStart := time.Now()
 		if err := s.addNewBlock(block, lastAppliedBlock, chans, blockchainCurHeight); err != nil {
 			return nil, err
 		}
+		metricBlockApplyDuration.Observe(time.Since(blockStart).Seconds())
 		if s.needToFinishVotingPeriod(blockchainCurHeight + 1) {
 			// If we need to finish voting period on the next block (h+1) then
 			// we have to check that protobuf will be activated on next block
@@ -1432,6 +1444,10 @@ func (s *stateManager) addBlocks() (*proto.Block, error) {
 	if err := s.flush(); err != nil {
 		return nil, wrapErr(ModificationError, err)
 	}
+	metricBlocksApplied.Add(float64(blocksNumber))
+	metricBlocksBatchSize.Observe(float64(blocksNumber))
+	metricBlocksBatchDuration.Observe(time.Since(batchStart).Seconds())
+	metricHeight.Set(float64(height + uint64(blocksNumber)))
 	zap.S().Infof(
 		"Height: %d; Block ID: %s, GenSig: %s, ts: %d",
 		height+uint64(blocksNumber),
@@ -1501,6 +1517,10 @@ func (s *stateManager) rollbackToImpl(removalEdge proto.BlockID) error {
 	if err := s.loadLastBlock(); err != nil {
 		zap.S().Fatalf("Failed to load last block after rollback: %v", err)
 	}
+	metricRollbacks.Inc()
+	if height, err := s.Height(); err == nil {
+		metricHeight.Set(float64(height))
+	}
 	return nil
 }
 
@@ -1629,11 +1649,22 @@ func (s *stateManager) NewestAddrByAlias(alias proto.Alias) (proto.WavesAddress,
 func (s *stateManager) AddrByAlias(alias proto.Alias) (proto.WavesAddress, error) {
 	addr, err := s.stor.aliases.addrByAlias(alias.Alias)
 	if err != nil {
+		if err == errAliasDisabled {
+			return proto.WavesAddress{}, wrapErr(NotFoundError, err)
+		}
 		return proto.WavesAddress{}, wrapErr(RetrievalError, err)
 	}
 	return *addr, nil
 }
 
+func (s *stateManager) AliasesByAddr(addr proto.WavesAddress) ([]proto.AliasInfo, error) {
+	aliases, err := s.stor.aliases.aliasesByAddr(addr)
+	if err != nil {
+		return nil, wrapErr(RetrievalError, err)
+	}
+	return aliases, nil
+}
+
 func (s *stateManager) VotesNumAtHeight(featureID int16, height proto.Height) (uint64, error) {
 	votesNum, err := s.stor.features.featureVotesAtHeight(featureID, height)
 	if err != nil {
@@ -1737,6 +1768,79 @@ func (s *stateManager) EstimatorVersion() (int, error) {
 	return 0, errors.New("inactive RIDE")
 }
 
+func (s *stateManager) OrderFillInfo(orderID crypto.Digest) (*proto.OrderFillInfo, error) {
+	volume, err := s.stor.ordersVolumes.volumeByID(orderID.Bytes())
+	if err != nil {
+		if err == keyvalue.ErrNotFound || err == errEmptyHist {
+			return nil, wrapErr(NotFoundError, errors.Errorf("order %s is not filled", orderID.String()))
+		}
+		return nil, wrapErr(RetrievalError, err)
+	}
+	ids, err := s.stor.ordersVolumes.transactions(orderID.Bytes())
+	if err != nil {
+		return nil, wrapErr(RetrievalError, err)
+	}
+	return &proto.OrderFillInfo{
+		FilledAmount: volume.amountFilled,
+		FilledFee:    volume.feeFilled,
+		Transactions: ids,
+	}, nil
+}
+
+func (s *stateManager) RewardsInfo(height proto.Height) (*proto.RewardsInfo, error) {
+	current, err := s.Height()
+	if err != nil {
+		return nil, err
+	}
+	if height < 1 || height > current {
+		return nil, wrapErr(InvalidInputError, errors.Errorf("invalid height %d, current height is %d", height, current))
+	}
+	feature := int16(settings.BlockReward)
+	if !s.stor.features.isActivatedAtHeight(feature, height) {
+		return nil, wrapErr(NotFoundError, errors.Errorf("block reward feature is not activated at height %d", height))
+	}
+	activation, err := s.stor.features.activationHeight(feature)
+	if err != nil {
+		return nil, wrapErr(RetrievalError, err)
+	}
+	fs := s.settings.FunctionalitySettings
+	if fs.BlockRewardTerm == 0 {
+		return nil, wrapErr(Other, errors.New("zero block reward term"))
+	}
+	reward, err := s.stor.monetaryPolicy.blockRewardAtHeight(height, activation)
+	if err != nil {
+		return nil, wrapErr(RetrievalError, err)
+	}
+	rewards, err := s.stor.monetaryPolicy.totalRewards(height, activation)
+	if err != nil {
+		return nil, wrapErr(RetrievalError, err)
+	}
+	votes, err := s.stor.monetaryPolicy.votesAtHeight(height)
+	if err != nil {
+		return nil, wrapErr(RetrievalError, err)
+	}
+	var initial uint64
+	for _, tx := range s.genesis.Transactions {
+		if g, ok := tx.(*proto.Genesis); ok {
+			initial += g.Amount
+		}
+	}
+	start, end := blockRewardTermBoundaries(height, activation, fs)
+	return &proto.RewardsInfo{
+		Height:              height,
+		TotalWavesAmount:    initial + rewards,
+		CurrentReward:       reward,
+		MinIncrement:        fs.BlockRewardIncrement,
+		Term:                fs.BlockRewardTerm,
+		NextCheck:           end,
+		VotingIntervalStart: start,
+		VotingInterval:      fs.BlockRewardVotingPeriod,
+		VotingThreshold:     fs.BlockRewardVotingPeriod/2 + 1,
+		VotesIncrease:       votes.increase,
+		VotesDecrease:       votes.decrease,
+	}, nil
+}
+
 // Accounts data storage.
 
 func (s *stateManager) RetrieveNewestEntry(account proto.Recipient, key string) (proto.DataEntry, error) {
@@ -2164,13 +2268,33 @@ func (s *stateManager) ScriptInfoByAccount(account proto.Recipient) (*proto.Scri
 		return nil, wrapErr(Other, err)
 	}
 	return &proto.ScriptInfo{
-		Version:    version,
-		Bytes:      scriptBytes,
-		Base64:     text,
-		Complexity: uint64(est.Estimation),
+		Version:            version,
+		Bytes:              scriptBytes,
+		Base64:             text,
+		Complexity:         uint64(est.Estimation),
+		VerifierComplexity: uint64(est.Verifier),
 	}, nil
 }
 
+func (s *stateManager) ScriptComplexitiesByAccount(account proto.Recipient) (map[int]ride.TreeEstimation, error) {
+	addr, err := s.recipientToAddress(account)
+	if err != nil {
+		return nil, wrapErr(RetrievalError, err)
+	}
+	hasScript, err := s.stor.scriptsStorage.accountHasScript(*addr)
+	if err != nil {
+		return nil, wrapErr(RetrievalError, err)
+	}
+	if !hasScript {
+		return nil, wrapErr(NotFoundError, errors.Errorf("account %s has no script", addr.String()))
+	}
+	estimations, err := s.stor.scriptsComplexity.scriptComplexitiesByAddress(*addr)
+	if err != nil {
+		return nil, wrapErr(RetrievalError, err)
+	}
+	return estimations, nil
+}
+
 func (s *stateManager) ScriptInfoByAsset(assetID proto.AssetID) (*proto.ScriptInfo, error) {
 	scriptBytes, err := s.stor.scriptsStorage.scriptBytesByAsset(assetID)
 	if err != nil {
@@ -2223,6 +2347,22 @@ func (s *stateManager) IsActiveLeasing(leaseID crypto.Digest) (bool, error) {
 	return isActive, nil
 }
 
+func (s *stateManager) LeasingInfo(id crypto.Digest) (*proto.LeaseInfo, error) {
+	l, err := s.stor.leases.leasingInfo(id)
+	if err != nil {
+		return nil, wrapErr(RetrievalError, err)
+	}
+	return l.toLeaseInfo(), nil
+}
+
+func (s *stateManager) LeasesByAddr(addr proto.WavesAddress, activeOnly bool) ([]crypto.Digest, error) {
+	ids, err := s.stor.leases.leasesByAddr(addr.ID(), activeOnly)
+	if err != nil {
+		return nil, wrapErr(RetrievalError, err)
+	}
+	return ids, nil
+}
+
 func (s *stateManager) InvokeResultByID(invokeID crypto.Digest) (*proto.ScriptResult, error) {
 	hasData, err := s.storesExtendedApiData()
 	if err != nil {
@@ -7,6 +7,7 @@ import (
 
 	"github.com/wavesplatform/gowaves/pkg/crypto"
 	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride"
 	"github.com/wavesplatform/gowaves/pkg/ride/ast"
 	"github.com/wavesplatform/gowaves/pkg/settings"
 )
@@ -182,12 +183,30 @@ func (a *ThreadSafeReadWrapper) EstimatorVersion() (int, error) {
 	return a.s.EstimatorVersion()
 }
 
+func (a *ThreadSafeReadWrapper) OrderFillInfo(orderID crypto.Digest) (*proto.OrderFillInfo, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.s.OrderFillInfo(orderID)
+}
+
+func (a *ThreadSafeReadWrapper) RewardsInfo(height proto.Height) (*proto.RewardsInfo, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.s.RewardsInfo(height)
+}
+
 func (a *ThreadSafeReadWrapper) AddrByAlias(alias proto.Alias) (proto.WavesAddress, error) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
 	return a.s.AddrByAlias(alias)
 }
 
+func (a *ThreadSafeReadWrapper) AliasesByAddr(addr proto.WavesAddress) ([]proto.AliasInfo, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.s.AliasesByAddr(addr)
+}
+
 func (a *ThreadSafeReadWrapper) RetrieveEntries(account proto.Recipient) ([]proto.DataEntry, error) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
@@ -278,6 +297,12 @@ func (a *ThreadSafeReadWrapper) ScriptInfoByAccount(account proto.Recipient) (*p
 	return a.s.ScriptInfoByAccount(account)
 }
 
+func (a *ThreadSafeReadWrapper) ScriptComplexitiesByAccount(account proto.Recipient) (map[int]ride.TreeEstimation, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.s.ScriptComplexitiesByAccount(account)
+}
+
 func (a *ThreadSafeReadWrapper) ScriptInfoByAsset(assetID proto.AssetID) (*proto.ScriptInfo, error) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
@@ -302,6 +327,18 @@ func (a *ThreadSafeReadWrapper) IsActiveLeasing(leaseID crypto.Digest) (bool, er
 	return a.s.IsActiveLeasing(leaseID)
 }
 
+func (a *ThreadSafeReadWrapper) LeasingInfo(leaseID crypto.Digest) (*proto.LeaseInfo, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.s.LeasingInfo(leaseID)
+}
+
+func (a *ThreadSafeReadWrapper) LeasesByAddr(addr proto.WavesAddress, activeOnly bool) ([]crypto.Digest, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.s.LeasesByAddr(addr, activeOnly)
+}
+
 func (a *ThreadSafeReadWrapper) InvokeResultByID(invokeID crypto.Digest) (*proto.ScriptResult, error) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
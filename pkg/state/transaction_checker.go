@@ -19,7 +19,7 @@ import (
 )
 
 const (
-	maxEstimatorVersion = 4
+	maxEstimatorVersion = ride.MaxEstimatorVersion
 )
 
 type checkerInfo struct {
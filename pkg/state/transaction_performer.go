@@ -154,7 +154,15 @@ func (tp *transactionPerformer) increaseOrderVolume(order proto.Order, tx proto.
 	if err := tp.stor.ordersVolumes.increaseFilledAmount(orderId, tx.GetAmount(), info.blockID); err != nil {
 		return err
 	}
-	return nil
+	txID, err := tx.GetID(tp.settings.AddressSchemeCharacter)
+	if err != nil {
+		return errors.Wrap(err, "failed to get exchange transaction ID")
+	}
+	id, err := crypto.NewDigestFromBytes(txID)
+	if err != nil {
+		return err
+	}
+	return tp.stor.ordersVolumes.addTransaction(orderId, id, info.blockID)
 }
 
 func (tp *transactionPerformer) performExchange(transaction proto.Transaction, info *performerInfo) error {
@@ -252,12 +260,13 @@ func (tp *transactionPerformer) performCreateAlias(tx *proto.CreateAlias, info *
 	if err != nil {
 		return err
 	}
+	blockHeight := info.height + 1
 	// Save alias to aliases storage.
 	inf := &aliasInfo{
 		stolen: tp.stor.aliases.exists(tx.Alias.Alias),
 		addr:   senderAddr,
 	}
-	if err := tp.stor.aliases.createAlias(tx.Alias.Alias, inf, info.blockID); err != nil {
+	if err := tp.stor.aliases.createAlias(tx.Alias.Alias, inf, blockHeight, info.blockID); err != nil {
 		return err
 	}
 	return nil
@@ -199,6 +199,16 @@ func TestPerformExchange(t *testing.T) {
 	filledAmount, err = to.stor.entities.ordersVolumes.newestFilledAmount(buyOrderId)
 	assert.NoError(t, err)
 	assert.Equal(t, tx.GetAmount(), filledAmount)
+
+	txID, err := tx.GetID(proto.MainNetScheme)
+	require.NoError(t, err)
+	id, err := crypto.NewDigestFromBytes(txID)
+	require.NoError(t, err)
+	for _, orderID := range [][]byte{sellOrderId, buyOrderId} {
+		ids, err := to.stor.entities.ordersVolumes.transactions(orderID)
+		assert.NoError(t, err)
+		assert.Equal(t, []crypto.Digest{id}, ids)
+	}
 }
 
 func TestPerformLeaseWithSig(t *testing.T) {
@@ -309,7 +319,7 @@ func TestPerformCreateAliasWithSig(t *testing.T) {
 	err = to.tp.performCreateAliasWithSig(tx, defaultPerformerInfo())
 	assert.NoError(t, err, "performCreateAliasWithSig() failed")
 	to.stor.flush(t)
-	err = to.stor.entities.aliases.disableStolenAliases()
+	err = to.stor.entities.aliases.disableStolenAliases(blockID0)
 	assert.NoError(t, err, "disableStolenAliases() failed")
 	to.stor.flush(t)
 	_, err = to.stor.entities.aliases.addrByAlias(tx.Alias.Alias)
@@ -332,7 +342,7 @@ func TestPerformCreateAliasWithProofs(t *testing.T) {
 	err = to.tp.performCreateAliasWithProofs(tx, defaultPerformerInfo())
 	assert.NoError(t, err, "performCreateAliasWithProofs() failed")
 	to.stor.flush(t)
-	err = to.stor.entities.aliases.disableStolenAliases()
+	err = to.stor.entities.aliases.disableStolenAliases(blockID0)
 	assert.NoError(t, err, "disableStolenAliases() failed")
 	to.stor.flush(t)
 	_, err = to.stor.entities.aliases.addrByAlias(tx.Alias.Alias)
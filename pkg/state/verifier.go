@@ -195,6 +195,11 @@ func checkTx(tx proto.Transaction, checkTxSig, checkOrder1, checkOrder2 bool, sc
 }
 
 func handleTask(task *verifyTask, scheme proto.Scheme) error {
+	return checkTask(task, scheme, true)
+}
+
+// checkTask performs the checks of task, signatures are skipped if checkSignatures is false.
+func checkTask(task *verifyTask, scheme proto.Scheme, checkSignatures bool) error {
 	switch task.taskType {
 	case verifyBlock:
 		// Check parent.
@@ -202,13 +207,15 @@ func handleTask(task *verifyTask, scheme proto.Scheme) error {
 			return errors.Errorf("incorrect parent: want: %s, have: %s", task.parentID.String(), task.block.Parent.String())
 		}
 		// Check block signature and transactions root hash if applied.
-		validSig, err := task.block.VerifySignature(scheme)
-		if err != nil {
-			return errors.Wrap(err, "State: handleTask: failed to verify block signature")
-		}
-		if !validSig {
-			return errors.Errorf("State: handleTask: invalid block signature (%s) of block '%s'",
-				task.block.BlockSignature.String(), task.block.ID.String())
+		if checkSignatures {
+			validSig, err := task.block.VerifySignature(scheme)
+			if err != nil {
+				return errors.Wrap(err, "State: handleTask: failed to verify block signature")
+			}
+			if !validSig {
+				return errors.Errorf("State: handleTask: invalid block signature (%s) of block '%s'",
+					task.block.BlockSignature.String(), task.block.ID.String())
+			}
 		}
 		validRootHash, err := task.block.VerifyTransactionsRoot(scheme)
 		if err != nil {
@@ -219,7 +226,10 @@ func handleTask(task *verifyTask, scheme proto.Scheme) error {
 				task.block.TransactionsRoot.String(), task.block.ID.String())
 		}
 	case verifyTx:
-		if err := checkTx(task.tx, task.checkTxSig, task.checkOrder1, task.checkOrder2, scheme); err != nil {
+		checkTxSig := checkSignatures && task.checkTxSig
+		checkOrder1 := checkSignatures && task.checkOrder1
+		checkOrder2 := checkSignatures && task.checkOrder2
+		if err := checkTx(task.tx, checkTxSig, checkOrder1, checkOrder2, scheme); err != nil {
 			txID, txIdErr := task.tx.GetID(scheme)
 			if txIdErr != nil {
 				return errors.Wrap(txIdErr, "failed to get transaction ID")
@@ -248,7 +258,9 @@ func verify(ctx context.Context, tasks <-chan *verifyTask, scheme proto.Scheme)
 	}
 }
 
-func launchVerifier(ctx context.Context, goroutinesNum int, scheme proto.Scheme) *verifierChans {
+// launchVerifier runs goroutines that verify tasks, signatures are verified in batches of batchSize signatures
+// if batchSize is positive.
+func launchVerifier(ctx context.Context, goroutinesNum, batchSize int, scheme proto.Scheme) *verifierChans {
 	if goroutinesNum <= 0 {
 		panic("verifier launched with negative or zero goroutines number")
 	}
@@ -257,6 +269,9 @@ func launchVerifier(ctx context.Context, goroutinesNum int, scheme proto.Scheme)
 	tasksChan := make(chan *verifyTask)
 	for i := 0; i < goroutinesNum; i++ {
 		errgr.Go(func() error {
+			if batchSize > 0 {
+				return verifyInBatches(ctx, tasksChan, batchSize, scheme)
+			}
 			return verify(ctx, tasksChan, scheme)
 		})
 	}
@@ -0,0 +1,229 @@
+package state
+
+import (
+	"context"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+// defaultVerificationBatchSize is the number of signatures verified in one batch by each verifier goroutine.
+const defaultVerificationBatchSize = 256
+
+// taskBatch collects signatures of tasks until they are verified at once.
+type taskBatch struct {
+	scheme   proto.Scheme
+	size     int
+	verifier *crypto.BatchVerifier
+	tasks    []*verifyTask
+}
+
+func newTaskBatch(size int, scheme proto.Scheme) *taskBatch {
+	return &taskBatch{scheme: scheme, size: size, verifier: crypto.NewBatchVerifier(size)}
+}
+
+// add checks the task without signatures and adds its signatures to the batch. The task is checked completely
+// if its signatures can't be verified in batch.
+func (b *taskBatch) add(task *verifyTask) error {
+	if !addTaskSignatures(b.verifier, task, b.scheme) {
+		return handleTask(task, b.scheme)
+	}
+	if err := checkTask(task, b.scheme, false); err != nil {
+		return err
+	}
+	b.tasks = append(b.tasks, task)
+	if b.verifier.Len() >= b.size {
+		return b.flush()
+	}
+	return nil
+}
+
+// flush verifies collected signatures. If the batch fails the tasks are checked one by one to report the error
+// of the invalid one.
+func (b *taskBatch) flush() error {
+	defer func() {
+		b.verifier.Reset()
+		b.tasks = b.tasks[:0]
+	}()
+	if b.verifier.Verify() {
+		return nil
+	}
+	for _, task := range b.tasks {
+		if err := handleTask(task, b.scheme); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func verifyInBatches(ctx context.Context, tasks <-chan *verifyTask, batchSize int, scheme proto.Scheme) error {
+	batch := newTaskBatch(batchSize, scheme)
+	for {
+		select {
+		case task, ok := <-tasks:
+			if !ok {
+				return batch.flush()
+			}
+			if err := batch.add(task); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// addTaskSignatures adds signatures of the task to the batch, false is returned if any of the signatures
+// can't be verified in batch. In the latter case the batch is left unchanged.
+func addTaskSignatures(bv *crypto.BatchVerifier, task *verifyTask, scheme proto.Scheme) bool {
+	var signatures []signedData
+	switch task.taskType {
+	case verifyBlock:
+		data, err := task.block.BytesToSign(scheme)
+		if err != nil {
+			return false
+		}
+		signatures = append(signatures, signedData{pk: task.block.GenPublicKey, sig: task.block.BlockSignature, data: data})
+	case verifyTx:
+		if task.checkTxSig {
+			sd, ok := txSignedData(task.tx, scheme)
+			if !ok {
+				return false
+			}
+			signatures = append(signatures, sd)
+		}
+		if task.checkOrder1 || task.checkOrder2 {
+			exchange, ok := task.tx.(proto.Exchange)
+			if !ok {
+				return false
+			}
+			if task.checkOrder1 {
+				sd, ok := orderSignedData(exchange.GetOrder1(), scheme)
+				if !ok {
+					return false
+				}
+				signatures = append(signatures, sd)
+			}
+			if task.checkOrder2 {
+				sd, ok := orderSignedData(exchange.GetOrder2(), scheme)
+				if !ok {
+					return false
+				}
+				signatures = append(signatures, sd)
+			}
+		}
+	default:
+		return false
+	}
+	for _, sd := range signatures {
+		bv.Add(sd.pk, sd.sig, sd.data)
+	}
+	return true
+}
+
+type signedData struct {
+	pk   crypto.PublicKey
+	sig  crypto.Signature
+	data []byte
+}
+
+// txSignedData returns the signature of transaction verified by its Verify method. Transactions with special
+// signing rules (payments and Ethereum transactions) are not supported.
+func txSignedData(tx proto.Transaction, scheme proto.Scheme) (signedData, bool) {
+	var (
+		pk     crypto.PublicKey
+		sig    *crypto.Signature
+		proofs *proto.ProofsV1
+	)
+	switch t := tx.(type) {
+	case *proto.TransferWithSig:
+		pk, sig = t.SenderPK, t.Signature
+	case *proto.IssueWithSig:
+		pk, sig = t.SenderPK, t.Signature
+	case *proto.ReissueWithSig:
+		pk, sig = t.SenderPK, t.Signature
+	case *proto.BurnWithSig:
+		pk, sig = t.SenderPK, t.Signature
+	case *proto.ExchangeWithSig:
+		pk, sig = t.SenderPK, t.Signature
+	case *proto.LeaseWithSig:
+		pk, sig = t.SenderPK, t.Signature
+	case *proto.LeaseCancelWithSig:
+		pk, sig = t.SenderPK, t.Signature
+	case *proto.CreateAliasWithSig:
+		pk, sig = t.SenderPK, t.Signature
+	case *proto.TransferWithProofs:
+		pk, proofs = t.SenderPK, t.Proofs
+	case *proto.IssueWithProofs:
+		pk, proofs = t.SenderPK, t.Proofs
+	case *proto.ReissueWithProofs:
+		pk, proofs = t.SenderPK, t.Proofs
+	case *proto.BurnWithProofs:
+		pk, proofs = t.SenderPK, t.Proofs
+	case *proto.ExchangeWithProofs:
+		pk, proofs = t.SenderPK, t.Proofs
+	case *proto.LeaseWithProofs:
+		pk, proofs = t.SenderPK, t.Proofs
+	case *proto.LeaseCancelWithProofs:
+		pk, proofs = t.SenderPK, t.Proofs
+	case *proto.CreateAliasWithProofs:
+		pk, proofs = t.SenderPK, t.Proofs
+	case *proto.SponsorshipWithProofs:
+		pk, proofs = t.SenderPK, t.Proofs
+	case *proto.MassTransferWithProofs:
+		pk, proofs = t.SenderPK, t.Proofs
+	case *proto.DataWithProofs:
+		pk, proofs = t.SenderPK, t.Proofs
+	case *proto.SetScriptWithProofs:
+		pk, proofs = t.SenderPK, t.Proofs
+	case *proto.SetAssetScriptWithProofs:
+		pk, proofs = t.SenderPK, t.Proofs
+	case *proto.InvokeScriptWithProofs:
+		pk, proofs = t.SenderPK, t.Proofs
+	case *proto.InvokeExpressionTransactionWithProofs:
+		pk, proofs = t.SenderPK, t.Proofs
+	case *proto.UpdateAssetInfoWithProofs:
+		pk, proofs = t.SenderPK, t.Proofs
+	default:
+		return signedData{}, false
+	}
+	if proofs != nil {
+		s, err := proofs.ExtractSignature()
+		if err != nil {
+			return signedData{}, false
+		}
+		sig = &s
+	}
+	if sig == nil {
+		return signedData{}, false
+	}
+	data, err := proto.MarshalTxBody(scheme, tx)
+	if err != nil {
+		return signedData{}, false
+	}
+	return signedData{pk: pk, sig: *sig, data: data}, true
+}
+
+// orderSignedData returns the signature of order, Ethereum orders are not supported.
+func orderSignedData(order proto.Order, scheme proto.Scheme) (signedData, bool) {
+	if _, ok := order.(*proto.EthereumOrderV4); ok {
+		return signedData{}, false
+	}
+	pk, err := crypto.NewPublicKeyFromBytes(order.GetSenderPKBytes())
+	if err != nil {
+		return signedData{}, false
+	}
+	proofs, err := order.GetProofs()
+	if err != nil {
+		return signedData{}, false
+	}
+	sig, err := proofs.ExtractSignature()
+	if err != nil {
+		return signedData{}, false
+	}
+	data, err := proto.MarshalOrderBody(scheme, order)
+	if err != nil {
+		return signedData{}, false
+	}
+	return signedData{pk: pk, sig: sig, data: data}, true
+}
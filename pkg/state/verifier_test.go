@@ -2,10 +2,12 @@ package state
 
 import (
 	"context"
+	"fmt"
 	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/wavesplatform/gowaves/pkg/crypto"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 )
@@ -40,6 +42,14 @@ func verifyBlocks(blocks []proto.Block, chans *verifierChans) error {
 }
 
 func TestVerifier(t *testing.T) {
+	for _, batchSize := range []int{0, 4, defaultVerificationBatchSize} {
+		t.Run(fmt.Sprintf("BatchSize-%d", batchSize), func(t *testing.T) {
+			testVerifier(t, batchSize)
+		})
+	}
+}
+
+func testVerifier(t *testing.T, batchSize int) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	// Read real blocks.
@@ -51,33 +61,33 @@ func TestVerifier(t *testing.T) {
 	txs := last.Transactions
 
 	// Test valid blocks.
-	chans := launchVerifier(ctx, runtime.NumCPU(), proto.TestNetScheme)
+	chans := launchVerifier(ctx, runtime.NumCPU(), batchSize, proto.TestNetScheme)
 	err = verifyBlocks(blocks, chans)
 	assert.NoError(t, err, "verifyBlocks() failed with valid blocks")
-	chans = launchVerifier(ctx, runtime.NumCPU(), proto.TestNetScheme)
+	chans = launchVerifier(ctx, runtime.NumCPU(), batchSize, proto.TestNetScheme)
 	// Test valid transactions.
 	err = verifyTransactions(txs, chans)
 	assert.NoError(t, err, "verifyTransactions() failed with valid transactions")
-	chans = launchVerifier(ctx, runtime.NumCPU(), proto.TestNetScheme)
+	chans = launchVerifier(ctx, runtime.NumCPU(), batchSize, proto.TestNetScheme)
 	// Spoil block parent.
 	backup := blocks[len(blocks)/2]
 	blocks[len(blocks)/2].Parent = proto.NewBlockIDFromSignature(crypto.Signature{})
 	err = verifyBlocks(blocks, chans)
 	assert.Error(t, err, "verifyBlocks() did not fail with wrong parent")
-	chans = launchVerifier(ctx, runtime.NumCPU(), proto.TestNetScheme)
+	chans = launchVerifier(ctx, runtime.NumCPU(), batchSize, proto.TestNetScheme)
 	blocks[len(blocks)/2] = backup
 	err = verifyBlocks(blocks, chans)
 	assert.NoError(t, err, "verifyBlocks() failed with valid blocks")
-	chans = launchVerifier(ctx, runtime.NumCPU(), proto.TestNetScheme)
+	chans = launchVerifier(ctx, runtime.NumCPU(), batchSize, proto.TestNetScheme)
 	// Spoil block signature.
 	blocks[len(blocks)/2].BlockSignature = crypto.Signature{}
 	err = verifyBlocks(blocks, chans)
 	assert.Error(t, err, "verifyBlocks() did not fail with wrong signature")
-	chans = launchVerifier(ctx, runtime.NumCPU(), proto.TestNetScheme)
+	chans = launchVerifier(ctx, runtime.NumCPU(), batchSize, proto.TestNetScheme)
 	blocks[len(blocks)/2] = backup
 	err = verifyBlocks(blocks, chans)
 	assert.NoError(t, err, "verifyBlocks() failed with valid blocks")
-	chans = launchVerifier(ctx, runtime.NumCPU(), proto.TestNetScheme)
+	chans = launchVerifier(ctx, runtime.NumCPU(), batchSize, proto.TestNetScheme)
 	// Test unsigned tx failure.
 	spk, err := crypto.NewPublicKeyFromBase58(testPK)
 	assert.NoError(t, err, "NewPublicKeyFromBase58() failed")
@@ -88,10 +98,65 @@ func TestVerifier(t *testing.T) {
 	txs = []proto.Transaction{unsignedTx}
 	err = verifyTransactions(txs, chans)
 	assert.Error(t, err, "verifyTransactions() did not fail with unsigned tx")
-	chans = launchVerifier(ctx, runtime.NumCPU(), proto.TestNetScheme)
+	chans = launchVerifier(ctx, runtime.NumCPU(), batchSize, proto.TestNetScheme)
 	// Test invalid tx failure.
 	invalidTx := proto.NewUnsignedGenesis(recipient, 0, 0)
 	txs = []proto.Transaction{invalidTx}
 	err = verifyTransactions(txs, chans)
 	assert.Error(t, err, "verifyTransactions() did not fail with invalid tx")
 }
+
+func signedTransfers(t testing.TB, n int) []proto.Transaction {
+	sk, pk, err := crypto.GenerateKeyPair([]byte("verifier"))
+	require.NoError(t, err)
+	recipient, err := proto.NewAddressFromString(testAddr)
+	require.NoError(t, err)
+	txs := make([]proto.Transaction, n)
+	for i := range txs {
+		tx := proto.NewUnsignedTransferWithProofs(3, pk, proto.NewOptionalAssetWaves(), proto.NewOptionalAssetWaves(),
+			uint64(1600000000000+i), 100, 100000, proto.NewRecipientFromAddress(recipient), nil)
+		require.NoError(t, tx.Sign(proto.TestNetScheme, sk))
+		txs[i] = tx
+	}
+	return txs
+}
+
+func TestVerifierBatchReportsInvalidTransaction(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	txs := signedTransfers(t, 100)
+	// Sign one transaction with another key, the batch must fail and report the error of this transaction.
+	otherSK, _, err := crypto.GenerateKeyPair([]byte("other"))
+	require.NoError(t, err)
+	invalid := txs[42].(*proto.TransferWithProofs)
+	invalid.Proofs = proto.NewProofs()
+	require.NoError(t, invalid.Sign(proto.TestNetScheme, otherSK))
+	expected := verifyTransactions(txs, launchVerifier(ctx, 1, 0, proto.TestNetScheme))
+	require.Error(t, expected)
+	for _, batchSize := range []int{16, defaultVerificationBatchSize} {
+		err = verifyTransactions(txs, launchVerifier(ctx, 1, batchSize, proto.TestNetScheme))
+		assert.EqualError(t, err, expected.Error())
+	}
+}
+
+func BenchmarkVerifier(b *testing.B) {
+	blocks, err := readBlocksFromTestPath(1000)
+	require.NoError(b, err)
+	txs := signedTransfers(b, 1000)
+	for _, batchSize := range []int{0, defaultVerificationBatchSize} {
+		b.Run(fmt.Sprintf("Blocks-BatchSize-%d", batchSize), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				if err := verifyBlocks(blocks, launchVerifier(context.Background(), runtime.NumCPU(), batchSize, proto.TestNetScheme)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("Transactions-BatchSize-%d", batchSize), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				if err := verifyTransactions(txs, launchVerifier(context.Background(), runtime.NumCPU(), batchSize, proto.TestNetScheme)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
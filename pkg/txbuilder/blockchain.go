@@ -0,0 +1,103 @@
+package txbuilder
+
+import (
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride/serialization"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+	"github.com/wavesplatform/gowaves/pkg/state"
+)
+
+// Blockchain provides the builder with the state that affects transaction versions and fees.
+type Blockchain interface {
+	state.ActivationChecker
+	// AccountScript returns the complexity of the account verifier, false is returned if the account has no script.
+	AccountScript(addr proto.WavesAddress) (int, bool, error)
+	IsSmartAsset(assetID crypto.Digest) (bool, error)
+	// SponsorshipCost returns the minimal fee in the asset, zero is returned if the asset is not sponsored.
+	SponsorshipCost(assetID crypto.Digest) (uint64, error)
+}
+
+// StaticBlockchain is the Blockchain with fixed parameters, it is used to build transactions without access to the node.
+type StaticBlockchain struct {
+	Features []settings.Feature
+	// AccountScripts holds verifier complexities of scripted accounts.
+	AccountScripts map[proto.WavesAddress]int
+	SmartAssets    map[crypto.Digest]bool
+	// Sponsorship holds minimal fees of sponsored assets.
+	Sponsorship map[crypto.Digest]uint64
+}
+
+func (b *StaticBlockchain) IsActivated(featureID int16) (bool, error) {
+	for _, f := range b.Features {
+		if int16(f) == featureID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (b *StaticBlockchain) AccountScript(addr proto.WavesAddress) (int, bool, error) {
+	complexity, ok := b.AccountScripts[addr]
+	return complexity, ok, nil
+}
+
+func (b *StaticBlockchain) IsSmartAsset(assetID crypto.Digest) (bool, error) {
+	return b.SmartAssets[assetID], nil
+}
+
+func (b *StaticBlockchain) SponsorshipCost(assetID crypto.Digest) (uint64, error) {
+	return b.Sponsorship[assetID], nil
+}
+
+type stateBlockchain struct {
+	state state.StateInfo
+}
+
+// NewStateBlockchain creates the Blockchain backed by the node state.
+func NewStateBlockchain(st state.StateInfo) Blockchain {
+	return &stateBlockchain{state: st}
+}
+
+func (b *stateBlockchain) IsActivated(featureID int16) (bool, error) {
+	return b.state.IsActivated(featureID)
+}
+
+func (b *stateBlockchain) AccountScript(addr proto.WavesAddress) (int, bool, error) {
+	info, err := b.state.ScriptInfoByAccount(proto.NewRecipientFromAddress(addr))
+	if err != nil {
+		if state.IsNotFound(err) {
+			return 0, false, nil
+		}
+		return 0, false, errors.Wrapf(err, "failed to get script of account %s", addr.String())
+	}
+	if len(info.Bytes) == 0 {
+		return 0, false, nil
+	}
+	tree, err := serialization.Parse(info.Bytes)
+	if err != nil {
+		return 0, false, errors.Wrapf(err, "failed to parse script of account %s", addr.String())
+	}
+	// Transactions of dApp without verifier are not verified by script.
+	if !tree.HasVerifier() {
+		return 0, false, nil
+	}
+	return int(info.VerifierComplexity), true, nil
+}
+
+func (b *stateBlockchain) IsSmartAsset(assetID crypto.Digest) (bool, error) {
+	info, err := b.state.AssetInfo(proto.AssetIDFromDigest(assetID))
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get info of asset %s", assetID.String())
+	}
+	return info.Scripted, nil
+}
+
+func (b *stateBlockchain) SponsorshipCost(assetID crypto.Digest) (uint64, error) {
+	info, err := b.state.FullAssetInfo(proto.AssetIDFromDigest(assetID))
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get info of asset %s", assetID.String())
+	}
+	return info.SponsorshipCost, nil
+}
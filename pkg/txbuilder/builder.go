@@ -0,0 +1,220 @@
+// Package txbuilder builds and signs transactions.
+//
+// The builder chooses the latest transaction version allowed by the activated features and
+// calculates the minimal fee by the rules of the node, including extra fee for smart accounts and
+// smart assets and conversion of the fee to the sponsored asset:
+//
+//	tx, err := txbuilder.New(proto.MainNetScheme, blockchain, senderPK).
+//		FeeAsset(sponsoredAsset).
+//		Transfer(recipient, amount, proto.NewOptionalAssetWaves(), nil).
+//		Sign(sk).
+//		Transaction()
+//
+// Multisig transactions are signed with several keys, each signature is placed as a proof at the position of the key:
+//
+//	d := txbuilder.New(scheme, blockchain, multisigPK).Lease(recipient, amount).Sign(sk0, sk1, sk2)
+//	js, err := d.JSON()
+package txbuilder
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+// maxFeeIterations limits attempts to find the fee that covers the size of transaction with that fee.
+const maxFeeIterations = 3
+
+// Builder holds parameters that are common for all transactions.
+type Builder struct {
+	scheme    proto.Scheme
+	chain     Blockchain
+	sender    crypto.PublicKey
+	timestamp uint64
+	fee       uint64
+	feeAsset  proto.OptionalAsset
+	version   byte
+}
+
+// New creates the builder of transactions sent by the owner of the public key.
+func New(scheme proto.Scheme, chain Blockchain, sender crypto.PublicKey) *Builder {
+	return &Builder{
+		scheme:   scheme,
+		chain:    chain,
+		sender:   sender,
+		feeAsset: proto.NewOptionalAssetWaves(),
+	}
+}
+
+// Timestamp sets the timestamp in milliseconds, the current time is used by default.
+func (b *Builder) Timestamp(ts uint64) *Builder {
+	b.timestamp = ts
+	return b
+}
+
+// Fee sets the fee, the minimal fee is calculated by default.
+func (b *Builder) Fee(fee uint64) *Builder {
+	b.fee = fee
+	return b
+}
+
+// FeeAsset sets the sponsored asset to pay the fee. Only transfer and invoke script transactions can be
+// paid in sponsored asset.
+func (b *Builder) FeeAsset(asset proto.OptionalAsset) *Builder {
+	b.feeAsset = asset
+	return b
+}
+
+// Version sets the version of transaction, the latest available version is used by default.
+func (b *Builder) Version(v byte) *Builder {
+	b.version = v
+	return b
+}
+
+// spec constructs the unsigned transaction of specific type.
+type spec struct {
+	txType proto.TransactionType
+	// assets lists assets those scripts are run by the transaction.
+	assets []proto.OptionalAsset
+	// feeAssetAllowed is set for transactions that can be paid in sponsored asset.
+	feeAssetAllowed bool
+	build           func(v byte, fee, timestamp uint64) (proto.Transaction, *proto.ProofsV1)
+}
+
+func (b *Builder) draft(s spec) *Draft {
+	d := &Draft{scheme: b.scheme}
+	if b.feeAsset.Present && !s.feeAssetAllowed {
+		d.err = errors.Errorf("fee of transaction type %d can't be paid in asset", s.txType)
+		return d
+	}
+	v := b.version
+	if v == 0 {
+		var err error
+		v, err = latestVersion(b.chain, s.txType)
+		if err != nil {
+			d.err = err
+			return d
+		}
+	}
+	ts := b.timestamp
+	if ts == 0 {
+		ts = proto.NewTimestampFromTime(time.Now())
+	}
+	fee := b.fee
+	tx, proofs := s.build(v, fee, ts)
+	if b.fee == 0 {
+		for i := 0; i < maxFeeIterations; i++ {
+			min, err := minFee(b.scheme, b.chain, tx, b.feeAsset, s.assets)
+			if err != nil {
+				d.err = err
+				return d
+			}
+			if min == fee {
+				break
+			}
+			fee = min
+			tx, proofs = s.build(v, fee, ts)
+		}
+	}
+	if _, err := tx.Validate(b.scheme); err != nil {
+		d.err = errors.Wrap(err, "invalid transaction")
+		return d
+	}
+	if err := tx.GenerateID(b.scheme); err != nil {
+		d.err = errors.Wrap(err, "failed to generate transaction ID")
+		return d
+	}
+	d.tx, d.proofs = tx, proofs
+	return d
+}
+
+// Draft is the transaction under signing. Errors of building and signing are deferred until the transaction is requested.
+type Draft struct {
+	scheme proto.Scheme
+	tx     proto.Transaction
+	proofs *proto.ProofsV1
+	err    error
+}
+
+// BodyBytes returns the bytes to sign, they are useful to collect signatures of multisig participants.
+func (d *Draft) BodyBytes() ([]byte, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	return proto.MarshalTxBody(d.scheme, d.tx)
+}
+
+// Sign signs the transaction with keys, signatures are placed as proofs in the order of keys.
+func (d *Draft) Sign(keys ...crypto.SecretKey) *Draft {
+	for i, sk := range keys {
+		d.SignAt(i, sk)
+	}
+	return d
+}
+
+// SignAt signs the transaction and places the signature as the proof at the index.
+func (d *Draft) SignAt(index int, sk crypto.SecretKey) *Draft {
+	body, err := d.BodyBytes()
+	if err != nil {
+		d.fail(err)
+		return d
+	}
+	sig, err := crypto.Sign(sk, body)
+	if err != nil {
+		d.fail(errors.Wrap(err, "failed to sign transaction"))
+		return d
+	}
+	return d.AddProof(index, sig.Bytes())
+}
+
+// AddProof places the proof at the index, proofs at previous positions are left empty if not set.
+func (d *Draft) AddProof(index int, proof []byte) *Draft {
+	if d.err != nil {
+		return d
+	}
+	if index < 0 {
+		d.fail(errors.Errorf("invalid proof index %d", index))
+		return d
+	}
+	for len(d.proofs.Proofs) <= index {
+		d.proofs.Proofs = append(d.proofs.Proofs, proto.B58Bytes{})
+	}
+	d.proofs.Proofs[index] = proof
+	if err := d.proofs.Valid(); err != nil {
+		d.fail(errors.Wrap(err, "invalid proofs"))
+	}
+	return d
+}
+
+func (d *Draft) fail(err error) {
+	if d.err == nil {
+		d.err = err
+	}
+}
+
+// Transaction returns the built transaction.
+func (d *Draft) Transaction() (proto.Transaction, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	return d.tx, nil
+}
+
+// JSON returns the transaction in the form accepted by the broadcast endpoint of REST API.
+func (d *Draft) JSON() ([]byte, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	return json.Marshal(d.tx)
+}
+
+// Protobuf returns bytes of the signed transaction protobuf message accepted by the gRPC Broadcast.
+func (d *Draft) Protobuf() ([]byte, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	return proto.MarshalSignedTxDeterministic(d.tx, d.scheme)
+}
@@ -0,0 +1,221 @@
+package txbuilder
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+)
+
+const testTimestamp = 1666000000000
+
+type testAccount struct {
+	sk   crypto.SecretKey
+	pk   crypto.PublicKey
+	addr proto.WavesAddress
+}
+
+func newTestAccount(t *testing.T, seed string) testAccount {
+	sk, pk, err := crypto.GenerateKeyPair([]byte(seed))
+	require.NoError(t, err)
+	addr, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+	return testAccount{sk: sk, pk: pk, addr: addr}
+}
+
+func TestTransfer(t *testing.T) {
+	sender := newTestAccount(t, "sender")
+	recipient := newTestAccount(t, "recipient")
+	chain := &StaticBlockchain{Features: []settings.Feature{settings.SmartAccounts, settings.BlockV5, settings.RideV5}}
+
+	d := New(proto.TestNetScheme, chain, sender.pk).
+		Timestamp(testTimestamp).
+		Transfer(proto.NewRecipientFromAddress(recipient.addr), 12345, proto.NewOptionalAssetWaves(), proto.Attachment("test")).
+		Sign(sender.sk)
+	tx, err := d.Transaction()
+	require.NoError(t, err)
+	transfer, ok := tx.(*proto.TransferWithProofs)
+	require.True(t, ok)
+	assert.Equal(t, byte(3), transfer.Version)
+	assert.Equal(t, uint64(100000), transfer.Fee)
+	assert.Equal(t, uint64(testTimestamp), transfer.Timestamp)
+	ok, err = transfer.Verify(proto.TestNetScheme, sender.pk)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	js, err := d.JSON()
+	require.NoError(t, err)
+	fromJSON := new(proto.TransferWithProofs)
+	require.NoError(t, json.Unmarshal(js, fromJSON))
+	assert.Equal(t, transfer.ID, fromJSON.ID)
+	assert.Equal(t, transfer.Proofs, fromJSON.Proofs)
+
+	pb, err := d.Protobuf()
+	require.NoError(t, err)
+	fromPB, err := proto.SignedTxFromProtobuf(pb)
+	require.NoError(t, err)
+	id, err := fromPB.GetID(proto.TestNetScheme)
+	require.NoError(t, err)
+	assert.Equal(t, transfer.ID.Bytes(), id)
+}
+
+func TestLatestVersion(t *testing.T) {
+	sender := newTestAccount(t, "sender")
+	chain := &StaticBlockchain{Features: []settings.Feature{settings.SmartAccounts}}
+	b := New(proto.TestNetScheme, chain, sender.pk).Timestamp(testTimestamp)
+
+	tx, err := b.Lease(proto.NewRecipientFromAddress(newTestAccount(t, "recipient").addr), 100).Transaction()
+	require.NoError(t, err)
+	assert.Equal(t, byte(2), tx.GetVersion())
+	tx, err = b.Data(proto.DataEntries{&proto.IntegerDataEntry{Key: "k", Value: 1}}).Transaction()
+	require.NoError(t, err)
+	assert.Equal(t, byte(1), tx.GetVersion())
+
+	chain.Features = append(chain.Features, settings.BlockV5)
+	tx, err = b.Lease(proto.NewRecipientFromAddress(newTestAccount(t, "recipient").addr), 100).Transaction()
+	require.NoError(t, err)
+	assert.Equal(t, byte(3), tx.GetVersion())
+
+	tx, err = b.Version(2).Lease(proto.NewRecipientFromAddress(newTestAccount(t, "recipient").addr), 100).Transaction()
+	require.NoError(t, err)
+	assert.Equal(t, byte(2), tx.GetVersion())
+}
+
+func TestMinFee(t *testing.T) {
+	sender := newTestAccount(t, "sender")
+	recipient := proto.NewRecipientFromAddress(newTestAccount(t, "recipient").addr)
+	smartAsset := crypto.MustFastHash([]byte("smart asset"))
+	sponsoredAsset := crypto.MustFastHash([]byte("sponsored asset"))
+	for i, test := range []struct {
+		features []settings.Feature
+		scripts  map[proto.WavesAddress]int
+		build    func(b *Builder) *Draft
+		fee      uint64
+	}{
+		{nil, nil, func(b *Builder) *Draft {
+			return b.Transfer(recipient, 1, proto.NewOptionalAssetWaves(), nil)
+		}, 100000},
+		{nil, map[proto.WavesAddress]int{sender.addr: 100}, func(b *Builder) *Draft {
+			return b.Transfer(recipient, 1, proto.NewOptionalAssetWaves(), nil)
+		}, 500000},
+		{[]settings.Feature{settings.BlockV5, settings.RideV5}, map[proto.WavesAddress]int{sender.addr: 100}, func(b *Builder) *Draft {
+			return b.Transfer(recipient, 1, proto.NewOptionalAssetWaves(), nil)
+		}, 100000},
+		{[]settings.Feature{settings.BlockV5, settings.RideV5}, map[proto.WavesAddress]int{sender.addr: 300}, func(b *Builder) *Draft {
+			return b.Transfer(recipient, 1, proto.NewOptionalAssetWaves(), nil)
+		}, 500000},
+		{nil, nil, func(b *Builder) *Draft {
+			return b.Transfer(recipient, 1, *proto.NewOptionalAssetFromDigest(smartAsset), nil)
+		}, 500000},
+		{[]settings.Feature{settings.BlockV5, settings.RideV5}, nil, func(b *Builder) *Draft {
+			return b.Transfer(recipient, 1, *proto.NewOptionalAssetFromDigest(smartAsset), nil)
+		}, 100000},
+		{nil, nil, func(b *Builder) *Draft {
+			return b.FeeAsset(*proto.NewOptionalAssetFromDigest(sponsoredAsset)).Transfer(recipient, 1, proto.NewOptionalAssetWaves(), nil)
+		}, 10},
+		{nil, nil, func(b *Builder) *Draft {
+			return b.Issue("token", "", 1000, 2, true, nil)
+		}, 100000000},
+		{[]settings.Feature{settings.ReduceNFTFee}, nil, func(b *Builder) *Draft {
+			return b.Issue("collectible", "", 1, 0, false, nil)
+		}, 100000},
+		{nil, nil, func(b *Builder) *Draft {
+			return b.MassTransfer([]proto.MassTransferEntry{{Recipient: recipient, Amount: 1}, {Recipient: recipient, Amount: 2}, {Recipient: recipient, Amount: 3}}, proto.NewOptionalAssetWaves(), nil)
+		}, 300000},
+	} {
+		chain := &StaticBlockchain{
+			Features:       append([]settings.Feature{settings.SmartAccounts}, test.features...),
+			AccountScripts: test.scripts,
+			SmartAssets:    map[crypto.Digest]bool{smartAsset: true},
+			Sponsorship:    map[crypto.Digest]uint64{sponsoredAsset: 10},
+		}
+		tx, err := test.build(New(proto.TestNetScheme, chain, sender.pk).Timestamp(testTimestamp)).Transaction()
+		require.NoError(t, err, i)
+		assert.Equal(t, test.fee, tx.GetFee(), i)
+	}
+}
+
+func TestFeeAssetErrors(t *testing.T) {
+	sender := newTestAccount(t, "sender")
+	recipient := proto.NewRecipientFromAddress(newTestAccount(t, "recipient").addr)
+	asset := *proto.NewOptionalAssetFromDigest(crypto.MustFastHash([]byte("asset")))
+	b := New(proto.TestNetScheme, &StaticBlockchain{}, sender.pk).FeeAsset(asset)
+
+	_, err := b.Transfer(recipient, 1, proto.NewOptionalAssetWaves(), nil).Transaction()
+	assert.EqualError(t, err, "asset "+asset.ID.String()+" is not sponsored, cannot be used to pay fees")
+	_, err = b.Lease(recipient, 1).Transaction()
+	assert.Error(t, err)
+}
+
+func TestMultisig(t *testing.T) {
+	multisig := newTestAccount(t, "multisig")
+	signers := []testAccount{newTestAccount(t, "first"), newTestAccount(t, "second"), newTestAccount(t, "third")}
+	chain := &StaticBlockchain{
+		Features:       []settings.Feature{settings.SmartAccounts, settings.BlockV5},
+		AccountScripts: map[proto.WavesAddress]int{multisig.addr: 200},
+	}
+	d := New(proto.TestNetScheme, chain, multisig.pk).
+		Timestamp(testTimestamp).
+		Lease(proto.NewRecipientFromAddress(newTestAccount(t, "recipient").addr), 100).
+		Sign(signers[0].sk).
+		SignAt(2, signers[2].sk)
+	tx, err := d.Transaction()
+	require.NoError(t, err)
+	lease := tx.(*proto.LeaseWithProofs)
+	assert.Equal(t, uint64(500000), lease.Fee)
+	require.Len(t, lease.Proofs.Proofs, 3)
+	assert.Empty(t, lease.Proofs.Proofs[1])
+	body, err := d.BodyBytes()
+	require.NoError(t, err)
+	for _, i := range []int{0, 2} {
+		sig, err := crypto.NewSignatureFromBytes(lease.Proofs.Proofs[i])
+		require.NoError(t, err)
+		assert.True(t, crypto.Verify(signers[i].pk, sig, body))
+	}
+
+	sig, err := crypto.Sign(signers[1].sk, body)
+	require.NoError(t, err)
+	d.AddProof(1, sig.Bytes())
+	_, err = d.Transaction()
+	require.NoError(t, err)
+	assert.Equal(t, proto.B58Bytes(sig.Bytes()), lease.Proofs.Proofs[1])
+}
+
+func TestMinFeeOfTransaction(t *testing.T) {
+	sender := newTestAccount(t, "sender")
+	recipient := proto.NewRecipientFromAddress(newTestAccount(t, "recipient").addr)
+	smart := crypto.MustFastHash([]byte("smart asset"))
+	sponsored := crypto.MustFastHash([]byte("sponsored asset"))
+	chain := &StaticBlockchain{
+		Features:       []settings.Feature{settings.SmartAccounts, settings.BlockV5},
+		AccountScripts: map[proto.WavesAddress]int{sender.addr: 100},
+		SmartAssets:    map[crypto.Digest]bool{smart: true},
+		Sponsorship:    map[crypto.Digest]uint64{sponsored: 10},
+	}
+	tx := proto.NewUnsignedTransferWithProofs(3, sender.pk, *proto.NewOptionalAssetFromDigest(smart),
+		*proto.NewOptionalAssetFromDigest(sponsored), testTimestamp, 1, 0, recipient, nil)
+
+	waves, inAsset, err := MinFee(proto.TestNetScheme, chain, tx, tx.FeeAsset)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100000+400000+400000), waves)
+	assert.Equal(t, uint64(90), inAsset)
+
+	// Extra fees for smart asset and verifier with low complexity are erased since RideV5.
+	chain.Features = append(chain.Features, settings.RideV5)
+	waves, inAsset, err = MinFee(proto.TestNetScheme, chain, tx, proto.NewOptionalAssetWaves())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100000), waves)
+	assert.Zero(t, inAsset)
+
+	_, _, err = MinFee(proto.TestNetScheme, chain, tx, *proto.NewOptionalAssetFromDigest(smart))
+	assert.Error(t, err)
+
+	invoke := proto.NewUnsignedInvokeScriptWithProofs(2, proto.TestNetScheme, sender.pk, recipient,
+		proto.FunctionCall{Default: true}, nil, proto.NewOptionalAssetWaves(), 0, testTimestamp)
+	_, _, err = MinFee(proto.TestNetScheme, chain, invoke, proto.NewOptionalAssetWaves())
+	assert.ErrorIs(t, err, ErrInvokeFee)
+}
@@ -0,0 +1,179 @@
+package txbuilder
+
+import (
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+	"github.com/wavesplatform/gowaves/pkg/state"
+)
+
+// latestVersion returns the latest version of transaction type, protobuf versions are available after BlockV5 activation.
+func latestVersion(features state.ActivationChecker, txType proto.TransactionType) (byte, error) {
+	pbVersion, ok := proto.ProtobufTransactionsVersions[txType]
+	if !ok {
+		return 0, errors.Errorf("unsupported transaction type %d", txType)
+	}
+	blockV5, err := features.IsActivated(int16(settings.BlockV5))
+	if err != nil {
+		return 0, err
+	}
+	if blockV5 || pbVersion == 1 {
+		return pbVersion, nil
+	}
+	return pbVersion - 1, nil
+}
+
+// ErrInvokeFee is returned by MinFee for invoke transactions. The minimal fee of invocation depends on the scripts of
+// assets used by its actions and the assets issued by it, which are known only after the evaluation of the script.
+var ErrInvokeFee = errors.New("minimal fee of invoke transaction is known only after invocation")
+
+// MinFee calculates the minimal fee of the transaction in Waves and, if the fee asset is present, in the sponsored fee asset.
+// The scripts of assets used by the transaction are taken into account. ErrInvokeFee is returned for invoke transactions.
+func MinFee(scheme proto.Scheme, chain Blockchain, tx proto.Transaction, feeAsset proto.OptionalAsset) (uint64, uint64, error) {
+	switch tx.(type) {
+	case *proto.InvokeScriptWithProofs, *proto.InvokeExpressionTransactionWithProofs:
+		return 0, 0, ErrInvokeFee
+	}
+	assets, err := transactionAssets(tx)
+	if err != nil {
+		return 0, 0, err
+	}
+	waves, err := minWavesFee(scheme, chain, tx, feeAsset, assets)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !feeAsset.Present {
+		return waves, 0, nil
+	}
+	inAsset, err := toSponsoredAsset(chain, waves, feeAsset)
+	if err != nil {
+		return 0, 0, err
+	}
+	return waves, inAsset, nil
+}
+
+// minFee calculates the minimal fee of transaction in Waves or in sponsored fee asset.
+// The fee for assets issued or actions performed by invoked scripts is not included.
+func minFee(scheme proto.Scheme, chain Blockchain, tx proto.Transaction, feeAsset proto.OptionalAsset, assets []proto.OptionalAsset) (uint64, error) {
+	fee, err := minWavesFee(scheme, chain, tx, feeAsset, assets)
+	if err != nil {
+		return 0, err
+	}
+	if !feeAsset.Present {
+		return fee, nil
+	}
+	return toSponsoredAsset(chain, fee, feeAsset)
+}
+
+func minWavesFee(scheme proto.Scheme, chain Blockchain, tx proto.Transaction, feeAsset proto.OptionalAsset, assets []proto.OptionalAsset) (uint64, error) {
+	units, err := state.MinFeeInUnits(chain, scheme, tx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to calculate minimal fee")
+	}
+	rideV5, err := chain.IsActivated(int16(settings.RideV5))
+	if err != nil {
+		return 0, err
+	}
+	sender, err := tx.GetSender(scheme)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get transaction sender")
+	}
+	senderAddr, err := sender.ToWavesAddress(scheme)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get transaction sender")
+	}
+	complexity, scripted, err := chain.AccountScript(senderAddr)
+	if err != nil {
+		return 0, err
+	}
+	var smartAccounts, smartAssets uint64
+	if scripted {
+		smartAccounts = 1
+	}
+	if feeAsset.Present {
+		assets = append(assets[:len(assets):len(assets)], feeAsset)
+	}
+	for _, a := range assets {
+		if !a.Present {
+			continue
+		}
+		smart, err := chain.IsSmartAsset(a.ID)
+		if err != nil {
+			return 0, err
+		}
+		if smart {
+			smartAssets++
+		}
+	}
+	return units*state.FeeUnit + state.ScriptsExtraFee(smartAssets, smartAccounts, rideV5, complexity, scripted), nil
+}
+
+func toSponsoredAsset(chain Blockchain, fee uint64, feeAsset proto.OptionalAsset) (uint64, error) {
+	cost, err := chain.SponsorshipCost(feeAsset.ID)
+	if err != nil {
+		return 0, err
+	}
+	if cost == 0 {
+		return 0, errors.Errorf("asset %s is not sponsored, cannot be used to pay fees", feeAsset.ID.String())
+	}
+	return state.WavesToSponsoredAsset(fee, cost)
+}
+
+// transactionAssets returns assets those scripts are run by the transaction.
+func transactionAssets(tx proto.Transaction) ([]proto.OptionalAsset, error) {
+	switch t := tx.(type) {
+	case *proto.TransferWithSig:
+		return []proto.OptionalAsset{t.AmountAsset}, nil
+	case *proto.TransferWithProofs:
+		return []proto.OptionalAsset{t.AmountAsset}, nil
+	case *proto.MassTransferWithProofs:
+		return []proto.OptionalAsset{t.Asset}, nil
+	case *proto.ReissueWithSig:
+		return []proto.OptionalAsset{*proto.NewOptionalAssetFromDigest(t.AssetID)}, nil
+	case *proto.ReissueWithProofs:
+		return []proto.OptionalAsset{*proto.NewOptionalAssetFromDigest(t.AssetID)}, nil
+	case *proto.BurnWithSig:
+		return []proto.OptionalAsset{*proto.NewOptionalAssetFromDigest(t.AssetID)}, nil
+	case *proto.BurnWithProofs:
+		return []proto.OptionalAsset{*proto.NewOptionalAssetFromDigest(t.AssetID)}, nil
+	case *proto.SetAssetScriptWithProofs:
+		return []proto.OptionalAsset{*proto.NewOptionalAssetFromDigest(t.AssetID)}, nil
+	case *proto.UpdateAssetInfoWithProofs:
+		return []proto.OptionalAsset{*proto.NewOptionalAssetFromDigest(t.AssetID)}, nil
+	case *proto.InvokeScriptWithProofs:
+		assets := make([]proto.OptionalAsset, len(t.Payments))
+		for i, p := range t.Payments {
+			assets[i] = p.Asset
+		}
+		return assets, nil
+	case proto.Exchange:
+		return exchangeAssets(t)
+	default:
+		return nil, nil
+	}
+}
+
+func exchangeAssets(tx proto.Exchange) ([]proto.OptionalAsset, error) {
+	sell, err := tx.GetSellOrder()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get sell order")
+	}
+	pair := sell.GetAssetPair()
+	assets := []proto.OptionalAsset{pair.AmountAsset, pair.PriceAsset}
+	// Matcher fee assets are available since orders of version 3.
+	for _, o := range []proto.Order{tx.GetOrder1(), tx.GetOrder2()} {
+		if o.GetVersion() >= 3 && !containsAsset(assets, o.GetMatcherFeeAsset()) {
+			assets = append(assets, o.GetMatcherFeeAsset())
+		}
+	}
+	return assets, nil
+}
+
+func containsAsset(assets []proto.OptionalAsset, asset proto.OptionalAsset) bool {
+	for _, a := range assets {
+		if a == asset {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,175 @@
+package txbuilder
+
+import (
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+// Transfer builds the transfer of amount of asset to recipient.
+func (b *Builder) Transfer(recipient proto.Recipient, amount uint64, asset proto.OptionalAsset, attachment proto.Attachment) *Draft {
+	return b.draft(spec{
+		txType:          proto.TransferTransaction,
+		assets:          []proto.OptionalAsset{asset},
+		feeAssetAllowed: true,
+		build: func(v byte, fee, timestamp uint64) (proto.Transaction, *proto.ProofsV1) {
+			tx := proto.NewUnsignedTransferWithProofs(v, b.sender, asset, b.feeAsset, timestamp, amount, fee, recipient, attachment)
+			tx.Proofs = proto.NewProofs()
+			return tx, tx.Proofs
+		},
+	})
+}
+
+// MassTransfer builds the transfer of asset to several recipients.
+func (b *Builder) MassTransfer(transfers []proto.MassTransferEntry, asset proto.OptionalAsset, attachment proto.Attachment) *Draft {
+	return b.draft(spec{
+		txType: proto.MassTransferTransaction,
+		assets: []proto.OptionalAsset{asset},
+		build: func(v byte, fee, timestamp uint64) (proto.Transaction, *proto.ProofsV1) {
+			tx := proto.NewUnsignedMassTransferWithProofs(v, b.sender, asset, transfers, fee, timestamp, attachment)
+			tx.Proofs = proto.NewProofs()
+			return tx, tx.Proofs
+		},
+	})
+}
+
+// Issue builds the issue of new asset. The asset is an NFT if quantity is 1, decimals is 0 and the asset is not reissuable.
+func (b *Builder) Issue(name, description string, quantity uint64, decimals byte, reissuable bool, script []byte) *Draft {
+	return b.draft(spec{
+		txType: proto.IssueTransaction,
+		build: func(v byte, fee, timestamp uint64) (proto.Transaction, *proto.ProofsV1) {
+			tx := proto.NewUnsignedIssueWithProofs(v, b.scheme, b.sender, name, description, quantity, decimals, reissuable, script, timestamp, fee)
+			tx.Proofs = proto.NewProofs()
+			return tx, tx.Proofs
+		},
+	})
+}
+
+// Reissue builds the reissue of quantity of the asset.
+func (b *Builder) Reissue(assetID crypto.Digest, quantity uint64, reissuable bool) *Draft {
+	return b.draft(spec{
+		txType: proto.ReissueTransaction,
+		assets: []proto.OptionalAsset{*proto.NewOptionalAssetFromDigest(assetID)},
+		build: func(v byte, fee, timestamp uint64) (proto.Transaction, *proto.ProofsV1) {
+			tx := proto.NewUnsignedReissueWithProofs(v, b.scheme, b.sender, assetID, quantity, reissuable, timestamp, fee)
+			tx.Proofs = proto.NewProofs()
+			return tx, tx.Proofs
+		},
+	})
+}
+
+// Burn builds the burn of amount of the asset.
+func (b *Builder) Burn(assetID crypto.Digest, amount uint64) *Draft {
+	return b.draft(spec{
+		txType: proto.BurnTransaction,
+		assets: []proto.OptionalAsset{*proto.NewOptionalAssetFromDigest(assetID)},
+		build: func(v byte, fee, timestamp uint64) (proto.Transaction, *proto.ProofsV1) {
+			tx := proto.NewUnsignedBurnWithProofs(v, b.scheme, b.sender, assetID, amount, timestamp, fee)
+			tx.Proofs = proto.NewProofs()
+			return tx, tx.Proofs
+		},
+	})
+}
+
+// Lease builds the lease of amount of Waves to recipient.
+func (b *Builder) Lease(recipient proto.Recipient, amount uint64) *Draft {
+	return b.draft(spec{
+		txType: proto.LeaseTransaction,
+		build: func(v byte, fee, timestamp uint64) (proto.Transaction, *proto.ProofsV1) {
+			tx := proto.NewUnsignedLeaseWithProofs(v, b.sender, recipient, amount, fee, timestamp)
+			tx.Proofs = proto.NewProofs()
+			return tx, tx.Proofs
+		},
+	})
+}
+
+// LeaseCancel builds the cancellation of the lease.
+func (b *Builder) LeaseCancel(leaseID crypto.Digest) *Draft {
+	return b.draft(spec{
+		txType: proto.LeaseCancelTransaction,
+		build: func(v byte, fee, timestamp uint64) (proto.Transaction, *proto.ProofsV1) {
+			tx := proto.NewUnsignedLeaseCancelWithProofs(v, b.scheme, b.sender, leaseID, fee, timestamp)
+			tx.Proofs = proto.NewProofs()
+			return tx, tx.Proofs
+		},
+	})
+}
+
+// CreateAlias builds the creation of alias for the sender's address.
+func (b *Builder) CreateAlias(alias string) *Draft {
+	return b.draft(spec{
+		txType: proto.CreateAliasTransaction,
+		build: func(v byte, fee, timestamp uint64) (proto.Transaction, *proto.ProofsV1) {
+			tx := proto.NewUnsignedCreateAliasWithProofs(v, b.sender, *proto.NewAlias(b.scheme, alias), fee, timestamp)
+			tx.Proofs = proto.NewProofs()
+			return tx, tx.Proofs
+		},
+	})
+}
+
+// Data builds the update of the sender's data entries.
+func (b *Builder) Data(entries proto.DataEntries) *Draft {
+	return b.draft(spec{
+		txType: proto.DataTransaction,
+		build: func(v byte, fee, timestamp uint64) (proto.Transaction, *proto.ProofsV1) {
+			tx := proto.NewUnsignedDataWithProofs(v, b.sender, fee, timestamp)
+			tx.Entries = entries
+			tx.Proofs = proto.NewProofs()
+			return tx, tx.Proofs
+		},
+	})
+}
+
+// SetScript builds the update of the sender's script, nil script removes the script.
+func (b *Builder) SetScript(script []byte) *Draft {
+	return b.draft(spec{
+		txType: proto.SetScriptTransaction,
+		build: func(v byte, fee, timestamp uint64) (proto.Transaction, *proto.ProofsV1) {
+			tx := proto.NewUnsignedSetScriptWithProofs(v, b.scheme, b.sender, script, fee, timestamp)
+			tx.Proofs = proto.NewProofs()
+			return tx, tx.Proofs
+		},
+	})
+}
+
+// SetAssetScript builds the update of the asset script.
+func (b *Builder) SetAssetScript(assetID crypto.Digest, script []byte) *Draft {
+	return b.draft(spec{
+		txType: proto.SetAssetScriptTransaction,
+		assets: []proto.OptionalAsset{*proto.NewOptionalAssetFromDigest(assetID)},
+		build: func(v byte, fee, timestamp uint64) (proto.Transaction, *proto.ProofsV1) {
+			tx := proto.NewUnsignedSetAssetScriptWithProofs(v, b.scheme, b.sender, assetID, script, fee, timestamp)
+			tx.Proofs = proto.NewProofs()
+			return tx, tx.Proofs
+		},
+	})
+}
+
+// Sponsorship builds the sponsorship of the asset, zero minimal fee cancels the sponsorship.
+func (b *Builder) Sponsorship(assetID crypto.Digest, minAssetFee uint64) *Draft {
+	return b.draft(spec{
+		txType: proto.SponsorshipTransaction,
+		build: func(v byte, fee, timestamp uint64) (proto.Transaction, *proto.ProofsV1) {
+			tx := proto.NewUnsignedSponsorshipWithProofs(v, b.sender, assetID, minAssetFee, fee, timestamp)
+			tx.Proofs = proto.NewProofs()
+			return tx, tx.Proofs
+		},
+	})
+}
+
+// InvokeScript builds the invocation of dApp function. The fee doesn't include the extra fee for assets issued by the dApp.
+func (b *Builder) InvokeScript(dApp proto.Recipient, call proto.FunctionCall, payments proto.ScriptPayments) *Draft {
+	assets := make([]proto.OptionalAsset, len(payments))
+	for i, p := range payments {
+		assets[i] = p.Asset
+	}
+	return b.draft(spec{
+		txType:          proto.InvokeScriptTransaction,
+		assets:          assets,
+		feeAssetAllowed: true,
+		build: func(v byte, fee, timestamp uint64) (proto.Transaction, *proto.ProofsV1) {
+			tx := proto.NewUnsignedInvokeScriptWithProofs(v, b.scheme, b.sender, dApp, call, payments, b.feeAsset, fee, timestamp)
+			tx.Proofs = proto.NewProofs()
+			return tx, tx.Proofs
+		},
+	})
+}
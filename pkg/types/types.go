@@ -13,11 +13,6 @@ type Scheduler interface {
 	Reschedule()
 }
 
-// Handler is an abstract function that called when an event happens.
-type Handler interface {
-	Handle()
-}
-
 // UtxPool storage interface
 type UtxPool interface {
 	Add(t proto.Transaction) error
@@ -27,6 +22,8 @@ type UtxPool interface {
 	AllTransactions() []*TransactionWithBytes
 	Count() int
 	ExistsByID(id []byte) bool
+	AddBundle(bundle TransactionsBundle) error
+	PopBundle() TransactionsBundle
 }
 
 type TransactionWithBytes struct {
@@ -34,6 +31,28 @@ type TransactionWithBytes struct {
 	B []byte
 }
 
+// TransactionsBundle is a group of transactions that must be included into a block
+// all together and in the given order, or not included at all.
+type TransactionsBundle []*TransactionWithBytes
+
+// Size returns the total size of bundle transactions in bytes.
+func (b TransactionsBundle) Size() int {
+	size := 0
+	for _, t := range b {
+		size += len(t.B)
+	}
+	return size
+}
+
+// Fee returns the total fee of bundle transactions.
+func (b TransactionsBundle) Fee() uint64 {
+	fee := uint64(0)
+	for _, t := range b {
+		fee += t.T.GetFee()
+	}
+	return fee
+}
+
 //go:generate moq -out ../state/smart_state_moq_test.go -pkg state . SmartState:AnotherMockSmartState
 
 // WavesBalanceProfile contains essential parts of Waves balance and
@@ -117,7 +136,7 @@ type InvRequester interface {
 type BaseTarget = uint64
 
 type Miner interface {
-	MineKeyBlock(ctx context.Context, t proto.Timestamp, k proto.KeyPair, parent proto.BlockID, baseTarget BaseTarget, gs []byte, vrf []byte) (*proto.Block, proto.MiningLimits, error)
+	MineKeyBlock(ctx context.Context, t proto.Timestamp, pk crypto.PublicKey, parent proto.BlockID, baseTarget BaseTarget, gs []byte, vrf []byte) (*proto.Block, proto.MiningLimits, error)
 }
 
 type Time interface {
@@ -138,3 +157,20 @@ type EmbeddedWallet interface {
 	Load(password []byte) error
 	Seeds() [][]byte
 }
+
+// Signer holds secret keys of the node and produces all signatures on behalf of the miner and the API.
+type Signer interface {
+	// PublicKeys returns public keys of all accounts available for signing.
+	PublicKeys() ([]crypto.PublicKey, error)
+	// SignBlock signs key block or the resulting block of microblock with the key of given public key.
+	SignBlock(pk crypto.PublicKey, block *proto.Block) error
+	SignMicroBlock(pk crypto.PublicKey, micro *proto.MicroBlock) error
+	SignMicroBlockInv(pk crypto.PublicKey, inv *proto.MicroBlockInv) error
+	// VRF calculates VRF proof and VRF value of message.
+	VRF(pk crypto.PublicKey, message []byte) ([]byte, []byte, error)
+	SignTransaction(pk crypto.PublicKey, tx proto.Transaction) error
+	// SignText signs arbitrary message, it is used by the API to prove the ownership of account.
+	SignText(pk crypto.PublicKey, message []byte) (crypto.Signature, error)
+	// SharedKey derives the key of message encryption shared by the account and the owner of public key.
+	SharedKey(pk, other crypto.PublicKey, prefix []byte) ([]byte, error)
+}
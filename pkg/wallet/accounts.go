@@ -0,0 +1,55 @@
+package wallet
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+// AccountSeed derives the seed of account number nonce from the wallet seed.
+// The account seed is the secure hash of 4 bytes of nonce in big-endian order followed by the wallet seed.
+func AccountSeed(seed []byte, nonce uint32) ([]byte, error) {
+	buf := make([]byte, 4+len(seed))
+	binary.BigEndian.PutUint32(buf, nonce)
+	copy(buf[4:], seed)
+	d, err := crypto.SecureHash(buf)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to derive account seed")
+	}
+	return d.Bytes(), nil
+}
+
+// DeriveKeyPair generates key pair of account number nonce derived from the wallet seed.
+func DeriveKeyPair(seed []byte, nonce uint32) (crypto.SecretKey, crypto.PublicKey, error) {
+	as, err := AccountSeed(seed, nonce)
+	if err != nil {
+		return crypto.SecretKey{}, crypto.PublicKey{}, err
+	}
+	return crypto.GenerateKeyPair(as)
+}
+
+// DerivedAccount is the account derived from the wallet seed.
+type DerivedAccount struct {
+	Nonce     uint32
+	PublicKey crypto.PublicKey
+	Address   proto.WavesAddress
+}
+
+// DeriveAccounts returns count accounts with nonces starting from zero derived from the wallet seed.
+func DeriveAccounts(scheme proto.Scheme, seed []byte, count uint32) ([]DerivedAccount, error) {
+	r := make([]DerivedAccount, count)
+	for i := uint32(0); i < count; i++ {
+		_, pk, err := DeriveKeyPair(seed, i)
+		if err != nil {
+			return nil, err
+		}
+		addr, err := proto.NewAddressFromPublicKey(scheme, pk)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create address")
+		}
+		r[i] = DerivedAccount{Nonce: i, PublicKey: pk, Address: addr}
+	}
+	return r, nil
+}
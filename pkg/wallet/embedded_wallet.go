@@ -11,6 +11,15 @@ type seeder interface {
 	Seeds() [][]byte
 }
 
+// accountSeeder provides seeds of all wallet accounts including derived ones.
+type accountSeeder struct {
+	w Wallet
+}
+
+func (a accountSeeder) Seeds() [][]byte {
+	return a.w.AccountSeeds()
+}
+
 type EmbeddedWalletImpl struct {
 	loader Loader
 	seeder seeder
@@ -42,7 +51,7 @@ func (a *EmbeddedWalletImpl) Load(password []byte) error {
 		return err
 	}
 	a.mu.Lock()
-	a.seeder = w
+	a.seeder = accountSeeder{w: w}
 	a.mu.Unlock()
 	return nil
 }
@@ -69,4 +69,20 @@ func TestEmbeddedWalletImpl_Load(t *testing.T) {
 		w := NewEmbeddedWallet(testLoader{bts: nil, err: errors.New("loaderr")}, nil, proto.TestNetScheme)
 		require.Errorf(t, w.Load(nil), "loaderr")
 	})
+	t.Run("derived accounts", func(t *testing.T) {
+		wal := NewWallet()
+		_ = wal.AddSeed([]byte("seed"))
+		require.NoError(t, wal.AddDerivedSeed([]byte("mnemonic"), 2))
+		bts, err := wal.Encode([]byte("pass"))
+		require.NoError(t, err)
+		w := NewEmbeddedWallet(testLoader{bts: bts}, nil, proto.TestNetScheme)
+		require.NoError(t, w.Load([]byte("pass")))
+		require.Len(t, w.Seeds(), 3)
+
+		_, pk, err := DeriveKeyPair([]byte("mnemonic"), 1)
+		require.NoError(t, err)
+		tx := byte_helpers.TransferWithSig.Transaction.Clone()
+		tx.SenderPK = pk
+		require.NoError(t, w.SignTransactionWith(pk, tx))
+	})
 }
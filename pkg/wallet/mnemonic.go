@@ -0,0 +1,125 @@
+package wallet
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	_ "embed"
+	"math/big"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	bitsPerWord     = 11
+	minEntropyBits  = 128
+	maxEntropyBits  = 256
+	entropyBitsStep = 32
+	// DefaultMnemonicWords is the number of words in seed phrases generated by Waves applications.
+	DefaultMnemonicWords = 15
+)
+
+//go:embed english.txt
+var englishWords string
+
+var (
+	wordList  = strings.Fields(englishWords)
+	wordIndex = makeWordIndex(wordList)
+)
+
+func makeWordIndex(words []string) map[string]int {
+	r := make(map[string]int, len(words))
+	for i, w := range words {
+		r[w] = i
+	}
+	return r
+}
+
+// GenerateMnemonic generates the BIP39 mnemonic of the given number of words using English word list.
+// Allowed numbers of words are 12, 15, 18, 21 and 24.
+func GenerateMnemonic(words int) (string, error) {
+	// Each 3 words encode 32 bits of entropy and 1 bit of checksum.
+	if words%3 != 0 {
+		return "", errors.Errorf("invalid number of mnemonic words %d", words)
+	}
+	bits := words / 3 * entropyBitsStep
+	if bits < minEntropyBits || bits > maxEntropyBits {
+		return "", errors.Errorf("invalid number of mnemonic words %d", words)
+	}
+	entropy := make([]byte, bits/8)
+	if _, err := rand.Read(entropy); err != nil {
+		return "", errors.Wrap(err, "failed to generate entropy")
+	}
+	return NewMnemonic(entropy)
+}
+
+// NewMnemonic encodes entropy into BIP39 mnemonic. The size of entropy must be from 16 to 32 bytes
+// and a multiple of 4 bytes.
+func NewMnemonic(entropy []byte) (string, error) {
+	bits := len(entropy) * 8
+	if bits < minEntropyBits || bits > maxEntropyBits || bits%entropyBitsStep != 0 {
+		return "", errors.Errorf("invalid entropy size %d", len(entropy))
+	}
+	checksumBits := bits / entropyBitsStep
+	n := new(big.Int).SetBytes(entropy)
+	n.Lsh(n, uint(checksumBits))
+	n.Or(n, big.NewInt(int64(checksum(entropy, checksumBits))))
+	count := (bits + checksumBits) / bitsPerWord
+	words := make([]string, count)
+	mask := big.NewInt(1<<bitsPerWord - 1)
+	idx := new(big.Int)
+	for i := count - 1; i >= 0; i-- {
+		idx.And(n, mask)
+		words[i] = wordList[idx.Int64()]
+		n.Rsh(n, bitsPerWord)
+	}
+	return strings.Join(words, " "), nil
+}
+
+// MnemonicToEntropy decodes BIP39 mnemonic and validates its checksum.
+func MnemonicToEntropy(mnemonic string) ([]byte, error) {
+	words := strings.Fields(mnemonic)
+	total := len(words) * bitsPerWord
+	// Total number of bits is 33/32 of entropy bits.
+	if total%(entropyBitsStep+1) != 0 {
+		return nil, errors.Errorf("invalid number of mnemonic words %d", len(words))
+	}
+	checksumBits := total / (entropyBitsStep + 1)
+	bits := total - checksumBits
+	if bits < minEntropyBits || bits > maxEntropyBits {
+		return nil, errors.Errorf("invalid number of mnemonic words %d", len(words))
+	}
+	n := new(big.Int)
+	for _, w := range words {
+		i, ok := wordIndex[w]
+		if !ok {
+			return nil, errors.Errorf("unknown mnemonic word '%s'", w)
+		}
+		n.Lsh(n, bitsPerWord)
+		n.Or(n, big.NewInt(int64(i)))
+	}
+	cs := new(big.Int).And(n, big.NewInt(1<<checksumBits-1)).Uint64()
+	n.Rsh(n, uint(checksumBits))
+	entropy := n.FillBytes(make([]byte, bits/8))
+	if uint64(checksum(entropy, checksumBits)) != cs {
+		return nil, errors.New("invalid mnemonic checksum")
+	}
+	return entropy, nil
+}
+
+// ValidateMnemonic checks that mnemonic consists of BIP39 English words and has the correct checksum.
+func ValidateMnemonic(mnemonic string) error {
+	_, err := MnemonicToEntropy(mnemonic)
+	return err
+}
+
+// NormalizeMnemonic removes extra spaces between words of mnemonic.
+func NormalizeMnemonic(mnemonic string) string {
+	return strings.Join(strings.Fields(mnemonic), " ")
+}
+
+// checksum returns first bits of SHA-256 hash of entropy, bits is not greater than 8.
+func checksum(entropy []byte, bits int) byte {
+	h := sha256.Sum256(entropy)
+	return h[0] >> (8 - bits)
+}
@@ -0,0 +1,56 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMnemonicVectors(t *testing.T) {
+	for _, tc := range []struct {
+		entropy  string
+		mnemonic string
+	}{
+		{"00000000000000000000000000000000", "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"},
+		{"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f", "legal winner thank year wave sausage worth useful legal winner thank yellow"},
+		{"80808080808080808080808080808080", "letter advice cage absurd amount doctor acoustic avoid letter advice cage above"},
+		{"ffffffffffffffffffffffffffffffff", "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong"},
+		{"000000000000000000000000000000000000000000000000", "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon agent"},
+		{"808080808080808080808080808080808080808080808080", "letter advice cage absurd amount doctor acoustic avoid letter advice cage absurd amount doctor acoustic avoid letter always"},
+		{"0000000000000000000000000000000000000000000000000000000000000000", "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art"},
+		{"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f", "legal winner thank year wave sausage worth useful legal winner thank year wave sausage worth useful legal winner thank year wave sausage worth title"},
+	} {
+		entropy, err := hex.DecodeString(tc.entropy)
+		require.NoError(t, err)
+		m, err := NewMnemonic(entropy)
+		require.NoError(t, err)
+		assert.Equal(t, tc.mnemonic, m)
+		e, err := MnemonicToEntropy(tc.mnemonic)
+		require.NoError(t, err)
+		assert.Equal(t, entropy, e)
+	}
+}
+
+func TestGenerateMnemonic(t *testing.T) {
+	for _, words := range []int{12, 15, 18, 21, 24} {
+		m, err := GenerateMnemonic(words)
+		require.NoError(t, err)
+		assert.Len(t, strings.Fields(m), words)
+		assert.NoError(t, ValidateMnemonic(m))
+	}
+	for _, words := range []int{0, 9, 13, 27} {
+		_, err := GenerateMnemonic(words)
+		assert.Error(t, err)
+	}
+}
+
+func TestValidateMnemonic(t *testing.T) {
+	assert.NoError(t, ValidateMnemonic(" legal  winner thank year wave sausage worth useful legal winner thank yellow "))
+	assert.EqualError(t, ValidateMnemonic("legal winner thank year wave sausage worth useful legal winner thank zoo"), "invalid mnemonic checksum")
+	assert.EqualError(t, ValidateMnemonic("legal winner thank year wave sausage worth useful legal winner thank gowaves"), "unknown mnemonic word 'gowaves'")
+	assert.EqualError(t, ValidateMnemonic("legal winner thank"), "invalid number of mnemonic words 3")
+	assert.Equal(t, "legal winner", NormalizeMnemonic(" legal\twinner "))
+}
@@ -1,6 +1,7 @@
 package wallet
 
 import (
+	"bytes"
 	"encoding/binary"
 	"encoding/json"
 
@@ -10,13 +11,29 @@ import (
 
 const curVersion = 1
 
+// DerivedSeed is the wallet seed, usually a mnemonic, with the number of accounts derived from it.
+type DerivedSeed struct {
+	Seed     []byte `json:"seed"`
+	Accounts uint32 `json:"accounts"`
+}
+
 type WalletFormat struct {
-	Seed [][]byte `json:"seeds"`
+	Seed    [][]byte      `json:"seeds"`
+	Derived []DerivedSeed `json:"derived,omitempty"`
 }
 
 type Wallet interface {
+	// Seeds returns seeds of single accounts.
 	Seeds() [][]byte
+	// AccountSeeds returns seeds of all accounts including the accounts derived from the wallet seeds.
+	AccountSeeds() [][]byte
+	// AddSeed adds the seed of single account.
 	AddSeed([]byte) error
+	// DerivedSeeds returns wallet seeds of derived accounts.
+	DerivedSeeds() []DerivedSeed
+	// AddDerivedSeed adds the wallet seed with the number of accounts derived from it, the number of accounts
+	// is updated if the seed is already in the wallet.
+	AddDerivedSeed(seed []byte, accounts uint32) error
 	Encode(pass []byte) ([]byte, error)
 }
 
@@ -29,6 +46,39 @@ func (a *WalletImpl) Seeds() [][]byte {
 	return a.format.Seed
 }
 
+func (a *WalletImpl) AccountSeeds() [][]byte {
+	r := make([][]byte, 0, len(a.format.Seed))
+	r = append(r, a.format.Seed...)
+	for _, d := range a.format.Derived {
+		for i := uint32(0); i < d.Accounts; i++ {
+			as, err := AccountSeed(d.Seed, i)
+			if err != nil {
+				panic(err) // Secure hash never fails
+			}
+			r = append(r, as)
+		}
+	}
+	return r
+}
+
+func (a *WalletImpl) DerivedSeeds() []DerivedSeed {
+	return a.format.Derived
+}
+
+func (a *WalletImpl) AddDerivedSeed(seed []byte, accounts uint32) error {
+	if accounts == 0 {
+		return errors.New("number of accounts must be positive")
+	}
+	for i, d := range a.format.Derived {
+		if bytes.Equal(d.Seed, seed) {
+			a.format.Derived[i].Accounts = accounts
+			return nil
+		}
+	}
+	a.format.Derived = append(a.format.Derived, DerivedSeed{Seed: common.Dup(seed), Accounts: accounts})
+	return nil
+}
+
 func NewWallet() *WalletImpl {
 	return &WalletImpl{
 		format: WalletFormat{},
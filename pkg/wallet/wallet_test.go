@@ -5,6 +5,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
 )
 
 func TestWallet_EncodeDecode(t *testing.T) {
@@ -24,3 +26,44 @@ func TestWallet_EncodeDecode(t *testing.T) {
 	_, err = Decode(bts, []byte("unknown password"))
 	require.Error(t, err)
 }
+
+func TestWallet_DerivedSeeds(t *testing.T) {
+	password := []byte("123456")
+	mnemonic := []byte("legal winner thank year wave sausage worth useful legal winner thank yellow")
+
+	w := NewWallet()
+	require.NoError(t, w.AddSeed([]byte("single")))
+	require.NoError(t, w.AddDerivedSeed(mnemonic, 2))
+	require.NoError(t, w.AddDerivedSeed(mnemonic, 3))
+	require.Error(t, w.AddDerivedSeed(mnemonic, 0))
+	assert.Equal(t, []DerivedSeed{{Seed: mnemonic, Accounts: 3}}, w.DerivedSeeds())
+	assert.Equal(t, [][]byte{[]byte("single")}, w.Seeds())
+
+	seeds := w.AccountSeeds()
+	require.Len(t, seeds, 4)
+	assert.Equal(t, []byte("single"), seeds[0])
+	accounts, err := DeriveAccounts(proto.MainNetScheme, mnemonic, 3)
+	require.NoError(t, err)
+	for i, a := range accounts {
+		_, pk, err := crypto.GenerateKeyPair(seeds[i+1])
+		require.NoError(t, err)
+		assert.Equal(t, a.PublicKey, pk)
+		assert.Equal(t, uint32(i), a.Nonce)
+	}
+	assert.NotEqual(t, accounts[0].PublicKey, accounts[1].PublicKey)
+
+	bts, err := w.Encode(password)
+	require.NoError(t, err)
+	w2, err := Decode(bts, password)
+	require.NoError(t, err)
+	assert.Equal(t, w.AccountSeeds(), w2.AccountSeeds())
+}
+
+func TestAccountSeed(t *testing.T) {
+	seed := []byte("seed")
+	as, err := AccountSeed(seed, 1)
+	require.NoError(t, err)
+	expected, err := crypto.SecureHash(append([]byte{0, 0, 0, 1}, seed...))
+	require.NoError(t, err)
+	assert.Equal(t, expected.Bytes(), as)
+}